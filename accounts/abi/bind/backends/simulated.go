@@ -922,6 +922,12 @@ func (fb *filterBackend) ServiceFilter(ctx context.Context, ms *bloombits.Matche
 	panic("not supported")
 }
 
+func (fb *filterBackend) BorBloomStatus() (uint64, uint64) { return 4096, 0 }
+
+func (fb *filterBackend) ServiceBorFilter(ctx context.Context, ms *bloombits.MatcherSession) {
+	panic("not supported")
+}
+
 func (fb *filterBackend) ChainConfig() *params.ChainConfig {
 	panic("not supported")
 }
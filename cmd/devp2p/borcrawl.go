@@ -0,0 +1,181 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/urfave/cli/v2"
+
+	"github.com/ethereum/go-ethereum/cmd/devp2p/internal/ethtest"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/consensus/bor/heimdall"
+	"github.com/ethereum/go-ethereum/eth/downloader/whitelist"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/p2p/enode"
+)
+
+// Whitelist states of a node's chain, as recorded by the crawler.
+const (
+	borForkWhitelisted    = "whitelisted"     // The node serves the whitelisted blocks
+	borForkNonWhitelisted = "non-whitelisted" // The node serves a different block at a whitelisted height
+	borForkBehind         = "behind"          // The node does not serve the whitelisted blocks yet
+)
+
+var (
+	borCheckpointFlag = &cli.StringFlag{
+		Name:  "bor.checkpoint",
+		Usage: "Whitelisted checkpoint as <number>:<hash>",
+	}
+	borMilestoneFlag = &cli.StringFlag{
+		Name:  "bor.milestone",
+		Usage: "Whitelisted milestone as <number>:<hash>",
+	}
+	borHeimdallFlag = &cli.StringFlag{
+		Name:  "bor.heimdall",
+		Usage: "URL of heimdall to fetch the latest milestone from",
+	}
+	borCrawlFlags = []cli.Flag{borCheckpointFlag, borMilestoneFlag, borHeimdallFlag}
+
+	borTxArrivalWaitFlag = &cli.DurationFlag{
+		Name:  "txarrivalwait",
+		Usage: "Configured txarrivalwait of the tested node",
+		Value: 500 * time.Millisecond,
+	}
+)
+
+// borHeadJSON is the chain head a node claimed when it was last probed,
+// relative to the latest milestone.
+type borHeadJSON struct {
+	Head      common.Hash `json:"head"`
+	Number    uint64      `json:"number"`
+	Milestone uint64      `json:"milestone,omitempty"` // Number of the milestone the head was compared with
+	Distance  int64       `json:"distance"`            // Head number minus milestone number
+	Fork      string      `json:"fork,omitempty"`      // Whitelist state of the node's chain
+	Error     string      `json:"error,omitempty"`     // Error of the last probe, if it failed
+}
+
+// borProbe checks the chain of crawled nodes against the whitelisted
+// checkpoint and milestone.
+type borProbe struct {
+	service   *whitelist.Service
+	milestone *ethtest.Finality
+}
+
+// parseFinality parses a whitelisted block given as <number>:<hash>.
+func parseFinality(arg string) (*ethtest.Finality, error) {
+	number, hash, ok := strings.Cut(arg, ":")
+	if !ok {
+		return nil, fmt.Errorf("invalid block %q, want <number>:<hash>", arg)
+	}
+
+	n, err := strconv.ParseUint(number, 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("invalid block number %q: %v", number, err)
+	}
+
+	if len(common.FromHex(hash)) != common.HashLength {
+		return nil, fmt.Errorf("invalid block hash %q", hash)
+	}
+
+	return &ethtest.Finality{Number: n, Hash: common.HexToHash(hash)}, nil
+}
+
+// borFinality returns the checkpoint and milestone set on the command line.
+func borFinality(ctx *cli.Context) (*ethtest.Finality, *ethtest.Finality, error) {
+	var checkpoint, milestone *ethtest.Finality
+
+	if ctx.IsSet(borCheckpointFlag.Name) {
+		f, err := parseFinality(ctx.String(borCheckpointFlag.Name))
+		if err != nil {
+			return nil, nil, fmt.Errorf("-%s: %v", borCheckpointFlag.Name, err)
+		}
+
+		checkpoint = f
+	}
+
+	if ctx.IsSet(borMilestoneFlag.Name) {
+		f, err := parseFinality(ctx.String(borMilestoneFlag.Name))
+		if err != nil {
+			return nil, nil, fmt.Errorf("-%s: %v", borMilestoneFlag.Name, err)
+		}
+
+		milestone = f
+	}
+
+	if ctx.IsSet(borHeimdallFlag.Name) {
+		if milestone != nil {
+			return nil, nil, fmt.Errorf("-%s and -%s are mutually exclusive", borMilestoneFlag.Name, borHeimdallFlag.Name)
+		}
+
+		client := heimdall.NewHeimdallClient(ctx.String(borHeimdallFlag.Name))
+		defer client.Close()
+
+		fetchCtx, cancel := context.WithTimeout(ctx.Context, 30*time.Second)
+		defer cancel()
+
+		latest, err := client.FetchMilestone(fetchCtx)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to fetch latest milestone: %v", err)
+		}
+
+		milestone = &ethtest.Finality{Number: latest.EndBlock.Uint64(), Hash: latest.Hash}
+		log.Info("Fetched latest milestone", "number", milestone.Number, "hash", milestone.Hash)
+	}
+
+	return checkpoint, milestone, nil
+}
+
+// newBorProbe creates the probe of the crawled nodes' chains, or nil if no
+// checkpoint or milestone is set.
+func newBorProbe(ctx *cli.Context) (*borProbe, error) {
+	checkpoint, milestone, err := borFinality(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if checkpoint == nil && milestone == nil {
+		return nil, nil
+	}
+
+	return &borProbe{service: ethtest.NewWhitelist(checkpoint, milestone), milestone: milestone}, nil
+}
+
+// probe connects to the node and records its claimed head and whitelist
+// state. A failed probe only records the error over the previous state.
+func (p *borProbe) probe(n *enode.Node, prev *borHeadJSON) *borHeadJSON {
+	head, err := ethtest.ProbeBorHead(n, p.service)
+	if err != nil {
+		log.Debug("Bor probe failed", "id", n.ID(), "err", err)
+
+		res := new(borHeadJSON)
+		if prev != nil {
+			*res = *prev
+		}
+
+		res.Error = err.Error()
+
+		return res
+	}
+
+	res := &borHeadJSON{Head: head.Hash, Number: head.Number}
+
+	switch {
+	case head.Whitelist == nil:
+		res.Fork = borForkWhitelisted
+	case errors.Is(head.Whitelist, whitelist.ErrMismatch):
+		res.Fork = borForkNonWhitelisted
+	default:
+		res.Fork = borForkBehind
+	}
+
+	if p.milestone != nil {
+		res.Milestone = p.milestone.Number
+		res.Distance = int64(head.Number) - int64(p.milestone.Number)
+	}
+
+	return res
+}
@@ -36,6 +36,7 @@ type crawler struct {
 
 	// settings
 	revalidateInterval time.Duration
+	bor                *borProbe // Probe of the nodes' chains, nil to skip
 	mu                 sync.RWMutex
 }
 
@@ -216,6 +217,10 @@ func (c *crawler) updateNode(n *enode.Node) int {
 		}
 
 		node.LastResponse = node.LastCheck
+
+		if c.bor != nil && nn.TCP() != 0 {
+			node.Bor = c.bor.probe(nn, node.Bor)
+		}
 	}
 	// Store/update node in output set.
 	c.mu.Lock()
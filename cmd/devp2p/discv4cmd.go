@@ -80,7 +80,7 @@ var (
 		Name:   "crawl",
 		Usage:  "Updates a nodes.json file with random nodes found in the DHT",
 		Action: discv4Crawl,
-		Flags:  flags.Merge(discoveryNodeFlags, []cli.Flag{crawlTimeoutFlag, crawlParallelismFlag}),
+		Flags:  flags.Merge(discoveryNodeFlags, []cli.Flag{crawlTimeoutFlag, crawlParallelismFlag}, borCrawlFlags),
 	}
 	discv4TestCommand = &cli.Command{
 		Name:   "test",
@@ -228,6 +228,11 @@ func discv4Crawl(ctx *cli.Context) error {
 
 	nodesFile := ctx.Args().First()
 
+	probe, err := newBorProbe(ctx)
+	if err != nil {
+		return err
+	}
+
 	var inputSet nodeSet
 
 	if common.FileExist(nodesFile) {
@@ -238,6 +243,7 @@ func discv4Crawl(ctx *cli.Context) error {
 	defer disc.Close()
 	c := newCrawler(inputSet, disc, disc.RandomNodes())
 	c.revalidateInterval = 10 * time.Minute
+	c.bor = probe
 	output := c.run(ctx.Duration(crawlTimeoutFlag.Name), ctx.Int(crawlParallelismFlag.Name))
 	writeNodesJSON(nodesFile, output)
 
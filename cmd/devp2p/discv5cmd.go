@@ -59,7 +59,7 @@ var (
 		Action: discv5Crawl,
 		Flags: flags.Merge(discoveryNodeFlags, []cli.Flag{
 			crawlTimeoutFlag,
-		}),
+		}, borCrawlFlags),
 	}
 	discv5TestCommand = &cli.Command{
 		Name:   "test",
@@ -109,6 +109,11 @@ func discv5Crawl(ctx *cli.Context) error {
 
 	nodesFile := ctx.Args().First()
 
+	probe, err := newBorProbe(ctx)
+	if err != nil {
+		return err
+	}
+
 	var inputSet nodeSet
 
 	if common.FileExist(nodesFile) {
@@ -119,6 +124,7 @@ func discv5Crawl(ctx *cli.Context) error {
 	defer disc.Close()
 	c := newCrawler(inputSet, disc, disc.RandomNodes())
 	c.revalidateInterval = 10 * time.Minute
+	c.bor = probe
 	output := c.run(ctx.Duration(crawlTimeoutFlag.Name), ctx.Int(crawlParallelismFlag.Name))
 	writeNodesJSON(nodesFile, output)
 
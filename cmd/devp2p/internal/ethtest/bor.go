@@ -0,0 +1,633 @@
+package ethtest
+
+import (
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/eth/downloader/whitelist"
+	"github.com/ethereum/go-ethereum/eth/protocols/eth"
+	"github.com/ethereum/go-ethereum/internal/utesting"
+	"github.com/ethereum/go-ethereum/p2p/enode"
+	"github.com/ethereum/go-ethereum/params"
+	"github.com/ethereum/go-ethereum/rlp"
+
+	borproto "github.com/ethereum/go-ethereum/eth/protocols/bor"
+)
+
+const (
+	// borTxGatherSlack mirrors the interval the transaction fetcher uses to
+	// collate almost-expired announcements.
+	borTxGatherSlack = 100 * time.Millisecond
+
+	// borMaxTxArrivalWait is the cap Bor applies to the txarrivalwait setting.
+	borMaxTxArrivalWait = 500 * time.Millisecond
+
+	// borProbeQuietPeriod is how long a node must stay away from a peer which
+	// failed its whitelist probe for the peer to be considered rejected.
+	borProbeQuietPeriod = 10 * time.Second
+)
+
+// Finality is a whitelisted (checkpointed or milestoned) block of a Bor chain.
+type Finality struct {
+	Number uint64
+	Hash   common.Hash
+}
+
+// BorOptions holds the Bor-specific expectations of the bor test suite.
+type BorOptions struct {
+	Checkpoint    *Finality     // Checkpoint the node must agree with
+	Milestone     *Finality     // Milestone the node must agree with, the chain head if neither is set
+	TxArrivalWait time.Duration // Configured txarrivalwait of the node
+}
+
+// NewWhitelist creates a whitelist service tracking the given checkpoint and
+// milestone, either of which may be nil.
+func NewWhitelist(checkpoint *Finality, milestone *Finality) *whitelist.Service {
+	service := whitelist.NewService(rawdb.NewMemoryDatabase())
+
+	if checkpoint != nil {
+		service.ProcessCheckpoint(checkpoint.Number, checkpoint.Hash)
+	}
+
+	if milestone != nil {
+		service.ProcessMilestone(milestone.Number, milestone.Hash)
+	}
+
+	return service
+}
+
+func (s *Suite) BorTests() []utesting.Test {
+	return []utesting.Test{
+		{Name: "TestBorStatus", Fn: s.TestBorStatus},
+		{Name: "TestBorReceipts", Fn: s.TestBorReceipts},
+		{Name: "TestBorReceiptsRange", Fn: s.TestBorReceiptsRange},
+		{Name: "TestBorWhitelist", Fn: s.TestBorWhitelist},
+		{Name: "TestBorWhitelistReject", Fn: s.TestBorWhitelistReject},
+		{Name: "TestBorTxArrivalWait", Fn: s.TestBorTxArrivalWait},
+	}
+}
+
+// TestBorStatus attempts to connect to the given node and exchange a status
+// message with it while negotiating the bor protocol.
+func (s *Suite) TestBorStatus(t *utesting.T) {
+	conn, err := s.dialBor()
+	if err != nil {
+		t.Fatalf("dial failed: %v", err)
+	}
+
+	defer conn.Close()
+
+	if err := conn.peer(s.chain, nil); err != nil {
+		t.Fatalf("peering failed: %v", err)
+	}
+}
+
+// TestBorReceipts requests the bor receipts of the first blocks of the chain
+// and of an unknown block, and checks that only sprint start blocks have one.
+func (s *Suite) TestBorReceipts(t *utesting.T) {
+	config := s.borConfig(t)
+
+	conn, err := s.dialBor()
+	if err != nil {
+		t.Fatalf("dial failed: %v", err)
+	}
+
+	defer conn.Close()
+
+	if err := conn.peer(s.chain, nil); err != nil {
+		t.Fatalf("peering failed: %v", err)
+	}
+
+	var hashes []common.Hash
+
+	for i := 1; i < s.chain.Len() && len(hashes) < 128; i++ {
+		hashes = append(hashes, s.chain.blocks[i].Hash())
+	}
+
+	hashes = append(hashes, common.Hash{0xde, 0xad})
+
+	receipts, err := conn.borReceipts(hashes)
+	if err != nil {
+		t.Fatalf("could not get bor receipts: %v", err)
+	}
+
+	if len(receipts) > len(hashes) {
+		t.Fatalf("too many bor receipts: have %d, requested %d", len(receipts), len(hashes))
+	}
+
+	for i, receipt := range receipts {
+		if receipt == nil {
+			continue
+		}
+
+		if i == len(hashes)-1 {
+			t.Fatalf("bor receipt served for an unknown block")
+		}
+
+		if number := uint64(i + 1); !config.IsSprintStart(number) {
+			t.Fatalf("bor receipt served for block %d, which is not a sprint start", number)
+		}
+	}
+}
+
+// TestBorReceiptsRange requests the bor receipts of a range of canonical
+// blocks and checks the reply against the chain and the bor receipts served
+// by hash.
+func (s *Suite) TestBorReceiptsRange(t *utesting.T) {
+	config := s.borConfig(t)
+
+	conn, err := s.dialBor()
+	if err != nil {
+		t.Fatalf("dial failed: %v", err)
+	}
+
+	defer conn.Close()
+
+	if err := conn.peer(s.chain, nil); err != nil {
+		t.Fatalf("peering failed: %v", err)
+	}
+
+	if conn.negotiatedBorProtoVersion < borproto.BOR2 {
+		t.Fatalf("node does not support bor/%d", borproto.BOR2)
+	}
+
+	amount := uint64(s.chain.Len() - 1)
+
+	last, entries, err := conn.borReceiptsRange(1, amount)
+	if err != nil {
+		t.Fatalf("could not get bor receipt range: %v", err)
+	}
+
+	if last > amount {
+		t.Fatalf("reply covers block %d beyond the requested range", last)
+	}
+
+	served := make(map[uint64]bool)
+
+	for i, entry := range entries {
+		if entry.Number < 1 || entry.Number > last {
+			t.Fatalf("entry %d: block %d outside of the covered range 1-%d", i, entry.Number, last)
+		}
+
+		if i > 0 && entry.Number <= entries[i-1].Number {
+			t.Fatalf("entry %d: block %d not in ascending order", i, entry.Number)
+		}
+
+		if !config.IsSprintStart(entry.Number) {
+			t.Fatalf("entry %d: block %d is not a sprint start", i, entry.Number)
+		}
+
+		if have, want := entry.Hash, s.chain.blocks[entry.Number].Hash(); have != want {
+			t.Fatalf("entry %d: block %d hash mismatch: have %x, want %x", i, entry.Number, have, want)
+		}
+
+		if err := rlpDecodeBorReceipt(entry.Receipt); err != nil {
+			t.Fatalf("entry %d: block %d: %v", i, entry.Number, err)
+		}
+
+		served[entry.Number] = true
+	}
+
+	// The blocks served by hash must match the range reply
+	var hashes []common.Hash
+
+	for number := uint64(1); number <= last && len(hashes) < 128; number++ {
+		hashes = append(hashes, s.chain.blocks[number].Hash())
+	}
+
+	receipts, err := conn.borReceipts(hashes)
+	if err != nil {
+		t.Fatalf("could not get bor receipts: %v", err)
+	}
+
+	for i, receipt := range receipts {
+		if number := uint64(i + 1); (receipt != nil) != served[number] {
+			t.Fatalf("block %d: bor receipt served by hash: %v, in range: %v", number, receipt != nil, served[number])
+		}
+	}
+
+	// A range beyond the head of the node is empty
+	origin := uint64(s.chain.Len()) + 1000
+
+	last, entries, err = conn.borReceiptsRange(origin, 16)
+	if err != nil {
+		t.Fatalf("could not get bor receipt range: %v", err)
+	}
+
+	if last != origin-1 || len(entries) != 0 {
+		t.Fatalf("range beyond head: have last %d with %d entries, want %d with none", last, len(entries), origin-1)
+	}
+}
+
+// TestBorWhitelist checks the chain of the node against the configured
+// checkpoint and milestone, with the same header probes a Bor node runs
+// against its peers before syncing from them.
+func (s *Suite) TestBorWhitelist(t *utesting.T) {
+	conn, err := s.dial()
+	if err != nil {
+		t.Fatalf("dial failed: %v", err)
+	}
+
+	defer conn.Close()
+
+	if err := conn.peer(s.chain, nil); err != nil {
+		t.Fatalf("peering failed: %v", err)
+	}
+
+	checkpoint, milestone := s.Bor.Checkpoint, s.Bor.Milestone
+	if checkpoint == nil && milestone == nil {
+		head := s.chain.Head()
+		milestone = &Finality{Number: head.NumberU64(), Hash: head.Hash()}
+	}
+
+	if _, err := NewWhitelist(checkpoint, milestone).IsValidPeer(conn.fetchHeadersByNumber); err != nil {
+		t.Fatalf("node is not on the whitelisted chain: %v", err)
+	}
+}
+
+// TestBorWhitelistReject announces a heavier fork of the chain to the node
+// and serves forged headers to its whitelist probes. The node must probe its
+// whitelisted checkpoint or milestone before looking for a common ancestor,
+// and stop syncing from the peer once the probe fails.
+func (s *Suite) TestBorWhitelistReject(t *utesting.T) {
+	conn, err := s.dialBor()
+	if err != nil {
+		t.Fatalf("dial failed: %v", err)
+	}
+
+	defer conn.Close()
+
+	if err := conn.handshake(); err != nil {
+		t.Fatalf("handshake failed: %v", err)
+	}
+
+	head := s.chain.Head()
+
+	forged := forgeHeader(head.Header())
+	forged.Number = new(big.Int).Add(head.Number(), common.Big1)
+	forged.ParentHash = head.Hash()
+
+	status := &Status{
+		ProtocolVersion: uint32(conn.negotiatedProtoVersion),
+		NetworkID:       s.chain.chainConfig.ChainID.Uint64(),
+		TD:              new(big.Int).Mul(s.chain.TD(), common.Big2),
+		Head:            forged.Hash(),
+		Genesis:         s.chain.blocks[0].Hash(),
+		ForkID:          s.chain.ForkID(),
+	}
+	if _, err := conn.statusExchange(s.chain, status); err != nil {
+		t.Fatalf("status exchange failed: %v", err)
+	}
+
+	var (
+		probed   bool
+		deadline = time.Now().Add(timeout)
+	)
+
+	for time.Now().Before(deadline) {
+		conn.SetReadDeadline(deadline)
+
+		switch msg := conn.Read().(type) {
+		case *Error, *Disconnect:
+			if !probed {
+				t.Fatalf("node did not probe the whitelisted chain: %s", pretty.Sdump(msg))
+			}
+
+			return
+		case *Ping:
+			conn.Write(&Pong{})
+		case *GetBlockHeaders:
+			var (
+				req     = msg.GetBlockHeadersPacket
+				headers []*types.Header
+			)
+
+			switch {
+			case req.Origin.Hash == forged.Hash():
+				headers = []*types.Header{forged}
+			case req.Origin.Hash != (common.Hash{}):
+				// Unknown hash, nothing to serve
+			case req.Amount == 1 && req.Skip == 0:
+				probed = true
+				deadline = time.Now().Add(borProbeQuietPeriod)
+
+				if number := req.Origin.Number; number < uint64(s.chain.Len()) {
+					headers = []*types.Header{forgeHeader(s.chain.blocks[number].Header())}
+				}
+			case probed:
+				t.Fatalf("node kept syncing after a failed whitelist probe: %s", pretty.Sdump(req))
+			default:
+				t.Fatalf("node looked for a common ancestor without probing its whitelisted checkpoint or milestone: %s", pretty.Sdump(req))
+			}
+
+			if err := conn.Write(&BlockHeaders{RequestId: msg.RequestId, BlockHeadersPacket: headers}); err != nil {
+				t.Fatalf("could not write to connection: %v", err)
+			}
+		case *GetBlockBodies:
+			t.Fatalf("node requested block bodies from a peer on a non-whitelisted fork")
+		}
+	}
+
+	if !probed {
+		t.Fatalf("node did not sync from a peer announcing a higher total difficulty")
+	}
+}
+
+// TestBorTxArrivalWait announces two transactions to the node and broadcasts
+// one of them right away. The node must only request the other one, once the
+// txarrivalwait duration elapsed.
+func (s *Suite) TestBorTxArrivalWait(t *utesting.T) {
+	conn, err := s.dial()
+	if err != nil {
+		t.Fatalf("dial failed: %v", err)
+	}
+
+	defer conn.Close()
+
+	if err := conn.peer(s.chain, nil); err != nil {
+		t.Fatalf("peering failed: %v", err)
+	}
+
+	key, _ := crypto.GenerateKey()
+
+	delivered, err := types.SignTx(types.NewTransaction(0, common.Address{0x01}, common.Big1, params.TxGas, big.NewInt(params.GWei), nil), types.LatestSigner(s.chain.chainConfig), key)
+	if err != nil {
+		t.Fatalf("failed to sign transaction: %v", err)
+	}
+
+	var announced common.Hash
+	if _, err := rand.Read(announced[:]); err != nil {
+		t.Fatalf("failed to generate hash: %v", err)
+	}
+
+	var (
+		hashes = []common.Hash{announced, delivered.Hash()}
+		ann    Message
+	)
+
+	if conn.negotiatedProtoVersion < eth.ETH68 {
+		ann = NewPooledTransactionHashes66(hashes)
+	} else {
+		ann = NewPooledTransactionHashes{
+			Types:  []byte{types.LegacyTxType, delivered.Type()},
+			Sizes:  []uint32{uint32(delivered.Size()), uint32(delivered.Size())},
+			Hashes: hashes,
+		}
+	}
+
+	if err := conn.Write(ann); err != nil {
+		t.Fatalf("failed to write to connection: %v", err)
+	}
+
+	start := time.Now()
+
+	if err := conn.Write(&Transactions{delivered}); err != nil {
+		t.Fatalf("failed to write to connection: %v", err)
+	}
+
+	wait := s.Bor.TxArrivalWait
+	if wait > borMaxTxArrivalWait {
+		wait = borMaxTxArrivalWait
+	}
+
+	for {
+		conn.SetReadDeadline(start.Add(timeout))
+
+		switch msg := conn.Read().(type) {
+		case *Error, *Disconnect:
+			t.Fatalf("no transaction request received: %s", pretty.Sdump(msg))
+		case *Ping:
+			conn.Write(&Pong{})
+		case *GetPooledTransactions:
+			var requested bool
+
+			for _, hash := range msg.GetPooledTransactionsPacket {
+				switch hash {
+				case delivered.Hash():
+					t.Fatalf("node requested a transaction delivered within the arrival wait")
+				case announced:
+					requested = true
+				}
+			}
+
+			if !requested {
+				continue
+			}
+
+			elapsed := time.Since(start)
+			if elapsed < wait-borTxGatherSlack {
+				t.Fatalf("transaction requested after %v, before the %v arrival wait", elapsed, wait)
+			}
+
+			if elapsed > wait+time.Second {
+				t.Fatalf("transaction requested after %v, long after the %v arrival wait", elapsed, wait)
+			}
+
+			return
+		}
+	}
+}
+
+// BorHead is the chain head claimed by a peer, along with the result of the
+// whitelist probe of its chain.
+type BorHead struct {
+	Hash      common.Hash
+	Number    uint64
+	TD        *big.Int
+	Whitelist error // Error of the whitelist probe, nil if the peer is on the whitelisted chain
+}
+
+// ProbeBorHead connects to the given node, retrieves the head block it claims
+// in its status, and checks its chain against the checkpoint and milestone of
+// the whitelist service, the same way a Bor node does before syncing.
+func ProbeBorHead(dest *enode.Node, service *whitelist.Service) (*BorHead, error) {
+	conn, err := (&Suite{Dest: dest}).dial()
+	if err != nil {
+		return nil, err
+	}
+
+	defer conn.Close()
+
+	if err := conn.handshake(); err != nil {
+		return nil, fmt.Errorf("handshake failed: %v", err)
+	}
+
+	status, err := conn.echoStatus()
+	if err != nil {
+		return nil, fmt.Errorf("status exchange failed: %v", err)
+	}
+
+	headers, err := conn.headers(&eth.GetBlockHeadersPacket{Origin: eth.HashOrNumber{Hash: status.Head}, Amount: 1})
+	if err != nil {
+		return nil, err
+	}
+
+	if len(headers) != 1 || headers[0].Hash() != status.Head {
+		return nil, errors.New("head header not served")
+	}
+
+	head := &BorHead{
+		Hash:   status.Head,
+		Number: headers[0].Number.Uint64(),
+		TD:     status.TD,
+	}
+
+	_, head.Whitelist = service.IsValidPeer(conn.fetchHeadersByNumber)
+
+	return head, nil
+}
+
+// borConfig returns the Bor config of the test chain.
+func (s *Suite) borConfig(t *utesting.T) *params.BorConfig {
+	t.Helper()
+
+	if s.chain.chainConfig.Bor == nil {
+		t.Fatalf("chain config has no bor section")
+	}
+
+	return s.chain.chainConfig.Bor
+}
+
+// echoStatus reads the status of the node and replies with the same status,
+// peering without knowing its chain.
+func (c *Conn) echoStatus() (*Status, error) {
+	defer c.SetDeadline(time.Time{})
+	c.SetDeadline(time.Now().Add(20 * time.Second))
+
+	for {
+		switch msg := c.Read().(type) {
+		case *Status:
+			if err := c.Write(msg); err != nil {
+				return nil, fmt.Errorf("write to connection failed: %v", err)
+			}
+
+			return msg, nil
+		case *Disconnect:
+			return nil, fmt.Errorf("disconnect received: %v", msg.Reason)
+		case *Ping:
+			c.Write(&Pong{})
+		default:
+			return nil, fmt.Errorf("bad status message: %s", pretty.Sdump(msg))
+		}
+	}
+}
+
+// request writes the given request and waits for the response with the same
+// request ID. Header requests of the node are answered with no headers.
+func (c *Conn) request(req Message) (Message, error) {
+	defer c.SetDeadline(time.Time{})
+	c.SetDeadline(time.Now().Add(timeout))
+
+	if err := c.Write(req); err != nil {
+		return nil, fmt.Errorf("could not write to connection: %v", err)
+	}
+
+	for {
+		switch msg := c.Read().(type) {
+		case *Error:
+			return nil, msg
+		case *Disconnect:
+			return nil, fmt.Errorf("disconnect received: %v", msg.Reason)
+		case *Ping:
+			c.Write(&Pong{})
+		case *GetBlockHeaders:
+			if err := c.Write(&BlockHeaders{RequestId: msg.RequestId}); err != nil {
+				return nil, fmt.Errorf("could not write to connection: %v", err)
+			}
+		default:
+			if msg.ReqID() == req.ReqID() && msg.Code() == req.Code()+1 {
+				return msg, nil
+			}
+		}
+	}
+}
+
+// headers retrieves the block headers matching the given query.
+func (c *Conn) headers(query *eth.GetBlockHeadersPacket) ([]*types.Header, error) {
+	msg, err := c.request(&GetBlockHeaders{RequestId: randomID(), GetBlockHeadersPacket: query})
+	if err != nil {
+		return nil, err
+	}
+
+	return msg.(*BlockHeaders).BlockHeadersPacket, nil
+}
+
+// fetchHeadersByNumber retrieves block headers the way the whitelist service
+// expects from a downloader peer.
+func (c *Conn) fetchHeadersByNumber(number uint64, amount int, skip int, reverse bool) ([]*types.Header, []common.Hash, error) {
+	headers, err := c.headers(&eth.GetBlockHeadersPacket{
+		Origin:  eth.HashOrNumber{Number: number},
+		Amount:  uint64(amount),
+		Skip:    uint64(skip),
+		Reverse: reverse,
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	hashes := make([]common.Hash, len(headers))
+	for i, header := range headers {
+		hashes[i] = header.Hash()
+	}
+
+	return headers, hashes, nil
+}
+
+// borReceipts retrieves the bor receipts of the given blocks, nil for the
+// blocks without one.
+func (c *Conn) borReceipts(hashes []common.Hash) ([]*types.ReceiptForStorage, error) {
+	msg, err := c.request(&GetBorReceipts{ID: randomID(), Hashes: hashes})
+	if err != nil {
+		return nil, err
+	}
+
+	return (*borproto.BorReceiptsPacket)(msg.(*BorReceipts)).Unpack()
+}
+
+// borReceiptsRange retrieves the bor receipts of a range of canonical blocks.
+func (c *Conn) borReceiptsRange(origin uint64, amount uint64) (uint64, []*borproto.BorReceiptEntry, error) {
+	msg, err := c.request(&GetBorReceiptsRange{ID: randomID(), Origin: origin, Amount: amount})
+	if err != nil {
+		return 0, nil, err
+	}
+
+	res := msg.(*BorReceiptsRange)
+
+	return res.Last, res.Receipts, nil
+}
+
+// rlpDecodeBorReceipt checks that a bor receipt is in storage format.
+func rlpDecodeBorReceipt(data []byte) error {
+	packet := &borproto.BorReceiptsPacket{Receipts: []rlp.RawValue{data}}
+
+	receipts, err := packet.Unpack()
+	if err != nil {
+		return err
+	}
+
+	if receipts[0] == nil {
+		return errors.New("empty bor receipt")
+	}
+
+	return nil
+}
+
+// forgeHeader returns a copy of the header with a different hash.
+func forgeHeader(header *types.Header) *types.Header {
+	forged := types.CopyHeader(header)
+	forged.Extra = append(forged.Extra, []byte("bor-test fork")...)
+
+	return forged
+}
+
+func randomID() uint64 {
+	id, _ := rand.Int(rand.Reader, new(big.Int).SetUint64(1<<62))
+	return id.Uint64() + 1
+}
@@ -0,0 +1,75 @@
+package ethtest
+
+import (
+	borproto "github.com/ethereum/go-ethereum/eth/protocols/bor"
+	"github.com/ethereum/go-ethereum/rlp"
+)
+
+// borProtocolLengths are the number of message codes reserved by each version
+// of the bor protocol. As "bor" sorts before "eth" and "snap", the bor messages
+// take the codes right after the base protocol and shift all the others.
+var borProtocolLengths = map[uint]uint64{borproto.BOR1: 2, borproto.BOR2: 4}
+
+// GetBorReceipts represents a bor receipt query.
+type GetBorReceipts borproto.GetBorReceiptsPacket
+
+func (msg GetBorReceipts) Code() int     { return 16 }
+func (msg GetBorReceipts) ReqID() uint64 { return msg.ID }
+
+type BorReceipts borproto.BorReceiptsPacket
+
+func (msg BorReceipts) Code() int     { return 17 }
+func (msg BorReceipts) ReqID() uint64 { return msg.ID }
+
+// GetBorReceiptsRange represents a query for the bor receipts of a block range.
+type GetBorReceiptsRange borproto.GetBorReceiptsRangePacket
+
+func (msg GetBorReceiptsRange) Code() int     { return 18 }
+func (msg GetBorReceiptsRange) ReqID() uint64 { return msg.ID }
+
+type BorReceiptsRange borproto.BorReceiptsRangePacket
+
+func (msg BorReceiptsRange) Code() int     { return 19 }
+func (msg BorReceiptsRange) ReqID() uint64 { return msg.ID }
+
+// isBorMessage returns whether the message belongs to the bor protocol, whose
+// codes are not shifted.
+func isBorMessage(msg Message) bool {
+	switch msg.(type) {
+	case GetBorReceipts, *GetBorReceipts, BorReceipts, *BorReceipts,
+		GetBorReceiptsRange, *GetBorReceiptsRange, BorReceiptsRange, *BorReceiptsRange:
+		return true
+	default:
+		return false
+	}
+}
+
+// borLength returns the number of message codes taken by the negotiated bor
+// protocol, zero if it was not negotiated.
+func (c *Conn) borLength() uint64 {
+	return borProtocolLengths[c.negotiatedBorProtoVersion]
+}
+
+// readBor decodes a bor protocol packet.
+func (c *Conn) readBor(code uint64, rawData []byte) Message {
+	var msg Message
+
+	switch int(code) {
+	case (GetBorReceipts{}).Code():
+		msg = new(GetBorReceipts)
+	case (BorReceipts{}).Code():
+		msg = new(BorReceipts)
+	case (GetBorReceiptsRange{}).Code():
+		msg = new(GetBorReceiptsRange)
+	case (BorReceiptsRange{}).Code():
+		msg = new(BorReceiptsRange)
+	default:
+		return errorf("invalid bor message code: %d", code)
+	}
+
+	if err := rlp.DecodeBytes(rawData, msg); err != nil {
+		return errorf("could not rlp decode message: %v", err)
+	}
+
+	return msg
+}
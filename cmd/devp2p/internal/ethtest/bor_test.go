@@ -0,0 +1,93 @@
+package ethtest
+
+import (
+	"net"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/eth/protocols/eth"
+	"github.com/ethereum/go-ethereum/p2p/rlpx"
+)
+
+func newBorTestConns(t *testing.T) (*Conn, *Conn) {
+	t.Helper()
+
+	fd1, fd2 := net.Pipe()
+	key1, _ := crypto.GenerateKey()
+	key2, _ := crypto.GenerateKey()
+
+	conn1 := &Conn{Conn: rlpx.NewConn(fd1, &key2.PublicKey), negotiatedBorProtoVersion: 2}
+	conn2 := &Conn{Conn: rlpx.NewConn(fd2, nil), negotiatedBorProtoVersion: 2}
+
+	errc := make(chan error, 1)
+
+	go func() {
+		_, err := conn2.Handshake(key2)
+		errc <- err
+	}()
+
+	if _, err := conn1.Handshake(key1); err != nil {
+		t.Fatalf("handshake failed: %v", err)
+	}
+
+	if err := <-errc; err != nil {
+		t.Fatalf("handshake failed: %v", err)
+	}
+
+	t.Cleanup(func() {
+		conn1.Close()
+		conn2.Close()
+	})
+
+	return conn1, conn2
+}
+
+func TestBorMessageCodes(t *testing.T) {
+	t.Parallel()
+
+	conn1, conn2 := newBorTestConns(t)
+
+	tests := []struct {
+		msg  Message
+		code uint64
+	}{
+		{&Ping{}, 0x02},
+		{&GetBorReceipts{ID: 1, Hashes: []common.Hash{{0x01}}}, 16},
+		{&BorReceiptsRange{ID: 2, Last: 8}, 19},
+		{&Status{ProtocolVersion: 68}, 20},
+		{&GetBlockHeaders{RequestId: 3, GetBlockHeadersPacket: &eth.GetBlockHeadersPacket{Amount: 1}}, 23},
+		{&GetAccountRange{ID: 4}, 37},
+	}
+
+	for _, tt := range tests {
+		errc := make(chan error, 1)
+
+		go func(msg Message) {
+			errc <- conn1.Write(msg)
+		}(tt.msg)
+
+		code, _, _, err := conn2.Conn.Read()
+		if err != nil {
+			t.Fatalf("%T: read failed: %v", tt.msg, err)
+		}
+
+		if err := <-errc; err != nil {
+			t.Fatalf("%T: write failed: %v", tt.msg, err)
+		}
+
+		if code != tt.code {
+			t.Errorf("%T: wire code mismatch: have %d, want %d", tt.msg, code, tt.code)
+		}
+	}
+
+	// Messages read back are mapped to their unshifted types
+	for _, msg := range []Message{&BorReceiptsRange{ID: 5, Last: 4}, &BlockHeaders{RequestId: 6}} {
+		go conn1.Write(msg)
+
+		have := conn2.Read()
+		if have.Code() != msg.Code() || have.ReqID() != msg.ReqID() {
+			t.Errorf("read %T (code %d, id %d), want %T", have, have.Code(), have.ReqID(), msg)
+		}
+	}
+}
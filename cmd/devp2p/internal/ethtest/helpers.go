@@ -86,6 +86,19 @@ func (s *Suite) dialSnap() (*Conn, error) {
 	return conn, nil
 }
 
+// dialBor creates a connection with bor/1 and bor/2 capability.
+func (s *Suite) dialBor() (*Conn, error) {
+	conn, err := s.dial()
+	if err != nil {
+		return nil, fmt.Errorf("dial failed: %v", err)
+	}
+
+	conn.caps = append(conn.caps, p2p.Cap{Name: "bor", Version: 1}, p2p.Cap{Name: "bor", Version: 2})
+	conn.ourHighestBorProtoVersion = 2
+
+	return conn, nil
+}
+
 // peer performs both the protocol handshake and the status message
 // exchange with the node in order to peer with it.
 func (c *Conn) peer(chain *Chain, status *Status) error {
@@ -132,6 +145,10 @@ func (c *Conn) handshake() error {
 		if c.ourHighestSnapProtoVersion != c.negotiatedSnapProtoVersion {
 			return fmt.Errorf("could not negotiate snap protocol (remote caps: %v, local snap version: %v)", msg.Caps, c.ourHighestSnapProtoVersion)
 		}
+		// If we offered bor, verify that it was negotiated
+		if c.ourHighestBorProtoVersion != 0 && c.negotiatedBorProtoVersion == 0 {
+			return fmt.Errorf("could not negotiate bor protocol (remote caps: %v, local bor version: %v)", msg.Caps, c.ourHighestBorProtoVersion)
+		}
 
 		return nil
 	default:
@@ -146,6 +163,8 @@ func (c *Conn) negotiateEthProtocol(caps []p2p.Cap) {
 
 	var highestSnapVersion uint
 
+	var highestBorVersion uint
+
 	for _, capability := range caps {
 		switch capability.Name {
 		case "eth":
@@ -156,11 +175,16 @@ func (c *Conn) negotiateEthProtocol(caps []p2p.Cap) {
 			if capability.Version > highestSnapVersion && capability.Version <= c.ourHighestSnapProtoVersion {
 				highestSnapVersion = capability.Version
 			}
+		case "bor":
+			if capability.Version > highestBorVersion && capability.Version <= c.ourHighestBorProtoVersion {
+				highestBorVersion = capability.Version
+			}
 		}
 	}
 
 	c.negotiatedProtoVersion = highestEthVersion
 	c.negotiatedSnapProtoVersion = highestSnapVersion
+	c.negotiatedBorProtoVersion = highestBorVersion
 }
 
 // statusExchange performs a `Status` message exchange with the given node.
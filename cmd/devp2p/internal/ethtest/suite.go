@@ -29,6 +29,7 @@ import (
 // to the eth protocol.
 type Suite struct {
 	Dest *enode.Node
+	Bor  BorOptions // Expectations of the bor test suite
 
 	chain     *Chain
 	fullChain *Chain
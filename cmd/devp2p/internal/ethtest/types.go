@@ -157,16 +157,41 @@ type Conn struct {
 	negotiatedSnapProtoVersion uint
 	ourHighestProtoVersion     uint
 	ourHighestSnapProtoVersion uint
+	negotiatedBorProtoVersion  uint
+	ourHighestBorProtoVersion  uint
 	caps                       []p2p.Cap
 }
 
+// baseProtocolLength is the number of message codes reserved by the devp2p
+// base protocol.
+const baseProtocolLength = 16
+
+// readCode reads a packet from the connection and maps its code to the one of
+// the eth and snap message types, undoing the shift caused by the bor protocol.
+func (c *Conn) readCode() (uint64, []byte, error) {
+	code, rawData, _, err := c.Conn.Read()
+	if err != nil {
+		return 0, nil, err
+	}
+
+	if n := c.borLength(); n > 0 && code >= baseProtocolLength+n {
+		code -= n
+	}
+
+	return code, rawData, nil
+}
+
 // Read reads an eth66 packet from the connection.
 func (c *Conn) Read() Message {
-	code, rawData, _, err := c.Conn.Read()
+	code, rawData, err := c.readCode()
 	if err != nil {
 		return errorf("could not read from connection: %v", err)
 	}
 
+	if n := c.borLength(); n > 0 && code >= baseProtocolLength && code < baseProtocolLength+n {
+		return c.readBor(code, rawData)
+	}
+
 	var msg Message
 
 	switch int(code) {
@@ -258,7 +283,12 @@ func (c *Conn) Write(msg Message) error {
 		return err
 	}
 
-	_, err = c.Conn.Write(uint64(msg.Code()), payload)
+	code := uint64(msg.Code())
+	if code >= baseProtocolLength && !isBorMessage(msg) {
+		code += c.borLength()
+	}
+
+	_, err = c.Conn.Write(code, payload)
 
 	return err
 }
@@ -269,7 +299,7 @@ func (c *Conn) ReadSnap(id uint64) (Message, error) {
 	start := time.Now()
 
 	for respId != id && time.Since(start) < timeout {
-		code, rawData, _, err := c.Conn.Read()
+		code, rawData, err := c.readCode()
 		if err != nil {
 			return nil, fmt.Errorf("could not read from connection: %v", err)
 		}
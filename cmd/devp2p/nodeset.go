@@ -46,6 +46,9 @@ type nodeJSON struct {
 	LastResponse  time.Time `json:"lastResponse,omitempty"`
 	// This one tracks the time of our last attempt to contact the node.
 	LastCheck time.Time `json:"lastCheck,omitempty"`
+	// This one tracks the chain head of the node, if the crawl probed it
+	// against the Bor whitelist.
+	Bor *borHeadJSON `json:"bor,omitempty"`
 }
 
 func loadNodesJSON(file string) nodeSet {
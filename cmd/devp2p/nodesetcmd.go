@@ -154,6 +154,7 @@ var filterFlags = map[string]nodeFilterC{
 	"-eth-network": {1, ethFilter},
 	"-les-server":  {0, lesFilter},
 	"-snap":        {0, snapFilter},
+	"-bor-fork":    {1, borForkFilter},
 }
 
 // parseFilters parses nodeFilters from args.
@@ -311,3 +312,17 @@ func snapFilter(args []string) (nodeFilter, error) {
 
 	return f, nil
 }
+
+func borForkFilter(args []string) (nodeFilter, error) {
+	switch args[0] {
+	case borForkWhitelisted, borForkNonWhitelisted, borForkBehind:
+	default:
+		return nil, fmt.Errorf("unknown fork state %q", args[0])
+	}
+
+	f := func(n nodeJSON) bool {
+		return n.Bor != nil && n.Bor.Fork == args[0]
+	}
+
+	return f, nil
+}
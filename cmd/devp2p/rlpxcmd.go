@@ -38,6 +38,7 @@ var (
 			rlpxPingCommand,
 			rlpxEthTestCommand,
 			rlpxSnapTestCommand,
+			rlpxBorTestCommand,
 		},
 	}
 	rlpxPingCommand = &cli.Command{
@@ -65,6 +66,20 @@ var (
 			testTAPFlag,
 		},
 	}
+	rlpxBorTestCommand = &cli.Command{
+		Name:      "bor-test",
+		Usage:     "Runs Bor tests against a node",
+		ArgsUsage: "<node> <chain.rlp> <genesis.json>",
+		Action:    rlpxBorTest,
+		Flags: []cli.Flag{
+			testPatternFlag,
+			testTAPFlag,
+			borCheckpointFlag,
+			borMilestoneFlag,
+			borHeimdallFlag,
+			borTxArrivalWaitFlag,
+		},
+	}
 )
 
 func rlpxPing(ctx *cli.Context) error {
@@ -137,3 +152,23 @@ func rlpxSnapTest(ctx *cli.Context) error {
 
 	return runTests(ctx, suite.SnapTests())
 }
+
+// rlpxBorTest runs the bor protocol test suite.
+func rlpxBorTest(ctx *cli.Context) error {
+	if ctx.NArg() < 3 {
+		exit("missing path to chain.rlp as command-line argument")
+	}
+
+	suite, err := ethtest.NewSuite(getNodeArg(ctx), ctx.Args().Get(1), ctx.Args().Get(2))
+	if err != nil {
+		exit(err)
+	}
+
+	if suite.Bor.Checkpoint, suite.Bor.Milestone, err = borFinality(ctx); err != nil {
+		exit(err)
+	}
+
+	suite.Bor.TxArrivalWait = ctx.Duration(borTxArrivalWaitFlag.Name)
+
+	return runTests(ctx, suite.BorTests())
+}
@@ -5,6 +5,7 @@ import (
 
 	"github.com/urfave/cli/v2"
 
+	"github.com/ethereum/go-ethereum/consensus/bor"
 	"github.com/ethereum/go-ethereum/eth"
 	"github.com/ethereum/go-ethereum/eth/ethconfig"
 	"github.com/ethereum/go-ethereum/node"
@@ -35,6 +36,34 @@ var (
 		Value: "",
 	}
 
+	// HeimdallgRPCTLSCertFlag flag for the heimdall gRPC client certificate
+	HeimdallgRPCTLSCertFlag = &cli.StringFlag{
+		Name:  "bor.heimdallgRPC.tlscert",
+		Usage: "Client certificate presented to the Heimdall gRPC service",
+		Value: "",
+	}
+
+	// HeimdallgRPCTLSKeyFlag flag for the heimdall gRPC client certificate key
+	HeimdallgRPCTLSKeyFlag = &cli.StringFlag{
+		Name:  "bor.heimdallgRPC.tlskey",
+		Usage: "Private key of the Heimdall gRPC client certificate",
+		Value: "",
+	}
+
+	// HeimdallgRPCTLSCAFlag flag for the heimdall gRPC server CA bundle
+	HeimdallgRPCTLSCAFlag = &cli.StringFlag{
+		Name:  "bor.heimdallgRPC.tlsca",
+		Usage: "CA bundle to verify the Heimdall gRPC service, enables TLS",
+		Value: "",
+	}
+
+	// HeimdallgRPCTokenFlag flag for the heimdall gRPC bearer token
+	HeimdallgRPCTokenFlag = &cli.StringFlag{
+		Name:  "bor.heimdallgRPC.token",
+		Usage: "Bearer token sent to the Heimdall gRPC service",
+		Value: "",
+	}
+
 	// RunHeimdallFlag flag for running heimdall internally from bor
 	RunHeimdallFlag = &cli.BoolFlag{
 		Name:  "bor.runheimdall",
@@ -53,14 +82,26 @@ var (
 		Usage: "Use child heimdall process to fetch data, Only works when bor.runheimdall is true",
 	}
 
+	// BorTrustedCheckpointFlag flag for the checkpoint to bootstrap the chain from
+	BorTrustedCheckpointFlag = &cli.StringFlag{
+		Name:  "bor.trustedcheckpoint",
+		Usage: "Heimdall checkpoint to bootstrap the chain from, as <start>-<end>:<root hash>:<end block hash>",
+		Value: "",
+	}
+
 	// BorFlags all bor related flags
 	BorFlags = []cli.Flag{
 		HeimdallURLFlag,
 		WithoutHeimdallFlag,
 		HeimdallgRPCAddressFlag,
+		HeimdallgRPCTLSCertFlag,
+		HeimdallgRPCTLSKeyFlag,
+		HeimdallgRPCTLSCAFlag,
+		HeimdallgRPCTokenFlag,
 		RunHeimdallFlag,
 		RunHeimdallArgsFlag,
 		UseHeimdallAppFlag,
+		BorTrustedCheckpointFlag,
 	}
 )
 
@@ -69,9 +110,22 @@ func SetBorConfig(ctx *cli.Context, cfg *eth.Config) {
 	cfg.HeimdallURL = ctx.String(HeimdallURLFlag.Name)
 	cfg.WithoutHeimdall = ctx.Bool(WithoutHeimdallFlag.Name)
 	cfg.HeimdallgRPCAddress = ctx.String(HeimdallgRPCAddressFlag.Name)
+	cfg.HeimdallgRPCTLSCert = ctx.String(HeimdallgRPCTLSCertFlag.Name)
+	cfg.HeimdallgRPCTLSKey = ctx.String(HeimdallgRPCTLSKeyFlag.Name)
+	cfg.HeimdallgRPCTLSCA = ctx.String(HeimdallgRPCTLSCAFlag.Name)
+	cfg.HeimdallgRPCToken = ctx.String(HeimdallgRPCTokenFlag.Name)
 	cfg.RunHeimdall = ctx.Bool(RunHeimdallFlag.Name)
 	cfg.RunHeimdallArgs = ctx.String(RunHeimdallArgsFlag.Name)
 	cfg.UseHeimdallApp = ctx.Bool(UseHeimdallAppFlag.Name)
+
+	if ctx.IsSet(BorTrustedCheckpointFlag.Name) {
+		checkpoint, err := bor.ParseTrustedCheckpoint(ctx.String(BorTrustedCheckpointFlag.Name))
+		if err != nil {
+			Fatalf("Invalid %s: %v", BorTrustedCheckpointFlag.Name, err)
+		}
+
+		cfg.BorTrustedCheckpoint = checkpoint
+	}
 }
 
 // CreateBorEthereum Creates bor ethereum object from eth.Config
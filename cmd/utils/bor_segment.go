@@ -0,0 +1,813 @@
+package utils
+
+import (
+	"compress/gzip"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+	"os"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/consensus"
+	"github.com/ethereum/go-ethereum/consensus/bor"
+	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/ethdb"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/params"
+	"github.com/ethereum/go-ethereum/rlp"
+	"github.com/ethereum/go-ethereum/trie"
+)
+
+// BorSegmentVersion is the version of the chain segment format written by
+// ExportBorSegment. Segments with a different version are rejected on import.
+const BorSegmentVersion = 1
+
+// DefaultBorSegmentChunkSize is the default number of blocks per segment chunk.
+const DefaultBorSegmentChunkSize = 128
+
+var (
+	errBorSegmentChecksum = errors.New("chain segment chunk checksum mismatch")
+	errBorSegmentVersion  = errors.New("unsupported chain segment version")
+	errBorSegmentGenesis  = errors.New("chain segment belongs to a different network")
+)
+
+// borSegmentChunk is the checksummed envelope of every record in a segment
+// file. Chunk 0 carries the segment header, chunks 1..N carry the blocks.
+type borSegmentChunk struct {
+	Index    uint64
+	Checksum common.Hash
+	Payload  []byte
+}
+
+// borSegmentFinality is a whitelisted checkpoint or milestone entry. A zero
+// hash means the exporting node had no entry within the segment.
+type borSegmentFinality struct {
+	Number uint64
+	Hash   common.Hash
+}
+
+// borSegmentSnapshot is an encoded Bor snapshot persisted at a block hash.
+type borSegmentSnapshot struct {
+	Number uint64
+	Hash   common.Hash
+	Blob   []byte
+}
+
+// borSegmentHeader describes the block range and the Bor-specific state
+// carried by a segment.
+type borSegmentHeader struct {
+	Version    uint64
+	Genesis    common.Hash
+	First      uint64
+	Last       uint64
+	Chunks     uint64
+	Checkpoint borSegmentFinality
+	Milestone  borSegmentFinality
+	Snapshots  []*borSegmentSnapshot
+}
+
+// borSegmentBlock is a single block with all of its associated data.
+type borSegmentBlock struct {
+	Header       rlp.RawValue
+	Body         rlp.RawValue
+	Receipts     rlp.RawValue
+	Td           *big.Int
+	BorReceipt   []byte // RLP of the bor receipt, empty if the block has none
+	BorTxIndexed bool   // Whether the bor tx lookup entry was indexed
+}
+
+// borSegmentChunkData is the payload of a block chunk.
+type borSegmentChunkData struct {
+	Blocks []*borSegmentBlock
+}
+
+func newBorSegmentChunk(index uint64, val interface{}) (*borSegmentChunk, error) {
+	payload, err := rlp.EncodeToBytes(val)
+	if err != nil {
+		return nil, err
+	}
+
+	return &borSegmentChunk{Index: index, Checksum: crypto.Keccak256Hash(payload), Payload: payload}, nil
+}
+
+// open verifies the chunk index and checksum and decodes the payload into val.
+func (c *borSegmentChunk) open(index uint64, val interface{}) error {
+	if c.Index != index {
+		return fmt.Errorf("unexpected chain segment chunk %d, want %d", c.Index, index)
+	}
+
+	if crypto.Keccak256Hash(c.Payload) != c.Checksum {
+		return fmt.Errorf("%w: chunk %d", errBorSegmentChecksum, index)
+	}
+
+	return rlp.DecodeBytes(c.Payload, val)
+}
+
+// ExportBorSegment exports the canonical blocks [first, last] into the
+// specified file, together with their bor receipts and bor tx lookup entries,
+// the Bor snapshots at the segment boundaries and the whitelisted checkpoint
+// and milestone. The file is split in checksummed chunks of chunkSize blocks.
+func ExportBorSegment(db ethdb.Database, fn string, first uint64, last uint64, chunkSize uint64) error {
+	if first > last {
+		return fmt.Errorf("invalid block range %d-%d", first, last)
+	}
+
+	if chunkSize == 0 {
+		chunkSize = DefaultBorSegmentChunkSize
+	}
+
+	genesis := rawdb.ReadCanonicalHash(db, 0)
+	if genesis == (common.Hash{}) {
+		return errors.New("genesis block not found")
+	}
+
+	header := &borSegmentHeader{
+		Version: BorSegmentVersion,
+		Genesis: genesis,
+		First:   first,
+		Last:    last,
+		Chunks:  (last - first + chunkSize) / chunkSize,
+	}
+
+	if number, hash, err := rawdb.ReadFinality[*rawdb.Checkpoint](db); err == nil && number <= last {
+		header.Checkpoint = borSegmentFinality{Number: number, Hash: hash}
+	}
+
+	if number, hash, err := rawdb.ReadFinality[*rawdb.Milestone](db); err == nil && number <= last {
+		header.Milestone = borSegmentFinality{Number: number, Hash: hash}
+	}
+
+	for _, number := range []uint64{first, last} {
+		if snap := readBorSegmentSnapshot(db, number); snap != nil {
+			if len(header.Snapshots) == 0 || header.Snapshots[0].Hash != snap.Hash {
+				header.Snapshots = append(header.Snapshots, snap)
+			}
+		}
+	}
+
+	log.Info("Exporting chain segment", "file", fn, "first", first, "last", last, "chunks", header.Chunks)
+
+	// Open the file handle and potentially wrap with a gzip stream
+	fh, err := os.OpenFile(fn, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, os.ModePerm)
+	if err != nil {
+		return err
+	}
+	defer fh.Close()
+
+	var writer io.Writer = fh
+	if strings.HasSuffix(fn, ".gz") {
+		writer = gzip.NewWriter(writer)
+	}
+
+	chunk, err := newBorSegmentChunk(0, header)
+	if err != nil {
+		return err
+	}
+
+	if err := rlp.Encode(writer, chunk); err != nil {
+		return err
+	}
+
+	for index := uint64(1); index <= header.Chunks; index++ {
+		start := first + (index-1)*chunkSize
+		end := start + chunkSize - 1
+
+		if end > last {
+			end = last
+		}
+
+		data := &borSegmentChunkData{Blocks: make([]*borSegmentBlock, 0, end-start+1)}
+
+		for number := start; number <= end; number++ {
+			block, err := readBorSegmentBlock(db, number)
+			if err != nil {
+				return err
+			}
+
+			data.Blocks = append(data.Blocks, block)
+		}
+
+		if chunk, err = newBorSegmentChunk(index, data); err != nil {
+			return err
+		}
+
+		if err := rlp.Encode(writer, chunk); err != nil {
+			return err
+		}
+
+		log.Info("Exported chain segment chunk", "chunk", index, "first", start, "last", end)
+	}
+
+	// Flush the gzip stream and the file, a failure leaves a truncated segment
+	if gz, ok := writer.(*gzip.Writer); ok {
+		if err := gz.Close(); err != nil {
+			return err
+		}
+	}
+
+	if err := fh.Close(); err != nil {
+		return err
+	}
+
+	log.Info("Exported chain segment", "file", fn)
+
+	return nil
+}
+
+// readBorSegmentBlock collects the canonical block at the given number.
+func readBorSegmentBlock(db ethdb.Database, number uint64) (*borSegmentBlock, error) {
+	hash := rawdb.ReadCanonicalHash(db, number)
+	if hash == (common.Hash{}) {
+		return nil, fmt.Errorf("canonical block %d not found", number)
+	}
+
+	block := &borSegmentBlock{
+		Header:     rawdb.ReadHeaderRLP(db, hash, number),
+		Body:       rawdb.ReadBodyRLP(db, hash, number),
+		Receipts:   rawdb.ReadReceiptsRLP(db, hash, number),
+		Td:         rawdb.ReadTd(db, hash, number),
+		BorReceipt: rawdb.ReadBorReceiptRLP(db, hash, number),
+	}
+
+	switch {
+	case len(block.Header) == 0:
+		return nil, fmt.Errorf("header %d not found", number)
+	case len(block.Body) == 0:
+		return nil, fmt.Errorf("body %d not found", number)
+	case len(block.Receipts) == 0:
+		return nil, fmt.Errorf("receipts %d not found", number)
+	case block.Td == nil:
+		return nil, fmt.Errorf("total difficulty %d not found", number)
+	}
+
+	if len(block.BorReceipt) > 0 {
+		txHash := types.GetDerivedBorTxHash(types.BorReceiptKey(number, hash))
+		if entry := rawdb.ReadBorTxLookupEntry(db, txHash); entry != nil && *entry == number {
+			block.BorTxIndexed = true
+		}
+	}
+
+	return block, nil
+}
+
+// readBorSegmentSnapshot looks up the latest Bor snapshot persisted on the
+// canonical chain at or below the given block number.
+func readBorSegmentSnapshot(db ethdb.Database, number uint64) *borSegmentSnapshot {
+	for n := number - number%bor.SnapshotInterval; ; n -= bor.SnapshotInterval {
+		hash := rawdb.ReadCanonicalHash(db, n)
+		if hash != (common.Hash{}) {
+			if blob := bor.ReadSnapshotBlob(db, hash); len(blob) > 0 {
+				return &borSegmentSnapshot{Number: n, Hash: hash, Blob: blob}
+			}
+		}
+
+		if n < bor.SnapshotInterval {
+			return nil
+		}
+	}
+}
+
+// borSegmentHeaderWindow is the number of recently verified segment headers
+// kept around for the seal verification of the following ones.
+const borSegmentHeaderWindow = 1024
+
+// borSegmentReader reads the chunks of a segment file.
+type borSegmentReader struct {
+	file   *os.File
+	stream *rlp.Stream
+	header borSegmentHeader
+}
+
+// openBorSegment opens a segment file and reads its header.
+func openBorSegment(fn string) (*borSegmentReader, error) {
+	fh, err := os.Open(fn)
+	if err != nil {
+		return nil, err
+	}
+
+	// Potentially unwrap the gzip stream
+	var reader io.Reader = fh
+	if strings.HasSuffix(fn, ".gz") {
+		if reader, err = gzip.NewReader(reader); err != nil {
+			fh.Close()
+			return nil, err
+		}
+	}
+
+	segment := &borSegmentReader{file: fh, stream: rlp.NewStream(reader, 0)}
+
+	var chunk borSegmentChunk
+	if err := segment.stream.Decode(&chunk); err != nil {
+		fh.Close()
+		return nil, fmt.Errorf("failed to read chain segment header: %w", err)
+	}
+
+	if err := chunk.open(0, &segment.header); err != nil {
+		fh.Close()
+		return nil, err
+	}
+
+	if segment.header.Version != BorSegmentVersion {
+		fh.Close()
+		return nil, fmt.Errorf("%w: %d", errBorSegmentVersion, segment.header.Version)
+	}
+
+	return segment, nil
+}
+
+// next reads the block chunk with the given index, returning its checksum.
+func (r *borSegmentReader) next(index uint64) (*borSegmentChunkData, common.Hash, error) {
+	var (
+		chunk borSegmentChunk
+		data  borSegmentChunkData
+	)
+
+	if err := r.stream.Decode(&chunk); err != nil {
+		return nil, common.Hash{}, fmt.Errorf("failed to read chain segment chunk %d: %w", index, err)
+	}
+
+	if err := chunk.open(index, &data); err != nil {
+		return nil, common.Hash{}, err
+	}
+
+	return &data, chunk.Checksum, nil
+}
+
+func (r *borSegmentReader) Close() error {
+	return r.file.Close()
+}
+
+// borSealVerifier is implemented by consensus engines able to check the seal of
+// a header on its own, without the state the full header verification needs.
+type borSealVerifier interface {
+	VerifySeal(chain consensus.ChainHeaderReader, header *types.Header) error
+}
+
+// borSnapshotVerifier is implemented by consensus engines able to check an
+// encoded snapshot against the one they derive from the chain.
+type borSnapshotVerifier interface {
+	VerifySnapshot(chain consensus.ChainHeaderReader, number uint64, hash common.Hash, blob []byte) error
+}
+
+// borSegmentChain is the chain header reader the segment headers are verified
+// against. It serves the recently verified segment headers on top of the local
+// chain, as they are only written once the whole segment is verified.
+type borSegmentChain struct {
+	db      ethdb.Database
+	config  *params.ChainConfig
+	headers map[common.Hash]*types.Header
+	numbers map[uint64]common.Hash
+}
+
+func newBorSegmentChain(db ethdb.Database, config *params.ChainConfig) *borSegmentChain {
+	return &borSegmentChain{
+		db:      db,
+		config:  config,
+		headers: make(map[common.Hash]*types.Header),
+		numbers: make(map[uint64]common.Hash),
+	}
+}
+
+// add tracks a verified segment header, dropping the ones out of the window.
+func (c *borSegmentChain) add(header *types.Header) {
+	number := header.Number.Uint64()
+
+	c.headers[header.Hash()] = header
+	c.numbers[number] = header.Hash()
+
+	if number >= borSegmentHeaderWindow {
+		if hash, ok := c.numbers[number-borSegmentHeaderWindow]; ok {
+			delete(c.headers, hash)
+			delete(c.numbers, number-borSegmentHeaderWindow)
+		}
+	}
+}
+
+func (c *borSegmentChain) Config() *params.ChainConfig { return c.config }
+
+func (c *borSegmentChain) CurrentHeader() *types.Header { return rawdb.ReadHeadHeader(c.db) }
+
+func (c *borSegmentChain) GetHeader(hash common.Hash, number uint64) *types.Header {
+	if header, ok := c.headers[hash]; ok {
+		return header
+	}
+
+	return rawdb.ReadHeader(c.db, hash, number)
+}
+
+func (c *borSegmentChain) GetHeaderByNumber(number uint64) *types.Header {
+	if hash, ok := c.numbers[number]; ok {
+		return c.headers[hash]
+	}
+
+	return rawdb.ReadHeader(c.db, rawdb.ReadCanonicalHash(c.db, number), number)
+}
+
+func (c *borSegmentChain) GetHeaderByHash(hash common.Hash) *types.Header {
+	if header, ok := c.headers[hash]; ok {
+		return header
+	}
+
+	if number := rawdb.ReadHeaderNumber(c.db, hash); number != nil {
+		return rawdb.ReadHeader(c.db, hash, *number)
+	}
+
+	return nil
+}
+
+func (c *borSegmentChain) GetTd(hash common.Hash, number uint64) *big.Int {
+	return rawdb.ReadTd(c.db, hash, number)
+}
+
+// ImportBorSegment verifies a chain segment written by ExportBorSegment and,
+// unless verifyOnly is set, writes its contents into the database. The segment
+// must connect to the local canonical chain and must not conflict with it, and
+// the headers of the new blocks are verified by the consensus engine. The whole
+// segment is verified before anything is written.
+//
+// The Bor snapshots and the whitelisted checkpoint and milestone carried by the
+// segment must be on the verified chain, and the snapshots must match the ones
+// the engine derives at the same blocks. They are not written though: the
+// engine persists the snapshots it derives while verifying the seals, and the
+// finalized blocks are whitelisted from Heimdall once the node runs.
+//
+// Blocks are stored without state, the chain head is therefore only advanced
+// for headers and receipts.
+func ImportBorSegment(db ethdb.Database, engine consensus.Engine, fn string, verifyOnly bool) error {
+	log.Info("Importing chain segment", "file", fn, "verify", verifyOnly)
+
+	segment, err := openBorSegment(fn)
+	if err != nil {
+		return err
+	}
+
+	header := segment.header
+
+	genesis := rawdb.ReadCanonicalHash(db, 0)
+	if genesis != header.Genesis {
+		segment.Close()
+		return fmt.Errorf("%w: have genesis %x, segment %x", errBorSegmentGenesis, genesis, header.Genesis)
+	}
+
+	config := rawdb.ReadChainConfig(db, genesis)
+	if config == nil {
+		segment.Close()
+		return errors.New("chain config not found")
+	}
+
+	// Bor seals are verified against the snapshot of the parent block, which
+	// has to be derived from one persisted by the local chain
+	if _, ok := engine.(*bor.Bor); ok && header.First > 0 && readBorSegmentSnapshot(db, header.First-1) == nil {
+		segment.Close()
+		return fmt.Errorf("no bor snapshot persisted at or below block %d", header.First-1)
+	}
+
+	checksums, _, err := processBorSegment(db, config, engine, segment, nil)
+	segment.Close()
+
+	if err != nil {
+		return err
+	}
+
+	if verifyOnly {
+		log.Info("Verified chain segment", "file", fn, "first", header.First, "last", header.Last)
+		return nil
+	}
+
+	// Read the segment again to write it, making sure it is still the one verified
+	if segment, err = openBorSegment(fn); err != nil {
+		return err
+	}
+	defer segment.Close()
+
+	_, last, err := processBorSegment(db, config, nil, segment, checksums)
+	if err != nil {
+		return err
+	}
+
+	// Advance the header and snap sync heads, state is not carried over
+	if head := rawdb.ReadHeadHeader(db); head == nil || head.Number.Uint64() < header.Last {
+		rawdb.WriteHeadHeaderHash(db, last.Hash())
+	}
+
+	if hash := rawdb.ReadHeadFastBlockHash(db); hash != (common.Hash{}) {
+		if head := rawdb.ReadHeaderNumber(db, hash); head != nil && *head+1 >= header.First && *head < header.Last {
+			rawdb.WriteHeadFastBlockHash(db, last.Hash())
+		}
+	}
+
+	log.Info("Imported chain segment", "file", fn, "first", header.First, "last", header.Last)
+
+	return nil
+}
+
+// processBorSegment runs through the block chunks of a segment on top of the
+// local chain. Without checksums, the blocks are verified, including their
+// headers by the given engine, and the checksums of the chunks are returned.
+// With the checksums of a previous verification, the chunks are checked to be
+// the verified ones and the new blocks are written. The last header of the
+// segment is returned as well.
+func processBorSegment(db ethdb.Database, config *params.ChainConfig, engine consensus.Engine, segment *borSegmentReader, checksums []common.Hash) ([]common.Hash, *types.Header, error) {
+	var (
+		header   = segment.header
+		write    = checksums != nil
+		chain    = newBorSegmentChain(db, config)
+		parent   *types.Header
+		parentTd *big.Int
+		number   = header.First
+		genesis  = rawdb.ReadCanonicalHash(db, 0)
+		verified []common.Hash
+	)
+
+	if write && uint64(len(checksums)) != header.Chunks {
+		return nil, nil, errors.New("chain segment changed since its verification")
+	}
+
+	if number == 0 {
+		number = 1
+	} else {
+		hash := rawdb.ReadCanonicalHash(db, number-1)
+		if parent = rawdb.ReadHeader(db, hash, number-1); parent == nil {
+			return nil, nil, fmt.Errorf("chain segment does not connect to the local chain at block %d", number-1)
+		}
+
+		parentTd = rawdb.ReadTd(db, hash, number-1)
+	}
+
+	// Check the snapshots and finalized blocks carried by the segment which
+	// are on the local chain, the others are checked along the segment
+	if !write {
+		for _, anchor := range header.anchors() {
+			if anchor > header.Last {
+				return nil, nil, fmt.Errorf("chain segment refers to block %d past its end", anchor)
+			}
+
+			if anchor < number {
+				if err := verifyBorSegmentAnchors(engine, chain, &header, anchor, rawdb.ReadCanonicalHash(db, anchor)); err != nil {
+					return nil, nil, err
+				}
+			}
+		}
+	}
+
+	for index := uint64(1); index <= header.Chunks; index++ {
+		data, checksum, err := segment.next(index)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		if write && checksum != checksums[index-1] {
+			return nil, nil, fmt.Errorf("chain segment chunk %d changed since its verification", index)
+		}
+
+		verified = append(verified, checksum)
+
+		batch := db.NewBatch()
+
+		for _, entry := range data.Blocks {
+			block, receipts, err := verifyBorSegmentBlock(entry, parent, parentTd)
+			if err != nil {
+				return nil, nil, err
+			}
+
+			// The genesis block is only used to anchor the segment
+			if block.NumberU64() == 0 {
+				if block.Hash() != genesis {
+					return nil, nil, fmt.Errorf("%w: segment genesis %x", errBorSegmentGenesis, block.Hash())
+				}
+
+				parent, parentTd = block.Header(), entry.Td
+
+				continue
+			}
+
+			if block.NumberU64() != number {
+				return nil, nil, fmt.Errorf("unexpected block %d in chain segment, want %d", block.NumberU64(), number)
+			}
+
+			if err := verifyBorSegmentReceipts(block, receipts); err != nil {
+				return nil, nil, err
+			}
+
+			local := rawdb.ReadCanonicalHash(db, number)
+			if local != (common.Hash{}) && local != block.Hash() {
+				return nil, nil, fmt.Errorf("block %d conflicts with the local chain: have %x, segment %x", number, local, block.Hash())
+			}
+
+			if local == (common.Hash{}) {
+				if write {
+					writeBorSegmentBlock(batch, block, receipts, entry)
+				} else if err := verifyBorSegmentHeader(engine, chain, block.Header()); err != nil {
+					return nil, nil, fmt.Errorf("invalid block %d in chain segment: %w", number, err)
+				}
+			}
+
+			chain.add(block.Header())
+
+			if !write {
+				if err := verifyBorSegmentAnchors(engine, chain, &header, number, block.Hash()); err != nil {
+					return nil, nil, err
+				}
+			}
+
+			parent, parentTd = block.Header(), entry.Td
+			number++
+		}
+
+		if number <= header.Last && len(data.Blocks) == 0 {
+			return nil, nil, fmt.Errorf("empty chain segment chunk %d", index)
+		}
+
+		if write {
+			if err := batch.Write(); err != nil {
+				return nil, nil, err
+			}
+
+			log.Info("Imported chain segment chunk", "chunk", index, "number", number-1)
+		} else {
+			log.Info("Verified chain segment chunk", "chunk", index, "number", number-1)
+		}
+	}
+
+	if number != header.Last+1 {
+		return nil, nil, fmt.Errorf("chain segment ended at block %d, want %d", number-1, header.Last)
+	}
+
+	return verified, parent, nil
+}
+
+// anchors returns the numbers of the blocks the segment carries a snapshot or a
+// finalized entry for.
+func (h *borSegmentHeader) anchors() []uint64 {
+	var numbers []uint64
+
+	for _, finality := range []borSegmentFinality{h.Checkpoint, h.Milestone} {
+		if finality.Hash != (common.Hash{}) {
+			numbers = append(numbers, finality.Number)
+		}
+	}
+
+	for _, snap := range h.Snapshots {
+		numbers = append(numbers, snap.Number)
+	}
+
+	return numbers
+}
+
+// verifyBorSegmentAnchors checks the snapshots and the finalized entries carried
+// by the segment at the given block of the verified chain. Snapshots are also
+// checked against the ones derived by Bor engines.
+func verifyBorSegmentAnchors(engine consensus.Engine, chain *borSegmentChain, header *borSegmentHeader, number uint64, hash common.Hash) error {
+	for name, finality := range map[string]borSegmentFinality{"checkpoint": header.Checkpoint, "milestone": header.Milestone} {
+		if finality.Hash != (common.Hash{}) && finality.Number == number && finality.Hash != hash {
+			return fmt.Errorf("%s %d conflicts with the chain: have %x, segment %x", name, number, hash, finality.Hash)
+		}
+	}
+
+	for _, snap := range header.Snapshots {
+		if snap.Number != number {
+			continue
+		}
+
+		if snap.Hash != hash {
+			return fmt.Errorf("bor snapshot %d conflicts with the chain: have %x, segment %x", number, hash, snap.Hash)
+		}
+
+		if verifier, ok := engine.(borSnapshotVerifier); ok {
+			if err := verifier.VerifySnapshot(chain, number, hash, snap.Blob); err != nil {
+				return fmt.Errorf("invalid bor snapshot at block %d: %w", number, err)
+			}
+		}
+	}
+
+	return nil
+}
+
+// verifyBorSegmentHeader verifies a segment header with the consensus engine.
+// Bor headers only have their seal verified, as the validator set checks need
+// the state of the chain or Heimdall.
+func verifyBorSegmentHeader(engine consensus.Engine, chain *borSegmentChain, header *types.Header) error {
+	if verifier, ok := engine.(borSealVerifier); ok {
+		return verifier.VerifySeal(chain, header)
+	}
+
+	return engine.VerifyHeader(chain, header)
+}
+
+// verifyBorSegmentBlock decodes a segment block and checks it against its
+// parent and its own header commitments.
+func verifyBorSegmentBlock(entry *borSegmentBlock, parent *types.Header, parentTd *big.Int) (*types.Block, types.Receipts, error) {
+	var (
+		header   types.Header
+		body     types.Body
+		receipts []*types.ReceiptForStorage
+	)
+
+	if err := rlp.DecodeBytes(entry.Header, &header); err != nil {
+		return nil, nil, fmt.Errorf("invalid chain segment header: %w", err)
+	}
+
+	number := header.Number.Uint64()
+
+	if err := rlp.DecodeBytes(entry.Body, &body); err != nil {
+		return nil, nil, fmt.Errorf("invalid body %d: %w", number, err)
+	}
+
+	if err := rlp.DecodeBytes(entry.Receipts, &receipts); err != nil {
+		return nil, nil, fmt.Errorf("invalid receipts %d: %w", number, err)
+	}
+
+	if len(entry.BorReceipt) > 0 {
+		if err := rlp.DecodeBytes(entry.BorReceipt, new(types.ReceiptForStorage)); err != nil {
+			return nil, nil, fmt.Errorf("invalid bor receipt %d: %w", number, err)
+		}
+	} else if entry.BorTxIndexed {
+		return nil, nil, fmt.Errorf("bor tx lookup without bor receipt at block %d", number)
+	}
+
+	block := types.NewBlockWithHeader(&header).WithBody(body.Transactions, body.Uncles)
+
+	if hash := types.DeriveSha(types.Transactions(body.Transactions), trie.NewStackTrie(nil)); hash != header.TxHash {
+		return nil, nil, fmt.Errorf("transaction root mismatch at block %d: have %x, want %x", number, hash, header.TxHash)
+	}
+
+	if hash := types.CalcUncleHash(body.Uncles); hash != header.UncleHash {
+		return nil, nil, fmt.Errorf("uncle root mismatch at block %d: have %x, want %x", number, hash, header.UncleHash)
+	}
+
+	if parent == nil {
+		if number != 0 {
+			return nil, nil, fmt.Errorf("block %d has no parent in the chain segment", number)
+		}
+	} else {
+		if header.ParentHash != parent.Hash() || number != parent.Number.Uint64()+1 {
+			return nil, nil, fmt.Errorf("block %d does not extend its parent %d [%x]", number, parent.Number, parent.Hash())
+		}
+
+		if parentTd != nil && entry.Td != nil {
+			if td := new(big.Int).Add(parentTd, header.Difficulty); td.Cmp(entry.Td) != 0 {
+				return nil, nil, fmt.Errorf("total difficulty mismatch at block %d: have %v, want %v", number, entry.Td, td)
+			}
+		}
+	}
+
+	if entry.Td == nil {
+		return nil, nil, fmt.Errorf("missing total difficulty at block %d", number)
+	}
+
+	result := make(types.Receipts, len(receipts))
+	for i, receipt := range receipts {
+		result[i] = (*types.Receipt)(receipt)
+	}
+
+	return block, result, nil
+}
+
+// verifyBorSegmentReceipts checks the receipts against the block's receipt root.
+func verifyBorSegmentReceipts(block *types.Block, receipts types.Receipts) error {
+	txs := block.Transactions()
+	if len(txs) != len(receipts) {
+		return fmt.Errorf("receipt count mismatch at block %d: have %d, want %d", block.NumberU64(), len(receipts), len(txs))
+	}
+
+	// The receipt type is not persisted, restore it before deriving the root
+	for i, receipt := range receipts {
+		receipt.Type = txs[i].Type()
+	}
+
+	if hash := types.DeriveSha(receipts, trie.NewStackTrie(nil)); hash != block.ReceiptHash() {
+		return fmt.Errorf("receipt root mismatch at block %d: have %x, want %x", block.NumberU64(), hash, block.ReceiptHash())
+	}
+
+	return nil
+}
+
+func writeBorSegmentBlock(db ethdb.KeyValueWriter, block *types.Block, receipts types.Receipts, entry *borSegmentBlock) {
+	hash, number := block.Hash(), block.NumberU64()
+
+	rawdb.WriteTd(db, hash, number, entry.Td)
+	rawdb.WriteBlock(db, block)
+	rawdb.WriteReceipts(db, hash, number, receipts)
+	rawdb.WriteCanonicalHash(db, hash, number)
+	rawdb.WriteTxLookupEntriesByBlock(db, block)
+
+	if len(entry.BorReceipt) > 0 {
+		var receipt types.ReceiptForStorage
+
+		// Already validated in verifyBorSegmentBlock
+		_ = rlp.DecodeBytes(entry.BorReceipt, &receipt)
+
+		// Bor receipts are not committed to by the headers, keep them from
+		// being served until derived locally
+		rawdb.WriteBorReceipt(db, hash, number, &receipt)
+		rawdb.WriteBorReceiptUnverified(db, hash, number)
+
+		if entry.BorTxIndexed {
+			rawdb.WriteBorTxLookupEntry(db, hash, number)
+		}
+	}
+}
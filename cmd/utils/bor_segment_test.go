@@ -0,0 +1,301 @@
+package utils
+
+import (
+	"fmt"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/consensus/bor"
+	"github.com/ethereum/go-ethereum/consensus/ethash"
+	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/ethdb"
+	"github.com/ethereum/go-ethereum/params"
+)
+
+func newBorSegmentTestChain(t *testing.T) (*core.Genesis, []*types.Block, []types.Receipts) {
+	t.Helper()
+
+	var (
+		key, _ = crypto.HexToECDSA("b71c71a67e1177ad4e901695e1b4b9ee17ae16c6668d313eac2f96dbcda3f291")
+		addr   = crypto.PubkeyToAddress(key.PublicKey)
+		gspec  = &core.Genesis{
+			Config: params.TestChainConfig,
+			Alloc:  core.GenesisAlloc{addr: {Balance: big.NewInt(1000000000000000000)}},
+		}
+		signer = types.LatestSigner(gspec.Config)
+	)
+
+	_, blocks, receipts := core.GenerateChainWithGenesis(gspec, ethash.NewFaker(), 10, func(i int, gen *core.BlockGen) {
+		tx, err := types.SignTx(types.NewTransaction(gen.TxNonce(addr), common.Address{0x01}, big.NewInt(1), params.TxGas, gen.BaseFee(), nil), signer, key)
+		if err != nil {
+			t.Fatalf("failed to sign tx: %v", err)
+		}
+
+		gen.AddTx(tx)
+	})
+
+	return gspec, blocks, receipts
+}
+
+func writeBorSegmentTestBlocks(db ethdb.Database, blocks []*types.Block, receipts []types.Receipts) {
+	td := rawdb.ReadTd(db, rawdb.ReadCanonicalHash(db, 0), 0)
+
+	for i, block := range blocks {
+		td = new(big.Int).Add(td, block.Difficulty())
+
+		rawdb.WriteTd(db, block.Hash(), block.NumberU64(), td)
+		rawdb.WriteBlock(db, block)
+		rawdb.WriteReceipts(db, block.Hash(), block.NumberU64(), receipts[i])
+		rawdb.WriteCanonicalHash(db, block.Hash(), block.NumberU64())
+		rawdb.WriteHeadHeaderHash(db, block.Hash())
+		rawdb.WriteHeadFastBlockHash(db, block.Hash())
+	}
+}
+
+func TestBorSegmentExportImport(t *testing.T) {
+	t.Parallel()
+
+	gspec, blocks, receipts := newBorSegmentTestChain(t)
+
+	// Source node with the full chain, a bor receipt, a snapshot and a checkpoint
+	src := rawdb.NewMemoryDatabase()
+	genesis := gspec.MustCommit(src)
+	writeBorSegmentTestBlocks(src, blocks, receipts)
+
+	borBlock := blocks[3]
+	rawdb.WriteBorReceipt(src, borBlock.Hash(), borBlock.NumberU64(), &types.ReceiptForStorage{Status: types.ReceiptStatusSuccessful, Logs: []*types.Log{}})
+	rawdb.WriteBorTxLookupEntry(src, borBlock.Hash(), borBlock.NumberU64())
+
+	snapshot := []byte(fmt.Sprintf(`{"number":0,"hash":"%s"}`, genesis.Hash().Hex()))
+	if err := bor.WriteSnapshotBlob(src, genesis.Hash(), snapshot); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := rawdb.WriteLastFinality[*rawdb.Checkpoint](src, blocks[5].NumberU64(), blocks[5].Hash()); err != nil {
+		t.Fatal(err)
+	}
+
+	fn := filepath.Join(t.TempDir(), "segment.gz")
+	if err := ExportBorSegment(src, fn, 3, 10, 3); err != nil {
+		t.Fatalf("failed to export segment: %v", err)
+	}
+
+	// Destination node which already has the first two blocks
+	dst := rawdb.NewMemoryDatabase()
+	gspec.MustCommit(dst)
+	writeBorSegmentTestBlocks(dst, blocks[:2], receipts[:2])
+
+	if err := ImportBorSegment(dst, ethash.NewFaker(), fn, true); err != nil {
+		t.Fatalf("failed to verify segment: %v", err)
+	}
+
+	if hash := rawdb.ReadCanonicalHash(dst, 3); hash != (common.Hash{}) {
+		t.Fatalf("verification wrote block 3")
+	}
+
+	if err := ImportBorSegment(dst, ethash.NewFaker(), fn, false); err != nil {
+		t.Fatalf("failed to import segment: %v", err)
+	}
+
+	for _, block := range blocks[2:] {
+		if hash := rawdb.ReadCanonicalHash(dst, block.NumberU64()); hash != block.Hash() {
+			t.Fatalf("block %d: canonical hash mismatch: have %x, want %x", block.NumberU64(), hash, block.Hash())
+		}
+
+		if !rawdb.HasReceipts(dst, block.Hash(), block.NumberU64()) {
+			t.Fatalf("block %d: missing receipts", block.NumberU64())
+		}
+	}
+
+	if receipt := rawdb.ReadRawBorReceipt(dst, borBlock.Hash(), borBlock.NumberU64()); receipt == nil {
+		t.Fatalf("missing bor receipt")
+	}
+
+	if !rawdb.IsBorReceiptUnverified(dst, borBlock.Hash(), borBlock.NumberU64()) {
+		t.Fatalf("imported bor receipt not marked unverified")
+	}
+
+	txHash := types.GetDerivedBorTxHash(types.BorReceiptKey(borBlock.NumberU64(), borBlock.Hash()))
+	if entry := rawdb.ReadBorTxLookupEntry(dst, txHash); entry == nil || *entry != borBlock.NumberU64() {
+		t.Fatalf("missing bor tx lookup entry")
+	}
+
+	// Snapshots and finality are only verified, they are rebuilt locally
+	if blob := bor.ReadSnapshotBlob(dst, genesis.Hash()); len(blob) != 0 {
+		t.Fatalf("snapshot imported from the segment: %s", blob)
+	}
+
+	if _, _, err := rawdb.ReadFinality[*rawdb.Checkpoint](dst); err == nil {
+		t.Fatalf("checkpoint imported from the segment")
+	}
+
+	if head := rawdb.ReadHeadHeaderHash(dst); head != blocks[9].Hash() {
+		t.Fatalf("head header mismatch: have %x, want %x", head, blocks[9].Hash())
+	}
+
+	if head := rawdb.ReadHeadFastBlockHash(dst); head != blocks[9].Hash() {
+		t.Fatalf("head fast block mismatch: have %x, want %x", head, blocks[9].Hash())
+	}
+
+	// Importing the same segment again is a no-op
+	if err := ImportBorSegment(dst, ethash.NewFaker(), fn, false); err != nil {
+		t.Fatalf("failed to re-import segment: %v", err)
+	}
+}
+
+func TestBorSegmentImportRejects(t *testing.T) {
+	t.Parallel()
+
+	gspec, blocks, receipts := newBorSegmentTestChain(t)
+
+	src := rawdb.NewMemoryDatabase()
+	gspec.MustCommit(src)
+	writeBorSegmentTestBlocks(src, blocks, receipts)
+
+	fn := filepath.Join(t.TempDir(), "segment")
+	if err := ExportBorSegment(src, fn, 5, 10, 2); err != nil {
+		t.Fatalf("failed to export segment: %v", err)
+	}
+
+	// The segment does not connect to a node without blocks 1-4
+	dst := rawdb.NewMemoryDatabase()
+	gspec.MustCommit(dst)
+
+	if err := ImportBorSegment(dst, ethash.NewFaker(), fn, true); err == nil {
+		t.Fatalf("imported disconnected segment")
+	}
+
+	// Any modification of the file is detected
+	writeBorSegmentTestBlocks(dst, blocks[:4], receipts[:4])
+
+	data, err := os.ReadFile(fn)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	data[len(data)-8] ^= 0xff
+
+	if err := os.WriteFile(fn, data, 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := ImportBorSegment(dst, ethash.NewFaker(), fn, false); err == nil {
+		t.Fatalf("imported corrupted segment")
+	}
+
+	if hash := rawdb.ReadCanonicalHash(dst, 10); hash != (common.Hash{}) {
+		t.Fatalf("corrupted chunk was written")
+	}
+}
+
+func TestBorSegmentImportVerifiesHeaders(t *testing.T) {
+	t.Parallel()
+
+	gspec, blocks, receipts := newBorSegmentTestChain(t)
+
+	src := rawdb.NewMemoryDatabase()
+	gspec.MustCommit(src)
+	writeBorSegmentTestBlocks(src, blocks, receipts)
+
+	fn := filepath.Join(t.TempDir(), "segment")
+	if err := ExportBorSegment(src, fn, 3, 10, 3); err != nil {
+		t.Fatalf("failed to export segment: %v", err)
+	}
+
+	dst := rawdb.NewMemoryDatabase()
+	gspec.MustCommit(dst)
+	writeBorSegmentTestBlocks(dst, blocks[:2], receipts[:2])
+
+	// A header failing verification in the last chunk rejects the whole segment
+	if err := ImportBorSegment(dst, ethash.NewFakeFailer(9), fn, false); err == nil {
+		t.Fatalf("imported segment with an invalid header")
+	}
+
+	for number := uint64(3); number <= 10; number++ {
+		if hash := rawdb.ReadCanonicalHash(dst, number); hash != (common.Hash{}) {
+			t.Fatalf("block %d written before the segment was verified", number)
+		}
+	}
+
+	// A segment starting at genesis must link its blocks to it
+	if err := ExportBorSegment(src, fn, 0, 4, 2); err != nil {
+		t.Fatalf("failed to export segment: %v", err)
+	}
+
+	if err := ImportBorSegment(dst, ethash.NewFaker(), fn, true); err != nil {
+		t.Fatalf("failed to verify segment: %v", err)
+	}
+
+	entry, err := readBorSegmentBlock(src, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, _, err := verifyBorSegmentBlock(entry, nil, nil); err == nil {
+		t.Fatalf("accepted block without parent")
+	}
+}
+
+func TestBorSegmentImportVerifiesAnchors(t *testing.T) {
+	t.Parallel()
+
+	gspec, blocks, receipts := newBorSegmentTestChain(t)
+
+	src := rawdb.NewMemoryDatabase()
+	gspec.MustCommit(src)
+	writeBorSegmentTestBlocks(src, blocks, receipts)
+
+	dst := rawdb.NewMemoryDatabase()
+	gspec.MustCommit(dst)
+	writeBorSegmentTestBlocks(dst, blocks[:2], receipts[:2])
+
+	fn := filepath.Join(t.TempDir(), "segment")
+
+	// A checkpoint within the segment must be on its chain
+	if err := rawdb.WriteLastFinality[*rawdb.Checkpoint](src, blocks[5].NumberU64(), common.Hash{0x01}); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := ExportBorSegment(src, fn, 3, 10, 3); err != nil {
+		t.Fatalf("failed to export segment: %v", err)
+	}
+
+	if err := ImportBorSegment(dst, ethash.NewFaker(), fn, true); err == nil {
+		t.Fatalf("accepted segment with a conflicting checkpoint")
+	}
+
+	// So must a milestone below the segment, on the local chain
+	if err := rawdb.WriteLastFinality[*rawdb.Checkpoint](src, blocks[5].NumberU64(), blocks[5].Hash()); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := rawdb.WriteLastFinality[*rawdb.Milestone](src, blocks[1].NumberU64(), common.Hash{0x02}); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := ExportBorSegment(src, fn, 3, 10, 3); err != nil {
+		t.Fatalf("failed to export segment: %v", err)
+	}
+
+	if err := ImportBorSegment(dst, ethash.NewFaker(), fn, true); err == nil {
+		t.Fatalf("accepted segment with a conflicting milestone")
+	}
+
+	if err := rawdb.WriteLastFinality[*rawdb.Milestone](src, blocks[1].NumberU64(), blocks[1].Hash()); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := ExportBorSegment(src, fn, 3, 10, 3); err != nil {
+		t.Fatalf("failed to export segment: %v", err)
+	}
+
+	if err := ImportBorSegment(dst, ethash.NewFaker(), fn, true); err != nil {
+		t.Fatalf("failed to verify segment: %v", err)
+	}
+}
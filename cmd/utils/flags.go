@@ -2063,6 +2063,7 @@ func RegisterFilterAPI(stack *node.Node, backend ethapi.Backend, ethcfg *ethconf
 	isLightClient := ethcfg.SyncMode == downloader.LightSync
 	filterSystem := filters.NewFilterSystem(backend, filters.Config{
 		LogCacheSize: ethcfg.FilterLogCacheSize,
+		BorLogs:      ethcfg.BorLogs,
 	})
 
 	filterAPI := filters.NewFilterAPI(filterSystem, isLightClient, ethcfg.BorLogs)
@@ -2298,6 +2299,10 @@ func MakeChain(ctx *cli.Context, stack *node.Node, readonly bool) (*core.BlockCh
 		HeimdallURL:         ctx.String(HeimdallURLFlag.Name),
 		WithoutHeimdall:     ctx.Bool(WithoutHeimdallFlag.Name),
 		HeimdallgRPCAddress: ctx.String(HeimdallgRPCAddressFlag.Name),
+		HeimdallgRPCTLSCert: ctx.String(HeimdallgRPCTLSCertFlag.Name),
+		HeimdallgRPCTLSKey:  ctx.String(HeimdallgRPCTLSKeyFlag.Name),
+		HeimdallgRPCTLSCA:   ctx.String(HeimdallgRPCTLSCAFlag.Name),
+		HeimdallgRPCToken:   ctx.String(HeimdallgRPCTokenFlag.Name),
 		RunHeimdall:         ctx.Bool(RunHeimdallArgsFlag.Name),
 		RunHeimdallArgs:     ctx.String(RunHeimdallArgsFlag.Name),
 		UseHeimdallApp:      ctx.Bool(UseHeimdallAppFlag.Name),
@@ -47,6 +47,7 @@ const (
 	checkpointInterval = 1024 // Number of blocks after which to save the vote snapshot to the database
 	inmemorySnapshots  = 128  // Number of recent vote snapshots to keep in memory
 	inmemorySignatures = 4096 // Number of recent block signatures to keep in memory
+	inmemorySpans      = 128  // Number of Heimdall spans to keep in memory for stateless header verification
 )
 
 // Bor protocol constants.
@@ -85,6 +86,10 @@ var (
 	// invalid list of validators (i.e. non divisible by 40 bytes).
 	errInvalidSpanValidators = errors.New("invalid validator list on sprint end block")
 
+	// errUnknownSpan is returned if no Heimdall span covering a block could be
+	// found while verifying headers without local state.
+	errUnknownSpan = errors.New("unknown span")
+
 	// errInvalidMixDigest is returned if a block's mix digest is non-zero.
 	errInvalidMixDigest = errors.New("non-zero mix digest")
 
@@ -102,6 +107,10 @@ var (
 	// be modified via out-of-range or non-contiguous headers.
 	errOutOfRangeChain = errors.New("out of range or non-contiguous chain")
 
+	// errSnapshotMismatch is returned if an encoded snapshot doesn't match the
+	// one derived from the local chain at the same block.
+	errSnapshotMismatch = errors.New("snapshot mismatch")
+
 	errUncleDetected     = errors.New("uncles not allowed")
 	errUnknownValidators = errors.New("unknown validators")
 )
@@ -216,6 +225,7 @@ type Bor struct {
 
 	recents    *lru.ARCCache // Snapshots for recent block to speed up reorgs
 	signatures *lru.ARCCache // Signatures of recent blocks to speed up mining
+	spans      *lru.ARCCache // Heimdall spans used to verify headers without local state
 
 	authorizedSigner atomic.Pointer[signer] // Ethereum address and sign function of the signing key
 
@@ -224,6 +234,8 @@ type Bor struct {
 	GenesisContractsClient GenesisContract
 	HeimdallClient         IHeimdallClient
 
+	trustedCheckpoint atomic.Pointer[TrustedCheckpoint] // Checkpoint up to which headers are trusted
+
 	// The fields below are for testing only
 	fakeDiff      bool // Skip difficulty verifications
 	devFakeAuthor bool
@@ -256,6 +268,7 @@ func New(
 	// Allocate the snapshot caches and create the engine
 	recents, _ := lru.NewARC(inmemorySnapshots)
 	signatures, _ := lru.NewARC(inmemorySignatures)
+	spans, _ := lru.NewARC(inmemorySpans)
 
 	c := &Bor{
 		chainConfig:            chainConfig,
@@ -264,6 +277,7 @@ func New(
 		ethAPI:                 ethAPI,
 		recents:                recents,
 		signatures:             signatures,
+		spans:                  spans,
 		spanner:                spanner,
 		GenesisContractsClient: genesisContracts,
 		HeimdallClient:         heimdallClient,
@@ -340,6 +354,12 @@ func (c *Bor) verifyHeader(chain consensus.ChainHeaderReader, header *types.Head
 
 	number := header.Number.Uint64()
 
+	// Headers up to a trusted checkpoint synced without state are anchored to
+	// it by their hash chain
+	if tc := c.trustedCheckpoint.Load(); tc != nil && number <= tc.EndBlock && c.verifyWithoutState(chain) {
+		return c.verifyTrustedHeader(header, tc)
+	}
+
 	// Don't waste time checking blocks from the future
 	if header.Time > uint64(time.Now().Unix()) {
 		return consensus.ErrFutureBlock
@@ -462,9 +482,16 @@ func (c *Bor) verifyCascadingFields(chain consensus.ChainHeaderReader, header *t
 		return err
 	}
 
-	// Verify the validator list match the local contract
+	// Verify the validator list match the local contract, or the Heimdall span
+	// if the headers are verified without any local state (snap sync)
 	if IsSprintStart(number+1, c.config.CalculateSprint(number)) {
-		newValidators, err := c.spanner.GetCurrentValidatorsByBlockNrOrHash(context.Background(), rpc.BlockNumberOrHashWithNumber(rpc.LatestBlockNumber), number+1)
+		var newValidators []*valset.Validator
+
+		if c.verifyWithoutState(chain) {
+			newValidators, err = c.getSpanValidators(context.Background(), number+1)
+		} else {
+			newValidators, err = c.spanner.GetCurrentValidatorsByBlockNrOrHash(context.Background(), rpc.BlockNumberOrHashWithNumber(rpc.LatestBlockNumber), number+1)
+		}
 
 		if err != nil {
 			return err
@@ -539,8 +566,21 @@ func (c *Bor) snapshot(chain consensus.ChainHeaderReader, number uint64, hash co
 			break
 		}
 
-		// If an on-disk checkpoint snapshot can be found, use that
-		if number%checkpointInterval == 0 {
+		// The headers before a trusted checkpoint are not verified, seed the
+		// snapshot at its end block instead of gathering them
+		trusted, err := c.trustedSnapshot(chain, number, hash)
+		if err != nil {
+			return nil, err
+		}
+
+		if trusted != nil {
+			snap = trusted
+
+			break
+		}
+
+		// If an on-disk checkpoint or seeded snapshot can be found, use that
+		if number%checkpointInterval == 0 || c.isSeeded(number, hash) {
 			if s, err := loadSnapshot(c.config, c.signatures, c.db, hash); err == nil {
 				log.Trace("Loaded snapshot from disk", "number", number, "hash", hash)
 
@@ -648,6 +688,47 @@ func (c *Bor) VerifySeal(chain consensus.ChainHeaderReader, header *types.Header
 	return c.verifySeal(chain, header, nil)
 }
 
+// VerifySnapshot checks an encoded snapshot, as persisted at the given block,
+// against the snapshot derived from the given chain at the same block. Only the
+// validator sets are compared, as the recent signers depend on how far back the
+// snapshot was built from.
+func (c *Bor) VerifySnapshot(chain consensus.ChainHeaderReader, number uint64, hash common.Hash, blob []byte) error {
+	snap := new(Snapshot)
+	if err := json.Unmarshal(blob, snap); err != nil {
+		return err
+	}
+
+	if snap.Number != number || snap.Hash != hash {
+		return fmt.Errorf("%w: snapshot of block %d [%x] at block %d", errSnapshotMismatch, snap.Number, snap.Hash, number)
+	}
+
+	if snap.ValidatorSet == nil {
+		return fmt.Errorf("%w: no validator set at block %d", errSnapshotMismatch, number)
+	}
+
+	local, err := c.snapshot(chain, snap.Number, snap.Hash, nil)
+	if err != nil {
+		return err
+	}
+
+	have, want := snap.ValidatorSet.Validators, local.ValidatorSet.Validators
+	if len(have) != len(want) {
+		return fmt.Errorf("%w: %d validators at block %d, want %d", errSnapshotMismatch, len(have), snap.Number, len(want))
+	}
+
+	for i, val := range want {
+		if have[i].Address != val.Address || have[i].VotingPower != val.VotingPower || have[i].ProposerPriority != val.ProposerPriority {
+			return fmt.Errorf("%w: validator %d at block %d", errSnapshotMismatch, i, snap.Number)
+		}
+	}
+
+	if proposer := snap.ValidatorSet.Proposer; proposer != nil && proposer.Address != local.ValidatorSet.GetProposer().Address {
+		return fmt.Errorf("%w: proposer at block %d", errSnapshotMismatch, snap.Number)
+	}
+
+	return nil
+}
+
 // verifySeal checks whether the signature contained in the header satisfies the
 // consensus protocol requirements. The method accepts an optional list of parent
 // headers that aren't yet part of the local blockchain to generate the snapshots
@@ -857,6 +938,58 @@ func (c *Bor) Finalize(chain consensus.ChainHeaderReader, header *types.Header,
 	bc.SetStateSync(stateSyncData)
 }
 
+// ReplayStateSync implements core.StateSyncReplayer. It re-executes the span
+// and state-sync system calls of a sprint start block on top of the given
+// state, which must be the state after the block's transactions, so the emitted
+// logs can be used to rebuild the bor receipt of the block. Unlike during block
+// processing, failing to fetch the state-syncs from Heimdall is an error.
+func (c *Bor) ReplayStateSync(chain consensus.ChainHeaderReader, header *types.Header, state *state.StateDB) error {
+	number := header.Number.Uint64()
+	if !IsSprintStart(number, c.config.CalculateSprint(number)) {
+		return nil
+	}
+
+	if c.HeimdallClient == nil {
+		return errors.New("state-sync replay requires Heimdall")
+	}
+
+	return c.applySystemCalls(chain, header, state, nil, true)
+}
+
+// TraceSystemCalls re-executes the span and state-sync system calls of a sprint
+// start block on top of the given state, which must be the state after the
+// block's transactions, notifying the tracer of each call. Failing to fetch the
+// state-syncs from Heimdall is an error, rather than a trace silently missing
+// them.
+func (c *Bor) TraceSystemCalls(chain consensus.ChainHeaderReader, header *types.Header, state *state.StateDB, tracer statefull.SystemCallTracer) error {
+	number := header.Number.Uint64()
+	if !IsSprintStart(number, c.config.CalculateSprint(number)) {
+		return nil
+	}
+
+	return c.applySystemCalls(chain, header, state, tracer, true)
+}
+
+// applySystemCalls commits the span and the state-syncs of a sprint start block
+// as Finalize does, optionally tracing the system calls. If strict is set, the
+// state-syncs failing to be fetched from Heimdall is reported.
+func (c *Bor) applySystemCalls(chain consensus.ChainHeaderReader, header *types.Header, state *state.StateDB, tracer statefull.SystemCallTracer, strict bool) error {
+	ctx := context.Background()
+	cx := statefull.ChainContext{Chain: chain, Bor: c, Tracer: tracer}
+
+	if err := c.checkAndCommitSpan(ctx, state, header, cx); err != nil {
+		return err
+	}
+
+	if c.HeimdallClient == nil {
+		return nil
+	}
+
+	_, err := c.commitStates(ctx, state, header, cx, strict)
+
+	return err
+}
+
 func decodeGenesisAlloc(i interface{}) (core.GenesisAlloc, error) {
 	var alloc core.GenesisAlloc
 
@@ -1217,6 +1350,18 @@ func (c *Bor) CommitStates(
 	state *state.StateDB,
 	header *types.Header,
 	chain statefull.ChainContext,
+) ([]*types.StateSyncData, error) {
+	return c.commitStates(ctx, state, header, chain, false)
+}
+
+// commitStates commits the state-syncs of a sprint start block. A failure to
+// fetch them from Heimdall is only logged, unless strict is set.
+func (c *Bor) commitStates(
+	ctx context.Context,
+	state *state.StateDB,
+	header *types.Header,
+	chain statefull.ChainContext,
+	strict bool,
 ) ([]*types.StateSyncData, error) {
 	fetchStart := time.Now()
 	number := header.Number.Uint64()
@@ -1256,6 +1401,10 @@ func (c *Bor) CommitStates(
 
 	eventRecords, err := c.HeimdallClient.StateSyncEvents(ctx, from, to.Unix())
 	if err != nil {
+		if strict {
+			return nil, fmt.Errorf("failed to fetch state sync events from %d: %w", from, err)
+		}
+
 		log.Error("Error occurred when fetching state sync events", "fromID", from, "to", to.Unix(), "err", err)
 	}
 
@@ -1329,6 +1478,65 @@ func (c *Bor) GetCurrentValidators(ctx context.Context, headerHash common.Hash,
 	return c.spanner.GetCurrentValidatorsByHash(ctx, headerHash, blockNumber)
 }
 
+// NextProducerSlot reports whether the signer is part of the validator set as
+// of the given header and returns the first block, not beyond limit, which the
+// signer is the in-turn producer of. A zero block means no slot was found, e.g.
+// because the proposer rotation reaches the signer only in a later span.
+func (c *Bor) NextProducerSlot(chain consensus.ChainHeaderReader, header *types.Header, signer common.Address, limit uint64) (bool, uint64, error) {
+	snap, err := c.snapshot(chain, header.Number.Uint64(), header.Hash(), nil)
+	if err != nil {
+		return false, 0, err
+	}
+
+	if !snap.ValidatorSet.HasAddress(signer) {
+		return false, 0, nil
+	}
+
+	return true, snap.nextInTurnBlock(signer, limit), nil
+}
+
+// FetchStateSyncs retrieves from Heimdall the state-syncs from the given id on,
+// emitted before the given header. It serves the state-syncs committed by blocks
+// whose ones weren't stored locally.
+func (c *Bor) FetchStateSyncs(ctx context.Context, fromID uint64, header *types.Header) ([]*types.StateSyncData, error) {
+	if c.HeimdallClient == nil {
+		return nil, errors.New("fetching state-syncs requires Heimdall")
+	}
+
+	records, err := c.HeimdallClient.StateSyncEvents(ctx, fromID, int64(header.Time))
+	if err != nil {
+		return nil, err
+	}
+
+	syncs := make([]*types.StateSyncData, 0, len(records))
+	for _, record := range records {
+		syncs = append(syncs, &types.StateSyncData{
+			ID:       record.ID,
+			Contract: record.Contract,
+			Data:     hex.EncodeToString(record.Data),
+			TxHash:   record.TxHash,
+		})
+	}
+
+	return syncs, nil
+}
+
+// StateSyncBacklog returns the id of the last state sync event committed as of
+// the given header, and the number of newer events already known to Heimdall.
+func (c *Bor) StateSyncBacklog(ctx context.Context, header *types.Header) (uint64, int, error) {
+	lastStateID, err := c.GenesisContractsClient.LastStateId(nil, header.Number.Uint64(), header.Hash())
+	if err != nil {
+		return 0, 0, err
+	}
+
+	events, err := c.HeimdallClient.StateSyncEvents(ctx, lastStateID.Uint64()+1, time.Now().Unix())
+	if err != nil {
+		return lastStateID.Uint64(), 0, err
+	}
+
+	return lastStateID.Uint64(), len(events), nil
+}
+
 //
 // Private methods
 //
@@ -3,6 +3,7 @@ package heimdallgrpc
 import (
 	"time"
 
+	"github.com/ethereum/go-ethereum/internal/grpcauth"
 	"github.com/ethereum/go-ethereum/log"
 
 	grpc_retry "github.com/grpc-ecosystem/go-grpc-middleware/retry"
@@ -22,23 +23,45 @@ type HeimdallGRPCClient struct {
 	client proto.HeimdallClient
 }
 
-func NewHeimdallGRPCClient(address string) *HeimdallGRPCClient {
+// NewHeimdallGRPCClient connects to the Heimdall gRPC server at the given
+// address. The connection uses TLS if any TLS material is configured, and
+// sends the bearer token, if set, with every call.
+func NewHeimdallGRPCClient(address string, tlsConfig *grpcauth.TLSConfig, token string) *HeimdallGRPCClient {
 	opts := []grpc_retry.CallOption{
 		grpc_retry.WithMax(10000),
 		grpc_retry.WithBackoff(grpc_retry.BackoffLinear(5 * time.Second)),
 		grpc_retry.WithCodes(codes.Internal, codes.Unavailable, codes.Aborted, codes.NotFound),
 	}
 
-	conn, err := grpc.Dial(address,
+	creds := insecure.NewCredentials()
+
+	if tlsConfig.Enabled() {
+		var err error
+		if creds, err = grpcauth.NewClientCredentials(tlsConfig); err != nil {
+			log.Crit("Failed to load Heimdall gRPC TLS credentials", "error", err)
+		}
+	}
+
+	dialOpts := []grpc.DialOption{
 		grpc.WithStreamInterceptor(grpc_retry.StreamClientInterceptor(opts...)),
 		grpc.WithUnaryInterceptor(grpc_retry.UnaryClientInterceptor(opts...)),
-		grpc.WithTransportCredentials(insecure.NewCredentials()),
-	)
+		grpc.WithTransportCredentials(creds),
+	}
+
+	if token != "" {
+		if !tlsConfig.Enabled() {
+			log.Warn("Sending Heimdall gRPC token over an insecure connection", "address", address)
+		}
+
+		dialOpts = append(dialOpts, grpc.WithPerRPCCredentials(grpcauth.NewTokenCredentials(token, tlsConfig.Enabled())))
+	}
+
+	conn, err := grpc.Dial(address, dialOpts...)
 	if err != nil {
 		log.Crit("Failed to connect to Heimdall gRPC", "error", err)
 	}
 
-	log.Info("Connected to Heimdall gRPC server", "address", address)
+	log.Info("Connected to Heimdall gRPC server", "address", address, "tls", tlsConfig.Enabled())
 
 	return &HeimdallGRPCClient{
 		conn:   conn,
@@ -1,5 +1,43 @@
 package bor
 
+import (
+	"math/big"
+
+	"github.com/xsleonard/go-merkle"
+	"golang.org/x/crypto/sha3"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// computeRootHash returns the Merkle root of a contiguous range of headers, as
+// committed to by Heimdall checkpoints.
+func computeRootHash(blockHeaders []*types.Header) (common.Hash, error) {
+	headers := make([][32]byte, nextPowerOfTwo(uint64(len(blockHeaders))))
+
+	for i, blockHeader := range blockHeaders {
+		header := crypto.Keccak256(appendBytes32(
+			blockHeader.Number.Bytes(),
+			new(big.Int).SetUint64(blockHeader.Time).Bytes(),
+			blockHeader.TxHash.Bytes(),
+			blockHeader.ReceiptHash.Bytes(),
+		))
+
+		var arr [32]byte
+
+		copy(arr[:], header)
+		headers[i] = arr
+	}
+
+	tree := merkle.NewTreeWithOpts(merkle.TreeOptions{EnableHashSorting: false, DisableHashLeaves: true})
+	if err := tree.Generate(convert(headers), sha3.NewLegacyKeccak256()); err != nil {
+		return common.Hash{}, err
+	}
+
+	return common.BytesToHash(tree.Root().Hash), nil
+}
+
 func appendBytes32(data ...[]byte) []byte {
 	var result []byte
 
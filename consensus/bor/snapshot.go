@@ -48,7 +48,7 @@ func newSnapshot(
 
 // loadSnapshot loads an existing snapshot from the database.
 func loadSnapshot(config *params.BorConfig, sigcache *lru.ARCCache, db ethdb.Database, hash common.Hash) (*Snapshot, error) {
-	blob, err := db.Get(append([]byte("bor-"), hash[:]...))
+	blob, err := db.Get(snapshotKey(hash))
 	if err != nil {
 		return nil, err
 	}
@@ -79,7 +79,28 @@ func (s *Snapshot) store(db ethdb.Database) error {
 		return err
 	}
 
-	return db.Put(append([]byte("bor-"), s.Hash[:]...), blob)
+	return db.Put(snapshotKey(s.Hash), blob)
+}
+
+// SnapshotInterval is the number of blocks after which a snapshot is
+// persisted to the database.
+const SnapshotInterval = checkpointInterval
+
+// ReadSnapshotBlob retrieves the encoded snapshot created at the given block
+// hash, or nil if none was persisted.
+func ReadSnapshotBlob(db ethdb.KeyValueReader, hash common.Hash) []byte {
+	blob, _ := db.Get(snapshotKey(hash))
+	return blob
+}
+
+// WriteSnapshotBlob stores an encoded snapshot for the given block hash.
+func WriteSnapshotBlob(db ethdb.KeyValueWriter, hash common.Hash, blob []byte) error {
+	return db.Put(snapshotKey(hash), blob)
+}
+
+// snapshotKey = "bor-" + block hash
+func snapshotKey(hash common.Hash) []byte {
+	return append([]byte("bor-"), hash[:]...)
 }
 
 // copy creates a deep copy of the snapshot, though not the individual votes.
@@ -154,9 +175,7 @@ func (s *Snapshot) apply(headers []*types.Header) (*Snapshot, error) {
 
 			// get validators from headers and use that for new validator set
 			newVals, _ := valset.ParseValidators(validatorBytes)
-			v := getUpdatedValidatorSet(snap.ValidatorSet.Copy(), newVals)
-			v.IncrementProposerPriority(1)
-			snap.ValidatorSet = v
+			snap.rotate(newVals)
 		}
 	}
 
@@ -166,6 +185,14 @@ func (s *Snapshot) apply(headers []*types.Header) (*Snapshot, error) {
 	return snap, nil
 }
 
+// rotate applies the validator set announced by the last block of a sprint and
+// moves the proposer on to the next sprint.
+func (s *Snapshot) rotate(validators []*valset.Validator) {
+	v := getUpdatedValidatorSet(s.ValidatorSet.Copy(), validators)
+	v.IncrementProposerPriority(1)
+	s.ValidatorSet = v
+}
+
 // GetSignerSuccessionNumber returns the relative position of signer in terms of the in-turn proposer
 func (s *Snapshot) GetSignerSuccessionNumber(signer common.Address) (int, error) {
 	validators := s.ValidatorSet.Validators
@@ -192,6 +219,27 @@ func (s *Snapshot) GetSignerSuccessionNumber(signer common.Address) (int, error)
 	return tempIndex - proposerIndex, nil
 }
 
+// nextInTurnBlock returns the first block after the snapshot, not beyond limit,
+// which the signer is the in-turn producer of, or zero if there is none. The
+// proposer only rotates at sprint ends, so the rotation is replayed sprint by
+// sprint assuming an unchanged validator set.
+func (s *Snapshot) nextInTurnBlock(signer common.Address, limit uint64) uint64 {
+	validators := s.ValidatorSet.Copy()
+
+	for number := s.Number + 1; number <= limit; {
+		if validators.GetProposer().Address == signer {
+			return number
+		}
+
+		sprint := s.config.CalculateSprint(number)
+		number = (number/sprint + 1) * sprint
+
+		validators.IncrementProposerPriority(1)
+	}
+
+	return 0
+}
+
 // signers retrieves the list of authorized signers in ascending order.
 func (s *Snapshot) signers() []common.Address {
 	sigs := make([]common.Address, 0, len(s.ValidatorSet.Validators))
@@ -0,0 +1,208 @@
+package bor
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/consensus"
+	"github.com/ethereum/go-ethereum/consensus/bor/heimdall/span"
+	"github.com/ethereum/go-ethereum/consensus/bor/valset"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/log"
+)
+
+// seedKey = "bor-seed-" + num (uint64 big endian) -> hash of the seeded block
+func seedKey(number uint64) []byte {
+	enc := make([]byte, 8)
+	binary.BigEndian.PutUint64(enc, number)
+
+	return append([]byte("bor-seed-"), enc...)
+}
+
+// isSeeded reports whether a snapshot was seeded at the given block, so it's
+// available on disk even if not at a checkpoint interval.
+func (c *Bor) isSeeded(number uint64, hash common.Hash) bool {
+	blob, _ := c.db.Get(seedKey(number))
+	return common.BytesToHash(blob) == hash && len(blob) == common.HashLength
+}
+
+// SeedSnapshot creates the snapshot at the given header from the Heimdall spans
+// and stores it, so that the snapshots above it are built without gathering the
+// headers back to genesis. It's used to establish the validator set at the snap
+// sync pivot and the trusted checkpoint, whose ancestors are not fully verified.
+func (c *Bor) SeedSnapshot(ctx context.Context, chain consensus.ChainHeaderReader, header *types.Header) error {
+	// Nothing to do if the headers were verified up to here already
+	if _, ok := c.recents.Get(header.Hash()); ok {
+		return nil
+	}
+
+	_, err := c.seedSnapshot(ctx, chain, header.Number.Uint64(), header.Hash())
+	return err
+}
+
+// seedSnapshot returns the snapshot at the given block, loading it from disk if
+// it was seeded before and replaying the validator set rotations announced by
+// the Heimdall spans otherwise.
+//
+// The validator set only changes at sprint ends, where the headers carry the
+// producers of the span covering the next block, which are checked against
+// Heimdall when verifying headers without state. The rotations are replayed
+// from the producers of the span preceding the one covering the block, so only
+// these two spans (and the next one, if the block ends its span) are needed,
+// instead of every span since genesis.
+func (c *Bor) seedSnapshot(ctx context.Context, chain consensus.ChainHeaderReader, number uint64, hash common.Hash) (*Snapshot, error) {
+	if c.isSeeded(number, hash) {
+		if snap, err := loadSnapshot(c.config, c.signatures, c.db, hash); err == nil {
+			return snap, nil
+		}
+	}
+
+	if c.HeimdallClient == nil {
+		return nil, errors.New("seeding a snapshot requires Heimdall")
+	}
+
+	start := time.Now()
+
+	current, err := c.getSpanForBlock(ctx, number)
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		validators []*valset.Validator
+		first      = uint64(1)
+	)
+
+	if current.ID == 0 {
+		genesis := chain.GetHeaderByNumber(0)
+		if genesis == nil {
+			return nil, errors.New("genesis header not found")
+		}
+
+		if validators, err = c.spanner.GetCurrentValidatorsByHash(ctx, genesis.Hash(), 1); err != nil {
+			return nil, err
+		}
+	} else {
+		id := current.ID - 1
+		if current, err = c.getSpan(ctx, id); err != nil {
+			return nil, fmt.Errorf("span %d: %w", id, err)
+		}
+
+		validators, first = spanProducers(current), current.StartBlock
+	}
+
+	snap := newSnapshot(c.config, c.signatures, first-1, common.Hash{}, validators)
+
+	err = c.forEachSprintEnd(first, number, func(end uint64) error {
+		// Move on to the span covering the first block of the next sprint
+		for end+1 > current.EndBlock {
+			id := current.ID + 1
+			if current, err = c.getSpan(ctx, id); err != nil {
+				return fmt.Errorf("span %d: %w", id, err)
+			}
+		}
+
+		if end+1 < current.StartBlock {
+			return fmt.Errorf("%w: block %d", errUnknownSpan, end+1)
+		}
+
+		snap.rotate(spanProducers(current))
+
+		return ctx.Err()
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	snap.Number, snap.Hash = number, hash
+
+	if err := snap.store(c.db); err != nil {
+		return nil, err
+	}
+
+	if err := c.db.Put(seedKey(number), hash.Bytes()); err != nil {
+		return nil, err
+	}
+
+	c.recents.Add(hash, snap)
+
+	log.Info("Seeded bor snapshot", "number", number, "hash", hash, "validators", len(snap.ValidatorSet.Validators), "elapsed", common.PrettyDuration(time.Since(start)))
+
+	return snap, nil
+}
+
+// spanProducers returns the producers selected by a span, sorted by address as
+// in the headers announcing them.
+func spanProducers(heimdallSpan *span.HeimdallSpan) []*valset.Validator {
+	producers := make([]*valset.Validator, 0, len(heimdallSpan.SelectedProducers))
+	for _, val := range heimdallSpan.SelectedProducers {
+		producers = append(producers, valset.NewValidator(val.Address, val.VotingPower))
+	}
+
+	sort.Sort(valset.ValidatorsByAddress(producers))
+
+	return producers
+}
+
+// forEachSprintEnd calls fn for the last block of every sprint in [first, last],
+// in ascending order. These are the blocks Snapshot.apply rotates the validator
+// set at.
+func (c *Bor) forEachSprintEnd(first uint64, last uint64, fn func(number uint64) error) error {
+	// Sort the sprint length changes to walk them in order
+	keys := make([]uint64, 0, len(c.config.Sprint))
+
+	for k := range c.config.Sprint {
+		key, err := strconv.ParseUint(k, 10, 64)
+		if err != nil {
+			return err
+		}
+
+		keys = append(keys, key)
+	}
+
+	sort.Slice(keys, func(i, j int) bool { return keys[i] < keys[j] })
+
+	if len(keys) == 0 || keys[0] != 0 {
+		keys = append([]uint64{0}, keys...)
+	}
+
+	for i, from := range keys {
+		to := last
+		if i+1 < len(keys) && keys[i+1]-1 < to {
+			to = keys[i+1] - 1
+		}
+
+		if from < first {
+			from = first
+		}
+
+		if from > to {
+			continue
+		}
+
+		sprint := c.config.CalculateSprint(from)
+		if sprint == 0 {
+			return errors.New("zero sprint length")
+		}
+
+		// First block of the range followed by a sprint start
+		number := (from/sprint+1)*sprint - 1
+		if number == 0 {
+			number += sprint
+		}
+
+		for ; number <= to; number += sprint {
+			if err := fn(number); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
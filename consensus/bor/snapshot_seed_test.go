@@ -0,0 +1,218 @@
+package bor
+
+import (
+	"context"
+	"encoding/json"
+	"math/big"
+	"testing"
+
+	lru "github.com/hashicorp/golang-lru"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/consensus"
+	"github.com/ethereum/go-ethereum/consensus/bor/heimdall/span"
+	"github.com/ethereum/go-ethereum/consensus/bor/valset"
+	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/params"
+)
+
+// genesisSpanner serves the genesis validators.
+type genesisSpanner struct {
+	Spanner
+
+	validators []*valset.Validator
+}
+
+func (s *genesisSpanner) GetCurrentValidatorsByHash(context.Context, common.Hash, uint64) ([]*valset.Validator, error) {
+	validators := make([]*valset.Validator, len(s.validators))
+	for i, val := range s.validators {
+		validators[i] = val.Copy()
+	}
+
+	return validators, nil
+}
+
+// genesisChain is a header chain only containing the genesis header.
+type genesisChain struct {
+	consensus.ChainHeaderReader
+
+	genesis *types.Header
+}
+
+func (c *genesisChain) GetHeaderByNumber(number uint64) *types.Header {
+	if number == 0 {
+		return c.genesis
+	}
+
+	return nil
+}
+
+func (c *genesisChain) GetHeader(common.Hash, uint64) *types.Header { return nil }
+
+// newSeedTestBor creates a bor engine whose spans rotate among a handful of
+// producers with changing voting power.
+func newSeedTestBor(t *testing.T, sprint map[string]uint64) *Bor {
+	t.Helper()
+
+	client := &spanHeimdallClient{}
+
+	for id := 0; id < 12; id++ {
+		start, end := uint64(0), uint64(255)
+		if id > 0 {
+			start = 256 + uint64(id-1)*512
+			end = start + 511
+		}
+
+		var producers []valset.Validator
+		for i := 0; i < 3; i++ {
+			producers = append(producers, valset.Validator{
+				Address:     common.BigToAddress(big.NewInt(int64(1 + (id+i)%5))),
+				VotingPower: int64(10 + id*i),
+			})
+		}
+
+		client.spans = append(client.spans, &span.HeimdallSpan{
+			Span:              span.Span{ID: uint64(id), StartBlock: start, EndBlock: end},
+			SelectedProducers: producers,
+			ChainID:           "137",
+		})
+	}
+
+	var genesis []*valset.Validator
+	for _, val := range client.spans[0].SelectedProducers {
+		genesis = append(genesis, valset.NewValidator(val.Address, val.VotingPower))
+	}
+
+	recents, _ := lru.NewARC(inmemorySnapshots)
+	signatures, _ := lru.NewARC(inmemorySignatures)
+	spans, _ := lru.NewARC(inmemorySpans)
+
+	b := &Bor{
+		chainConfig:    &params.ChainConfig{ChainID: big.NewInt(137)},
+		config:         &params.BorConfig{Sprint: sprint},
+		db:             rawdb.NewMemoryDatabase(),
+		recents:        recents,
+		signatures:     signatures,
+		spans:          spans,
+		spanner:        &genesisSpanner{validators: genesis},
+		HeimdallClient: client,
+	}
+	b.authorizedSigner.Store(&signer{})
+
+	return b
+}
+
+func TestForEachSprintEnd(t *testing.T) {
+	t.Parallel()
+
+	b := newSeedTestBor(t, map[string]uint64{"0": 64, "1024": 16, "2048": 8})
+
+	for _, first := range []uint64{1, 63, 64, 1000, 1500, 2048, 2999} {
+		var have []uint64
+
+		require.NoError(t, b.forEachSprintEnd(first, 3000, func(number uint64) error {
+			have = append(have, number)
+			return nil
+		}))
+
+		var want []uint64
+
+		for number := first; number <= 3000; number++ {
+			if (number+1)%b.config.CalculateSprint(number) == 0 {
+				want = append(want, number)
+			}
+		}
+
+		require.Equal(t, want, have, "first %d", first)
+	}
+}
+
+// Tests that a seeded snapshot matches the one obtained by rotating the producers
+// of the previous span at every sprint end since, without fetching the spans
+// before it, and that it's found on disk afterwards.
+func TestSeedSnapshot(t *testing.T) {
+	t.Parallel()
+
+	b := newSeedTestBor(t, map[string]uint64{"0": 64, "1024": 16})
+	chain := &genesisChain{genesis: &types.Header{Number: big.NewInt(0)}}
+
+	// Block 4000 is covered by span 8, preceded by span 7 starting at 3328
+	const (
+		number = 4000
+		first  = 3328
+	)
+
+	client := b.HeimdallClient.(*spanHeimdallClient)
+
+	want := newSnapshot(b.config, b.signatures, first-1, common.Hash{}, spanProducers(client.spans[7]))
+
+	for n := uint64(first); n <= number; n++ {
+		if (n+1)%b.config.CalculateSprint(n) == 0 {
+			producers, err := b.getSpanValidators(context.Background(), n+1)
+			require.NoError(t, err)
+
+			var vals []*valset.Validator
+			for _, val := range producers {
+				vals = append(vals, valset.NewValidator(val.Address, val.VotingPower))
+			}
+
+			want.rotate(vals)
+		}
+	}
+
+	b.spans.Purge()
+	client.requests = 0
+
+	hash := common.HexToHash("0x01")
+	require.NoError(t, b.SeedSnapshot(context.Background(), chain, &types.Header{Number: big.NewInt(number), ParentHash: hash}))
+
+	// The first two spans locate the one covering the block
+	require.LessOrEqual(t, client.requests, 4)
+
+	seeded := (&types.Header{Number: big.NewInt(number), ParentHash: hash}).Hash()
+	require.True(t, b.isSeeded(number, seeded))
+
+	// Drop the in-memory copy, the snapshot is loaded from disk without
+	// gathering any header
+	b.recents.Purge()
+
+	snap, err := b.snapshot(chain, number, seeded, nil)
+	require.NoError(t, err)
+	require.Equal(t, want.ValidatorSet.GetProposer().Address, snap.ValidatorSet.GetProposer().Address)
+
+	for i, val := range want.ValidatorSet.Validators {
+		require.Equal(t, val.Address, snap.ValidatorSet.Validators[i].Address)
+		require.Equal(t, val.VotingPower, snap.ValidatorSet.Validators[i].VotingPower)
+		require.Equal(t, val.ProposerPriority, snap.ValidatorSet.Validators[i].ProposerPriority)
+	}
+}
+
+func TestVerifySnapshot(t *testing.T) {
+	t.Parallel()
+
+	b := newSeedTestBor(t, map[string]uint64{"0": 64})
+	chain := &genesisChain{genesis: &types.Header{Number: big.NewInt(0)}}
+
+	header := &types.Header{Number: big.NewInt(1000)}
+	require.NoError(t, b.SeedSnapshot(context.Background(), chain, header))
+
+	snap, err := b.snapshot(chain, 1000, header.Hash(), nil)
+	require.NoError(t, err)
+
+	blob, err := json.Marshal(snap)
+	require.NoError(t, err)
+	require.NoError(t, b.VerifySnapshot(chain, 1000, header.Hash(), blob))
+
+	// The snapshot must be the one of the given block
+	require.ErrorIs(t, b.VerifySnapshot(chain, 1000, common.Hash{0x01}, blob), errSnapshotMismatch)
+
+	// And the validator set must match the derived one
+	cpy := snap.copy()
+	cpy.ValidatorSet.Validators[0].ProposerPriority++
+
+	blob, err = json.Marshal(cpy)
+	require.NoError(t, err)
+	require.ErrorIs(t, b.VerifySnapshot(chain, 1000, header.Hash(), blob), errSnapshotMismatch)
+}
@@ -12,6 +12,7 @@ import (
 	"github.com/ethereum/go-ethereum/common"
 	unique "github.com/ethereum/go-ethereum/common/set"
 	"github.com/ethereum/go-ethereum/consensus/bor/valset"
+	"github.com/ethereum/go-ethereum/params"
 )
 
 const (
@@ -130,6 +131,33 @@ func TestGetSignerSuccessionNumber_SignerNotFound(t *testing.T) {
 	require.Equal(t, dummySignerAddress.Bytes(), e.Signer)
 }
 
+func TestNextInTurnBlock(t *testing.T) {
+	t.Parallel()
+
+	validators := buildRandomValidatorSet(numVals)
+	snap := Snapshot{
+		config:       &params.BorConfig{Sprint: map[string]uint64{"0": 4}},
+		Number:       10,
+		ValidatorSet: valset.NewValidatorSet(validators),
+	}
+
+	// The current proposer produces the very next block
+	proposer := snap.ValidatorSet.GetProposer().Address
+	require.Equal(t, uint64(11), snap.nextInTurnBlock(proposer, 100))
+
+	// The following proposer takes over with the next sprint
+	next := snap.ValidatorSet.Copy()
+	next.IncrementProposerPriority(1)
+
+	if follower := next.GetProposer().Address; follower != proposer {
+		require.Equal(t, uint64(12), snap.nextInTurnBlock(follower, 100))
+		require.Equal(t, uint64(0), snap.nextInTurnBlock(follower, 11))
+	}
+
+	// Unknown signers never get a slot
+	require.Equal(t, uint64(0), snap.nextInTurnBlock(randomAddress(toAddresses(validators)...), 100))
+}
+
 // nolint: unparam
 func buildRandomValidatorSet(numVals int) []*valset.Validator {
 	validators := make([]*valset.Validator, numVals)
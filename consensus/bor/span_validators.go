@@ -0,0 +1,137 @@
+package bor
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/consensus"
+	"github.com/ethereum/go-ethereum/consensus/bor/heimdall/span"
+	"github.com/ethereum/go-ethereum/consensus/bor/valset"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// maxSpanLookups is the number of spans probed around the estimated span id
+// before giving up on finding the span of a block.
+const maxSpanLookups = 16
+
+// stateChainReader is implemented by chain readers that process full blocks,
+// and therefore have the validator set contract state at hand.
+type stateChainReader interface {
+	CurrentBlock() *types.Header
+}
+
+// verifyWithoutState reports whether headers of the given chain have to be
+// verified without access to the validator set contract. This is the case for
+// the header-only chain used by snap sync, whose state stays at genesis until
+// the pivot block is committed.
+func (c *Bor) verifyWithoutState(chain consensus.ChainHeaderReader) bool {
+	if c.HeimdallClient == nil {
+		return false
+	}
+
+	_, ok := chain.(stateChainReader)
+
+	return !ok
+}
+
+// getSpanValidators returns the block producers of the Heimdall span covering
+// the given block, which is what the validator set contract would report.
+func (c *Bor) getSpanValidators(ctx context.Context, number uint64) ([]*valset.Validator, error) {
+	heimdallSpan, err := c.getSpanForBlock(ctx, number)
+	if err != nil {
+		return nil, err
+	}
+
+	validators := make([]*valset.Validator, 0, len(heimdallSpan.SelectedProducers))
+	for _, val := range heimdallSpan.SelectedProducers {
+		val := val
+		validators = append(validators, &val)
+	}
+
+	return validators, nil
+}
+
+// getSpanForBlock retrieves the Heimdall span covering the given block. All
+// spans but the first one have the same length, so the span id is estimated
+// from the second span and corrected by stepping through its neighbours.
+func (c *Bor) getSpanForBlock(ctx context.Context, number uint64) (*span.HeimdallSpan, error) {
+	first, err := c.getSpan(ctx, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	if number <= first.EndBlock {
+		return first, nil
+	}
+
+	second, err := c.getSpan(ctx, 1)
+	if err != nil {
+		return nil, err
+	}
+
+	id := uint64(1)
+	if length := second.EndBlock - second.StartBlock + 1; number > second.StartBlock && length > 0 {
+		id += (number - second.StartBlock) / length
+	}
+
+	for i := 0; i < maxSpanLookups; i++ {
+		heimdallSpan, err := c.getSpan(ctx, id)
+		if err != nil {
+			return nil, err
+		}
+
+		switch {
+		case number < heimdallSpan.StartBlock:
+			id--
+		case number > heimdallSpan.EndBlock:
+			id++
+		default:
+			return heimdallSpan, nil
+		}
+
+		if id == 0 {
+			break
+		}
+	}
+
+	return nil, fmt.Errorf("%w: block %d", errUnknownSpan, number)
+}
+
+// GetSpan retrieves a span from Heimdall.
+func (c *Bor) GetSpan(ctx context.Context, id uint64) (*span.HeimdallSpan, error) {
+	if c.HeimdallClient == nil {
+		return nil, errors.New("span retrieval requires Heimdall")
+	}
+
+	return c.getSpan(ctx, id)
+}
+
+// getSpan retrieves a span from Heimdall, caching it for subsequent headers.
+func (c *Bor) getSpan(ctx context.Context, id uint64) (*span.HeimdallSpan, error) {
+	if c.spans != nil {
+		if s, ok := c.spans.Get(id); ok {
+			return s.(*span.HeimdallSpan), nil
+		}
+	}
+
+	heimdallSpan, err := c.HeimdallClient.Span(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if heimdallSpan.ChainID != c.chainConfig.ChainID.String() {
+		return nil, fmt.Errorf(
+			"chain id of span %d, %s, and bor chain id, %s, doesn't match",
+			id,
+			heimdallSpan.ChainID,
+			c.chainConfig.ChainID,
+		)
+	}
+
+	if c.spans != nil {
+		c.spans.Add(id, heimdallSpan)
+	}
+
+	return heimdallSpan, nil
+}
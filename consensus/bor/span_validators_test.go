@@ -0,0 +1,119 @@
+package bor
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	lru "github.com/hashicorp/golang-lru"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/consensus/bor/heimdall/span"
+	"github.com/ethereum/go-ethereum/consensus/bor/valset"
+	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/params"
+)
+
+// spanHeimdallClient serves a fixed list of spans, counting the requests.
+type spanHeimdallClient struct {
+	IHeimdallClient
+
+	spans    []*span.HeimdallSpan
+	requests int
+}
+
+func (h *spanHeimdallClient) Span(_ context.Context, spanID uint64) (*span.HeimdallSpan, error) {
+	h.requests++
+
+	if spanID >= uint64(len(h.spans)) {
+		return nil, errUnknownSpan
+	}
+
+	return h.spans[spanID], nil
+}
+
+func newSpanHeimdallClient(count int) *spanHeimdallClient {
+	client := &spanHeimdallClient{}
+
+	for id := 0; id < count; id++ {
+		start, end := uint64(0), uint64(255)
+		if id > 0 {
+			start = 256 + uint64(id-1)*6400
+			end = start + 6399
+		}
+
+		client.spans = append(client.spans, &span.HeimdallSpan{
+			Span: span.Span{ID: uint64(id), StartBlock: start, EndBlock: end},
+			SelectedProducers: []valset.Validator{
+				{Address: common.BigToAddress(big.NewInt(int64(id + 1))), VotingPower: 10},
+			},
+			ChainID: "137",
+		})
+	}
+
+	return client
+}
+
+func TestGetSpanValidators(t *testing.T) {
+	t.Parallel()
+
+	client := newSpanHeimdallClient(10)
+	spans, _ := lru.NewARC(inmemorySpans)
+
+	b := &Bor{
+		chainConfig:    &params.ChainConfig{ChainID: big.NewInt(137)},
+		HeimdallClient: client,
+		spans:          spans,
+	}
+
+	for _, tt := range []struct {
+		number uint64
+		span   int
+	}{
+		{1, 0},
+		{255, 0},
+		{256, 1},
+		{6655, 1},
+		{6656, 2},
+		{40000, 7},
+		{57855, 9},
+	} {
+		validators, err := b.getSpanValidators(context.Background(), tt.number)
+		require.NoError(t, err, "block %d", tt.number)
+		require.Len(t, validators, 1)
+		require.Equal(t, common.BigToAddress(big.NewInt(int64(tt.span+1))), validators[0].Address, "block %d", tt.number)
+	}
+
+	_, err := b.getSpanValidators(context.Background(), 57856)
+	require.ErrorIs(t, err, errUnknownSpan)
+
+	// Spans are cached, so asking again doesn't reach out to Heimdall
+	requests := client.requests
+	_, err = b.getSpanValidators(context.Background(), 40000)
+	require.NoError(t, err)
+	require.Equal(t, requests, client.requests)
+}
+
+func TestGetSpanValidatorsChainID(t *testing.T) {
+	t.Parallel()
+
+	b := &Bor{
+		chainConfig:    &params.ChainConfig{ChainID: big.NewInt(80001)},
+		HeimdallClient: newSpanHeimdallClient(2),
+	}
+
+	_, err := b.getSpanValidators(context.Background(), 300)
+	require.ErrorContains(t, err, "doesn't match")
+}
+
+func TestVerifyWithoutState(t *testing.T) {
+	t.Parallel()
+
+	b := &Bor{}
+	require.False(t, b.verifyWithoutState(&core.HeaderChain{}))
+
+	b.HeimdallClient = newSpanHeimdallClient(1)
+	require.True(t, b.verifyWithoutState(&core.HeaderChain{}))
+	require.False(t, b.verifyWithoutState(&core.BlockChain{}))
+}
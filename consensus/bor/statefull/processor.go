@@ -22,6 +22,20 @@ var systemAddress = common.HexToAddress("0xffffFFFfFFffffffffffffffFfFFFfffFFFfF
 type ChainContext struct {
 	Chain consensus.ChainHeaderReader
 	Bor   consensus.Engine
+
+	// Tracer, if set, is notified of the system calls applied with the context
+	Tracer SystemCallTracer
+}
+
+// SystemCallTracer is notified of the span and state-sync system calls applied
+// through ApplyMessage, allowing them to be traced like transactions.
+type SystemCallTracer interface {
+	// SystemCallStart is called before a system call is applied and returns
+	// the logger to execute it with, or nil to leave it untraced.
+	SystemCallStart(msg Callmsg) vm.EVMLogger
+
+	// SystemCallEnd is called after a system call was applied.
+	SystemCallEnd(msg Callmsg, gasUsed uint64, ret []byte, err error)
 }
 
 func (c ChainContext) Engine() consensus.Engine {
@@ -74,9 +88,28 @@ func ApplyMessage(
 	// Create a new context to be used in the EVM environment
 	blockContext := core.NewEVMBlockContext(header, chainContext, &header.Coinbase)
 
+	// Trace the call if the context carries a tracer
+	var (
+		tracer    SystemCallTracer
+		txContext vm.TxContext
+		config    vm.Config
+	)
+
+	if cx, ok := chainContext.(ChainContext); ok && cx.Tracer != nil {
+		tracer = cx.Tracer
+		config.Tracer = tracer.SystemCallStart(msg)
+
+		// Tracers expect a gas price, which system calls don't otherwise need
+		txContext.GasPrice = msg.GasPrice()
+	}
+
 	// Create a new environment which holds all relevant information
 	// about the transaction and calling mechanisms.
-	vmenv := vm.NewEVM(blockContext, vm.TxContext{}, state, chainConfig, vm.Config{})
+	vmenv := vm.NewEVM(blockContext, txContext, state, chainConfig, config)
+
+	if config.Tracer != nil {
+		config.Tracer.CaptureTxStart(initialGas)
+	}
 
 	// nolint : contextcheck
 	// Apply the transaction to the current state (included in the env)
@@ -106,6 +139,14 @@ func ApplyMessage(
 
 	gasUsed := initialGas - gasLeft
 
+	if config.Tracer != nil {
+		config.Tracer.CaptureTxEnd(gasLeft)
+	}
+
+	if tracer != nil {
+		tracer.SystemCallEnd(msg, gasUsed, ret, err)
+	}
+
 	return gasUsed, nil
 }
 
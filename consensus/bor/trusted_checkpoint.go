@@ -0,0 +1,164 @@
+package bor
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/consensus"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// errTrustedCheckpointMismatch is returned if the headers of a trusted
+// checkpoint don't match its root hash or end block hash.
+var errTrustedCheckpointMismatch = errors.New("trusted checkpoint mismatch")
+
+// TrustedCheckpoint is a Heimdall checkpoint trusted by the operator to bootstrap
+// a node from. The headers in its range are verified against its root hash, and
+// the headers before it synced by snap sync are anchored to its end block by
+// their hash chain, so they skip the seal and validator set checks.
+type TrustedCheckpoint struct {
+	StartBlock uint64      // First block of the checkpoint
+	EndBlock   uint64      // Last block of the checkpoint
+	RootHash   common.Hash // Merkle root of the checkpoint headers
+	BlockHash  common.Hash // Hash of the last block of the checkpoint
+}
+
+// ParseTrustedCheckpoint parses a trusted checkpoint in the form of
+// "<start>-<end>:<root hash>:<end block hash>".
+func ParseTrustedCheckpoint(s string) (*TrustedCheckpoint, error) {
+	parts := strings.Split(s, ":")
+	if len(parts) != 3 {
+		return nil, fmt.Errorf("invalid trusted checkpoint %q, want <start>-<end>:<root hash>:<end block hash>", s)
+	}
+
+	bounds := strings.Split(parts[0], "-")
+	if len(bounds) != 2 {
+		return nil, fmt.Errorf("invalid trusted checkpoint range %q", parts[0])
+	}
+
+	start, err := strconv.ParseUint(bounds[0], 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("invalid trusted checkpoint start block: %v", err)
+	}
+
+	end, err := strconv.ParseUint(bounds[1], 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("invalid trusted checkpoint end block: %v", err)
+	}
+
+	if start > end || end-start+1 > MaxCheckpointLength {
+		return nil, &MaxCheckpointLengthExceededError{start, end}
+	}
+
+	root, err := hexutil.Decode(parts[1])
+	if err != nil || len(root) != common.HashLength {
+		return nil, fmt.Errorf("invalid trusted checkpoint root hash %q", parts[1])
+	}
+
+	hash, err := hexutil.Decode(parts[2])
+	if err != nil || len(hash) != common.HashLength {
+		return nil, fmt.Errorf("invalid trusted checkpoint block hash %q", parts[2])
+	}
+
+	return &TrustedCheckpoint{
+		StartBlock: start,
+		EndBlock:   end,
+		RootHash:   common.BytesToHash(root),
+		BlockHash:  common.BytesToHash(hash),
+	}, nil
+}
+
+// String implements fmt.Stringer, returning the checkpoint in the form accepted
+// by ParseTrustedCheckpoint.
+func (tc *TrustedCheckpoint) String() string {
+	return fmt.Sprintf("%d-%d:%s:%s", tc.StartBlock, tc.EndBlock, tc.RootHash.Hex(), tc.BlockHash.Hex())
+}
+
+// Bounds returns the block range of the checkpoint and the hash of its last block.
+func (tc *TrustedCheckpoint) Bounds() (uint64, uint64, common.Hash) {
+	return tc.StartBlock, tc.EndBlock, tc.BlockHash
+}
+
+// VerifyHeaders checks that the given headers are the full, linked range of the
+// checkpoint and match both its root hash and end block hash.
+func (tc *TrustedCheckpoint) VerifyHeaders(headers []*types.Header) error {
+	if uint64(len(headers)) != tc.EndBlock-tc.StartBlock+1 {
+		return fmt.Errorf("%w: have %d headers, want %d", errTrustedCheckpointMismatch, len(headers), tc.EndBlock-tc.StartBlock+1)
+	}
+
+	for i, header := range headers {
+		if header.Number.Uint64() != tc.StartBlock+uint64(i) {
+			return fmt.Errorf("%w: header %d out of order", errTrustedCheckpointMismatch, header.Number)
+		}
+
+		if i > 0 && header.ParentHash != headers[i-1].Hash() {
+			return fmt.Errorf("%w: header %d not linked to its parent", errTrustedCheckpointMismatch, header.Number)
+		}
+	}
+
+	if hash := headers[len(headers)-1].Hash(); hash != tc.BlockHash {
+		return fmt.Errorf("%w: end block hash %x, want %x", errTrustedCheckpointMismatch, hash, tc.BlockHash)
+	}
+
+	root, err := computeRootHash(headers)
+	if err != nil {
+		return err
+	}
+
+	if root != tc.RootHash {
+		return fmt.Errorf("%w: root hash %x, want %x", errTrustedCheckpointMismatch, root, tc.RootHash)
+	}
+
+	return nil
+}
+
+// SetTrustedCheckpoint sets the checkpoint up to which the headers synced
+// without state are trusted by their hash chain instead of being fully verified.
+func (c *Bor) SetTrustedCheckpoint(tc *TrustedCheckpoint) {
+	c.trustedCheckpoint.Store(tc)
+}
+
+// verifyTrustedHeader checks a header up to the trusted checkpoint. Its link
+// to the parent is enforced by the header chain, so only the end block needs
+// to match the checkpoint. Neither the seal nor the validator set are verified,
+// the snapshot at the end block is seeded from the Heimdall spans instead of
+// being built from these headers.
+func (c *Bor) verifyTrustedHeader(header *types.Header, tc *TrustedCheckpoint) error {
+	number := header.Number.Uint64()
+
+	if number == tc.EndBlock && header.Hash() != tc.BlockHash {
+		return fmt.Errorf("%w: block %d hash %x, want %x", errTrustedCheckpointMismatch, number, header.Hash(), tc.BlockHash)
+	}
+
+	if number == 0 {
+		return nil
+	}
+
+	return validateHeaderExtraField(header.Extra)
+}
+
+// trustedSnapshot returns the snapshot at the end block of the trusted
+// checkpoint, seeding it if needed, or nil if the block isn't the one.
+func (c *Bor) trustedSnapshot(chain consensus.ChainHeaderReader, number uint64, hash common.Hash) (*Snapshot, error) {
+	tc := c.trustedCheckpoint.Load()
+	if tc == nil || number != tc.EndBlock || hash != tc.BlockHash {
+		return nil, nil
+	}
+
+	return c.seedSnapshot(context.Background(), chain, number, hash)
+}
+
+// VerifyTrustedCheckpoint checks the trusted checkpoint against the local
+// canonical chain, which has to contain its end block if it reaches that far.
+func VerifyTrustedCheckpoint(chain consensus.ChainHeaderReader, tc *TrustedCheckpoint) error {
+	if header := chain.GetHeaderByNumber(tc.EndBlock); header != nil && header.Hash() != tc.BlockHash {
+		return fmt.Errorf("%w: local block %d hash %x, want %x", errTrustedCheckpointMismatch, tc.EndBlock, header.Hash(), tc.BlockHash)
+	}
+
+	return nil
+}
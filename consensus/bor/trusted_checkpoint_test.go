@@ -0,0 +1,191 @@
+package bor
+
+import (
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/consensus"
+	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+func TestParseTrustedCheckpoint(t *testing.T) {
+	t.Parallel()
+
+	root := common.HexToHash("0x1234")
+	hash := common.HexToHash("0xabcd")
+
+	tc, err := ParseTrustedCheckpoint("100-355:" + root.Hex() + ":" + hash.Hex())
+	require.NoError(t, err)
+	require.Equal(t, &TrustedCheckpoint{StartBlock: 100, EndBlock: 355, RootHash: root, BlockHash: hash}, tc)
+
+	parsed, err := ParseTrustedCheckpoint(tc.String())
+	require.NoError(t, err)
+	require.Equal(t, tc, parsed)
+
+	for _, invalid := range []string{
+		"",
+		"100-355:" + root.Hex(),
+		"355:" + root.Hex() + ":" + hash.Hex(),
+		"355-100:" + root.Hex() + ":" + hash.Hex(),
+		"0-40000:" + root.Hex() + ":" + hash.Hex(),
+		"100-355:0x1234:" + hash.Hex(),
+		"100-355:" + root.Hex() + ":hash",
+	} {
+		_, err := ParseTrustedCheckpoint(invalid)
+		require.Error(t, err, invalid)
+	}
+}
+
+// newCheckpointHeaders creates a linked range of headers with a trusted
+// checkpoint covering them.
+func newCheckpointHeaders(t *testing.T, start, end uint64) ([]*types.Header, *TrustedCheckpoint) {
+	t.Helper()
+
+	var (
+		headers []*types.Header
+		parent  common.Hash
+	)
+
+	for number := start; number <= end; number++ {
+		header := &types.Header{
+			ParentHash: parent,
+			Number:     new(big.Int).SetUint64(number),
+			Time:       1000 + number*2,
+			TxHash:     common.BigToHash(new(big.Int).SetUint64(number)),
+		}
+		headers = append(headers, header)
+		parent = header.Hash()
+	}
+
+	root, err := computeRootHash(headers)
+	require.NoError(t, err)
+
+	return headers, &TrustedCheckpoint{StartBlock: start, EndBlock: end, RootHash: root, BlockHash: parent}
+}
+
+func TestTrustedCheckpointVerifyHeaders(t *testing.T) {
+	t.Parallel()
+
+	headers, tc := newCheckpointHeaders(t, 256, 511)
+	require.NoError(t, tc.VerifyHeaders(headers))
+
+	// Missing headers
+	require.ErrorIs(t, tc.VerifyHeaders(headers[1:]), errTrustedCheckpointMismatch)
+
+	// Wrong root hash
+	wrongRoot := *tc
+	wrongRoot.RootHash = common.Hash{0x01}
+	require.ErrorIs(t, wrongRoot.VerifyHeaders(headers), errTrustedCheckpointMismatch)
+
+	// Wrong end block hash
+	wrongHash := *tc
+	wrongHash.BlockHash = common.Hash{0x01}
+	require.ErrorIs(t, wrongHash.VerifyHeaders(headers), errTrustedCheckpointMismatch)
+
+	// Tampered header breaking the root hash, even though the hash chain is rebuilt
+	tampered, _ := newCheckpointHeaders(t, 256, 511)
+	tampered[10].TxHash = common.Hash{0x02}
+
+	for i := 11; i < len(tampered); i++ {
+		tampered[i].ParentHash = tampered[i-1].Hash()
+	}
+
+	err := tc.VerifyHeaders(tampered)
+	require.True(t, errors.Is(err, errTrustedCheckpointMismatch))
+}
+
+// checkpointHeaderReader serves the canonical headers of a chain by number.
+type checkpointHeaderReader struct {
+	consensus.ChainHeaderReader
+
+	headers map[uint64]*types.Header
+}
+
+func (r *checkpointHeaderReader) GetHeaderByNumber(number uint64) *types.Header {
+	return r.headers[number]
+}
+
+func TestVerifyTrustedHeader(t *testing.T) {
+	t.Parallel()
+
+	var (
+		key, _  = crypto.GenerateKey()
+		b       = newSeedTestBor(t, map[string]uint64{"0": 64})
+		genesis = &types.Header{Number: big.NewInt(0)}
+		parent  = common.Hash{0x01}
+		headers []*types.Header
+	)
+
+	for number := uint64(1026); number <= 1030; number++ {
+		header := &types.Header{
+			ParentHash: parent,
+			Number:     new(big.Int).SetUint64(number),
+			Time:       1000 + number*4,
+			Difficulty: big.NewInt(1),
+			Extra:      make([]byte, types.ExtraVanityLength+types.ExtraSealLength),
+		}
+		require.NoError(t, Sign(func(_ accounts.Account, _ string, data []byte) ([]byte, error) {
+			return crypto.Sign(crypto.Keccak256(data), key)
+		}, crypto.PubkeyToAddress(key.PublicKey), header, b.config))
+
+		headers = append(headers, header)
+		parent = header.Hash()
+	}
+
+	root, err := computeRootHash(headers)
+	require.NoError(t, err)
+
+	b.SetTrustedCheckpoint(&TrustedCheckpoint{StartBlock: 1026, EndBlock: 1030, RootHash: root, BlockHash: parent})
+
+	// Headers synced without state are anchored by their hash chain, neither the
+	// unknown signer nor the missing snapshot matter
+	chain := &core.HeaderChain{}
+	for i := 1; i < len(headers); i++ {
+		require.NoError(t, b.verifyHeader(chain, headers[i], headers[:i]), "header %d", headers[i].Number)
+	}
+
+	// The end block has to match the checkpoint
+	forged := types.CopyHeader(headers[len(headers)-1])
+	forged.Extra = []byte("forged")
+	require.ErrorIs(t, b.verifyHeader(chain, forged, headers[:len(headers)-1]), errTrustedCheckpointMismatch)
+
+	// The snapshot at the end block is seeded without gathering any header
+	snap, err := b.snapshot(&genesisChain{genesis: genesis}, 1030, parent, nil)
+	require.NoError(t, err)
+	require.Equal(t, uint64(1030), snap.Number)
+	require.True(t, b.isSeeded(1030, parent))
+
+	// Headers of a chain with state go through the full verification
+	b.config.Period = map[string]uint64{"0": 2}
+	b.config.ProducerDelay = map[string]uint64{"0": 4}
+	b.config.BackupMultiplier = map[string]uint64{"0": 2}
+	require.ErrorIs(t, b.verifyHeader(&core.BlockChain{}, headers[1], headers[:1]), errInvalidUncleHash)
+}
+
+func TestVerifyTrustedCheckpoint(t *testing.T) {
+	t.Parallel()
+
+	headers, tc := newCheckpointHeaders(t, 256, 260)
+
+	// A chain not reaching the checkpoint yet
+	chain := &checkpointHeaderReader{headers: make(map[uint64]*types.Header)}
+	require.NoError(t, VerifyTrustedCheckpoint(chain, tc))
+
+	for _, header := range headers {
+		chain.headers[header.Number.Uint64()] = header
+	}
+
+	require.NoError(t, VerifyTrustedCheckpoint(chain, tc))
+
+	// A local chain on another fork
+	forked := *tc
+	forked.BlockHash = common.Hash{0x01}
+	require.ErrorIs(t, VerifyTrustedCheckpoint(chain, &forked), errTrustedCheckpointMismatch)
+}
@@ -151,6 +151,8 @@ type CacheConfig struct {
 	SnapshotLimit       int           // Memory allowance (MB) to use for caching snapshot entries in memory
 	Preimages           bool          // Whether to store preimage of trie key to the disk
 	TriesInMemory       uint64        // Number of recent tries to keep in memory
+	BorReceiptLimit     uint64        // Number of recent blocks to keep bor receipts for (0 = entire chain)
+	ChangeSets          bool          // Whether to index the account and storage changes of the imported blocks
 
 	SnapshotNoBuild bool // Whether the background generation is allowed
 	SnapshotWait    bool // Wait for snapshot construction on startup. TODO(karalabe): This is a dirty hack for testing, nuke it
@@ -250,6 +252,7 @@ type BlockChain struct {
 	stateSyncData    []*types.StateSyncData                  // State sync data
 	stateSyncFeed    event.Feed                              // State sync feed
 	chain2HeadFeed   event.Feed                              // Reorg/NewHead/Fork data feed
+	borReindex       borReindexer                            // Background bor receipt reindex
 }
 
 // NewBlockChain returns a fully initialised block chain using information
@@ -531,6 +534,10 @@ func (bc *BlockChain) ProcessBlock(block *types.Block, parent *types.Header) (ty
 			return nil, nil, 0, nil, err
 		}
 
+		if bc.cacheConfig.ChangeSets {
+			parallelStatedb.EnableChangeSet()
+		}
+
 		processorCount++
 
 		go func() {
@@ -546,6 +553,10 @@ func (bc *BlockChain) ProcessBlock(block *types.Block, parent *types.Header) (ty
 			return nil, nil, 0, nil, err
 		}
 
+		if bc.cacheConfig.ChangeSets {
+			statedb.EnableChangeSet()
+		}
+
 		processorCount++
 
 		go func() {
@@ -902,6 +913,9 @@ func (bc *BlockChain) setHeadBeyondRoot(head uint64, time uint64, root common.Ha
 			}
 			// Remove the hash <-> number mapping from the active store.
 			rawdb.DeleteHeaderNumber(db, hash)
+
+			// State-syncs are kept in the active store after freezing
+			rawdb.DeleteStateSyncs(db, hash, num)
 		} else {
 			// Remove relative body and receipts from the active store.
 			// The header, total difficulty and canonical hash will be
@@ -910,6 +924,7 @@ func (bc *BlockChain) setHeadBeyondRoot(head uint64, time uint64, root common.Ha
 			rawdb.DeleteReceipts(db, hash, num)
 			rawdb.DeleteBorReceipt(db, hash, num)
 			rawdb.DeleteBorTxLookupEntry(db, hash, num)
+			rawdb.DeleteStateSyncs(db, hash, num)
 		}
 		// Todo(rjl493456442) txlookup, bloombits, etc
 	}
@@ -1339,7 +1354,14 @@ func (bc *BlockChain) InsertReceiptChain(blockChain types.Blocks, receiptChain [
 		var headers []*types.Header
 
 		for _, block := range blockChain {
-			borReceipts = append(borReceipts, []*types.Receipt{bc.GetBorReceiptByHash(block.Hash())})
+			// The bor receipts are written into the key-value store ahead of the
+			// block bodies (snap sync), so read them raw without derived fields.
+			var borReceipt types.Receipts
+			if receipt := rawdb.ReadRawBorReceipt(bc.db, block.Hash(), block.NumberU64()); receipt != nil {
+				borReceipt = types.Receipts{receipt}
+			}
+
+			borReceipts = append(borReceipts, borReceipt)
 			headers = append(headers, block.Header())
 		}
 
@@ -1369,8 +1391,16 @@ func (bc *BlockChain) InsertReceiptChain(blockChain types.Blocks, receiptChain [
 		for i, block := range blockChain {
 			if bc.txLookupLimit == 0 || ancientLimit <= bc.txLookupLimit || block.NumberU64() >= ancientLimit-bc.txLookupLimit {
 				rawdb.WriteTxLookupEntriesByBlock(batch, block)
+
+				if len(borReceipts[i]) > 0 {
+					rawdb.WriteBorTxLookupEntry(batch, block.Hash(), block.NumberU64())
+				}
 			} else if rawdb.ReadTxIndexTail(bc.db) != nil {
 				rawdb.WriteTxLookupEntriesByBlock(batch, block)
+
+				if len(borReceipts[i]) > 0 {
+					rawdb.WriteBorTxLookupEntry(batch, block.Hash(), block.NumberU64())
+				}
 			}
 
 			stats.processed++
@@ -1471,6 +1501,11 @@ func (bc *BlockChain) InsertReceiptChain(blockChain types.Blocks, receiptChain [
 			rawdb.WriteReceipts(batch, block.Hash(), block.NumberU64(), receiptChain[i])
 			rawdb.WriteTxLookupEntriesByBlock(batch, block) // Always write tx indices for live blocks, we assume they are needed
 
+			// BOR: the bor receipt was written ahead of the block, index its state-sync tx
+			if rawdb.ReadBorReceiptRLP(bc.db, block.Hash(), block.NumberU64()) != nil {
+				rawdb.WriteBorTxLookupEntry(batch, block.Hash(), block.NumberU64())
+			}
+
 			// Write everything belongs to the blocks into the database. So that
 			// we can ensure all components of body is completed(body, receipts,
 			// tx indexes)
@@ -1637,11 +1672,25 @@ func (bc *BlockChain) writeBlockWithState(block *types.Block, receipts []*types.
 
 			// Write bor tx reverse lookup
 			rawdb.WriteBorTxLookupEntry(blockBatch, block.Hash(), block.NumberU64())
+
+			// Index the committed state-syncs for replayable subscriptions
+			if syncs := bc.stateSyncData; len(syncs) > 0 {
+				rawdb.WriteStateSyncs(blockBatch, block.Hash(), block.NumberU64(), syncs)
+
+				if last := syncs[len(syncs)-1].ID; last > rawdb.ReadLastStateSyncID(bc.db) {
+					rawdb.WriteLastStateSyncID(blockBatch, last)
+				}
+			}
 		}
 	}
 
 	rawdb.WritePreimages(blockBatch, state.Preimages())
 
+	// Index the account and storage changes, state-sync credits included
+	if set := state.ChangeSet(); set != nil {
+		rawdb.WriteChangeSet(blockBatch, block.Hash(), block.NumberU64(), set)
+	}
+
 	if err := blockBatch.Write(); err != nil {
 		log.Crit("Failed to write block into disk", "err", err)
 	}
@@ -2936,7 +2985,10 @@ func (bc *BlockChain) skipBlock(err error, it *insertIterator) bool {
 
 // indexBlocks reindexes or unindexes transactions depending on user configuration
 func (bc *BlockChain) indexBlocks(tail *uint64, head uint64, done chan struct{}) {
-	defer func() { close(done) }()
+	defer func() {
+		bc.expireBorReceipts(head)
+		close(done)
+	}()
 
 	// The tail flag is not existent, it means the node is just initialized
 	// and all blocks(may from ancient store) are not indexed yet.
@@ -140,3 +140,72 @@ func TestChain2HeadEvent(t *testing.T) {
 			replacementBlocks[3].Hash(),
 		}})
 }
+
+// Tests that bor receipts written ahead of a snap synced chain are moved along
+// with the blocks into the ancient store, and that their state-sync
+// transactions are indexed.
+func TestInsertReceiptChainBorReceipts(t *testing.T) {
+	t.Parallel()
+
+	gspec := &Genesis{Config: params.TestChainConfig}
+	_, blocks, receipts := GenerateChainWithGenesis(gspec, ethash.NewFaker(), 16, nil)
+
+	db, err := rawdb.NewDatabaseWithFreezer(rawdb.NewMemoryDatabase(), t.TempDir(), "", false)
+	if err != nil {
+		t.Fatalf("failed to create temp freezer db: %v", err)
+	}
+	defer db.Close()
+
+	chain, _ := NewBlockChain(db, nil, gspec, nil, ethash.NewFaker(), vm.Config{}, nil, nil, nil)
+	defer chain.Stop()
+
+	headers := make([]*types.Header, len(blocks))
+	for i, block := range blocks {
+		headers[i] = block.Header()
+	}
+
+	if n, err := chain.InsertHeaderChain(headers); err != nil {
+		t.Fatalf("failed to insert header %d: %v", n, err)
+	}
+
+	borReceipt := func(number uint64) *types.ReceiptForStorage {
+		return &types.ReceiptForStorage{
+			Status: types.ReceiptStatusSuccessful,
+			Logs:   []*types.Log{{Address: common.HexToAddress("0x1001"), Data: []byte{byte(number)}}},
+		}
+	}
+
+	for _, block := range blocks {
+		if block.NumberU64()%4 == 0 {
+			rawdb.WriteBorReceipt(db, block.Hash(), block.NumberU64(), borReceipt(block.NumberU64()))
+		}
+	}
+
+	if n, err := chain.InsertReceiptChain(blocks, receipts, uint64(len(blocks)/2)); err != nil {
+		t.Fatalf("failed to insert receipt %d: %v", n, err)
+	}
+
+	for _, block := range blocks {
+		number, hash := block.NumberU64(), block.Hash()
+		txHash := types.GetDerivedBorTxHash(types.BorReceiptKey(number, hash))
+
+		receipt := rawdb.ReadRawBorReceipt(db, hash, number)
+		lookup := rawdb.ReadBorTxLookupEntry(db, txHash)
+
+		if number%4 != 0 {
+			if receipt != nil || lookup != nil {
+				t.Errorf("block %d: unexpected bor receipt or lookup", number)
+			}
+
+			continue
+		}
+
+		if receipt == nil || len(receipt.Logs) != 1 || receipt.Logs[0].Data[0] != byte(number) {
+			t.Errorf("block %d: bor receipt mismatch: %v", number, receipt)
+		}
+
+		if lookup == nil || *lookup != number {
+			t.Errorf("block %d: bor tx lookup mismatch: %v", number, lookup)
+		}
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/core/rawdb"
 	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/log"
 )
 
 // GetBorReceiptByHash retrieves the bor block receipt in a given block.
@@ -29,3 +30,34 @@ func (bc *BlockChain) GetBorReceiptByHash(hash common.Hash) *types.Receipt {
 
 	return receipt
 }
+
+// borReceiptLimit returns the number of recent blocks whose bor receipts are
+// retained, 0 meaning the entire chain. Bor receipts never expire before the
+// transaction indices of their block.
+func (bc *BlockChain) borReceiptLimit() uint64 {
+	limit := bc.cacheConfig.BorReceiptLimit
+	if limit == 0 || bc.txLookupLimit == 0 {
+		return 0
+	}
+
+	return max(limit, bc.txLookupLimit)
+}
+
+// expireBorReceipts removes the bor receipts which fell out of the retained
+// history of the given head. It runs alongside the transaction indexer.
+func (bc *BlockChain) expireBorReceipts(head uint64) {
+	limit := bc.borReceiptLimit()
+	if limit == 0 || head < limit || bc.chainConfig.Bor == nil {
+		return
+	}
+
+	if err := rawdb.PruneBorReceipts(bc.db, bc.chainConfig.Bor, head-limit+1, bc.quit); err != nil {
+		log.Error("Failed to expire bor receipts", "err", err)
+	}
+}
+
+// ChangeSetsEnabled returns whether the account and storage changes of the
+// written blocks are indexed, for the block producers to track them too.
+func (bc *BlockChain) ChangeSetsEnabled() bool {
+	return bc.cacheConfig.ChangeSets
+}
@@ -0,0 +1,101 @@
+package core
+
+import (
+	"context"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/bitutil"
+	"github.com/ethereum/go-ethereum/core/bloombits"
+	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/ethdb"
+	"github.com/ethereum/go-ethereum/params"
+)
+
+// BorBloomIndexer implements a core.ChainIndexer, building up a rotated bloom bits
+// index for the bloom filters of the bor receipts. The state-sync logs are not
+// covered by the header blooms, so they need an index of their own for bor log
+// queries to skip the blocks without matches.
+type BorBloomIndexer struct {
+	size    uint64               // section size to generate bloombits for
+	db      ethdb.Database       // database instance to write index data and metadata into
+	config  *params.BorConfig    // bor config to skip the blocks without state-syncs
+	gen     *bloombits.Generator // generator to rotate the bloom bits crating the bloom index
+	section uint64               // Section is the section number being processed currently
+	head    common.Hash          // Head is the hash of the last header processed
+}
+
+// NewBorBloomIndexer returns a chain indexer that generates bloom bits data for
+// the bor receipts of the canonical chain for fast bor logs filtering. Sections
+// are rebuilt once the bor receipts they cover are marked stale.
+func NewBorBloomIndexer(db ethdb.Database, config *params.BorConfig, size, confirms uint64) *ChainIndexer {
+	backend := &BorBloomIndexer{
+		db:     db,
+		config: config,
+		size:   size,
+	}
+	table := rawdb.NewTable(db, string(rawdb.BorBloomBitsIndexPrefix))
+
+	indexer := NewChainIndexer(db, table, backend, size, confirms, bloomThrottling, "borbloombits")
+
+	// Bor receipts are also written by backfills, reindexes and repairs after
+	// their blocks got indexed, rebuild the sections they touch
+	indexer.stale = func() (uint64, bool) { return rawdb.TakeBorBloomStale(db) }
+
+	return indexer
+}
+
+// Reset implements core.ChainIndexerBackend, starting a new bloombits index
+// section.
+func (b *BorBloomIndexer) Reset(ctx context.Context, section uint64, lastSectionHead common.Hash) error {
+	gen, err := bloombits.NewGenerator(uint(b.size))
+	b.gen, b.section, b.head = gen, section, common.Hash{}
+
+	return err
+}
+
+// Process implements core.ChainIndexerBackend, adding the bloom of a header's
+// bor receipt into the index, an empty one if the block committed no state-sync.
+func (b *BorBloomIndexer) Process(ctx context.Context, header *types.Header) error {
+	var (
+		number = header.Number.Uint64()
+		bloom  types.Bloom
+	)
+
+	// Only sprint start blocks commit state-syncs
+	if b.config == nil || b.config.Sprint == nil || b.config.IsSprintStart(number) {
+		if receipt := rawdb.ReadRawBorReceipt(b.db, header.Hash(), number); receipt != nil {
+			bloom = types.CreateBloom(types.Receipts{receipt})
+		}
+	}
+
+	if err := b.gen.AddBloom(uint(number-b.section*b.size), bloom); err != nil {
+		return err
+	}
+
+	b.head = header.Hash()
+
+	return nil
+}
+
+// Commit implements core.ChainIndexerBackend, finalizing the bloom section and
+// writing it out into the database.
+func (b *BorBloomIndexer) Commit() error {
+	batch := b.db.NewBatchWithSize((int(b.size) / 8) * types.BloomBitLength)
+
+	for i := 0; i < types.BloomBitLength; i++ {
+		bits, err := b.gen.Bitset(uint(i))
+		if err != nil {
+			return err
+		}
+
+		rawdb.WriteBorBloomBits(batch, uint(i), b.section, b.head, bitutil.CompressBytes(bits))
+	}
+
+	return batch.Write()
+}
+
+// Prune returns an empty error since we don't support pruning here.
+func (b *BorBloomIndexer) Prune(threshold uint64) error {
+	return nil
+}
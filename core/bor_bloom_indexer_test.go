@@ -0,0 +1,148 @@
+package core
+
+import (
+	"context"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/bitutil"
+	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/params"
+)
+
+func TestBorBloomIndexer(t *testing.T) {
+	t.Parallel()
+
+	var (
+		db      = rawdb.NewMemoryDatabase()
+		config  = &params.BorConfig{Sprint: map[string]uint64{"0": 4}}
+		address = common.HexToAddress("0x1001")
+		headers = make([]*types.Header, 16)
+	)
+
+	for i := range headers {
+		headers[i] = &types.Header{Number: big.NewInt(int64(i))}
+	}
+
+	// Sprint start with a state-sync, and one off the sprint start which is skipped
+	for _, number := range []int{8, 9} {
+		receipt := &types.ReceiptForStorage{Status: types.ReceiptStatusSuccessful, Logs: []*types.Log{{Address: address}}}
+		rawdb.WriteBorReceipt(db, headers[number].Hash(), uint64(number), receipt)
+	}
+
+	indexer := &BorBloomIndexer{db: db, config: config, size: uint64(len(headers))}
+	if err := indexer.Reset(context.Background(), 0, common.Hash{}); err != nil {
+		t.Fatalf("failed to reset indexer: %v", err)
+	}
+
+	for _, header := range headers {
+		if err := indexer.Process(context.Background(), header); err != nil {
+			t.Fatalf("failed to process header %d: %v", header.Number, err)
+		}
+	}
+
+	if err := indexer.Commit(); err != nil {
+		t.Fatalf("failed to commit section: %v", err)
+	}
+
+	// Every bloom bit of the address must be set for the state-sync block only
+	hash := crypto.Keccak256(address.Bytes())
+	for i := 0; i < 6; i += 2 {
+		bit := (uint(hash[i])<<8)&2047 + uint(hash[i+1])
+
+		compressed, err := rawdb.ReadBorBloomBits(db, bit, 0, headers[15].Hash())
+		if err != nil {
+			t.Fatalf("failed to read bloom bits %d: %v", bit, err)
+		}
+
+		bits, err := bitutil.DecompressBytes(compressed, len(headers)/8)
+		if err != nil {
+			t.Fatalf("failed to decompress bloom bits %d: %v", bit, err)
+		}
+
+		for number := range headers {
+			want := number == 8
+			if have := bits[number/8]&(1<<(7-number%8)) != 0; have != want {
+				t.Errorf("bloom bit %d of block %d mismatch: have %v, want %v", bit, number, have, want)
+			}
+		}
+	}
+}
+
+// Tests that the sections covering bor receipts written after they got indexed,
+// e.g. by a backfill, are rebuilt once the receipts are marked stale.
+func TestBorBloomIndexerStale(t *testing.T) {
+	t.Parallel()
+
+	var (
+		db      = rawdb.NewMemoryDatabase()
+		config  = &params.BorConfig{Sprint: map[string]uint64{"0": 4}}
+		address = common.HexToAddress("0x1001")
+		headers = make([]*types.Header, 32)
+	)
+
+	for i := range headers {
+		headers[i] = &types.Header{Number: big.NewInt(int64(i))}
+		if i > 0 {
+			headers[i].ParentHash = headers[i-1].Hash()
+		}
+
+		rawdb.WriteHeader(db, headers[i])
+		rawdb.WriteCanonicalHash(db, headers[i].Hash(), uint64(i))
+	}
+
+	indexer := NewBorBloomIndexer(db, config, 16, 0)
+	defer indexer.Close()
+
+	// indexed reports whether the address is in the bloom bits of block 8
+	indexed := func() bool {
+		hash := crypto.Keccak256(address.Bytes())
+		bit := (uint(hash[0])<<8)&2047 + uint(hash[1])
+
+		compressed, err := rawdb.ReadBorBloomBits(db, bit, 0, headers[15].Hash())
+		if err != nil {
+			return false
+		}
+
+		bits, err := bitutil.DecompressBytes(compressed, 16/8)
+		if err != nil {
+			t.Fatalf("failed to decompress bloom bits %d: %v", bit, err)
+		}
+
+		return bits[1]&(1<<7) != 0
+	}
+
+	// wait polls the indexer until both sections are stored and the bloom bits
+	// of block 8 match the expectation
+	wait := func(want bool) {
+		t.Helper()
+
+		for deadline := time.Now().Add(5 * time.Second); time.Now().Before(deadline); time.Sleep(10 * time.Millisecond) {
+			if sections, _, _ := indexer.Sections(); sections == 2 && indexed() == want {
+				return
+			}
+		}
+
+		sections, _, _ := indexer.Sections()
+		t.Fatalf("indexer mismatch: sections %d, indexed %v, want 2, %v", sections, indexed(), want)
+	}
+
+	indexer.newHead(uint64(len(headers)-1), false)
+	wait(false)
+
+	// Backfill the bor receipt of block 8, the section stays stale until marked
+	receipt := &types.ReceiptForStorage{Status: types.ReceiptStatusSuccessful, Logs: []*types.Log{{Address: address}}}
+	rawdb.WriteBorReceipt(db, headers[8].Hash(), 8, receipt)
+	rawdb.MarkBorBloomStale(db, 8)
+
+	indexer.checkStale()
+	wait(true)
+
+	if _, ok := rawdb.TakeBorBloomStale(db); ok {
+		t.Fatalf("stale marker not cleared")
+	}
+}
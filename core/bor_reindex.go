@@ -0,0 +1,331 @@
+package core
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/consensus"
+	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/core/state"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/core/vm"
+	"github.com/ethereum/go-ethereum/log"
+)
+
+var (
+	errBorReindexRunning     = errors.New("bor reindex already running")
+	errBorReindexUnsupported = errors.New("consensus engine cannot replay state-sync system calls")
+)
+
+// StateSyncReplayer is implemented by consensus engines able to re-execute the
+// state-sync system calls of a sprint start block on top of the given state,
+// which is the state after the block's transactions.
+type StateSyncReplayer interface {
+	ReplayStateSync(chain consensus.ChainHeaderReader, header *types.Header, state *state.StateDB) error
+}
+
+// BorReindexStatus reports the progress of a bor receipt reindex.
+type BorReindexStatus struct {
+	rawdb.BorReindexProgress
+
+	Running bool   // Whether the reindex is in progress
+	Written uint64 // Number of bor receipts written since the reindex was (re)started
+	Err     string // Error which stopped the reindex, if any
+}
+
+// borReindexer tracks the background bor receipt reindex of a chain.
+type borReindexer struct {
+	lock   sync.Mutex
+	status BorReindexStatus
+	stop   chan struct{}
+	done   chan struct{} // Closed when the reindex goroutine exits
+}
+
+// StartBorReindex regenerates the bor receipts and bor tx lookup entries of
+// the blocks in [from, to] in the background, by replaying the transactions
+// and the state-sync system calls of every sprint start on top of the parent
+// state. The state of the parent blocks must therefore be available, and so
+// must Heimdall. Progress is persisted so
+// that an interrupted reindex is resumed by ResumeBorReindex.
+func (bc *BlockChain) StartBorReindex(from uint64, to uint64) error {
+	if from > to {
+		return fmt.Errorf("invalid block range %d-%d", from, to)
+	}
+
+	if head := bc.CurrentBlock().Number.Uint64(); to > head {
+		return fmt.Errorf("block %d above the current head %d", to, head)
+	}
+
+	return bc.startBorReindex(&rawdb.BorReindexProgress{From: from, To: to, Next: from}, true)
+}
+
+// ResumeBorReindex restarts an unfinished bor receipt reindex, if any.
+func (bc *BlockChain) ResumeBorReindex() {
+	progress := rawdb.ReadBorReindexProgress(bc.db)
+	if progress == nil {
+		return
+	}
+
+	log.Info("Resuming bor receipt reindex", "from", progress.From, "to", progress.To, "next", progress.Next)
+
+	if err := bc.startBorReindex(progress, false); err != nil {
+		log.Warn("Failed to resume bor receipt reindex", "err", err)
+	}
+}
+
+// StopBorReindex interrupts a running bor receipt reindex and waits for it to
+// exit. Its progress is kept and can be resumed later.
+func (bc *BlockChain) StopBorReindex() {
+	bc.borReindex.lock.Lock()
+
+	if !bc.borReindex.status.Running {
+		bc.borReindex.lock.Unlock()
+		return
+	}
+
+	select {
+	case <-bc.borReindex.stop:
+	default:
+		close(bc.borReindex.stop)
+	}
+
+	done := bc.borReindex.done
+	bc.borReindex.lock.Unlock()
+
+	// The worker holds the lock while updating the status, so wait unlocked
+	<-done
+}
+
+// BorReindexStatus returns the progress of the current or last bor receipt
+// reindex. If none ran since startup, the persisted progress is reported.
+func (bc *BlockChain) BorReindexStatus() BorReindexStatus {
+	bc.borReindex.lock.Lock()
+	defer bc.borReindex.lock.Unlock()
+
+	status := bc.borReindex.status
+	if bc.borReindex.stop == nil {
+		if progress := rawdb.ReadBorReindexProgress(bc.db); progress != nil {
+			status.BorReindexProgress = *progress
+		}
+	}
+
+	return status
+}
+
+func (bc *BlockChain) startBorReindex(progress *rawdb.BorReindexProgress, persist bool) error {
+	replayer, ok := bc.engine.(StateSyncReplayer)
+	if !ok || bc.chainConfig.Bor == nil {
+		return errBorReindexUnsupported
+	}
+
+	bc.borReindex.lock.Lock()
+	defer bc.borReindex.lock.Unlock()
+
+	if bc.borReindex.status.Running {
+		return errBorReindexRunning
+	}
+
+	if persist {
+		rawdb.WriteBorReindexProgress(bc.db, progress)
+	}
+
+	var (
+		stop = make(chan struct{})
+		done = make(chan struct{})
+	)
+
+	bc.borReindex.stop = stop
+	bc.borReindex.done = done
+	bc.borReindex.status = BorReindexStatus{BorReindexProgress: *progress, Running: true}
+
+	bc.wg.Add(1)
+
+	go func() {
+		defer bc.wg.Done()
+		defer close(done)
+
+		err := bc.reindexBor(replayer, *progress, stop)
+
+		bc.borReindex.lock.Lock()
+		defer bc.borReindex.lock.Unlock()
+
+		bc.borReindex.status.Running = false
+
+		if err != nil {
+			bc.borReindex.status.Err = err.Error()
+			log.Error("Bor receipt reindex failed", "next", bc.borReindex.status.Next, "err", err)
+		}
+	}()
+
+	return nil
+}
+
+// reindexBor replays the sprint start blocks of the given range until it is
+// done, fails or is interrupted.
+func (bc *BlockChain) reindexBor(replayer StateSyncReplayer, progress rawdb.BorReindexProgress, stop chan struct{}) error {
+	var (
+		start  = time.Now()
+		logged = time.Now()
+	)
+
+	for ; progress.Next <= progress.To; progress.Next++ {
+		select {
+		case <-stop:
+			log.Info("Bor receipt reindex interrupted", "next", progress.Next)
+			return nil
+		case <-bc.quit:
+			return nil
+		default:
+		}
+
+		number := progress.Next
+		if number == 0 || !bc.chainConfig.Bor.IsSprintStart(number) {
+			continue
+		}
+
+		// Persist the progress alongside the receipt, so the block is not
+		// replayed twice after a restart
+		next := &rawdb.BorReindexProgress{From: progress.From, To: progress.To, Next: number + 1}
+
+		written, err := bc.reindexBorBlock(replayer, number, next)
+		if err != nil {
+			return fmt.Errorf("block %d: %w", number, err)
+		}
+
+		bc.borReindex.lock.Lock()
+		bc.borReindex.status.Next = number + 1
+		if written {
+			bc.borReindex.status.Written++
+		}
+		bc.borReindex.lock.Unlock()
+
+		if time.Since(logged) > 8*time.Second {
+			log.Info("Reindexing bor receipts", "number", number, "to", progress.To, "elapsed", common.PrettyDuration(time.Since(start)))
+			logged = time.Now()
+		}
+	}
+
+	rawdb.DeleteBorReindexProgress(bc.db)
+
+	bc.borReindex.lock.Lock()
+	bc.borReindex.status.Next = progress.To + 1
+	bc.borReindex.lock.Unlock()
+
+	log.Info("Reindexed bor receipts", "from", progress.From, "to", progress.To, "elapsed", common.PrettyDuration(time.Since(start)))
+
+	return nil
+}
+
+// reindexBorBlock replays the transactions and the state-sync system calls of
+// a sprint start block and rewrites its bor receipt and bor tx lookup entry,
+// together with the given reindex progress. It returns whether the block
+// emitted state-sync logs.
+func (bc *BlockChain) reindexBorBlock(replayer StateSyncReplayer, number uint64, progress *rawdb.BorReindexProgress) (bool, error) {
+	header := bc.GetHeaderByNumber(number)
+	if header == nil {
+		return false, errors.New("header not found")
+	}
+
+	block := bc.GetBlock(header.Hash(), number)
+	if block == nil {
+		return false, errors.New("block not found")
+	}
+
+	parent := bc.GetHeader(header.ParentHash, number-1)
+	if parent == nil {
+		return false, errors.New("parent header not found")
+	}
+
+	statedb, err := bc.StateAt(parent.Root)
+	if err != nil {
+		return false, fmt.Errorf("parent state unavailable: %w", err)
+	}
+
+	// The state-sync system calls run on top of the block's transactions
+	var (
+		gp      = new(GasPool).AddGas(block.GasLimit())
+		usedGas = new(uint64)
+		signer  = types.MakeSigner(bc.chainConfig, header.Number, header.Time)
+		vmenv   = vm.NewEVM(NewEVMBlockContext(header, bc, nil), vm.TxContext{}, statedb, bc.chainConfig, vm.Config{})
+		txLogs  int
+	)
+
+	for i, tx := range block.Transactions() {
+		msg, err := TransactionToMessage(tx, signer, header.BaseFee)
+		if err != nil {
+			return false, fmt.Errorf("could not apply tx %d [%v]: %w", i, tx.Hash().Hex(), err)
+		}
+
+		statedb.SetTxContext(tx.Hash(), i)
+
+		receipt, err := applyTransaction(msg, bc.chainConfig, gp, statedb, header.Number, header.Hash(), tx, usedGas, vmenv, nil)
+		if err != nil {
+			return false, fmt.Errorf("could not apply tx %d [%v]: %w", i, tx.Hash().Hex(), err)
+		}
+
+		txLogs += len(receipt.Logs)
+	}
+
+	if err := replayer.ReplayStateSync(bc, header, statedb); err != nil {
+		return false, err
+	}
+
+	// State-sync logs are indexed after the logs of the block's transactions
+	logs := statedb.Logs()
+
+	sort.SliceStable(logs, func(i, j int) bool {
+		return logs[i].Index < logs[j].Index
+	})
+
+	batch := bc.db.NewBatch()
+	rawdb.WriteBorReindexProgress(batch, progress)
+
+	if len(logs) <= txLogs {
+		// Drop a bor receipt retrieved from the network for a block without
+		// state-syncs
+		unverified := rawdb.IsBorReceiptUnverified(bc.db, header.Hash(), number)
+		if unverified {
+			rawdb.DeleteBorReceipt(batch, header.Hash(), number)
+			rawdb.DeleteBorTxLookupEntry(batch, header.Hash(), number)
+		}
+
+		if err := batch.Write(); err != nil {
+			return false, err
+		}
+
+		if unverified {
+			// A frozen receipt can't be deleted, keep it from being served
+			if len(rawdb.ReadBorReceiptRLP(bc.db, header.Hash(), number)) > 0 {
+				rawdb.WriteBorReceiptUnverified(bc.db, header.Hash(), number)
+			}
+
+			bc.borReceiptsCache.Remove(header.Hash())
+			rawdb.MarkBorBloomStale(bc.db, number)
+		}
+
+		return false, nil
+	}
+
+	logs = logs[txLogs:]
+	types.DeriveFieldsForBorLogs(logs, header.Hash(), number, uint(len(block.Transactions())), uint(txLogs))
+
+	rawdb.WriteBorReceipt(batch, header.Hash(), number, &types.ReceiptForStorage{
+		Status: types.ReceiptStatusSuccessful,
+		Logs:   logs,
+	})
+	rawdb.WriteBorTxLookupEntry(batch, header.Hash(), number)
+	rawdb.DeleteBorReceiptUnverified(batch, header.Hash(), number)
+
+	if err := batch.Write(); err != nil {
+		return false, err
+	}
+
+	bc.borReceiptsCache.Remove(header.Hash())
+	rawdb.MarkBorBloomStale(bc.db, number)
+
+	return true, nil
+}
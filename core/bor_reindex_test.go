@@ -0,0 +1,262 @@
+package core
+
+import (
+	"errors"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/consensus"
+	"github.com/ethereum/go-ethereum/consensus/ethash"
+	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/core/state"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/core/vm"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/params"
+)
+
+// stateSyncReplayEngine is a consensus engine emitting a single state-sync log
+// per replayed block, carrying the balance of the recipient of the block's
+// transactions, and failing to replay a given block. If set, entered is
+// signalled on every replay, which then waits for release.
+type stateSyncReplayEngine struct {
+	consensus.Engine
+
+	recipient common.Address
+	fail      uint64
+
+	entered chan struct{}
+	release chan struct{}
+}
+
+func (e *stateSyncReplayEngine) ReplayStateSync(chain consensus.ChainHeaderReader, header *types.Header, statedb *state.StateDB) error {
+	if e.entered != nil {
+		e.entered <- struct{}{}
+		<-e.release
+	}
+
+	if header.Number.Uint64() == e.fail {
+		return errors.New("heimdall unreachable")
+	}
+
+	statedb.AddLog(&types.Log{Address: common.HexToAddress("0x1001"), Data: statedb.GetBalance(e.recipient).Bytes()})
+
+	return nil
+}
+
+func waitBorReindex(t *testing.T, chain *BlockChain) BorReindexStatus {
+	t.Helper()
+
+	for i := 0; i < 100; i++ {
+		if status := chain.BorReindexStatus(); !status.Running {
+			return status
+		}
+
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	t.Fatalf("bor reindex did not finish")
+
+	return BorReindexStatus{}
+}
+
+func TestBorReindex(t *testing.T) {
+	t.Parallel()
+
+	config := *params.TestChainConfig
+	config.Bor = &params.BorConfig{Sprint: map[string]uint64{"0": 4}, BurntContract: map[string]string{"0": "0x000000000000000000000000000000000000dead"}}
+
+	var (
+		key, _    = crypto.HexToECDSA("b71c71a67e1177ad4e901695e1b4b9ee17ae16c6668d313eac2f96dbcda3f291")
+		addr      = crypto.PubkeyToAddress(key.PublicKey)
+		recipient = common.HexToAddress("0x1002")
+		gspec     = &Genesis{Config: &config, Alloc: GenesisAlloc{addr: {Balance: big.NewInt(params.Ether)}}}
+		signer    = types.LatestSigner(&config)
+	)
+
+	// Every block sends a wei to the recipient
+	_, blocks, _ := GenerateChainWithGenesis(gspec, ethash.NewFaker(), 14, func(i int, gen *BlockGen) {
+		tx, err := types.SignTx(types.NewTransaction(gen.TxNonce(addr), recipient, big.NewInt(1), params.TxGas, gen.BaseFee(), nil), signer, key)
+		if err != nil {
+			t.Fatalf("failed to sign tx: %v", err)
+		}
+
+		gen.AddTx(tx)
+	})
+
+	db := rawdb.NewMemoryDatabase()
+	engine := &stateSyncReplayEngine{Engine: ethash.NewFaker(), recipient: recipient}
+
+	chain, err := NewBlockChain(db, nil, gspec, nil, engine, vm.Config{}, nil, nil, nil)
+	if err != nil {
+		t.Fatalf("failed to create chain: %v", err)
+	}
+	defer chain.Stop()
+
+	if n, err := chain.InsertChain(blocks); err != nil {
+		t.Fatalf("failed to insert block %d: %v", n, err)
+	}
+
+	if err := chain.StartBorReindex(1, 15); err == nil {
+		t.Fatalf("reindexed blocks above the head")
+	}
+
+	// A bor receipt retrieved from the network is replaced by the derived one
+	rawdb.WriteBorReceipt(db, blocks[3].Hash(), 4, &types.ReceiptForStorage{Status: types.ReceiptStatusSuccessful})
+	rawdb.WriteBorReceiptUnverified(db, blocks[3].Hash(), 4)
+
+	if err := chain.StartBorReindex(1, 12); err != nil {
+		t.Fatalf("failed to start reindex: %v", err)
+	}
+
+	status := waitBorReindex(t, chain)
+	if status.Err != "" || status.Written != 3 || status.Next != 13 {
+		t.Fatalf("unexpected status: %+v", status)
+	}
+
+	for _, block := range blocks {
+		number := block.NumberU64()
+
+		receipt := rawdb.ReadRawBorReceipt(db, block.Hash(), number)
+		if number%4 != 0 || number > 12 {
+			if receipt != nil {
+				t.Fatalf("block %d: unexpected bor receipt", number)
+			}
+
+			continue
+		}
+
+		if receipt == nil || len(receipt.Logs) != 1 {
+			t.Fatalf("block %d: missing bor receipt", number)
+		}
+
+		// State-syncs are replayed on top of the block's transactions
+		if balance := new(big.Int).SetBytes(receipt.Logs[0].Data); balance.Uint64() != number {
+			t.Fatalf("block %d: state-sync replayed on balance %v, want %d", number, balance, number)
+		}
+
+		txHash := types.GetDerivedBorTxHash(types.BorReceiptKey(number, block.Hash()))
+		if entry := rawdb.ReadBorTxLookupEntry(db, txHash); entry == nil || *entry != number {
+			t.Fatalf("block %d: missing bor tx lookup entry", number)
+		}
+	}
+
+	if rawdb.IsBorReceiptUnverified(db, blocks[3].Hash(), 4) {
+		t.Fatalf("block 4: derived bor receipt still marked unverified")
+	}
+
+	if progress := rawdb.ReadBorReindexProgress(db); progress != nil {
+		t.Fatalf("progress left after the reindex: %+v", progress)
+	}
+
+	// An interrupted reindex resumes from the persisted progress
+	for _, number := range []uint64{8, 12} {
+		rawdb.DeleteBorReceipt(db, blocks[number-1].Hash(), number)
+	}
+
+	rawdb.WriteBorReindexProgress(db, &rawdb.BorReindexProgress{From: 1, To: 12, Next: 9})
+
+	chain.ResumeBorReindex()
+
+	if status := waitBorReindex(t, chain); status.Err != "" || status.Written != 1 {
+		t.Fatalf("unexpected status: %+v", status)
+	}
+
+	if rawdb.ReadRawBorReceipt(db, blocks[7].Hash(), 8) != nil {
+		t.Fatalf("block 8 reindexed before the persisted progress")
+	}
+
+	if rawdb.ReadRawBorReceipt(db, blocks[11].Hash(), 12) == nil {
+		t.Fatalf("block 12 not reindexed")
+	}
+
+	// A block failing to replay stops the reindex, keeping its progress
+	engine.fail = 8
+
+	if err := chain.StartBorReindex(1, 12); err != nil {
+		t.Fatalf("failed to start reindex: %v", err)
+	}
+
+	if status := waitBorReindex(t, chain); status.Err == "" || status.Next != 5 {
+		t.Fatalf("unexpected status: %+v", status)
+	}
+
+	if progress := rawdb.ReadBorReindexProgress(db); progress == nil || progress.Next != 5 {
+		t.Fatalf("unexpected progress: %+v", progress)
+	}
+}
+
+func TestStopBorReindex(t *testing.T) {
+	t.Parallel()
+
+	config := *params.TestChainConfig
+	config.Bor = &params.BorConfig{Sprint: map[string]uint64{"0": 4}, BurntContract: map[string]string{"0": "0x000000000000000000000000000000000000dead"}}
+
+	gspec := &Genesis{Config: &config}
+	_, blocks, _ := GenerateChainWithGenesis(gspec, ethash.NewFaker(), 8, nil)
+
+	db := rawdb.NewMemoryDatabase()
+	engine := &stateSyncReplayEngine{Engine: ethash.NewFaker(), entered: make(chan struct{}), release: make(chan struct{})}
+
+	chain, err := NewBlockChain(db, nil, gspec, nil, engine, vm.Config{}, nil, nil, nil)
+	if err != nil {
+		t.Fatalf("failed to create chain: %v", err)
+	}
+	defer chain.Stop()
+
+	if n, err := chain.InsertChain(blocks); err != nil {
+		t.Fatalf("failed to insert block %d: %v", n, err)
+	}
+
+	if err := chain.StartBorReindex(1, 8); err != nil {
+		t.Fatalf("failed to start reindex: %v", err)
+	}
+
+	<-engine.entered
+
+	stopped := make(chan struct{})
+
+	go func() {
+		chain.StopBorReindex()
+		close(stopped)
+	}()
+
+	// The reindex keeps running until the worker is done with its block
+	time.Sleep(50 * time.Millisecond)
+
+	select {
+	case <-stopped:
+		t.Fatalf("stop returned before the reindex exited")
+	default:
+	}
+
+	if !chain.BorReindexStatus().Running {
+		t.Fatalf("reindex reported stopped while the worker runs")
+	}
+
+	if err := chain.StartBorReindex(1, 8); !errors.Is(err, errBorReindexRunning) {
+		t.Fatalf("second reindex started while stopping: %v", err)
+	}
+
+	close(engine.release)
+
+	select {
+	case <-stopped:
+	case <-time.After(time.Second):
+		t.Fatalf("stop did not return after the reindex exited")
+	}
+
+	status := chain.BorReindexStatus()
+	if status.Running || status.Next != 5 {
+		t.Fatalf("unexpected status: %+v", status)
+	}
+
+	if progress := rawdb.ReadBorReindexProgress(db); progress == nil || progress.Next != 5 {
+		t.Fatalf("unexpected progress: %+v", progress)
+	}
+
+	// Stopping an idle reindex is a noop
+	chain.StopBorReindex()
+}
@@ -92,6 +92,9 @@ type ChainIndexer struct {
 	checkpointSections uint64      // Number of sections covered by the checkpoint
 	checkpointHead     common.Hash // Section head belonging to the checkpoint
 
+	stale         func() (uint64, bool) // Optional source of the first block whose indexed data changed
+	invalidations uint64                // Number of stale data invalidations, to discard sections processed meanwhile
+
 	throttling time.Duration // Disk throttling to prevent a heavy upgrade from hogging resources
 
 	log  log.Logger
@@ -205,6 +208,7 @@ func (c *ChainIndexer) eventLoop(currentHeader *types.Header, events chan ChainH
 	defer sub.Unsubscribe()
 
 	// Fire the initial new head event to start any outstanding processing
+	c.checkStale()
 	c.newHead(currentHeader.Number.Uint64(), false)
 
 	var (
@@ -239,6 +243,7 @@ func (c *ChainIndexer) eventLoop(currentHeader *types.Header, events chan ChainH
 				}
 			}
 
+			c.checkStale()
 			c.newHead(header.Number.Uint64(), false)
 
 			prevHeader, prevHash = header, header.Hash()
@@ -312,6 +317,59 @@ func (c *ChainIndexer) newHead(head uint64, reorg bool) {
 	}
 }
 
+// checkStale invalidates the sections whose data changed since they were
+// indexed, if the indexer has a stale data source.
+func (c *ChainIndexer) checkStale() {
+	if c.stale == nil {
+		return
+	}
+
+	if number, ok := c.stale(); ok {
+		c.invalidate(number)
+	}
+}
+
+// invalidate reverts the stored sections to the one containing the given block,
+// so they are processed again from its updated data. Unlike a reorg, the chain
+// itself is unchanged and the known sections stay in place.
+func (c *ChainIndexer) invalidate(number uint64) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	section := number / c.sectionSize
+	if section < c.checkpointSections {
+		section = c.checkpointSections
+	}
+
+	// Discard the section being processed too, it may have read the old data
+	if section > c.storedSections {
+		return
+	}
+
+	c.invalidations++
+
+	if section == c.storedSections {
+		return
+	}
+
+	c.log.Info("Invalidating stale chain index sections", "section", section, "stored", c.storedSections)
+	c.setValidSections(section)
+
+	if head := section * c.sectionSize; head < c.cascadedHead {
+		c.cascadedHead = head
+		for _, child := range c.children {
+			child.newHead(c.cascadedHead, true)
+		}
+	}
+
+	if c.knownSections > c.storedSections {
+		select {
+		case c.update <- struct{}{}:
+		default:
+		}
+	}
+}
+
 // updateLoop is the main event loop of the indexer which pushes chain segments
 // down into the processing backend.
 func (c *ChainIndexer) updateLoop() {
@@ -343,7 +401,7 @@ func (c *ChainIndexer) updateLoop() {
 				}
 				// Cache the current section count and head to allow unlocking the mutex
 				c.verifyLastHead()
-				section := c.storedSections
+				section, invalidations := c.storedSections, c.invalidations
 
 				var oldHead common.Hash
 
@@ -367,7 +425,10 @@ func (c *ChainIndexer) updateLoop() {
 				c.lock.Lock()
 
 				// If processing succeeded and no reorgs occurred, mark the section completed
-				if err == nil && (section == 0 || oldHead == c.SectionHead(section-1)) {
+				if invalidations != c.invalidations {
+					// The indexed data changed meanwhile, process the reverted sections again
+					c.log.Debug("Chain index section invalidated while processing", "section", section)
+				} else if err == nil && (section == 0 || oldHead == c.SectionHead(section-1)) {
 					c.setSectionHead(section, newHead)
 					c.setValidSections(section + 1)
 
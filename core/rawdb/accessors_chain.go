@@ -854,9 +854,8 @@ func WriteBlock(db ethdb.KeyValueWriter, block *types.Block) {
 // WriteAncientBlocks writes entire block data into ancient store and returns the total written size.
 func WriteAncientBlocks(db ethdb.AncientWriter, blocks []*types.Block, receipts []types.Receipts, borReceipts []types.Receipts, td *big.Int) (int64, error) {
 	var (
-		tdSum         = new(big.Int).Set(td)
-		stReceipts    []*types.ReceiptForStorage
-		borStReceipts []*types.ReceiptForStorage
+		tdSum      = new(big.Int).Set(td)
+		stReceipts []*types.ReceiptForStorage
 	)
 
 	return db.ModifyAncients(func(op ethdb.AncientWriteOp) error {
@@ -867,10 +866,11 @@ func WriteAncientBlocks(db ethdb.AncientWriter, blocks []*types.Block, receipts
 				stReceipts = append(stReceipts, (*types.ReceiptForStorage)(receipt))
 			}
 
-			// Convert bor receipts to storage format and sum up total difficulty.
-			borStReceipts = borStReceipts[:0]
-			for _, borReceipt := range borReceipts[i] {
-				borStReceipts = append(borStReceipts, (*types.ReceiptForStorage)(borReceipt))
+			// Convert the bor receipt to storage format, the same way the chain
+			// freezer stores it: a single receipt, or nothing if the block has none.
+			var borReceipt *types.ReceiptForStorage
+			if len(borReceipts) > i && len(borReceipts[i]) > 0 && borReceipts[i][0] != nil {
+				borReceipt = (*types.ReceiptForStorage)(borReceipts[i][0])
 			}
 
 			header := block.Header()
@@ -878,7 +878,7 @@ func WriteAncientBlocks(db ethdb.AncientWriter, blocks []*types.Block, receipts
 				tdSum.Add(tdSum, header.Difficulty)
 			}
 
-			if err := writeAncientBlock(op, block, header, stReceipts, borStReceipts, tdSum); err != nil {
+			if err := writeAncientBlock(op, block, header, stReceipts, borReceipt, tdSum); err != nil {
 				return err
 			}
 		}
@@ -887,7 +887,7 @@ func WriteAncientBlocks(db ethdb.AncientWriter, blocks []*types.Block, receipts
 	})
 }
 
-func writeAncientBlock(op ethdb.AncientWriteOp, block *types.Block, header *types.Header, receipts []*types.ReceiptForStorage, borReceipts []*types.ReceiptForStorage, td *big.Int) error {
+func writeAncientBlock(op ethdb.AncientWriteOp, block *types.Block, header *types.Header, receipts []*types.ReceiptForStorage, borReceipt *types.ReceiptForStorage, td *big.Int) error {
 	num := block.NumberU64()
 	if err := op.AppendRaw(ChainFreezerHashTable, num, block.Hash().Bytes()); err != nil {
 		return fmt.Errorf("can't add block %d hash: %v", num, err)
@@ -909,7 +909,18 @@ func writeAncientBlock(op ethdb.AncientWriteOp, block *types.Block, header *type
 		return fmt.Errorf("can't append block %d total difficulty: %v", num, err)
 	}
 
-	if err := op.Append(freezerBorReceiptTable, num, borReceipts); err != nil {
+	var borReceiptRLP []byte
+
+	if borReceipt != nil {
+		data, err := rlp.EncodeToBytes(borReceipt)
+		if err != nil {
+			return fmt.Errorf("can't encode block %d borReceipt: %v", num, err)
+		}
+
+		borReceiptRLP = data
+	}
+
+	if err := op.AppendRaw(freezerBorReceiptTable, num, borReceiptRLP); err != nil {
 		return fmt.Errorf("can't append block %d borReceipts: %v", num, err)
 	}
 
@@ -923,12 +934,14 @@ func DeleteBlock(db ethdb.KeyValueWriter, hash common.Hash, number uint64) {
 	DeleteBody(db, hash, number)
 	DeleteTd(db, hash, number)
 
-	// delete bor receipt
+	// delete bor receipt and state-syncs
 	DeleteBorReceipt(db, hash, number)
+	DeleteStateSyncs(db, hash, number)
 }
 
 // DeleteBlockWithoutNumber removes all block data associated with a hash, except
-// the hash to number mapping.
+// the hash to number mapping. It's used to wipe frozen blocks, so the state-syncs
+// they committed are kept, the freezer not storing them.
 func DeleteBlockWithoutNumber(db ethdb.KeyValueWriter, hash common.Hash, number uint64) {
 	DeleteReceipts(db, hash, number)
 	deleteHeaderWithoutNumber(db, hash, number)
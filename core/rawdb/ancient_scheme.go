@@ -16,7 +16,10 @@
 
 package rawdb
 
-import "path/filepath"
+import (
+	"path/filepath"
+	"sort"
+)
 
 // The list of table names of chain freezer.
 const (
@@ -47,6 +50,25 @@ var chainFreezerNoSnappy = map[string]bool{
 	freezerBorReceiptTable:      false,
 }
 
+// chainFreezerIndependentTail lists the chain freezer tables whose tail can be
+// truncated ahead of the other tables, to expire their history separately.
+var chainFreezerIndependentTail = map[string]bool{
+	freezerBorReceiptTable: true,
+}
+
+// ChainFreezerTables returns the names of all the chain freezer tables, sorted
+// alphabetically.
+func ChainFreezerTables() []string {
+	tables := make([]string, 0, len(chainFreezerNoSnappy))
+	for table := range chainFreezerNoSnappy {
+		tables = append(tables, table)
+	}
+
+	sort.Strings(tables)
+
+	return tables
+}
+
 const (
 	// stateHistoryTableSize defines the maximum size of freezer data files.
 	stateHistoryTableSize = 2 * 1000 * 1000 * 1000
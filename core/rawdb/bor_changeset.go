@@ -0,0 +1,185 @@
+package rawdb
+
+import (
+	"encoding/binary"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/ethdb"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/rlp"
+)
+
+var (
+	// changeSetAccountPrefix + address + num (uint64 big endian) + hash -> balance and nonce change of the account in the block
+	changeSetAccountPrefix = []byte("matic-changeset-account-")
+
+	// changeSetStoragePrefix + address + slot + num (uint64 big endian) + hash -> change of the storage slot in the block
+	changeSetStoragePrefix = []byte("matic-changeset-storage-")
+)
+
+// AccountChangeEntry is an indexed change of an account, along with the block
+// it happened in.
+type AccountChangeEntry struct {
+	Number      uint64
+	Hash        common.Hash
+	PrevBalance *big.Int
+	Balance     *big.Int
+	PrevNonce   uint64
+	Nonce       uint64
+}
+
+// StorageChangeEntry is an indexed change of a storage slot, along with the
+// block it happened in.
+type StorageChangeEntry struct {
+	Number uint64
+	Hash   common.Hash
+	Prev   common.Hash
+	Value  common.Hash
+}
+
+// storedAccountChange is the stored form of an account change.
+type storedAccountChange struct {
+	PrevBalance *big.Int
+	Balance     *big.Int
+	PrevNonce   uint64
+	Nonce       uint64
+}
+
+// storedStorageChange is the stored form of a storage slot change.
+type storedStorageChange struct {
+	Prev  common.Hash
+	Value common.Hash
+}
+
+// changeSetAccountKey = changeSetAccountPrefix + address + num (uint64 big endian) + hash
+func changeSetAccountKey(addr common.Address, number uint64, hash common.Hash) []byte {
+	return append(append(append(append([]byte{}, changeSetAccountPrefix...), addr.Bytes()...), encodeBlockNumber(number)...), hash.Bytes()...)
+}
+
+// changeSetStorageKey = changeSetStoragePrefix + address + slot + num (uint64 big endian) + hash
+func changeSetStorageKey(addr common.Address, slot common.Hash, number uint64, hash common.Hash) []byte {
+	return append(append(append(append(append([]byte{}, changeSetStoragePrefix...), addr.Bytes()...), slot.Bytes()...), encodeBlockNumber(number)...), hash.Bytes()...)
+}
+
+// WriteChangeSet stores the account and storage changes of a block. The changes
+// are keyed by block hash too, so the ones of side-chain blocks at the same
+// height don't overwrite the canonical ones.
+func WriteChangeSet(db ethdb.KeyValueWriter, hash common.Hash, number uint64, set *types.ChangeSet) {
+	for _, change := range set.Accounts {
+		data, err := rlp.EncodeToBytes(&storedAccountChange{
+			PrevBalance: change.PrevBalance,
+			Balance:     change.Balance,
+			PrevNonce:   change.PrevNonce,
+			Nonce:       change.Nonce,
+		})
+		if err != nil {
+			log.Crit("Failed to encode account change", "err", err)
+		}
+
+		if err := db.Put(changeSetAccountKey(change.Address, number, hash), data); err != nil {
+			log.Crit("Failed to store account change", "err", err)
+		}
+	}
+
+	for _, change := range set.Storage {
+		data, err := rlp.EncodeToBytes(&storedStorageChange{Prev: change.Prev, Value: change.Value})
+		if err != nil {
+			log.Crit("Failed to encode storage change", "err", err)
+		}
+
+		if err := db.Put(changeSetStorageKey(change.Address, change.Slot, number, hash), data); err != nil {
+			log.Crit("Failed to store storage change", "err", err)
+		}
+	}
+}
+
+// iterateChangeSet calls fn with the number and value of the canonical entries
+// under the given prefix in [from, to], in block order, until fn returns false.
+// The entries of the reorged blocks are skipped.
+func iterateChangeSet(db ethdb.Database, prefix []byte, from, to uint64, fn func(number uint64, hash common.Hash, data []byte) bool) {
+	it := db.NewIterator(prefix, encodeBlockNumber(from))
+	defer it.Release()
+
+	for it.Next() {
+		key := it.Key()
+		if len(key) != len(prefix)+8+common.HashLength {
+			continue
+		}
+
+		number := binary.BigEndian.Uint64(key[len(prefix):])
+		if number > to {
+			return
+		}
+
+		hash := common.BytesToHash(key[len(prefix)+8:])
+		if ReadCanonicalHash(db, number) != hash {
+			continue
+		}
+
+		if !fn(number, hash, it.Value()) {
+			return
+		}
+	}
+}
+
+// ReadAccountChanges retrieves up to limit changes of the given account in the
+// canonical blocks within [from, to], in block order. The changes of the reorged
+// blocks are skipped.
+func ReadAccountChanges(db ethdb.Database, addr common.Address, from, to uint64, limit int) []*AccountChangeEntry {
+	var (
+		prefix  = append(append([]byte{}, changeSetAccountPrefix...), addr.Bytes()...)
+		changes []*AccountChangeEntry
+	)
+
+	iterateChangeSet(db, prefix, from, to, func(number uint64, hash common.Hash, data []byte) bool {
+		var stored storedAccountChange
+		if err := rlp.DecodeBytes(data, &stored); err != nil {
+			log.Error("Invalid account change RLP", "address", addr, "number", number, "err", err)
+			return true
+		}
+
+		changes = append(changes, &AccountChangeEntry{
+			Number:      number,
+			Hash:        hash,
+			PrevBalance: stored.PrevBalance,
+			Balance:     stored.Balance,
+			PrevNonce:   stored.PrevNonce,
+			Nonce:       stored.Nonce,
+		})
+
+		return len(changes) < limit
+	})
+
+	return changes
+}
+
+// ReadStorageChanges retrieves up to limit changes of the given storage slot in
+// the canonical blocks within [from, to], in block order. The changes of the
+// reorged blocks are skipped.
+func ReadStorageChanges(db ethdb.Database, addr common.Address, slot common.Hash, from, to uint64, limit int) []*StorageChangeEntry {
+	var (
+		prefix  = append(append(append([]byte{}, changeSetStoragePrefix...), addr.Bytes()...), slot.Bytes()...)
+		changes []*StorageChangeEntry
+	)
+
+	iterateChangeSet(db, prefix, from, to, func(number uint64, hash common.Hash, data []byte) bool {
+		var stored storedStorageChange
+		if err := rlp.DecodeBytes(data, &stored); err != nil {
+			log.Error("Invalid storage change RLP", "address", addr, "slot", slot, "number", number, "err", err)
+			return true
+		}
+
+		changes = append(changes, &StorageChangeEntry{
+			Number: number,
+			Hash:   hash,
+			Prev:   stored.Prev,
+			Value:  stored.Value,
+		})
+
+		return len(changes) < limit
+	})
+
+	return changes
+}
@@ -0,0 +1,86 @@
+package rawdb
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+func TestChangeSetStorage(t *testing.T) {
+	t.Parallel()
+
+	var (
+		db   = NewMemoryDatabase()
+		addr = common.HexToAddress("0x0a")
+		slot = common.HexToHash("0x01")
+	)
+
+	for number := uint64(1); number <= 5; number++ {
+		hash := common.BigToHash(new(big.Int).SetUint64(number))
+		WriteCanonicalHash(db, hash, number)
+		WriteChangeSet(db, hash, number, &types.ChangeSet{
+			Accounts: []*types.AccountChange{{
+				Address:     addr,
+				PrevBalance: new(big.Int).SetUint64(number - 1),
+				Balance:     new(big.Int).SetUint64(number),
+				PrevNonce:   number - 1,
+				Nonce:       number,
+			}},
+			Storage: []*types.StorageChange{{
+				Address: addr,
+				Slot:    slot,
+				Prev:    common.BigToHash(new(big.Int).SetUint64(number - 1)),
+				Value:   common.BigToHash(new(big.Int).SetUint64(number)),
+			}},
+		})
+	}
+
+	// Changes of a side block at the same height neither overwrite the canonical
+	// ones nor get reported
+	WriteChangeSet(db, common.HexToHash("0xdead"), 3, &types.ChangeSet{
+		Accounts: []*types.AccountChange{{Address: addr, PrevBalance: big.NewInt(2), Balance: big.NewInt(100)}},
+	})
+
+	accounts := ReadAccountChanges(db, addr, 2, 4, 10)
+	if len(accounts) != 3 {
+		t.Fatalf("account change count mismatch: have %d, want 3", len(accounts))
+	}
+
+	for i, change := range accounts {
+		if number := uint64(2 + i); change.Number != number || change.Balance.Uint64() != number || change.Nonce != number {
+			t.Errorf("account change %d mismatch: %+v", i, change)
+		}
+	}
+
+	// Once the side block becomes canonical, its changes are reported instead
+	WriteCanonicalHash(db, common.HexToHash("0xdead"), 3)
+
+	if changes := ReadAccountChanges(db, addr, 3, 3, 10); len(changes) != 1 || changes[0].Balance.Uint64() != 100 {
+		t.Fatalf("reorged account changes mismatch: %+v", changes)
+	}
+
+	WriteCanonicalHash(db, common.BigToHash(big.NewInt(3)), 3)
+
+	storage := ReadStorageChanges(db, addr, slot, 1, 5, 10)
+	if len(storage) != 5 {
+		t.Fatalf("storage change count mismatch: have %d, want 5", len(storage))
+	}
+
+	for i, change := range storage {
+		if number := uint64(i + 1); change.Number != number || change.Value != common.BigToHash(new(big.Int).SetUint64(number)) {
+			t.Errorf("storage change %d mismatch: %+v", i, change)
+		}
+	}
+
+	// The result is capped by the limit
+	if changes := ReadStorageChanges(db, addr, slot, 1, 5, 2); len(changes) != 2 || changes[1].Number != 2 {
+		t.Fatalf("limited storage changes mismatch: %+v", changes)
+	}
+
+	// Other slots are not reported
+	if changes := ReadStorageChanges(db, addr, common.HexToHash("0x02"), 0, 10, 10); len(changes) != 0 {
+		t.Fatalf("unexpected storage changes: %+v", changes)
+	}
+}
@@ -0,0 +1,467 @@
+package rawdb
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/ethdb"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/params"
+	"github.com/ethereum/go-ethereum/rlp"
+)
+
+// Kinds of inconsistencies reported by VerifyBorData.
+const (
+	BorIssueGap      = "gap"      // Data that should exist is missing
+	BorIssueOrphan   = "orphan"   // Data that is not referenced by the canonical chain
+	BorIssueMismatch = "mismatch" // Data that disagrees with the canonical chain
+	BorIssueCorrupt  = "corrupt"  // Data that cannot be decoded
+	BorIssueBoundary = "boundary" // Data on the wrong side of the freezer boundary
+)
+
+// borReceiptPrefix + num (uint64 big endian) + hash -> bor block receipt, cut
+// from the bor receipt key so the two can't drift apart
+var borReceiptPrefix = func() []byte {
+	key := borReceiptKey(0, common.Hash{})
+	return append([]byte{}, key[:len(key)-8-common.HashLength]...)
+}()
+
+// BorIntegrityIssue is an inconsistency found in the chain database.
+type BorIntegrityIssue struct {
+	Kind   string      // Kind of inconsistency, one of the BorIssue constants
+	Item   string      // Database item affected by the inconsistency
+	Number uint64      // Block number the item belongs to
+	Hash   common.Hash // Block or transaction hash the item belongs to
+	Detail string      // Human readable description
+
+	repair func(db ethdb.KeyValueWriter) // Fix for the issue, nil if not repairable
+}
+
+// Repairable returns whether RepairBorData can fix the issue.
+func (i *BorIntegrityIssue) Repairable() bool {
+	return i.repair != nil
+}
+
+// String implements fmt.Stringer.
+func (i *BorIntegrityIssue) String() string {
+	return fmt.Sprintf("%s %s #%d [%x]: %s", i.Kind, i.Item, i.Number, i.Hash, i.Detail)
+}
+
+// borIntegrityChecker collects the issues found while walking the database.
+type borIntegrityChecker struct {
+	db     ethdb.Database
+	config *params.ChainConfig
+	issues []*BorIntegrityIssue
+
+	frozen    uint64  // Number of blocks in the freezer
+	borTail   uint64  // Lowest block whose bor receipt is retained
+	head      uint64  // Number of the head header
+	bodyHead  uint64  // Number of the highest block expected to have a body and receipts
+	indexTail *uint64 // Lowest block with indexed transactions, nil if indexing is disabled
+}
+
+func (c *borIntegrityChecker) report(kind string, item string, number uint64, hash common.Hash, repair func(ethdb.KeyValueWriter), format string, args ...interface{}) {
+	c.issues = append(c.issues, &BorIntegrityIssue{
+		Kind:   kind,
+		Item:   item,
+		Number: number,
+		Hash:   hash,
+		Detail: fmt.Sprintf(format, args...),
+		repair: repair,
+	})
+}
+
+// VerifyBorData cross-checks the canonical chain in the range [from, to]
+// against the receipts, transaction indices, bor receipts and bor tx lookup
+// entries, both in the key-value store and in the freezer. It also checks
+// the whitelisted milestone, checkpoint and lock field. The range is capped
+// at the head header.
+func VerifyBorData(db ethdb.Database, config *params.ChainConfig, from uint64, to uint64) ([]*BorIntegrityIssue, error) {
+	head := ReadHeadHeader(db)
+	if head == nil {
+		return nil, errors.New("head header not found")
+	}
+
+	c := &borIntegrityChecker{
+		db:        db,
+		config:    config,
+		head:      head.Number.Uint64(),
+		indexTail: ReadTxIndexTail(db),
+	}
+
+	if block := ReadHeadBlock(db); block != nil {
+		c.bodyHead = block.NumberU64()
+	}
+
+	if hash := ReadHeadFastBlockHash(db); hash != (common.Hash{}) {
+		if number := ReadHeaderNumber(db, hash); number != nil && *number > c.bodyHead {
+			c.bodyHead = *number
+		}
+	}
+
+	// Databases without a freezer report no frozen blocks
+	c.frozen, _ = db.Ancients()
+
+	if tail := ReadBorReceiptTail(db); tail != nil {
+		c.borTail = *tail
+	}
+
+	if to > c.head {
+		to = c.head
+	}
+
+	var (
+		start  = time.Now()
+		logged = time.Now()
+	)
+
+	for number := from; number <= to; number++ {
+		c.verifyBlock(number)
+
+		if time.Since(logged) > 8*time.Second {
+			log.Info("Verifying chain data", "number", number, "head", to, "issues", len(c.issues), "elapsed", common.PrettyDuration(time.Since(start)))
+			logged = time.Now()
+		}
+	}
+
+	c.verifyBorReceipts(from, to)
+	c.verifyBorTxLookups(from, to)
+	c.verifyWhitelist()
+
+	log.Info("Verified chain data", "from", from, "to", to, "issues", len(c.issues), "elapsed", common.PrettyDuration(time.Since(start)))
+
+	return c.issues, nil
+}
+
+// RepairBorData fixes the repairable issues returned by VerifyBorData by
+// rebuilding derived data and removing orphaned entries. It returns the
+// number of repaired issues.
+func RepairBorData(db ethdb.Database, issues []*BorIntegrityIssue) (int, error) {
+	var (
+		batch    = db.NewBatch()
+		repaired int
+		stale    *uint64
+	)
+
+	for _, issue := range issues {
+		if issue.repair == nil {
+			continue
+		}
+
+		issue.repair(batch)
+		repaired++
+
+		if issue.Item == "bor-receipt" && (stale == nil || issue.Number < *stale) {
+			number := issue.Number
+			stale = &number
+		}
+
+		if batch.ValueSize() > ethdb.IdealBatchSize {
+			if err := batch.Write(); err != nil {
+				return repaired, err
+			}
+
+			batch.Reset()
+		}
+	}
+
+	if err := batch.Write(); err != nil {
+		return repaired, err
+	}
+
+	// Have the bor bloom bits rebuilt over the touched bor receipts
+	if stale != nil {
+		MarkBorBloomStale(db, *stale)
+	}
+
+	return repaired, nil
+}
+
+// verifyBlock checks the canonical block at the given number.
+func (c *borIntegrityChecker) verifyBlock(number uint64) {
+	hash := ReadCanonicalHash(c.db, number)
+	if hash == (common.Hash{}) {
+		c.report(BorIssueGap, "canonical-hash", number, hash, nil, "missing canonical hash")
+		return
+	}
+
+	frozen := number < c.frozen
+	if frozen && number > 0 {
+		c.verifyFreezerBoundary(number, hash)
+	}
+
+	data := ReadHeaderRLP(c.db, hash, number)
+	if len(data) == 0 {
+		c.report(BorIssueGap, "header", number, hash, nil, "missing header")
+		return
+	}
+
+	if crypto.Keccak256Hash(data) != hash {
+		c.report(BorIssueCorrupt, "header", number, hash, nil, "header does not match its hash")
+		return
+	}
+
+	if n := ReadHeaderNumber(c.db, hash); n == nil || *n != number {
+		c.report(BorIssueGap, "header-number", number, hash, func(db ethdb.KeyValueWriter) {
+			WriteHeaderNumber(db, hash, number)
+		}, "missing hash to number mapping")
+	}
+
+	if number > c.bodyHead {
+		return
+	}
+
+	if ReadTd(c.db, hash, number) == nil {
+		c.report(BorIssueGap, "td", number, hash, nil, "missing total difficulty")
+	}
+
+	body := ReadBody(c.db, hash, number)
+	if body == nil {
+		c.report(BorIssueGap, "body", number, hash, nil, "missing body")
+		return
+	}
+
+	if !HasReceipts(c.db, hash, number) {
+		c.report(BorIssueGap, "receipts", number, hash, nil, "missing receipts")
+	} else if receipts := ReadRawReceipts(c.db, hash, number); receipts == nil {
+		c.report(BorIssueCorrupt, "receipts", number, hash, nil, "undecodable receipts")
+	} else if len(receipts) != len(body.Transactions) {
+		c.report(BorIssueMismatch, "receipts", number, hash, nil, "%d receipts for %d transactions", len(receipts), len(body.Transactions))
+	}
+
+	if c.indexed(number) {
+		for _, tx := range body.Transactions {
+			txHash := tx.Hash()
+			repair := func(db ethdb.KeyValueWriter) {
+				WriteTxLookupEntries(db, number, []common.Hash{txHash})
+			}
+
+			if entry := ReadTxLookupEntry(c.db, txHash); entry == nil {
+				c.report(BorIssueGap, "tx-lookup", number, txHash, repair, "missing transaction index")
+			} else if *entry != number {
+				c.report(BorIssueMismatch, "tx-lookup", number, txHash, repair, "transaction indexed at block %d", *entry)
+			}
+		}
+	}
+
+	c.verifyBorReceipt(number, hash, frozen)
+}
+
+// verifyFreezerBoundary checks that a frozen block has no leftovers in the
+// key-value store and is complete in the freezer.
+func (c *borIntegrityChecker) verifyFreezerBoundary(number uint64, hash common.Hash) {
+	if has, _ := c.db.Has(headerHashKey(number)); has {
+		c.report(BorIssueBoundary, "canonical-hash", number, hash, func(db ethdb.KeyValueWriter) {
+			DeleteCanonicalHash(db, number)
+		}, "frozen block has a canonical hash in the key-value store")
+	}
+
+	frozenBorReceipt := c.frozenBorReceipt(number)
+
+	if has, _ := c.db.Has(headerKey(number, hash)); has {
+		c.report(BorIssueBoundary, "header", number, hash, func(db ethdb.KeyValueWriter) {
+			DeleteReceipts(db, hash, number)
+			deleteHeaderWithoutNumber(db, hash, number)
+			DeleteBody(db, hash, number)
+			DeleteTd(db, hash, number)
+
+			if frozenBorReceipt {
+				DeleteBorReceipt(db, hash, number)
+			}
+		}, "frozen block has data in the key-value store")
+	} else if has, _ := c.db.Has(borReceiptKey(number, hash)); has && frozenBorReceipt {
+		c.report(BorIssueBoundary, "bor-receipt", number, hash, func(db ethdb.KeyValueWriter) {
+			DeleteBorReceipt(db, hash, number)
+		}, "frozen bor receipt duplicated in the key-value store")
+	}
+
+	if has, _ := c.db.HasAncient(freezerBorReceiptTable, number); !has && number >= c.borTail {
+		c.report(BorIssueBoundary, "bor-receipt", number, hash, nil, "frozen block missing from the bor receipt table")
+	}
+}
+
+// frozenBorReceipt returns whether the freezer holds a bor receipt for the
+// block. Receipts reindexed after freezing are kept in the key-value store.
+func (c *borIntegrityChecker) frozenBorReceipt(number uint64) bool {
+	data, _ := c.db.Ancient(freezerBorReceiptTable, number)
+	return len(data) > 0
+}
+
+// verifyBorReceipt checks the bor receipt of a canonical block and its lookup
+// entry.
+func (c *borIntegrityChecker) verifyBorReceipt(number uint64, hash common.Hash, frozen bool) {
+	data := ReadBorReceiptRLP(c.db, hash, number)
+	if len(data) == 0 {
+		return
+	}
+
+	// Frozen receipts cannot be removed from the freezer
+	remove := func(db ethdb.KeyValueWriter) {
+		DeleteBorReceipt(db, hash, number)
+		DeleteBorTxLookupEntry(db, hash, number)
+	}
+	if frozen {
+		remove = nil
+	}
+
+	if err := rlp.DecodeBytes(data, new(types.ReceiptForStorage)); err != nil {
+		c.report(BorIssueCorrupt, "bor-receipt", number, hash, remove, "%v", err)
+		return
+	}
+
+	if c.config != nil && c.config.Bor != nil && !c.config.Bor.IsSprintStart(number) {
+		c.report(BorIssueOrphan, "bor-receipt", number, hash, remove, "bor receipt outside of a sprint start")
+		return
+	}
+
+	if !c.indexed(number) {
+		return
+	}
+
+	txHash := types.GetDerivedBorTxHash(borReceiptKey(number, hash))
+	repair := func(db ethdb.KeyValueWriter) {
+		WriteBorTxLookupEntry(db, hash, number)
+	}
+
+	if entry := ReadBorTxLookupEntry(c.db, txHash); entry == nil {
+		c.report(BorIssueGap, "bor-tx-lookup", number, txHash, repair, "missing bor transaction index")
+	} else if *entry != number {
+		c.report(BorIssueMismatch, "bor-tx-lookup", number, txHash, repair, "bor transaction indexed at block %d", *entry)
+	}
+}
+
+// verifyBorReceipts reports bor receipts in the key-value store that do not
+// belong to a canonical block.
+func (c *borIntegrityChecker) verifyBorReceipts(from uint64, to uint64) {
+	it := c.db.NewIterator(borReceiptPrefix, encodeBlockNumber(from))
+	defer it.Release()
+
+	for it.Next() {
+		key := it.Key()
+		if len(key) != len(borReceiptPrefix)+8+common.HashLength {
+			continue
+		}
+
+		number := binary.BigEndian.Uint64(key[len(borReceiptPrefix):])
+		if number > to {
+			break
+		}
+
+		hash := common.BytesToHash(key[len(borReceiptPrefix)+8:])
+		if ReadCanonicalHash(c.db, number) == hash {
+			continue
+		}
+
+		c.report(BorIssueOrphan, "bor-receipt", number, hash, func(db ethdb.KeyValueWriter) {
+			DeleteBorReceipt(db, hash, number)
+		}, "bor receipt of a non-canonical block")
+	}
+}
+
+// verifyBorTxLookups reports bor tx lookup entries pointing into the range
+// that do not resolve to a canonical bor receipt. These make state-sync
+// transactions and receipts resolve inconsistently over RPC.
+func (c *borIntegrityChecker) verifyBorTxLookups(from uint64, to uint64) {
+	it := c.db.NewIterator(borTxLookupPrefix, nil)
+	defer it.Release()
+
+	for it.Next() {
+		key := it.Key()
+		if len(key) != len(borTxLookupPrefix)+common.HashLength {
+			continue
+		}
+
+		var (
+			txHash = common.BytesToHash(key[len(borTxLookupPrefix):])
+			repair = func(db ethdb.KeyValueWriter) {
+				DeleteBorTxLookupEntryByTxHash(db, txHash)
+			}
+		)
+
+		entry := ReadBorTxLookupEntry(c.db, txHash)
+		if entry == nil {
+			c.report(BorIssueCorrupt, "bor-tx-lookup", 0, txHash, repair, "empty bor transaction index")
+			continue
+		}
+
+		number := *entry
+		if number < from || number > to {
+			continue
+		}
+
+		hash := ReadCanonicalHash(c.db, number)
+		if hash == (common.Hash{}) || types.GetDerivedBorTxHash(borReceiptKey(number, hash)) != txHash {
+			c.report(BorIssueOrphan, "bor-tx-lookup", number, txHash, repair, "bor transaction index of a non-canonical block")
+			continue
+		}
+
+		if len(ReadBorReceiptRLP(c.db, hash, number)) == 0 {
+			c.report(BorIssueOrphan, "bor-tx-lookup", number, txHash, repair, "bor transaction index without bor receipt")
+		}
+	}
+}
+
+// verifyWhitelist checks the persisted milestone, checkpoint and lock field
+// against the canonical chain.
+func (c *borIntegrityChecker) verifyWhitelist() {
+	c.verifyFinality(lastMilestone, func() (uint64, common.Hash, error) { return ReadFinality[*Milestone](c.db) })
+	c.verifyFinality(lastCheckpoint, func() (uint64, common.Hash, error) { return ReadFinality[*Checkpoint](c.db) })
+
+	if has, _ := c.db.Has(lockFieldKey); !has {
+		return
+	}
+
+	remove := func(db ethdb.KeyValueWriter) {
+		_ = db.Delete(lockFieldKey)
+	}
+
+	val, number, hash, _, err := ReadLockField(c.db)
+	if err != nil {
+		c.report(BorIssueCorrupt, string(lockFieldKey), number, hash, remove, "%v", err)
+		return
+	}
+
+	if val && !c.canonical(number, hash) {
+		c.report(BorIssueMismatch, string(lockFieldKey), number, hash, remove, "locked block is not canonical")
+	}
+}
+
+func (c *borIntegrityChecker) verifyFinality(key []byte, read func() (uint64, common.Hash, error)) {
+	if has, _ := c.db.Has(key); !has {
+		return
+	}
+
+	remove := func(db ethdb.KeyValueWriter) {
+		_ = db.Delete(key)
+	}
+
+	number, hash, err := read()
+	if err != nil {
+		c.report(BorIssueCorrupt, string(key), number, hash, remove, "%v", err)
+		return
+	}
+
+	if !c.canonical(number, hash) {
+		c.report(BorIssueMismatch, string(key), number, hash, remove, "whitelisted block is not canonical")
+	}
+}
+
+// canonical returns whether the block is canonical, blocks past the head
+// header are assumed to be.
+func (c *borIntegrityChecker) canonical(number uint64, hash common.Hash) bool {
+	if number > c.head {
+		return true
+	}
+
+	return ReadCanonicalHash(c.db, number) == hash
+}
+
+// indexed returns whether transactions of the given block should be indexed.
+func (c *borIntegrityChecker) indexed(number uint64) bool {
+	return c.indexTail != nil && number >= *c.indexTail
+}
@@ -0,0 +1,146 @@
+package rawdb
+
+import (
+	"fmt"
+	"math/big"
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/ethdb"
+	"github.com/ethereum/go-ethereum/params"
+)
+
+// newBorIntegrityTestDB writes a canonical chain of the given length with a
+// transaction per non-genesis block and a bor receipt on every sprint start.
+func newBorIntegrityTestDB(t *testing.T, n int, config *params.ChainConfig) (ethdb.Database, []*types.Block) {
+	t.Helper()
+
+	db := NewMemoryDatabase()
+	blocks := make([]*types.Block, n)
+
+	var parent common.Hash
+
+	for i := 0; i < n; i++ {
+		header := &types.Header{Number: big.NewInt(int64(i)), ParentHash: parent, Extra: []byte("test block")}
+		txs := []*types.Transaction{types.NewTransaction(uint64(i), common.Address{0x01}, big.NewInt(1), params.TxGas, big.NewInt(1), nil)}
+		receipts := types.Receipts{{Status: types.ReceiptStatusSuccessful, Logs: []*types.Log{}}}
+
+		// Genesis carries no transactions
+		if i == 0 {
+			txs, receipts = nil, nil
+		}
+
+		block := types.NewBlockWithHeader(header).WithBody(txs, nil)
+		blocks[i] = block
+		parent = block.Hash()
+
+		WriteBlock(db, block)
+		WriteTd(db, block.Hash(), block.NumberU64(), big.NewInt(int64(i+1)))
+		WriteReceipts(db, block.Hash(), block.NumberU64(), receipts)
+		WriteCanonicalHash(db, block.Hash(), block.NumberU64())
+		WriteTxLookupEntriesByBlock(db, block)
+
+		if i > 0 && config.Bor.IsSprintStart(uint64(i)) {
+			WriteBorReceipt(db, block.Hash(), block.NumberU64(), &types.ReceiptForStorage{Status: types.ReceiptStatusSuccessful, Logs: []*types.Log{}})
+			WriteBorTxLookupEntry(db, block.Hash(), block.NumberU64())
+		}
+	}
+
+	head := blocks[n-1]
+	WriteHeadHeaderHash(db, head.Hash())
+	WriteHeadBlockHash(db, head.Hash())
+	WriteHeadFastBlockHash(db, head.Hash())
+	WriteTxIndexTail(db, 0)
+
+	return db, blocks
+}
+
+func TestVerifyBorData(t *testing.T) {
+	t.Parallel()
+
+	config := &params.ChainConfig{Bor: &params.BorConfig{Sprint: map[string]uint64{"0": 4}}}
+	db, blocks := newBorIntegrityTestDB(t, 9, config)
+
+	issues, err := VerifyBorData(db, config, 0, 100)
+	if err != nil {
+		t.Fatalf("failed to verify: %v", err)
+	}
+
+	if len(issues) != 0 {
+		t.Fatalf("unexpected issues on a consistent database: %v", issues)
+	}
+
+	// Break the database in every way the checker knows about
+	DeleteTxLookupEntry(db, blocks[2].Transactions()[0].Hash())
+	DeleteBorTxLookupEntry(db, blocks[8].Hash(), 8)
+	WriteBorReceipt(db, blocks[5].Hash(), 5, &types.ReceiptForStorage{Status: types.ReceiptStatusSuccessful, Logs: []*types.Log{}})
+	WriteBorReceipt(db, common.Hash{0xff}, 4, &types.ReceiptForStorage{Status: types.ReceiptStatusSuccessful, Logs: []*types.Log{}})
+	WriteBorTxLookupEntry(db, common.Hash{0xfe}, 6)
+	DeleteBody(db, blocks[7].Hash(), 7)
+
+	if err := WriteLastFinality[*Milestone](db, 3, common.Hash{0x01}); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := db.Put(lockFieldKey, []byte("not json")); err != nil {
+		t.Fatal(err)
+	}
+
+	issues, err = VerifyBorData(db, config, 0, 100)
+	if err != nil {
+		t.Fatalf("failed to verify: %v", err)
+	}
+
+	want := map[string]string{
+		"tx-lookup":          BorIssueGap,
+		"body":               BorIssueGap,
+		"LastMilestone":      BorIssueMismatch,
+		string(lockFieldKey): BorIssueCorrupt,
+		"bor-receipt#5":      BorIssueOrphan,
+		"bor-receipt#4":      BorIssueOrphan,
+		"bor-tx-lookup#6":    BorIssueOrphan,
+		"bor-tx-lookup#8":    BorIssueGap,
+	}
+
+	have := make(map[string]string)
+
+	for _, issue := range issues {
+		key := issue.Item
+		if strings.HasPrefix(key, "bor-") {
+			key = fmt.Sprintf("%s#%d", issue.Item, issue.Number)
+		}
+
+		have[key] = issue.Kind
+	}
+
+	if len(have) != len(want) {
+		t.Fatalf("issue count mismatch: have %v, want %v", have, want)
+	}
+
+	for key, kind := range want {
+		if have[key] != kind {
+			t.Errorf("issue %s: have %q, want %q", key, have[key], kind)
+		}
+	}
+
+	repaired, err := RepairBorData(db, issues)
+	if err != nil {
+		t.Fatalf("failed to repair: %v", err)
+	}
+
+	if repaired != len(issues)-1 {
+		t.Fatalf("repaired %d issues, want %d", repaired, len(issues)-1)
+	}
+
+	// Only the missing body cannot be rebuilt from the database itself
+	issues, err = VerifyBorData(db, config, 0, 100)
+	if err != nil {
+		t.Fatalf("failed to verify: %v", err)
+	}
+
+	if len(issues) != 1 || issues[0].Item != "body" || issues[0].Repairable() {
+		t.Fatalf("unexpected issues after repair: %v", issues)
+	}
+}
@@ -1,7 +1,9 @@
 package rawdb
 
 import (
+	"encoding/binary"
 	"math/big"
+	"sync"
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/core/types"
@@ -17,8 +19,30 @@ var (
 
 	// borTxLookupPrefix + hash -> transaction/receipt lookup metadata
 	borTxLookupPrefix = []byte(borTxLookupPrefixStr)
+
+	// borReceiptUnverifiedPrefix + num (uint64 big endian) + hash -> marker of a
+	// bor receipt retrieved from the network, not derived locally
+	borReceiptUnverifiedPrefix = []byte("matic-bor-unverified-receipt-")
+
+	// borReindexProgressKey tracks the progress of a bor receipt reindex
+	borReindexProgressKey = []byte("BorReindexProgress")
+
+	// borBloomBitsPrefix + bloomBitsKey -> bloom bits of the bor receipts
+	borBloomBitsPrefix = []byte("matic-bor-bloom-bits-")
+
+	// BorBloomBitsIndexPrefix is the data table of the chain indexer building the
+	// bor receipt bloom bits to track its progress
+	BorBloomBitsIndexPrefix = []byte("matic-bor-bloom-index-")
+
+	// borBloomStaleKey tracks the first block whose bor receipt changed after
+	// its bloom bits section may have been indexed
+	borBloomStaleKey = []byte("BorBloomStale")
 )
 
+// borBloomStaleLock serializes the updates of the stale bor bloom marker, which
+// is lowered by concurrent writers.
+var borBloomStaleLock sync.Mutex
+
 const (
 	borTxLookupPrefixStr = "matic-bor-tx-lookup-"
 
@@ -31,20 +55,31 @@ func borTxLookupKey(hash common.Hash) []byte {
 	return append(borTxLookupPrefix, hash.Bytes()...)
 }
 
+// borReceiptUnverifiedKey = borReceiptUnverifiedPrefix + num (uint64 big endian) + hash
+func borReceiptUnverifiedKey(number uint64, hash common.Hash) []byte {
+	return append(append(append([]byte{}, borReceiptUnverifiedPrefix...), encodeBlockNumber(number)...), hash.Bytes()...)
+}
+
+// borBloomBitsKey = borBloomBitsPrefix + bloomBitsKey
+func borBloomBitsKey(bit uint, section uint64, hash common.Hash) []byte {
+	return append(append([]byte{}, borBloomBitsPrefix...), bloomBitsKey(bit, section, hash)...)
+}
+
 func ReadBorReceiptRLP(db ethdb.Reader, hash common.Hash, number uint64) rlp.RawValue {
+	// Receipts reindexed after freezing are kept in leveldb, taking precedence
+	// over the frozen ones
+	if data, _ := db.Get(borReceiptKey(number, hash)); len(data) > 0 {
+		return data
+	}
+
 	var data []byte
 
 	err := db.ReadAncients(func(reader ethdb.AncientReaderOp) error {
 		// Check if the data is in ancients
 		if isCanon(reader, number, hash) {
 			data, _ = reader.Ancient(freezerBorReceiptTable, number)
-
-			return nil
 		}
 
-		// If not, try reading from leveldb
-		data, _ = db.Get(borReceiptKey(number, hash))
-
 		return nil
 	})
 
@@ -77,12 +112,17 @@ func ReadRawBorReceipt(db ethdb.Reader, hash common.Hash, number uint64) *types.
 
 // ReadBorReceipt retrieves all the bor block receipts belonging to a block, including
 // its correspoinding metadata fields. If it is unable to populate these metadata
-// fields then nil is returned.
+// fields then nil is returned. Bor receipts retrieved from the network are not
+// returned until derived locally.
 func ReadBorReceipt(db ethdb.Reader, hash common.Hash, number uint64, config *params.ChainConfig) *types.Receipt {
 	if config != nil && config.Bor != nil && config.Bor.Sprint != nil && !config.Bor.IsSprintStart(number) {
 		return nil
 	}
 
+	if IsBorReceiptUnverified(db, hash, number) {
+		return nil
+	}
+
 	// We're deriving many fields from the block body, retrieve beside the receipt
 	borReceipt := ReadRawBorReceipt(db, hash, number)
 	if borReceipt == nil {
@@ -130,13 +170,40 @@ func DeleteBorReceipt(db ethdb.KeyValueWriter, hash common.Hash, number uint64)
 	if err := db.Delete(key); err != nil {
 		log.Crit("Failed to delete bor receipt", "err", err)
 	}
+
+	DeleteBorReceiptUnverified(db, hash, number)
+}
+
+// IsBorReceiptUnverified reports whether the bor receipt of a block was
+// retrieved from the network. Bor receipts are not committed to by the headers,
+// so such receipts are neither served to other peers nor over RPC until derived
+// locally.
+func IsBorReceiptUnverified(db ethdb.KeyValueReader, hash common.Hash, number uint64) bool {
+	ok, _ := db.Has(borReceiptUnverifiedKey(number, hash))
+	return ok
+}
+
+// WriteBorReceiptUnverified marks the bor receipt of a block as retrieved from
+// the network.
+func WriteBorReceiptUnverified(db ethdb.KeyValueWriter, hash common.Hash, number uint64) {
+	if err := db.Put(borReceiptUnverifiedKey(number, hash), []byte{0x01}); err != nil {
+		log.Crit("Failed to store unverified bor receipt marker", "err", err)
+	}
+}
+
+// DeleteBorReceiptUnverified removes the unverified marker of the bor receipt
+// of a block.
+func DeleteBorReceiptUnverified(db ethdb.KeyValueWriter, hash common.Hash, number uint64) {
+	if err := db.Delete(borReceiptUnverifiedKey(number, hash)); err != nil {
+		log.Crit("Failed to delete unverified bor receipt marker", "err", err)
+	}
 }
 
 // ReadBorTransactionWithBlockHash retrieves a specific bor (fake) transaction by tx hash and block hash, along with
 // its added positional metadata.
 func ReadBorTransactionWithBlockHash(db ethdb.Reader, txHash common.Hash, blockHash common.Hash) (*types.Transaction, common.Hash, uint64, uint64) {
 	blockNumber := ReadBorTxLookupEntry(db, txHash)
-	if blockNumber == nil {
+	if blockNumber == nil || IsBorReceiptUnverified(db, blockHash, *blockNumber) {
 		return nil, common.Hash{}, 0, 0
 	}
 
@@ -159,7 +226,7 @@ func ReadBorTransaction(db ethdb.Reader, hash common.Hash) (*types.Transaction,
 	}
 
 	blockHash := ReadCanonicalHash(db, *blockNumber)
-	if blockHash == (common.Hash{}) {
+	if blockHash == (common.Hash{}) || IsBorReceiptUnverified(db, blockHash, *blockNumber) {
 		return nil, common.Hash{}, 0, 0
 	}
 
@@ -210,3 +277,95 @@ func DeleteBorTxLookupEntryByTxHash(db ethdb.KeyValueWriter, txHash common.Hash)
 		log.Crit("Failed to delete bor transaction lookup entry", "err", err)
 	}
 }
+
+// BorReindexProgress is the persisted state of a bor receipt reindex over the
+// block range [From, To]. Next is the first block not yet reindexed.
+type BorReindexProgress struct {
+	From uint64
+	To   uint64
+	Next uint64
+}
+
+// ReadBorReindexProgress retrieves the progress of an unfinished bor receipt
+// reindex, or nil if there is none.
+func ReadBorReindexProgress(db ethdb.KeyValueReader) *BorReindexProgress {
+	data, _ := db.Get(borReindexProgressKey)
+	if len(data) == 0 {
+		return nil
+	}
+
+	var progress BorReindexProgress
+	if err := rlp.DecodeBytes(data, &progress); err != nil {
+		log.Error("Invalid bor reindex progress", "err", err)
+		return nil
+	}
+
+	return &progress
+}
+
+// WriteBorReindexProgress stores the progress of a bor receipt reindex.
+func WriteBorReindexProgress(db ethdb.KeyValueWriter, progress *BorReindexProgress) {
+	data, err := rlp.EncodeToBytes(progress)
+	if err != nil {
+		log.Crit("Failed to encode bor reindex progress", "err", err)
+	}
+
+	if err := db.Put(borReindexProgressKey, data); err != nil {
+		log.Crit("Failed to store bor reindex progress", "err", err)
+	}
+}
+
+// DeleteBorReindexProgress removes the progress of a finished bor receipt reindex.
+func DeleteBorReindexProgress(db ethdb.KeyValueWriter) {
+	if err := db.Delete(borReindexProgressKey); err != nil {
+		log.Crit("Failed to delete bor reindex progress", "err", err)
+	}
+}
+
+// ReadBorBloomBits retrieves the compressed bloom bit vector of the bor receipts
+// belonging to the given section and bit index.
+func ReadBorBloomBits(db ethdb.KeyValueReader, bit uint, section uint64, head common.Hash) ([]byte, error) {
+	return db.Get(borBloomBitsKey(bit, section, head))
+}
+
+// WriteBorBloomBits stores the compressed bloom bits vector of the bor receipts
+// belonging to the given section and bit index.
+func WriteBorBloomBits(db ethdb.KeyValueWriter, bit uint, section uint64, head common.Hash, bits []byte) {
+	if err := db.Put(borBloomBitsKey(bit, section, head), bits); err != nil {
+		log.Crit("Failed to store bor bloom bits", "err", err)
+	}
+}
+
+// MarkBorBloomStale records that the bor receipt of the given block was written
+// or deleted outside of the block processing, e.g. by a backfill or a reindex,
+// so that the bor bloom bits sections from the block's one on are rebuilt.
+func MarkBorBloomStale(db ethdb.KeyValueStore, number uint64) {
+	borBloomStaleLock.Lock()
+	defer borBloomStaleLock.Unlock()
+
+	if data, _ := db.Get(borBloomStaleKey); len(data) == 8 && binary.BigEndian.Uint64(data) <= number {
+		return
+	}
+
+	if err := db.Put(borBloomStaleKey, encodeBlockNumber(number)); err != nil {
+		log.Crit("Failed to store the stale bor bloom marker", "err", err)
+	}
+}
+
+// TakeBorBloomStale retrieves and clears the first block whose bor receipt
+// changed since the last call, if any.
+func TakeBorBloomStale(db ethdb.KeyValueStore) (uint64, bool) {
+	borBloomStaleLock.Lock()
+	defer borBloomStaleLock.Unlock()
+
+	data, _ := db.Get(borBloomStaleKey)
+	if len(data) != 8 {
+		return 0, false
+	}
+
+	if err := db.Delete(borBloomStaleKey); err != nil {
+		log.Crit("Failed to delete the stale bor bloom marker", "err", err)
+	}
+
+	return binary.BigEndian.Uint64(data), true
+}
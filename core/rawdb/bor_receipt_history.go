@@ -0,0 +1,112 @@
+package rawdb
+
+import (
+	"encoding/binary"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/ethdb"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/params"
+)
+
+// borReceiptTailKey tracks the oldest block whose bor receipt is retained
+var borReceiptTailKey = []byte("BorReceiptTail")
+
+// ReadBorReceiptTail retrieves the number of the oldest block whose bor
+// receipt is retained, or nil if bor receipts never expired.
+func ReadBorReceiptTail(db ethdb.KeyValueReader) *uint64 {
+	data, _ := db.Get(borReceiptTailKey)
+	if len(data) != 8 {
+		return nil
+	}
+
+	number := binary.BigEndian.Uint64(data)
+
+	return &number
+}
+
+// WriteBorReceiptTail stores the number of the oldest block whose bor receipt
+// is retained.
+func WriteBorReceiptTail(db ethdb.KeyValueWriter, number uint64) {
+	if err := db.Put(borReceiptTailKey, encodeBlockNumber(number)); err != nil {
+		log.Crit("Failed to store the bor receipt tail", "err", err)
+	}
+}
+
+// PruneBorReceipts expires the bor receipts and bor tx lookup entries of the
+// canonical blocks below the given tail, both from the key-value store and the
+// freezer, and moves the bor receipt tail forward. The freezer only releases
+// whole data files, so some expired receipts may linger on disk. Pruning can
+// be interrupted and resumes from the last persisted tail.
+func PruneBorReceipts(db ethdb.Database, config *params.BorConfig, tail uint64, interrupt chan struct{}) error {
+	var from uint64
+	if old := ReadBorReceiptTail(db); old != nil {
+		from = *old
+	}
+
+	if tail <= from {
+		return nil
+	}
+
+	var (
+		batch  = db.NewBatch()
+		start  = time.Now()
+		logged = start
+		blocks = 0
+	)
+
+	for number := from; number < tail; number++ {
+		if number == 0 || !config.IsSprintStart(number) {
+			continue
+		}
+
+		if hash := ReadCanonicalHash(db, number); hash != (common.Hash{}) {
+			DeleteBorReceipt(batch, hash, number)
+			DeleteBorTxLookupEntry(batch, hash, number)
+		}
+
+		blocks++
+
+		// Deletions barely grow the batch size, flush on the block count
+		if blocks%1000 == 0 {
+			WriteBorReceiptTail(batch, number+1)
+
+			if err := batch.Write(); err != nil {
+				return err
+			}
+
+			batch.Reset()
+
+			select {
+			case <-interrupt:
+				log.Debug("Bor receipt expiry interrupted", "tail", number+1)
+				return nil
+			default:
+			}
+		}
+
+		if time.Since(logged) > 8*time.Second {
+			log.Info("Expiring bor receipts", "number", number, "tail", tail, "elapsed", common.PrettyDuration(time.Since(start)))
+			logged = time.Now()
+		}
+	}
+
+	// Drop the expired part of the freezer table before moving the tail, the
+	// truncation is idempotent if interrupted
+	if frozen, err := db.Ancients(); err == nil && frozen > from {
+		if err := db.TruncateTableTail(freezerBorReceiptTable, min(tail, frozen)); err != nil {
+			return err
+		}
+	}
+
+	WriteBorReceiptTail(batch, tail)
+
+	if err := batch.Write(); err != nil {
+		return err
+	}
+
+	log.Debug("Expired bor receipts", "from", from, "tail", tail, "blocks", blocks, "elapsed", common.PrettyDuration(time.Since(start)))
+
+	return nil
+}
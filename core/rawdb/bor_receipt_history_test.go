@@ -0,0 +1,60 @@
+package rawdb
+
+import (
+	"testing"
+
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/params"
+)
+
+func TestPruneBorReceipts(t *testing.T) {
+	t.Parallel()
+
+	config := &params.ChainConfig{Bor: &params.BorConfig{Sprint: map[string]uint64{"0": 4}}}
+	db, blocks := newBorIntegrityTestDB(t, 17, config)
+
+	if tail := ReadBorReceiptTail(db); tail != nil {
+		t.Fatalf("unexpected bor receipt tail %d", *tail)
+	}
+
+	if err := PruneBorReceipts(db, config.Bor, 9, nil); err != nil {
+		t.Fatalf("failed to prune: %v", err)
+	}
+
+	if tail := ReadBorReceiptTail(db); tail == nil || *tail != 9 {
+		t.Fatalf("bor receipt tail mismatch: have %v, want 9", tail)
+	}
+
+	for _, block := range blocks[1:] {
+		number := block.NumberU64()
+		if !config.Bor.IsSprintStart(number) {
+			continue
+		}
+
+		receipt := ReadRawBorReceipt(db, block.Hash(), number)
+		lookup := ReadBorTxLookupEntry(db, types.GetDerivedBorTxHash(borReceiptKey(number, block.Hash())))
+
+		if expired := number < 9; expired != (receipt == nil) || expired != (lookup == nil) {
+			t.Errorf("block %d: have receipt %v lookup %v, want expired %v", number, receipt != nil, lookup != nil, expired)
+		}
+	}
+
+	// Expired bor receipts are not reported as missing
+	issues, err := VerifyBorData(db, config, 0, 100)
+	if err != nil {
+		t.Fatalf("failed to verify: %v", err)
+	}
+
+	if len(issues) != 0 {
+		t.Fatalf("unexpected issues after pruning: %v", issues)
+	}
+
+	// Moving the tail backwards is a no-op
+	if err := PruneBorReceipts(db, config.Bor, 5, nil); err != nil {
+		t.Fatalf("failed to prune: %v", err)
+	}
+
+	if tail := ReadBorReceiptTail(db); *tail != 9 {
+		t.Fatalf("bor receipt tail moved back to %d", *tail)
+	}
+}
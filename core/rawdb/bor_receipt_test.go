@@ -0,0 +1,66 @@
+package rawdb
+
+import (
+	"testing"
+
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/params"
+)
+
+// Tests that bor receipts retrieved from the network are not returned to RPC
+// callers until derived locally.
+func TestUnverifiedBorReceipt(t *testing.T) {
+	t.Parallel()
+
+	config := &params.ChainConfig{Bor: &params.BorConfig{Sprint: map[string]uint64{"0": 4}}}
+	db, blocks := newBorIntegrityTestDB(t, 5, config)
+
+	block := blocks[4]
+	txHash := types.GetDerivedBorTxHash(borReceiptKey(4, block.Hash()))
+
+	if ReadBorReceipt(db, block.Hash(), 4, config) == nil {
+		t.Fatalf("missing verified bor receipt")
+	}
+
+	WriteBorReceiptUnverified(db, block.Hash(), 4)
+
+	if ReadBorReceipt(db, block.Hash(), 4, config) != nil {
+		t.Errorf("unverified bor receipt returned")
+	}
+
+	if tx, _, _, _ := ReadBorTransaction(db, txHash); tx != nil {
+		t.Errorf("unverified bor transaction returned")
+	}
+
+	if tx, _, _, _ := ReadBorTransactionWithBlockHash(db, txHash, block.Hash()); tx != nil {
+		t.Errorf("unverified bor transaction returned by block hash")
+	}
+
+	// The raw receipt is still there to be moved into the freezer
+	if ReadRawBorReceipt(db, block.Hash(), 4) == nil {
+		t.Errorf("raw unverified bor receipt missing")
+	}
+}
+
+// Tests that the stale bor bloom marker keeps the lowest block until taken.
+func TestBorBloomStale(t *testing.T) {
+	t.Parallel()
+
+	db := NewMemoryDatabase()
+
+	if _, ok := TakeBorBloomStale(db); ok {
+		t.Fatalf("stale marker found in an empty database")
+	}
+
+	MarkBorBloomStale(db, 10)
+	MarkBorBloomStale(db, 20)
+	MarkBorBloomStale(db, 5)
+
+	if number, ok := TakeBorBloomStale(db); !ok || number != 5 {
+		t.Fatalf("stale marker mismatch: have %d, %v, want 5, true", number, ok)
+	}
+
+	if _, ok := TakeBorBloomStale(db); ok {
+		t.Fatalf("stale marker not cleared")
+	}
+}
@@ -0,0 +1,105 @@
+package rawdb
+
+import (
+	"encoding/binary"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/ethdb"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/rlp"
+)
+
+var (
+	// stateSyncsPrefix + num (uint64 big endian) + hash -> state-syncs committed by the block
+	stateSyncsPrefix = []byte("matic-state-syncs-")
+
+	// stateSyncLookupPrefix + id (uint64 big endian) -> number of the block committing the state-sync
+	stateSyncLookupPrefix = []byte("matic-state-sync-id-")
+
+	// lastStateSyncIDKey tracks the highest indexed state-sync ID
+	lastStateSyncIDKey = []byte("LastStateSyncID")
+)
+
+// stateSyncsKey = stateSyncsPrefix + num (uint64 big endian) + hash
+func stateSyncsKey(number uint64, hash common.Hash) []byte {
+	return append(append(append([]byte{}, stateSyncsPrefix...), encodeBlockNumber(number)...), hash.Bytes()...)
+}
+
+// stateSyncLookupKey = stateSyncLookupPrefix + id (uint64 big endian)
+func stateSyncLookupKey(id uint64) []byte {
+	return append(append([]byte{}, stateSyncLookupPrefix...), encodeBlockNumber(id)...)
+}
+
+// ReadStateSyncs retrieves the state-syncs committed by a block.
+func ReadStateSyncs(db ethdb.KeyValueReader, hash common.Hash, number uint64) []*types.StateSyncData {
+	data, _ := db.Get(stateSyncsKey(number, hash))
+	if len(data) == 0 {
+		return nil
+	}
+
+	var syncs []*types.StateSyncData
+	if err := rlp.DecodeBytes(data, &syncs); err != nil {
+		log.Error("Invalid state-syncs RLP", "hash", hash, "number", number, "err", err)
+		return nil
+	}
+
+	return syncs
+}
+
+// WriteStateSyncs stores the state-syncs committed by a block, along with their
+// ID lookup entries.
+func WriteStateSyncs(db ethdb.KeyValueWriter, hash common.Hash, number uint64, syncs []*types.StateSyncData) {
+	data, err := rlp.EncodeToBytes(syncs)
+	if err != nil {
+		log.Crit("Failed to encode state-syncs", "err", err)
+	}
+
+	if err := db.Put(stateSyncsKey(number, hash), data); err != nil {
+		log.Crit("Failed to store state-syncs", "err", err)
+	}
+
+	for _, sync := range syncs {
+		if err := db.Put(stateSyncLookupKey(sync.ID), encodeBlockNumber(number)); err != nil {
+			log.Crit("Failed to store state-sync lookup entry", "err", err)
+		}
+	}
+}
+
+// DeleteStateSyncs removes the state-syncs committed by a block. The ID lookup
+// entries are left in place, the canonical block overwriting them.
+func DeleteStateSyncs(db ethdb.KeyValueWriter, hash common.Hash, number uint64) {
+	if err := db.Delete(stateSyncsKey(number, hash)); err != nil {
+		log.Crit("Failed to delete state-syncs", "err", err)
+	}
+}
+
+// ReadStateSyncLookupEntry retrieves the number of the block which committed the
+// state-sync with the given ID.
+func ReadStateSyncLookupEntry(db ethdb.KeyValueReader, id uint64) *uint64 {
+	data, _ := db.Get(stateSyncLookupKey(id))
+	if len(data) != 8 {
+		return nil
+	}
+
+	number := binary.BigEndian.Uint64(data)
+
+	return &number
+}
+
+// ReadLastStateSyncID retrieves the highest indexed state-sync ID, zero if none.
+func ReadLastStateSyncID(db ethdb.KeyValueReader) uint64 {
+	data, _ := db.Get(lastStateSyncIDKey)
+	if len(data) != 8 {
+		return 0
+	}
+
+	return binary.BigEndian.Uint64(data)
+}
+
+// WriteLastStateSyncID stores the highest indexed state-sync ID.
+func WriteLastStateSyncID(db ethdb.KeyValueWriter, id uint64) {
+	if err := db.Put(lastStateSyncIDKey, encodeBlockNumber(id)); err != nil {
+		log.Crit("Failed to store last state-sync ID", "err", err)
+	}
+}
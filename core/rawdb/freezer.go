@@ -287,8 +287,8 @@ func (f *Freezer) TruncateHead(items uint64) (uint64, error) {
 	if oitems <= items {
 		return oitems, nil
 	}
-	for _, table := range f.tables {
-		if err := table.truncateHead(items); err != nil {
+	for kind, table := range f.tables {
+		if err := truncateTableHead(kind, table, items); err != nil {
 			return 0, err
 		}
 	}
@@ -296,6 +296,15 @@ func (f *Freezer) TruncateHead(items uint64) (uint64, error) {
 	return oitems, nil
 }
 
+// truncateTableHead truncates the head of a table. Tables with an independent
+// tail may have expired the items above the new head, they are reset instead.
+func truncateTableHead(kind string, table *freezerTable, items uint64) error {
+	if chainFreezerIndependentTail[kind] && items < table.itemHidden.Load() {
+		return table.resetTo(items)
+	}
+	return table.truncateHead(items)
+}
+
 // TruncateTail discards any recent data below the provided threshold number.
 func (f *Freezer) TruncateTail(tail uint64) (uint64, error) {
 	if f.readonly {
@@ -317,6 +326,26 @@ func (f *Freezer) TruncateTail(tail uint64) (uint64, error) {
 	return old, nil
 }
 
+// TruncateTableTail discards the items of a single table below the provided
+// threshold number, leaving the other tables untouched. Only the tables with
+// an independent tail support it. The tail is capped to the table's head.
+func (f *Freezer) TruncateTableTail(kind string, tail uint64) error {
+	if f.readonly {
+		return errReadOnly
+	}
+	f.writeLock.Lock()
+	defer f.writeLock.Unlock()
+
+	table, ok := f.tables[kind]
+	if !ok || !chainFreezerIndependentTail[kind] {
+		return errUnknownTable
+	}
+	if items := table.items.Load(); tail > items {
+		tail = items
+	}
+	return table.truncateTail(tail)
+}
+
 // Sync flushes all data tables to disk.
 func (f *Freezer) Sync() error {
 	var errs []error
@@ -342,8 +371,11 @@ func (f *Freezer) validate() error {
 		tail uint64
 		name string
 	)
-	// Hack to get boundary of any table
+	// Hack to get boundary of any table sharing the tail
 	for kind, table := range f.tables {
+		if chainFreezerIndependentTail[kind] {
+			continue
+		}
 		head = table.items.Load()
 		tail = table.itemHidden.Load()
 		name = kind
@@ -354,7 +386,7 @@ func (f *Freezer) validate() error {
 		if head != table.items.Load() {
 			return fmt.Errorf("freezer tables %s and %s have differing head: %d != %d", kind, name, table.items.Load(), head)
 		}
-		if tail != table.itemHidden.Load() {
+		if tail != table.itemHidden.Load() && !chainFreezerIndependentTail[kind] {
 			return fmt.Errorf("freezer tables %s and %s have differing tail: %d != %d", kind, name, table.itemHidden.Load(), tail)
 		}
 	}
@@ -369,18 +401,22 @@ func (f *Freezer) repair() error {
 		head = uint64(math.MaxUint64)
 		tail = uint64(0)
 	)
-	for _, table := range f.tables {
+	for kind, table := range f.tables {
 		items := table.items.Load()
 		if head > items {
 			head = items
 		}
+		// Tables expiring their history separately don't move the shared tail
+		if chainFreezerIndependentTail[kind] {
+			continue
+		}
 		hidden := table.itemHidden.Load()
 		if hidden > tail {
 			tail = hidden
 		}
 	}
-	for _, table := range f.tables {
-		if err := table.truncateHead(head); err != nil {
+	for kind, table := range f.tables {
+		if err := truncateTableHead(kind, table, head); err != nil {
 			return err
 		}
 		if err := table.truncateTail(tail); err != nil {
@@ -22,6 +22,7 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"math"
 	"os"
 	"path/filepath"
 	"sync"
@@ -1056,3 +1057,69 @@ func (t *freezerTable) Fill(number uint64) error {
 
 	return nil
 }
+
+// resetTo drops all the data of the table and moves both its head and tail to
+// the given item, new items are appended from there on. It is used to rewind
+// a table whose tail was truncated beyond the new head.
+func (t *freezerTable) resetTo(items uint64) error {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	if items > math.MaxUint32 {
+		return fmt.Errorf("reset position %d out of range", items)
+	}
+
+	oldSize, err := t.sizeNolock()
+	if err != nil {
+		return err
+	}
+
+	t.logger.Warn("Resetting freezer table", "items", t.items.Load(), "tail", t.itemHidden.Load(), "position", items)
+
+	// Commit the new virtual tail first, so that an interrupted reset leaves
+	// the table hidden instead of exposing stale items.
+	t.itemHidden.Store(items)
+
+	if err := writeMetadata(t.meta, newMetadata(items)); err != nil {
+		return err
+	}
+
+	if err := t.meta.Sync(); err != nil {
+		return err
+	}
+	// Continue in a fresh data file and point the index at it
+	newId := t.headId + 1
+	tail := indexEntry{filenum: newId, offset: uint32(items)}
+
+	if err := truncateFreezerFile(t.index, 0); err != nil {
+		return err
+	}
+
+	if _, err := t.index.Write(tail.append(nil)); err != nil {
+		return err
+	}
+
+	if err := t.index.Sync(); err != nil {
+		return err
+	}
+
+	t.releaseFilesBefore(newId, true)
+
+	if t.head, err = t.openFile(newId, openFreezerFileForAppend); err != nil {
+		return err
+	}
+
+	t.headId, t.tailId = newId, newId
+	t.headBytes = 0
+	t.itemOffset.Store(items)
+	t.items.Store(items)
+
+	newSize, err := t.sizeNolock()
+	if err != nil {
+		return err
+	}
+
+	t.sizeGauge.Dec(int64(oldSize - newSize))
+
+	return nil
+}
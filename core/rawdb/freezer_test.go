@@ -486,3 +486,95 @@ func TestFreezerCloseSync(t *testing.T) {
 		t.Fatalf("want %v, have %v", have, want)
 	}
 }
+
+func TestFreezerIndependentTail(t *testing.T) {
+	t.Parallel()
+
+	tables := map[string]bool{"shared": true, freezerBorReceiptTable: true}
+	f, dir := newFreezerForTesting(t, tables)
+
+	appendItems := func(from, to uint64) {
+		t.Helper()
+
+		_, err := f.ModifyAncients(func(op ethdb.AncientWriteOp) error {
+			for i := from; i < to; i++ {
+				if err := op.AppendRaw("shared", i, getChunk(256, int(i))); err != nil {
+					return err
+				}
+
+				if err := op.AppendRaw(freezerBorReceiptTable, i, getChunk(256, int(i))); err != nil {
+					return err
+				}
+			}
+
+			return nil
+		})
+		if err != nil {
+			t.Fatal("ModifyAncients failed:", err)
+		}
+	}
+
+	appendItems(0, 40)
+
+	if err := f.TruncateTableTail("shared", 10); err != errUnknownTable {
+		t.Fatalf("truncated the tail of a shared table: %v", err)
+	}
+
+	if err := f.TruncateTableTail(freezerBorReceiptTable, 30); err != nil {
+		t.Fatal("TruncateTableTail failed:", err)
+	}
+
+	// The other tables and the shared tail must survive a reopen
+	require.NoError(t, f.Close())
+
+	f, err := NewFreezer(dir, "", false, 2049, tables)
+	if err != nil {
+		t.Fatal("can't open freezer", err)
+	}
+	defer f.Close()
+
+	if tail, _ := f.Tail(); tail != 0 {
+		t.Fatalf("shared tail moved to %d", tail)
+	}
+
+	if _, err := f.Ancient("shared", 0); err != nil {
+		t.Fatalf("shared item 0 lost: %v", err)
+	}
+
+	if _, err := f.Ancient(freezerBorReceiptTable, 29); err == nil {
+		t.Fatalf("expired item 29 still readable")
+	}
+
+	if _, err := f.Ancient(freezerBorReceiptTable, 30); err != nil {
+		t.Fatalf("item 30 lost: %v", err)
+	}
+
+	// Rewinding below the expired tail resets the table, which keeps accepting
+	// the following items
+	if _, err := f.TruncateHead(20); err != nil {
+		t.Fatal("TruncateHead failed:", err)
+	}
+
+	appendItems(20, 25)
+	checkAncientCount(t, f, "shared", 25)
+	checkAncientCount(t, f, freezerBorReceiptTable, 25)
+
+	if _, err := f.Ancient(freezerBorReceiptTable, 19); err == nil {
+		t.Fatalf("reset item 19 still readable")
+	}
+
+	if v, err := f.Ancient(freezerBorReceiptTable, 22); err != nil || !bytes.Equal(v, getChunk(256, 22)) {
+		t.Fatalf("wrong value at 22: %x (%v)", v, err)
+	}
+
+	// The reset table must be consistent on disk as well
+	require.NoError(t, f.Close())
+
+	f, err = NewFreezer(dir, "", true, 2049, tables)
+	if err != nil {
+		t.Fatal("can't reopen freezer", err)
+	}
+	defer f.Close()
+
+	checkAncientCount(t, f, freezerBorReceiptTable, 25)
+}
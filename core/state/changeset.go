@@ -0,0 +1,168 @@
+package state
+
+import (
+	"bytes"
+	"math/big"
+	"sort"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// changeOrigin tracks the values the accounts and storage slots mutated by a
+// block had before it, as reported by the journal entries of its transactions.
+type changeOrigin struct {
+	balances map[common.Address]*big.Int
+	nonces   map[common.Address]uint64
+	storage  map[common.Address]map[common.Hash]common.Hash
+}
+
+func newChangeOrigin() *changeOrigin {
+	return &changeOrigin{
+		balances: make(map[common.Address]*big.Int),
+		nonces:   make(map[common.Address]uint64),
+		storage:  make(map[common.Address]map[common.Hash]common.Hash),
+	}
+}
+
+// record tracks the previous values reported by the given journal entries, the
+// first one reported for a value being its original one.
+func (c *changeOrigin) record(entries []journalEntry) {
+	for _, entry := range entries {
+		switch ch := entry.(type) {
+		case createObjectChange:
+			c.recordBalance(*ch.account, new(big.Int))
+			c.recordNonce(*ch.account, 0)
+		case resetObjectChange:
+			c.recordBalance(*ch.account, ch.prev.data.Balance)
+			c.recordNonce(*ch.account, ch.prev.data.Nonce)
+		case selfDestructChange:
+			c.recordBalance(*ch.account, ch.prevbalance)
+		case balanceChange:
+			c.recordBalance(*ch.account, ch.prev)
+		case nonceChange:
+			c.recordNonce(*ch.account, ch.prev)
+		case storageChange:
+			slots := c.storage[*ch.account]
+			if slots == nil {
+				slots = make(map[common.Hash]common.Hash)
+				c.storage[*ch.account] = slots
+			}
+
+			if _, ok := slots[ch.key]; !ok {
+				slots[ch.key] = ch.prevalue
+			}
+		}
+	}
+}
+
+func (c *changeOrigin) recordBalance(addr common.Address, balance *big.Int) {
+	if _, ok := c.balances[addr]; !ok {
+		c.balances[addr] = new(big.Int).Set(balance)
+	}
+}
+
+func (c *changeOrigin) recordNonce(addr common.Address, nonce uint64) {
+	if _, ok := c.nonces[addr]; !ok {
+		c.nonces[addr] = nonce
+	}
+}
+
+// copy returns a deep copy of the tracked values.
+func (c *changeOrigin) copy() *changeOrigin {
+	cpy := newChangeOrigin()
+
+	for addr, balance := range c.balances {
+		cpy.balances[addr] = new(big.Int).Set(balance)
+	}
+
+	for addr, nonce := range c.nonces {
+		cpy.nonces[addr] = nonce
+	}
+
+	for addr, slots := range c.storage {
+		cpy.storage[addr] = make(map[common.Hash]common.Hash, len(slots))
+		for key, value := range slots {
+			cpy.storage[addr][key] = value
+		}
+	}
+
+	return cpy
+}
+
+// EnableChangeSet starts tracking the account and storage changes applied on
+// the state, to be retrieved by ChangeSet.
+func (s *StateDB) EnableChangeSet() {
+	if s.changes == nil {
+		s.changes = newChangeOrigin()
+	}
+}
+
+// ChangeSet returns the account balance and nonce changes, along with the
+// storage slot changes, applied on the state since EnableChangeSet was called.
+// The values changed back to their original ones are left out. It returns nil
+// if the changes are not tracked.
+//
+// Note, the storage wiped by a self-destruct is not reported, only the slots
+// written through the journal.
+func (s *StateDB) ChangeSet() *types.ChangeSet {
+	if s.changes == nil {
+		return nil
+	}
+
+	// Pick up the changes of the current transaction, not finalised yet
+	s.changes.record(s.journal.entries)
+
+	set := new(types.ChangeSet)
+
+	addrs := make(map[common.Address]struct{}, len(s.changes.balances))
+	for addr := range s.changes.balances {
+		addrs[addr] = struct{}{}
+	}
+
+	for addr := range s.changes.nonces {
+		addrs[addr] = struct{}{}
+	}
+
+	for addr := range addrs {
+		change := &types.AccountChange{
+			Address: addr,
+			Balance: s.GetBalance(addr),
+			Nonce:   s.GetNonce(addr),
+		}
+
+		change.PrevBalance, change.PrevNonce = change.Balance, change.Nonce
+		if balance, ok := s.changes.balances[addr]; ok {
+			change.PrevBalance = balance
+		}
+
+		if nonce, ok := s.changes.nonces[addr]; ok {
+			change.PrevNonce = nonce
+		}
+
+		if change.PrevBalance.Cmp(change.Balance) != 0 || change.PrevNonce != change.Nonce {
+			set.Accounts = append(set.Accounts, change)
+		}
+	}
+
+	for addr, slots := range s.changes.storage {
+		for key, prev := range slots {
+			if value := s.GetState(addr, key); value != prev {
+				set.Storage = append(set.Storage, &types.StorageChange{Address: addr, Slot: key, Prev: prev, Value: value})
+			}
+		}
+	}
+
+	sort.Slice(set.Accounts, func(i, j int) bool {
+		return bytes.Compare(set.Accounts[i].Address[:], set.Accounts[j].Address[:]) < 0
+	})
+	sort.Slice(set.Storage, func(i, j int) bool {
+		if cmp := bytes.Compare(set.Storage[i].Address[:], set.Storage[j].Address[:]); cmp != 0 {
+			return cmp < 0
+		}
+
+		return bytes.Compare(set.Storage[i].Slot[:], set.Storage[j].Slot[:]) < 0
+	})
+
+	return set
+}
@@ -0,0 +1,100 @@
+package state
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+func TestChangeSet(t *testing.T) {
+	t.Parallel()
+
+	var (
+		db    = NewDatabase(rawdb.NewMemoryDatabase())
+		addrA = common.HexToAddress("0x0a")
+		addrB = common.HexToAddress("0x0b")
+		addrC = common.HexToAddress("0x0c")
+		slot1 = common.HexToHash("0x01")
+		slot2 = common.HexToHash("0x02")
+	)
+
+	// Set up the pre-state of the block
+	pre, _ := New(types.EmptyRootHash, db, nil)
+	pre.SetBalance(addrA, big.NewInt(10))
+	pre.SetNonce(addrA, 1)
+	pre.SetNonce(addrC, 1)
+	pre.SetState(addrC, slot1, common.HexToHash("0xaa"))
+	pre.SetState(addrC, slot2, common.HexToHash("0xbb"))
+
+	root, err := pre.Commit(0, false)
+	if err != nil {
+		t.Fatalf("failed to commit: %v", err)
+	}
+
+	state, _ := New(root, db, nil)
+	if state.ChangeSet() != nil {
+		t.Fatal("change set reported without tracking")
+	}
+
+	state.EnableChangeSet()
+
+	// First transaction, finalised
+	state.AddBalance(addrA, big.NewInt(5))
+	state.SetNonce(addrA, 2)
+	state.SetState(addrC, slot1, common.HexToHash("0xcc"))
+	state.SetState(addrC, slot2, common.HexToHash("0xdd"))
+	state.Finalise(true)
+
+	// Second transaction, pending: creates an account and reverts a slot
+	state.AddBalance(addrB, big.NewInt(7))
+	state.SetState(addrC, slot2, common.HexToHash("0xbb"))
+
+	want := &types.ChangeSet{
+		Accounts: []*types.AccountChange{
+			{Address: addrA, PrevBalance: big.NewInt(10), Balance: big.NewInt(15), PrevNonce: 1, Nonce: 2},
+			{Address: addrB, PrevBalance: new(big.Int), Balance: big.NewInt(7), PrevNonce: 0, Nonce: 0},
+		},
+		Storage: []*types.StorageChange{
+			{Address: addrC, Slot: slot1, Prev: common.HexToHash("0xaa"), Value: common.HexToHash("0xcc")},
+		},
+	}
+
+	checkChangeSet(t, state.ChangeSet(), want)
+
+	// The copy tracks the changes independently
+	cpy := state.Copy()
+	cpy.SetBalance(addrA, big.NewInt(10))
+	cpy.SetNonce(addrA, 1)
+
+	checkChangeSet(t, state.ChangeSet(), want)
+	checkChangeSet(t, cpy.ChangeSet(), &types.ChangeSet{Accounts: want.Accounts[1:], Storage: want.Storage})
+}
+
+func checkChangeSet(t *testing.T, have, want *types.ChangeSet) {
+	t.Helper()
+
+	if len(have.Accounts) != len(want.Accounts) {
+		t.Fatalf("account change count mismatch: have %d, want %d", len(have.Accounts), len(want.Accounts))
+	}
+
+	for i, change := range have.Accounts {
+		w := want.Accounts[i]
+		if change.Address != w.Address || change.PrevBalance.Cmp(w.PrevBalance) != 0 || change.Balance.Cmp(w.Balance) != 0 ||
+			change.PrevNonce != w.PrevNonce || change.Nonce != w.Nonce {
+			t.Errorf("account change %d mismatch: have %+v, want %+v", i, change, w)
+		}
+	}
+
+	if len(have.Storage) != len(want.Storage) {
+		t.Fatalf("storage change count mismatch: have %d, want %d", len(have.Storage), len(want.Storage))
+	}
+
+	for i, change := range have.Storage {
+		if *change != *want.Storage[i] {
+			t.Errorf("storage change %d mismatch: have %+v, want %+v", i, change, want.Storage[i])
+		}
+	}
+}
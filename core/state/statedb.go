@@ -133,6 +133,9 @@ type StateDB struct {
 	validRevisions []revision
 	nextRevisionId int
 
+	// Original values of the changed accounts and slots, nil if not tracked
+	changes *changeOrigin
+
 	// Measurements gathered during execution for debugging purposes
 	AccountReads         time.Duration
 	AccountHashes        time.Duration
@@ -1271,6 +1274,12 @@ func (s *StateDB) Copy() *StateDB {
 	state.accountsOrigin = copySet(state.accountsOrigin)
 	state.storagesOrigin = copy2DSet(state.storagesOrigin)
 
+	// Deep copy the tracked original values, the journal isn't carried over
+	if s.changes != nil {
+		state.changes = s.changes.copy()
+		state.changes.record(s.journal.entries)
+	}
+
 	// Deep copy the logs occurred in the scope of block
 	for hash, logs := range s.logs {
 		cpy := make([]*types.Log, len(logs))
@@ -1388,6 +1397,10 @@ func (s *StateDB) Finalise(deleteEmptyObjects bool) {
 	if s.prefetcher != nil && len(addressesToPrefetch) > 0 {
 		s.prefetcher.prefetch(common.Hash{}, s.originalRoot, common.Address{}, addressesToPrefetch)
 	}
+	// Track the original values of the changes before dropping the journal
+	if s.changes != nil {
+		s.changes.record(s.journal.entries)
+	}
 	// Invalidate journal because reverting across transactions is not allowed.
 	s.clearJournalAndRefund()
 }
@@ -0,0 +1,733 @@
+// Copyright 2024 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+// Package condpool implements the conditional transaction pool (EIP-4337).
+package condpool
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/core/state"
+	"github.com/ethereum/go-ethereum/core/txpool"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/event"
+	"github.com/ethereum/go-ethereum/log"
+)
+
+// txMaxSize is the maximum size a single transaction can have. It mirrors the
+// limit enforced by the legacy pool.
+const txMaxSize = 4 * 32 * 1024
+
+var (
+	// ErrBlockRangeExpired is returned if the next block is already past the
+	// maximum block number the transaction may be included in.
+	ErrBlockRangeExpired = errors.New("block number range expired")
+
+	// ErrTimeRangeExpired is returned if the next block is already past the
+	// maximum timestamp the transaction may be included at.
+	ErrTimeRangeExpired = errors.New("timestamp range expired")
+
+	// ErrKnownAccounts is returned if the known accounts of a transaction do not
+	// match the state at the current head anymore.
+	ErrKnownAccounts = errors.New("known accounts mismatch")
+
+	// ErrLifetimeExpired is returned if a transaction was kept in the pool for
+	// longer than the configured lifetime.
+	ErrLifetimeExpired = errors.New("lifetime expired")
+
+	// ErrNonceGap is returned if a transaction became non-executable because a
+	// lower nonce transaction of the same bundler was evicted.
+	ErrNonceGap = errors.New("preceding transaction evicted")
+
+	// ErrNonceConsumed is returned if the nonce of a transaction was consumed on
+	// chain, either by the transaction itself or by a replacement.
+	ErrNonceConsumed = errors.New("nonce consumed on chain")
+
+	// ErrPoolOverflow is returned if the pool is full and cannot accept any new
+	// conditional transactions.
+	ErrPoolOverflow = errors.New("conditional pool full")
+
+	// ErrBundlerReserved is returned if the bundler still has regular (non
+	// conditional) transactions pooled. An account is tracked by a single subpool
+	// at a time, so its regular transactions need to be included or dropped before
+	// it can submit conditional ones.
+	ErrBundlerReserved = errors.New("bundler has regular transactions pooled")
+
+	// ErrPoolCleared is recorded as the reason of the transactions dropped by
+	// an operator clearing the pool.
+	ErrPoolCleared = errors.New("pool cleared")
+)
+
+// Status is the lifecycle state of a conditional transaction.
+type Status string
+
+const (
+	StatusUnknown  Status = "unknown"  // Never seen or forgotten by the pool
+	StatusPending  Status = "pending"  // Includable in the next block as far as the pool knows
+	StatusQueued   Status = "queued"   // Waiting for its lower block or time bound
+	StatusIncluded Status = "included" // Nonce consumed on chain
+	StatusEvicted  Status = "evicted"  // Dropped, it can never become valid
+)
+
+// Report is the status of a conditional transaction as seen by the pool.
+type Report struct {
+	Hash    common.Hash    `json:"hash"`
+	Bundler common.Address `json:"bundler"`
+	Nonce   hexutil.Uint64 `json:"nonce"`
+	Status  Status         `json:"status"`
+	Reason  string         `json:"reason,omitempty"`
+	Block   hexutil.Uint64 `json:"block"` // Head block the status was determined at
+}
+
+// condTx is a pooled conditional transaction along with its evaluation against
+// the current head.
+type condTx struct {
+	tx    *types.Transaction
+	hash  common.Hash
+	added time.Time
+	ready bool // Whether the lower block and time bounds are met for the next block
+}
+
+// ConditionalPool is the transaction pool dedicated to conditional transactions
+// submitted via bor_sendRawTransactionConditional (EIP-4337 bundles).
+//
+// Contrary to the legacy pool, conditional transactions are re-evaluated against
+// every new head: transactions whose block or time range is exhausted or whose
+// known accounts mismatch the head state can never become valid anymore and are
+// evicted immediately. The outcome is remembered for a while so bundlers can
+// query what happened to their transactions.
+//
+// Transactions are kept strictly nonce ordered without gaps per bundler, and the
+// number of transactions a single bundler may have pooled is capped.
+//
+// Like every subpool, the conditional pool exclusively owns the accounts it
+// tracks: a bundler cannot have conditional and regular transactions pooled at
+// the same time. Conditional transactions of a bundler with pooled regular ones
+// are rejected with ErrBundlerReserved, and regular transactions of a bundler
+// with pooled conditional ones with txpool.ErrAlreadyReserved.
+type ConditionalPool struct {
+	config  Config                 // Pool configuration
+	reserve txpool.AddressReserver // Address reserver to ensure exclusivity across subpools
+
+	signer types.Signer // Transaction signer to use for sender recovery
+	chain  BlockChain   // Chain object to access the state through
+
+	head   *types.Header  // Current head of the chain
+	state  *state.StateDB // Current state at the head of the chain
+	gasTip *big.Int       // Currently accepted minimum gas tip
+
+	lookup map[common.Hash]*condTx      // Lookup table of pooled transactions
+	index  map[common.Address][]*condTx // Pooled transactions grouped by bundler, sorted by nonce
+	count  int                          // Number of pooled transactions
+	gone   map[common.Hash]*Report      // Reports of recently evicted or included transactions
+	order  []common.Hash                // Eviction order of the reports to cap the history
+
+	eventFeed  event.Feed              // Event feed to send out new tx events on pool inclusion
+	eventScope event.SubscriptionScope // Event scope to track and mass unsubscribe on termination
+
+	lock sync.RWMutex // Mutex protecting the pool during reorg handling
+}
+
+// New creates a new conditional transaction pool.
+func New(config Config, chain BlockChain) *ConditionalPool {
+	// Sanitize the input to ensure no vulnerable limits are set
+	config = (&config).sanitize()
+
+	return &ConditionalPool{
+		config: config,
+		signer: types.LatestSigner(chain.Config()),
+		chain:  chain,
+		lookup: make(map[common.Hash]*condTx),
+		index:  make(map[common.Address][]*condTx),
+		gone:   make(map[common.Hash]*Report),
+	}
+}
+
+// Filter returns whether the given transaction can be consumed by the
+// conditional pool.
+func (p *ConditionalPool) Filter(tx *types.Transaction) bool {
+	return tx.GetOptions() != nil
+}
+
+// Init sets the gas price needed to keep a transaction in the pool and the chain
+// head to allow balance / nonce checks.
+func (p *ConditionalPool) Init(gasTip *big.Int, head *types.Header, reserve txpool.AddressReserver) error {
+	state, err := p.chain.StateAt(head.Root)
+	if err != nil {
+		return err
+	}
+	p.reserve = reserve
+	p.head, p.state = head, state
+	p.gasTip = new(big.Int).Set(gasTip)
+
+	return nil
+}
+
+// Close terminates any background processing threads and releases any held
+// resources.
+func (p *ConditionalPool) Close() error {
+	p.eventScope.Close()
+	return nil
+}
+
+// Reset implements txpool.SubPool, re-evaluating all pooled conditional
+// transactions against the new head and evicting the ones that can never
+// become valid anymore.
+func (p *ConditionalPool) Reset(oldHead, newHead *types.Header) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	state, err := p.chain.StateAt(newHead.Root)
+	if err != nil {
+		log.Error("Failed to reset conditional pool state", "err", err)
+		return
+	}
+	p.head, p.state = newHead, state
+
+	for addr, txs := range p.index {
+		var (
+			next    = p.state.GetNonce(addr)
+			balance = p.state.GetBalance(addr)
+			spent   = new(big.Int)
+			keep    = 0
+			reason  error
+		)
+		// Drop everything that was included (or replaced) on chain
+		for keep < len(txs) && txs[keep].tx.Nonce() < next {
+			includedMeter.Mark(1)
+			p.forget(addr, txs[keep], StatusIncluded, ErrNonceConsumed)
+			keep++
+		}
+		txs = txs[keep:]
+
+		// If a reorg reverted some nonces, the remaining transactions are gapped
+		cut := len(txs)
+		if len(txs) > 0 && txs[0].tx.Nonce() != next {
+			cut, reason = 0, ErrNonceGap
+		}
+		for i, ctx := range txs {
+			if reason != nil {
+				p.evict(addr, ctx, reason)
+				continue
+			}
+			if time.Since(ctx.added) > p.config.Lifetime {
+				reason = ErrLifetimeExpired
+			} else if ctx.ready, reason = p.evaluate(ctx.tx); reason == nil {
+				if spent.Add(spent, ctx.tx.Cost()); balance.Cmp(spent) < 0 {
+					reason = core.ErrInsufficientFunds
+				}
+			}
+			if reason != nil {
+				// The first eviction of the bundler carries the real reason,
+				// all subsequent transactions are evicted due to the gap
+				p.evict(addr, ctx, reason)
+				cut, reason = i, ErrNonceGap
+			}
+		}
+		txs = txs[:cut]
+
+		if len(txs) == 0 {
+			delete(p.index, addr)
+			p.reserve(addr, false)
+			continue
+		}
+		p.index[addr] = txs
+	}
+	p.updateGauges()
+}
+
+// evaluate checks the conditions of a transaction against the next block built
+// on top of the current head. An error is returned if the transaction can never
+// become valid anymore, otherwise whether it is includable right away.
+func (p *ConditionalPool) evaluate(tx *types.Transaction) (bool, error) {
+	options := tx.GetOptions()
+
+	var (
+		number = new(big.Int).Add(p.head.Number, common.Big1)
+		stamp  = p.head.Time + 1 // The next block can't be earlier than this
+	)
+	if options.BlockNumberMax != nil && options.BlockNumberMax.Cmp(number) < 0 {
+		return false, fmt.Errorf("%w: next block %v, max %v", ErrBlockRangeExpired, number, options.BlockNumberMax)
+	}
+	if options.TimestampMax != nil && *options.TimestampMax < stamp {
+		return false, fmt.Errorf("%w: next block time %v, max %v", ErrTimeRangeExpired, stamp, *options.TimestampMax)
+	}
+	if err := p.state.ValidateKnownAccounts(options.KnownAccounts); err != nil {
+		return false, fmt.Errorf("%w: %v", ErrKnownAccounts, err)
+	}
+	ready := true
+	if options.BlockNumberMin != nil && options.BlockNumberMin.Cmp(number) > 0 {
+		ready = false
+	}
+	if options.TimestampMin != nil && *options.TimestampMin > stamp {
+		ready = false
+	}
+	return ready, nil
+}
+
+// evict drops a transaction from the pool, remembering the reason why.
+func (p *ConditionalPool) evict(addr common.Address, ctx *condTx, reason error) {
+	switch {
+	case errors.Is(reason, ErrBlockRangeExpired):
+		blockRangeMeter.Mark(1)
+	case errors.Is(reason, ErrTimeRangeExpired):
+		timeRangeMeter.Mark(1)
+	case errors.Is(reason, ErrKnownAccounts):
+		knownAccsMeter.Mark(1)
+	case errors.Is(reason, core.ErrInsufficientFunds):
+		fundsMeter.Mark(1)
+	case errors.Is(reason, ErrLifetimeExpired):
+		lifetimeMeter.Mark(1)
+	default:
+		gappedMeter.Mark(1)
+	}
+	log.Debug("Evicted conditional transaction", "hash", ctx.hash, "bundler", addr, "reason", reason)
+	p.forget(addr, ctx, StatusEvicted, reason)
+}
+
+// forget removes a transaction from the lookup tables and records its final
+// status in the bounded history.
+func (p *ConditionalPool) forget(addr common.Address, ctx *condTx, status Status, reason error) {
+	delete(p.lookup, ctx.hash)
+	p.count--
+
+	if p.config.History == 0 {
+		return
+	}
+	if len(p.order) >= p.config.History {
+		delete(p.gone, p.order[0])
+		p.order = p.order[1:]
+	}
+	p.gone[ctx.hash] = &Report{
+		Hash:    ctx.hash,
+		Bundler: addr,
+		Nonce:   hexutil.Uint64(ctx.tx.Nonce()),
+		Status:  status,
+		Reason:  reason.Error(),
+		Block:   hexutil.Uint64(p.head.Number.Uint64()),
+	}
+	p.order = append(p.order, ctx.hash)
+}
+
+// Clear drops all pooled conditional transactions, releasing their bundlers, and
+// returns the number of transactions dropped.
+func (p *ConditionalPool) Clear() int {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	var dropped int
+	for addr, txs := range p.index {
+		for _, ctx := range txs {
+			p.forget(addr, ctx, StatusEvicted, ErrPoolCleared)
+		}
+		dropped += len(txs)
+
+		delete(p.index, addr)
+		p.reserve(addr, false)
+	}
+	p.updateGauges()
+
+	log.Info("Conditional pool cleared", "dropped", dropped)
+	return dropped
+}
+
+// SetGasTip updates the minimum price required by the subpool for a new
+// transaction. Pooled transactions are not dropped, they are all local.
+func (p *ConditionalPool) SetGasTip(tip *big.Int) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	p.gasTip = new(big.Int).Set(tip)
+}
+
+// Quota returns the number of transaction slots used and allowed for a bundler.
+func (p *ConditionalPool) Quota(addr common.Address) (uint64, uint64) {
+	p.lock.RLock()
+	defer p.lock.RUnlock()
+
+	return uint64(len(p.index[addr])), p.config.BundlerSlots
+}
+
+// validateTx checks whether a transaction is valid according to the consensus
+// rules, the pool limits and its own conditions.
+func (p *ConditionalPool) validateTx(tx *types.Transaction, local bool) error {
+	opts := &txpool.ValidationOptions{
+		Config: p.chain.Config(),
+		Accept: 0 |
+			1<<types.LegacyTxType |
+			1<<types.AccessListTxType |
+			1<<types.DynamicFeeTxType,
+		MaxSize: txMaxSize,
+		MinTip:  p.gasTip,
+	}
+	if local {
+		opts.MinTip = new(big.Int)
+	}
+	if err := txpool.ValidateTransaction(tx, nil, nil, nil, p.head, p.signer, opts); err != nil {
+		return err
+	}
+	stateOpts := &txpool.ValidationOptionsWithState{
+		State: p.state,
+
+		FirstNonceGap: func(addr common.Address) uint64 {
+			// Nonce gaps are not permitted in the conditional pool, the bundle
+			// conditions are evaluated in nonce order.
+			return p.state.GetNonce(addr) + uint64(len(p.index[addr]))
+		},
+		UsedAndLeftSlots: func(addr common.Address) (int, int) {
+			have, limit := len(p.index[addr]), int(p.config.BundlerSlots)
+			if have >= limit {
+				return have, 0
+			}
+			return have, limit - have
+		},
+		ExistingExpenditure: func(addr common.Address) *big.Int {
+			spent := new(big.Int)
+			for _, ctx := range p.index[addr] {
+				spent.Add(spent, ctx.tx.Cost())
+			}
+			return spent
+		},
+		ExistingCost: func(addr common.Address, nonce uint64) *big.Int {
+			if prev := p.pooled(addr, nonce); prev != nil {
+				return prev.tx.Cost()
+			}
+			return nil
+		},
+	}
+	if err := txpool.ValidateTransactionWithState(tx, p.signer, stateOpts); err != nil {
+		return err
+	}
+	from, _ := types.Sender(p.signer, tx) // already validated above
+
+	prev := p.pooled(from, tx.Nonce())
+	if prev == nil && uint64(p.count) >= p.config.GlobalSlots {
+		return ErrPoolOverflow
+	}
+	if prev != nil {
+		// Replacements need to be bumped on both the fee cap and the tip
+		var (
+			multiplier = new(big.Int).SetUint64(100 + p.config.PriceBump)
+			hundred    = big.NewInt(100)
+
+			minGasFeeCap = new(big.Int).Div(new(big.Int).Mul(multiplier, prev.tx.GasFeeCap()), hundred)
+			minGasTipCap = new(big.Int).Div(new(big.Int).Mul(multiplier, prev.tx.GasTipCap()), hundred)
+		)
+		if tx.GasFeeCapIntCmp(minGasFeeCap) < 0 || tx.GasTipCapIntCmp(minGasTipCap) < 0 {
+			return fmt.Errorf("%w: new tx gas fee cap %v, tip %v <= %v, %v queued + %d%% replacement penalty", txpool.ErrReplaceUnderpriced, tx.GasFeeCap(), tx.GasTipCap(), prev.tx.GasFeeCap(), prev.tx.GasTipCap(), p.config.PriceBump)
+		}
+	}
+	// Last but not least, make sure the conditions can still be met
+	if options := tx.GetOptions(); options != nil {
+		if err := options.KnownAccounts.ValidateLength(); err != nil {
+			return err
+		}
+	}
+	_, err := p.evaluate(tx)
+
+	return err
+}
+
+// pooled returns the pooled transaction of a bundler with the given nonce.
+func (p *ConditionalPool) pooled(addr common.Address, nonce uint64) *condTx {
+	txs := p.index[addr]
+	if len(txs) == 0 || nonce < txs[0].tx.Nonce() {
+		return nil
+	}
+	if offset := nonce - txs[0].tx.Nonce(); offset < uint64(len(txs)) {
+		return txs[offset]
+	}
+	return nil
+}
+
+// Has returns an indicator whether subpool has a transaction cached with the
+// given hash.
+func (p *ConditionalPool) Has(hash common.Hash) bool {
+	p.lock.RLock()
+	defer p.lock.RUnlock()
+
+	_, ok := p.lookup[hash]
+	return ok
+}
+
+// Get returns a transaction if it is contained in the pool, or nil otherwise.
+func (p *ConditionalPool) Get(hash common.Hash) *txpool.Transaction {
+	p.lock.RLock()
+	defer p.lock.RUnlock()
+
+	if ctx, ok := p.lookup[hash]; ok {
+		return &txpool.Transaction{Tx: ctx.tx}
+	}
+	return nil
+}
+
+// Add inserts a set of conditional transactions into the pool if they pass
+// validation (consensus validity, pool restrictions and conditions).
+func (p *ConditionalPool) Add(txs []*txpool.Transaction, local bool, sync bool) []error {
+	var (
+		errs  = make([]error, len(txs))
+		added = make([]*types.Transaction, 0, len(txs))
+	)
+	for i, tx := range txs {
+		if errs[i] = p.add(tx.Tx, local); errs[i] == nil {
+			added = append(added, tx.Tx)
+		}
+	}
+	if len(added) > 0 {
+		p.eventFeed.Send(core.NewTxsEvent{Txs: added})
+	}
+	return errs
+}
+
+// add inserts a new conditional transaction into the pool if it passes
+// validation.
+func (p *ConditionalPool) add(tx *types.Transaction, local bool) (err error) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	hash := tx.Hash()
+	if _, ok := p.lookup[hash]; ok {
+		return txpool.ErrAlreadyKnown
+	}
+	if err := p.validateTx(tx, local); err != nil {
+		log.Trace("Conditional transaction validation failed", "hash", hash, "err", err)
+		return err
+	}
+	// If the address is not yet known, request exclusivity to track the account
+	// only by this subpool until all transactions are evicted
+	from, _ := types.Sender(p.signer, tx) // already validated above
+	if _, ok := p.index[from]; !ok {
+		if err := p.reserve(from, true); err != nil {
+			if errors.Is(err, txpool.ErrAlreadyReserved) {
+				return ErrBundlerReserved
+			}
+			return err
+		}
+	}
+	ready, _ := p.evaluate(tx) // already validated above
+	ctx := &condTx{
+		tx:    tx,
+		hash:  hash,
+		added: time.Now(),
+		ready: ready,
+	}
+	if prev := p.pooled(from, tx.Nonce()); prev != nil {
+		p.index[from][tx.Nonce()-p.index[from][0].tx.Nonce()] = ctx
+		p.forget(from, prev, StatusEvicted, fmt.Errorf("replaced by %v", hash))
+	} else {
+		p.index[from] = append(p.index[from], ctx)
+	}
+	p.lookup[hash] = ctx
+	p.count++
+
+	delete(p.gone, hash)
+	p.updateGauges()
+
+	return nil
+}
+
+// updateGauges recounts the pending and queued transactions. The caller must
+// hold the pool lock.
+func (p *ConditionalPool) updateGauges() {
+	var pending, queued int
+	for _, txs := range p.index {
+		run := executable(txs)
+		pending += run
+		queued += len(txs) - run
+	}
+	pendingGauge.Update(int64(pending))
+	queuedGauge.Update(int64(queued))
+}
+
+// executable returns the number of leading transactions of a nonce sorted list
+// that are includable in the next block.
+func executable(txs []*condTx) int {
+	for i, ctx := range txs {
+		if !ctx.ready {
+			return i
+		}
+	}
+	return len(txs)
+}
+
+// Pending retrieves all currently processable transactions, grouped by origin
+// account and sorted by nonce.
+//
+// Conditional transactions are only ever submitted locally, so tips are not
+// enforced on them.
+func (p *ConditionalPool) Pending(enforceTips bool) map[common.Address][]*txpool.LazyTransaction {
+	p.lock.RLock()
+	defer p.lock.RUnlock()
+
+	pending := make(map[common.Address][]*txpool.LazyTransaction)
+	for addr, txs := range p.index {
+		run := executable(txs)
+		if run == 0 {
+			continue
+		}
+		lazies := make([]*txpool.LazyTransaction, 0, run)
+		for _, ctx := range txs[:run] {
+			lazies = append(lazies, &txpool.LazyTransaction{
+				Pool:      p,
+				Hash:      ctx.hash,
+				Tx:        &txpool.Transaction{Tx: ctx.tx},
+				Time:      ctx.added,
+				GasFeeCap: ctx.tx.GasFeeCap(),
+				GasTipCap: ctx.tx.GasTipCap(),
+			})
+		}
+		pending[addr] = lazies
+	}
+	return pending
+}
+
+// SubscribeTransactions registers a subscription of NewTxsEvent and
+// starts sending event to the given channel.
+func (p *ConditionalPool) SubscribeTransactions(ch chan<- core.NewTxsEvent) event.Subscription {
+	return p.eventScope.Track(p.eventFeed.Subscribe(ch))
+}
+
+// Nonce returns the next nonce of an account, with all transactions executable
+// by the pool already applied on top.
+func (p *ConditionalPool) Nonce(addr common.Address) uint64 {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	if txs, ok := p.index[addr]; ok {
+		return txs[len(txs)-1].tx.Nonce() + 1
+	}
+	return p.state.GetNonce(addr)
+}
+
+// Stats retrieves the current pool stats, namely the number of pending and the
+// number of queued (non-executable) transactions.
+func (p *ConditionalPool) Stats() (int, int) {
+	p.lock.RLock()
+	defer p.lock.RUnlock()
+
+	var pending, queued int
+	for _, txs := range p.index {
+		run := executable(txs)
+		pending += run
+		queued += len(txs) - run
+	}
+	return pending, queued
+}
+
+// Content retrieves the data content of the transaction pool, returning all the
+// pending as well as queued transactions, grouped by account and sorted by nonce.
+func (p *ConditionalPool) Content() (map[common.Address][]*types.Transaction, map[common.Address][]*types.Transaction) {
+	p.lock.RLock()
+	defer p.lock.RUnlock()
+
+	var (
+		pending = make(map[common.Address][]*types.Transaction)
+		queued  = make(map[common.Address][]*types.Transaction)
+	)
+	for addr, txs := range p.index {
+		run, block := split(txs)
+		if len(run) > 0 {
+			pending[addr] = run
+		}
+		if len(block) > 0 {
+			queued[addr] = block
+		}
+	}
+	return pending, queued
+}
+
+// ContentFrom retrieves the data content of the transaction pool, returning the
+// pending as well as queued transactions of this address, grouped by nonce.
+func (p *ConditionalPool) ContentFrom(addr common.Address) ([]*types.Transaction, []*types.Transaction) {
+	p.lock.RLock()
+	defer p.lock.RUnlock()
+
+	return split(p.index[addr])
+}
+
+// split separates a nonce sorted list into the executable and the queued parts.
+func split(txs []*condTx) ([]*types.Transaction, []*types.Transaction) {
+	var (
+		run     = executable(txs)
+		pending = make([]*types.Transaction, 0, run)
+		queued  = make([]*types.Transaction, 0, len(txs)-run)
+	)
+	for i, ctx := range txs {
+		if i < run {
+			pending = append(pending, ctx.tx)
+		} else {
+			queued = append(queued, ctx.tx)
+		}
+	}
+	return pending, queued
+}
+
+// Locals retrieves the accounts currently considered local by the pool.
+//
+// There is no notion of local accounts in the conditional pool.
+func (p *ConditionalPool) Locals() []common.Address {
+	return []common.Address{}
+}
+
+// Status returns the known status (unknown/pending/queued) of a transaction
+// identified by their hashes.
+func (p *ConditionalPool) Status(hash common.Hash) txpool.TxStatus {
+	switch p.Inspect(hash).Status {
+	case StatusPending:
+		return txpool.TxStatusPending
+	case StatusQueued:
+		return txpool.TxStatusQueued
+	default:
+		return txpool.TxStatusUnknown
+	}
+}
+
+// Inspect returns the detailed status of a conditional transaction, including
+// the reason of its eviction if it was recently dropped from the pool.
+func (p *ConditionalPool) Inspect(hash common.Hash) *Report {
+	p.lock.RLock()
+	defer p.lock.RUnlock()
+
+	if report, ok := p.gone[hash]; ok {
+		cpy := *report
+		return &cpy
+	}
+	ctx, ok := p.lookup[hash]
+	if !ok {
+		return &Report{Hash: hash, Status: StatusUnknown, Block: hexutil.Uint64(p.head.Number.Uint64())}
+	}
+	from, _ := types.Sender(p.signer, ctx.tx) // already validated on insertion
+
+	report := &Report{
+		Hash:    hash,
+		Bundler: from,
+		Nonce:   hexutil.Uint64(ctx.tx.Nonce()),
+		Status:  StatusQueued,
+		Block:   hexutil.Uint64(p.head.Number.Uint64()),
+	}
+	txs := p.index[from]
+	if offset := int(ctx.tx.Nonce() - txs[0].tx.Nonce()); offset < executable(txs) {
+		report.Status = StatusPending
+	}
+	return report
+}
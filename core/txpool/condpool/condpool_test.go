@@ -0,0 +1,267 @@
+// Copyright 2024 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package condpool
+
+import (
+	"crypto/ecdsa"
+	"errors"
+	"math/big"
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/core/state"
+	"github.com/ethereum/go-ethereum/core/txpool"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/params"
+)
+
+// testBlockChain is a mock of the live chain for testing the pool.
+type testBlockChain struct {
+	head    *types.Header
+	statedb *state.StateDB
+}
+
+func (bc *testBlockChain) Config() *params.ChainConfig { return params.TestChainConfig }
+func (bc *testBlockChain) CurrentBlock() *types.Header { return bc.head }
+
+func (bc *testBlockChain) StateAt(common.Hash) (*state.StateDB, error) {
+	return bc.statedb.Copy(), nil
+}
+
+// advance moves the mock chain to the next block.
+func (bc *testBlockChain) advance() *types.Header {
+	bc.head = &types.Header{
+		Number:   new(big.Int).Add(bc.head.Number, common.Big1),
+		Time:     bc.head.Time + 2,
+		GasLimit: bc.head.GasLimit,
+		BaseFee:  bc.head.BaseFee,
+	}
+	return bc.head
+}
+
+var testContract = common.HexToAddress("0xc0ffee")
+
+// newTestPool creates a conditional pool on top of a mock chain with a funded
+// bundler account.
+func newTestPool(t *testing.T, config Config, bundler common.Address) (*ConditionalPool, *testBlockChain) {
+	t.Helper()
+
+	statedb, _ := state.New(types.EmptyRootHash, state.NewDatabase(rawdb.NewMemoryDatabase()), nil)
+	statedb.AddBalance(bundler, new(big.Int).Exp(big.NewInt(10), big.NewInt(18), nil))
+	statedb.SetState(testContract, common.Hash{1}, common.Hash{2})
+
+	chain := &testBlockChain{
+		head: &types.Header{
+			Number:   big.NewInt(100),
+			Time:     1000,
+			GasLimit: 30_000_000,
+			BaseFee:  big.NewInt(params.InitialBaseFee),
+		},
+		statedb: statedb,
+	}
+	pool := New(config, chain)
+	if err := pool.Init(big.NewInt(1), chain.CurrentBlock(), func(common.Address, bool) error { return nil }); err != nil {
+		t.Fatalf("failed to init pool: %v", err)
+	}
+	return pool, chain
+}
+
+// makeCondTx creates a signed conditional transaction with the given options.
+func makeCondTx(nonce uint64, key *ecdsa.PrivateKey, options *types.OptionsAA4337) *types.Transaction {
+	tx, _ := types.SignNewTx(key, types.LatestSigner(params.TestChainConfig), &types.DynamicFeeTx{
+		ChainID:   params.TestChainConfig.ChainID,
+		Nonce:     nonce,
+		GasTipCap: big.NewInt(params.GWei),
+		GasFeeCap: big.NewInt(10 * params.GWei),
+		Gas:       21000,
+		To:        &common.Address{},
+	})
+	tx.PutOptions(options)
+
+	return tx
+}
+
+// Tests that conditional transactions whose block range expires are evicted on
+// the next head, together with all subsequent transactions of the bundler.
+func TestEvictBlockRangeExpired(t *testing.T) {
+	key, _ := crypto.GenerateKey()
+	addr := crypto.PubkeyToAddress(key.PublicKey)
+
+	pool, chain := newTestPool(t, DefaultConfig, addr)
+
+	first := makeCondTx(0, key, &types.OptionsAA4337{BlockNumberMax: big.NewInt(102)})
+	second := makeCondTx(1, key, &types.OptionsAA4337{})
+
+	for i, err := range pool.Add([]*txpool.Transaction{{Tx: first}, {Tx: second}}, true, false) {
+		if err != nil {
+			t.Fatalf("tx %d: failed to add: %v", i, err)
+		}
+	}
+	if pending := pool.Pending(true)[addr]; len(pending) != 2 {
+		t.Fatalf("pending transactions mismatch: have %d, want %d", len(pending), 2)
+	}
+	// Block 102 may still contain the transaction, block 103 may not
+	pool.Reset(nil, chain.advance())
+	if status := pool.Inspect(first.Hash()).Status; status != StatusPending {
+		t.Fatalf("status mismatch: have %v, want %v", status, StatusPending)
+	}
+	pool.Reset(nil, chain.advance())
+
+	report := pool.Inspect(first.Hash())
+	if report.Status != StatusEvicted || !strings.Contains(report.Reason, ErrBlockRangeExpired.Error()) {
+		t.Errorf("first tx report mismatch: have %v (%s)", report.Status, report.Reason)
+	}
+	report = pool.Inspect(second.Hash())
+	if report.Status != StatusEvicted || report.Reason != ErrNonceGap.Error() {
+		t.Errorf("second tx report mismatch: have %v (%s)", report.Status, report.Reason)
+	}
+	if pending, queued := pool.Stats(); pending != 0 || queued != 0 {
+		t.Errorf("pool not empty: pending %d, queued %d", pending, queued)
+	}
+}
+
+// Tests that conditional transactions are evicted once their known accounts do
+// not match the head state anymore.
+func TestEvictKnownAccountsMismatch(t *testing.T) {
+	key, _ := crypto.GenerateKey()
+	addr := crypto.PubkeyToAddress(key.PublicKey)
+
+	pool, chain := newTestPool(t, DefaultConfig, addr)
+
+	known := types.KnownAccounts{}
+	types.InsertKnownAccounts(known, testContract, map[common.Hash]common.Hash{{1}: {2}})
+
+	tx := makeCondTx(0, key, &types.OptionsAA4337{KnownAccounts: known})
+	if err := pool.Add([]*txpool.Transaction{{Tx: tx}}, true, false)[0]; err != nil {
+		t.Fatalf("failed to add: %v", err)
+	}
+	chain.statedb.SetState(testContract, common.Hash{1}, common.Hash{3})
+	pool.Reset(nil, chain.advance())
+
+	report := pool.Inspect(tx.Hash())
+	if report.Status != StatusEvicted || !strings.Contains(report.Reason, ErrKnownAccounts.Error()) {
+		t.Errorf("report mismatch: have %v (%s)", report.Status, report.Reason)
+	}
+	// A fresh transaction with the stale known accounts must be rejected outright
+	stale := makeCondTx(0, key, &types.OptionsAA4337{KnownAccounts: known})
+	if err := pool.Add([]*txpool.Transaction{{Tx: stale}}, true, false)[0]; !errors.Is(err, ErrKnownAccounts) {
+		t.Errorf("stale tx error mismatch: have %v, want %v", err, ErrKnownAccounts)
+	}
+}
+
+// Tests that transactions waiting for their lower bound are queued and promoted
+// once the chain reaches it, and that included ones are reported as such.
+func TestQueuedPromotion(t *testing.T) {
+	key, _ := crypto.GenerateKey()
+	addr := crypto.PubkeyToAddress(key.PublicKey)
+
+	pool, chain := newTestPool(t, DefaultConfig, addr)
+
+	tx := makeCondTx(0, key, &types.OptionsAA4337{BlockNumberMin: big.NewInt(103)})
+	if err := pool.Add([]*txpool.Transaction{{Tx: tx}}, true, false)[0]; err != nil {
+		t.Fatalf("failed to add: %v", err)
+	}
+	if status := pool.Inspect(tx.Hash()).Status; status != StatusQueued {
+		t.Fatalf("status mismatch: have %v, want %v", status, StatusQueued)
+	}
+	if pending := pool.Pending(true); len(pending) != 0 {
+		t.Fatalf("queued transaction reported pending")
+	}
+	pool.Reset(nil, chain.advance())
+	pool.Reset(nil, chain.advance())
+
+	if status := pool.Inspect(tx.Hash()).Status; status != StatusPending {
+		t.Fatalf("status mismatch: have %v, want %v", status, StatusPending)
+	}
+	chain.statedb.SetNonce(addr, 1)
+	pool.Reset(nil, chain.advance())
+
+	if status := pool.Inspect(tx.Hash()).Status; status != StatusIncluded {
+		t.Fatalf("status mismatch: have %v, want %v", status, StatusIncluded)
+	}
+}
+
+// Tests that the number of transactions a single bundler may pool is capped.
+func TestBundlerQuota(t *testing.T) {
+	key, _ := crypto.GenerateKey()
+	addr := crypto.PubkeyToAddress(key.PublicKey)
+
+	config := DefaultConfig
+	config.BundlerSlots = 2
+
+	pool, _ := newTestPool(t, config, addr)
+
+	for nonce := uint64(0); nonce < 2; nonce++ {
+		if err := pool.Add([]*txpool.Transaction{{Tx: makeCondTx(nonce, key, &types.OptionsAA4337{})}}, true, false)[0]; err != nil {
+			t.Fatalf("tx %d: failed to add: %v", nonce, err)
+		}
+	}
+	if err := pool.Add([]*txpool.Transaction{{Tx: makeCondTx(2, key, &types.OptionsAA4337{})}}, true, false)[0]; !errors.Is(err, txpool.ErrAccountLimitExceeded) {
+		t.Errorf("quota error mismatch: have %v, want %v", err, txpool.ErrAccountLimitExceeded)
+	}
+	if used, limit := pool.Quota(addr); used != 2 || limit != 2 {
+		t.Errorf("quota mismatch: have %d/%d, want %d/%d", used, limit, 2, 2)
+	}
+}
+
+// Tests that conditional transactions of a bundler tracked by another subpool
+// are rejected with a dedicated error.
+func TestBundlerReserved(t *testing.T) {
+	key, _ := crypto.GenerateKey()
+	addr := crypto.PubkeyToAddress(key.PublicKey)
+
+	pool, _ := newTestPool(t, DefaultConfig, addr)
+	pool.reserve = func(common.Address, bool) error { return txpool.ErrAlreadyReserved }
+
+	if err := pool.Add([]*txpool.Transaction{{Tx: makeCondTx(0, key, &types.OptionsAA4337{})}}, true, false)[0]; !errors.Is(err, ErrBundlerReserved) {
+		t.Errorf("reservation error mismatch: have %v, want %v", err, ErrBundlerReserved)
+	}
+}
+
+// Tests that clearing the pool drops the transactions of every bundler and
+// reports them as evicted.
+func TestClear(t *testing.T) {
+	key, _ := crypto.GenerateKey()
+	addr := crypto.PubkeyToAddress(key.PublicKey)
+
+	pool, _ := newTestPool(t, DefaultConfig, addr)
+
+	txs := []*types.Transaction{makeCondTx(0, key, &types.OptionsAA4337{}), makeCondTx(1, key, &types.OptionsAA4337{})}
+	for i, err := range pool.Add([]*txpool.Transaction{{Tx: txs[0]}, {Tx: txs[1]}}, true, false) {
+		if err != nil {
+			t.Fatalf("tx %d: failed to add: %v", i, err)
+		}
+	}
+	if dropped := pool.Clear(); dropped != 2 {
+		t.Fatalf("dropped transactions mismatch: have %d, want %d", dropped, 2)
+	}
+	if pending, queued := pool.Stats(); pending != 0 || queued != 0 {
+		t.Fatalf("pool size mismatch: have %d/%d, want 0/0", pending, queued)
+	}
+	for _, tx := range txs {
+		if report := pool.Inspect(tx.Hash()); report == nil || report.Status != StatusEvicted || report.Reason != ErrPoolCleared.Error() {
+			t.Fatalf("report mismatch: %+v", report)
+		}
+	}
+	// The bundler can submit again
+	if err := pool.Add([]*txpool.Transaction{{Tx: makeCondTx(0, key, &types.OptionsAA4337{})}}, true, false)[0]; err != nil {
+		t.Fatalf("failed to add after clearing: %v", err)
+	}
+}
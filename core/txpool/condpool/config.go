@@ -0,0 +1,68 @@
+// Copyright 2024 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package condpool
+
+import (
+	"time"
+
+	"github.com/ethereum/go-ethereum/log"
+)
+
+// Config are the configuration parameters of the conditional transaction pool.
+type Config struct {
+	BundlerSlots uint64        // Maximum number of conditional transactions pooled per bundler (sender)
+	GlobalSlots  uint64        // Maximum number of conditional transactions pooled in total
+	PriceBump    uint64        // Minimum price bump percentage to replace an already existing nonce
+	Lifetime     time.Duration // Maximum amount of time a conditional transaction is kept around
+	History      int           // Number of evicted transactions to remember for status queries
+}
+
+// DefaultConfig contains the default configurations for the conditional pool.
+var DefaultConfig = Config{
+	BundlerSlots: 64,
+	GlobalSlots:  4096,
+	PriceBump:    10,
+	Lifetime:     time.Hour,
+	History:      16384,
+}
+
+// sanitize checks the provided user configurations and changes anything that's
+// unreasonable or unworkable.
+func (config *Config) sanitize() Config {
+	conf := *config
+	if conf.BundlerSlots < 1 {
+		log.Warn("Sanitizing invalid condpool bundler slots", "provided", conf.BundlerSlots, "updated", DefaultConfig.BundlerSlots)
+		conf.BundlerSlots = DefaultConfig.BundlerSlots
+	}
+	if conf.GlobalSlots < conf.BundlerSlots {
+		log.Warn("Sanitizing invalid condpool global slots", "provided", conf.GlobalSlots, "updated", conf.BundlerSlots)
+		conf.GlobalSlots = conf.BundlerSlots
+	}
+	if conf.PriceBump < 1 {
+		log.Warn("Sanitizing invalid condpool price bump", "provided", conf.PriceBump, "updated", DefaultConfig.PriceBump)
+		conf.PriceBump = DefaultConfig.PriceBump
+	}
+	if conf.Lifetime < 1 {
+		log.Warn("Sanitizing invalid condpool lifetime", "provided", conf.Lifetime, "updated", DefaultConfig.Lifetime)
+		conf.Lifetime = DefaultConfig.Lifetime
+	}
+	if conf.History < 0 {
+		log.Warn("Sanitizing invalid condpool history", "provided", conf.History, "updated", DefaultConfig.History)
+		conf.History = DefaultConfig.History
+	}
+	return conf
+}
@@ -0,0 +1,37 @@
+// Copyright 2024 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package condpool
+
+import "github.com/ethereum/go-ethereum/metrics"
+
+var (
+	// pendingGauge and queuedGauge track the number of conditional transactions
+	// that are currently includable and the ones waiting for their lower block
+	// or time bounds respectively.
+	pendingGauge = metrics.NewRegisteredGauge("condpool/pending", nil)
+	queuedGauge  = metrics.NewRegisteredGauge("condpool/queued", nil)
+
+	// The below metrics track the reasons conditional transactions are evicted
+	// with when the pool re-evaluates them against a new head.
+	includedMeter   = metrics.NewRegisteredMeter("condpool/evict/included", nil)
+	blockRangeMeter = metrics.NewRegisteredMeter("condpool/evict/blockrange", nil)
+	timeRangeMeter  = metrics.NewRegisteredMeter("condpool/evict/timerange", nil)
+	knownAccsMeter  = metrics.NewRegisteredMeter("condpool/evict/knownaccounts", nil)
+	fundsMeter      = metrics.NewRegisteredMeter("condpool/evict/funds", nil)
+	lifetimeMeter   = metrics.NewRegisteredMeter("condpool/evict/lifetime", nil)
+	gappedMeter     = metrics.NewRegisteredMeter("condpool/evict/gapped", nil)
+)
@@ -54,4 +54,8 @@ var (
 	// ErrFutureReplacePending is returned if a future transaction replaces a pending
 	// transaction. Future transactions should only be able to replace other future transactions.
 	ErrFutureReplacePending = errors.New("future transaction tries to replace pending")
+
+	// ErrAlreadyReserved is returned if the sender of a transaction is already
+	// tracked by a different subpool.
+	ErrAlreadyReserved = errors.New("address already reserved")
 )
@@ -452,6 +452,85 @@ func (pool *LegacyPool) SetGasTip(tip *big.Int) {
 	log.Info("Legacy pool tip threshold updated", "tip", tip)
 }
 
+// SetSlots updates the executable and non-executable transaction slot limits
+// of the pool. Lowered limits are enforced by the next pool maintenance cycle,
+// which is requested right away.
+func (pool *LegacyPool) SetSlots(accountSlots, globalSlots, accountQueue, globalQueue uint64) {
+	pool.mu.Lock()
+	conf := pool.config
+	conf.AccountSlots, conf.GlobalSlots = accountSlots, globalSlots
+	conf.AccountQueue, conf.GlobalQueue = accountQueue, globalQueue
+	conf = (&conf).sanitize()
+	pool.config = conf
+	pool.mu.Unlock()
+
+	pool.requestPromoteExecutables(newAccountSet(pool.signer))
+
+	log.Info("Legacy pool slot limits updated", "accountslots", conf.AccountSlots, "globalslots", conf.GlobalSlots,
+		"accountqueue", conf.AccountQueue, "globalqueue", conf.GlobalQueue)
+}
+
+// Clear removes all transactions from the pool and returns the number of
+// transactions dropped.
+func (pool *LegacyPool) Clear() int {
+	pool.mu.Lock()
+	defer pool.mu.Unlock()
+
+	accounts := make(map[common.Address]struct{}, len(pool.pending)+len(pool.queue))
+	for addr := range pool.pending {
+		accounts[addr] = struct{}{}
+	}
+
+	for addr := range pool.queue {
+		accounts[addr] = struct{}{}
+	}
+
+	var dropped int
+	for addr := range accounts {
+		dropped += pool.dropAccount(addr)
+	}
+
+	log.Info("Legacy pool cleared", "dropped", dropped)
+
+	return dropped
+}
+
+// DropAccount removes all transactions of the given account from the pool and
+// resets its pool nonce to the nonce of the current state. It returns the
+// number of transactions dropped.
+func (pool *LegacyPool) DropAccount(addr common.Address) int {
+	pool.mu.Lock()
+	defer pool.mu.Unlock()
+
+	dropped := pool.dropAccount(addr)
+	log.Info("Legacy pool account reset", "addr", addr, "dropped", dropped)
+
+	return dropped
+}
+
+// dropAccount removes all transactions of the given account and resets its
+// pool nonce. The caller must hold the pool lock.
+func (pool *LegacyPool) dropAccount(addr common.Address) int {
+	var txs types.Transactions
+	if list := pool.pending[addr]; list != nil {
+		txs = append(txs, list.Flatten()...)
+	}
+
+	if list := pool.queue[addr]; list != nil {
+		txs = append(txs, list.Flatten()...)
+	}
+	// Remove the highest nonces first, so nothing gets shuffled back into the queue
+	sort.Sort(sort.Reverse(types.TxByNonce(txs)))
+
+	for _, tx := range txs {
+		pool.removeTx(tx.Hash(), true, true)
+	}
+
+	pool.pendingNonces.set(addr, pool.currentState.GetNonce(addr))
+
+	return len(txs)
+}
+
 // Nonce returns the next nonce of an account, with all transactions executable
 // by the pool already applied on top.
 func (pool *LegacyPool) Nonce(addr common.Address) uint64 {
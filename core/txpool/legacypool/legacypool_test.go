@@ -990,6 +990,56 @@ func TestGapFilling(t *testing.T) {
 	}
 }
 
+// Tests that dropping an account removes both its pending and queued
+// transactions and resets its pool nonce, and that clearing empties the pool.
+func TestDropAccountAndClear(t *testing.T) {
+	t.Parallel()
+
+	pool, key := setupPool()
+	defer pool.Close()
+
+	account := crypto.PubkeyToAddress(key.PublicKey)
+	testAddBalance(pool, account, big.NewInt(1000000))
+
+	other, _ := crypto.GenerateKey()
+	testAddBalance(pool, crypto.PubkeyToAddress(other.PublicKey), big.NewInt(1000000))
+
+	// Add two executable and one gapped transaction, plus a foreign one
+	for _, tx := range []*types.Transaction{transaction(0, 100000, key), transaction(1, 100000, key), transaction(3, 100000, key), transaction(0, 100000, other)} {
+		if err := pool.addRemoteSync(tx); err != nil {
+			t.Fatalf("failed to add transaction: %v", err)
+		}
+	}
+
+	if pending, queued := pool.Stats(); pending != 3 || queued != 1 {
+		t.Fatalf("pool size mismatch: have %d/%d, want 3/1", pending, queued)
+	}
+
+	if dropped := pool.DropAccount(account); dropped != 3 {
+		t.Fatalf("dropped transaction mismatch: have %d, want 3", dropped)
+	}
+
+	if pending, queued := pool.Stats(); pending != 1 || queued != 0 {
+		t.Fatalf("pool size mismatch: have %d/%d, want 1/0", pending, queued)
+	}
+
+	if nonce := pool.Nonce(account); nonce != 0 {
+		t.Fatalf("account nonce mismatch: have %d, want 0", nonce)
+	}
+
+	if err := validatePoolInternals(pool); err != nil {
+		t.Fatalf("pool internal state corrupted: %v", err)
+	}
+
+	if dropped := pool.Clear(); dropped != 1 {
+		t.Fatalf("cleared transaction mismatch: have %d, want 1", dropped)
+	}
+
+	if pool.all.Count() != 0 {
+		t.Fatalf("pool not empty: %d transactions left", pool.all.Count())
+	}
+}
+
 // Tests that if the transaction count belonging to a single account goes above
 // some threshold, the higher transactions are dropped to prevent DOS attacks.
 func TestQueueAccountLimiting(t *testing.T) {
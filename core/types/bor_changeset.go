@@ -0,0 +1,33 @@
+package types
+
+import (
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// AccountChange is the change of the balance and nonce of an account within a
+// block.
+type AccountChange struct {
+	Address     common.Address
+	PrevBalance *big.Int
+	Balance     *big.Int
+	PrevNonce   uint64
+	Nonce       uint64
+}
+
+// StorageChange is the change of a storage slot of an account within a block.
+type StorageChange struct {
+	Address common.Address
+	Slot    common.Hash
+	Prev    common.Hash
+	Value   common.Hash
+}
+
+// ChangeSet is the set of the account and storage changes of a block, sorted by
+// address and slot. The state-sync credits are included, as they are applied on
+// the block state like the transactions.
+type ChangeSet struct {
+	Accounts []*AccountChange
+	Storage  []*StorageChange
+}
@@ -418,21 +418,55 @@ func (b *EthAPIBackend) UnprotectedAllowed() bool {
 }
 
 func (b *EthAPIBackend) RPCGasCap() uint64 {
+	b.eth.lock.RLock()
+	defer b.eth.lock.RUnlock()
+
 	return b.eth.config.RPCGasCap
 }
 
+// SetRPCGasCap updates the global gas cap applied to eth_call-like methods.
+func (b *EthAPIBackend) SetRPCGasCap(gasCap uint64) {
+	b.eth.lock.Lock()
+	defer b.eth.lock.Unlock()
+
+	b.eth.config.RPCGasCap = gasCap
+}
+
 func (b *EthAPIBackend) RPCRpcReturnDataLimit() uint64 {
 	return b.eth.config.RPCReturnDataLimit
 }
 
 func (b *EthAPIBackend) RPCEVMTimeout() time.Duration {
+	b.eth.lock.RLock()
+	defer b.eth.lock.RUnlock()
+
 	return b.eth.config.RPCEVMTimeout
 }
 
+// SetRPCEVMTimeout updates the global timeout applied to eth_call-like methods.
+func (b *EthAPIBackend) SetRPCEVMTimeout(timeout time.Duration) {
+	b.eth.lock.Lock()
+	defer b.eth.lock.Unlock()
+
+	b.eth.config.RPCEVMTimeout = timeout
+}
+
 func (b *EthAPIBackend) RPCTxFeeCap() float64 {
+	b.eth.lock.RLock()
+	defer b.eth.lock.RUnlock()
+
 	return b.eth.config.RPCTxFeeCap
 }
 
+// SetRPCTxFeeCap updates the global transaction fee cap of send-transaction
+// variants.
+func (b *EthAPIBackend) SetRPCTxFeeCap(feeCap float64) {
+	b.eth.lock.Lock()
+	defer b.eth.lock.Unlock()
+
+	b.eth.config.RPCTxFeeCap = feeCap
+}
+
 func (b *EthAPIBackend) BloomStatus() (uint64, uint64) {
 	sections, _, _ := b.eth.bloomIndexer.Sections()
 	return params.BloomBitsBlocks, sections
@@ -0,0 +1,54 @@
+// Copyright 2024 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package eth
+
+import (
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/txpool/condpool"
+)
+
+// ConditionalPoolAPI provides an API to follow the lifecycle of conditional
+// transactions submitted via bor_sendRawTransactionConditional.
+type ConditionalPoolAPI struct {
+	e *Ethereum
+}
+
+// NewConditionalPoolAPI creates a new ConditionalPoolAPI instance.
+func NewConditionalPoolAPI(e *Ethereum) *ConditionalPoolAPI {
+	return &ConditionalPoolAPI{e}
+}
+
+// GetConditionalTransactionStatus returns the status of a conditional transaction,
+// including the reason if it was evicted because it can never become valid.
+func (api *ConditionalPoolAPI) GetConditionalTransactionStatus(hash common.Hash) *condpool.Report {
+	return api.e.condPool.Inspect(hash)
+}
+
+// BundlerQuota is the number of conditional transaction slots used and allowed
+// for a bundler.
+type BundlerQuota struct {
+	Used  hexutil.Uint64 `json:"used"`
+	Limit hexutil.Uint64 `json:"limit"`
+}
+
+// GetBundlerQuota returns the conditional transaction slots used and allowed for
+// the given bundler.
+func (api *ConditionalPoolAPI) GetBundlerQuota(bundler common.Address) *BundlerQuota {
+	used, limit := api.e.condPool.Quota(bundler)
+	return &BundlerQuota{Used: hexutil.Uint64(used), Limit: hexutil.Uint64(limit)}
+}
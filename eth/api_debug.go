@@ -432,3 +432,20 @@ func (api *DebugAPI) SetTrieFlushInterval(interval string) error {
 func (api *DebugAPI) GetTrieFlushInterval() string {
 	return api.eth.blockchain.GetTrieFlushInterval().String()
 }
+
+// TxPropagation returns how a recently seen transaction reached the node, was
+// propagated to the peers and was included in the chain.
+func (api *DebugAPI) TxPropagation(hash common.Hash) (*TxPropagation, error) {
+	rec, ok := api.eth.handler.txProp.transaction(hash)
+	if !ok {
+		return nil, fmt.Errorf("transaction %#x not tracked", hash)
+	}
+
+	return rec, nil
+}
+
+// TxPropagationPeers returns the transaction propagation statistics of the
+// connected peers.
+func (api *DebugAPI) TxPropagationPeers() map[string]*TxPeerPropagation {
+	return api.eth.handler.txProp.peerStats()
+}
@@ -39,6 +39,7 @@ import (
 	"github.com/ethereum/go-ethereum/core/rawdb"
 	"github.com/ethereum/go-ethereum/core/state/pruner"
 	"github.com/ethereum/go-ethereum/core/txpool"
+	"github.com/ethereum/go-ethereum/core/txpool/condpool"
 	"github.com/ethereum/go-ethereum/core/txpool/legacypool"
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/core/vm"
@@ -47,6 +48,7 @@ import (
 	"github.com/ethereum/go-ethereum/eth/ethconfig"
 	"github.com/ethereum/go-ethereum/eth/filters"
 	"github.com/ethereum/go-ethereum/eth/gasprice"
+	borproto "github.com/ethereum/go-ethereum/eth/protocols/bor"
 	"github.com/ethereum/go-ethereum/eth/protocols/eth"
 	"github.com/ethereum/go-ethereum/eth/protocols/snap"
 	"github.com/ethereum/go-ethereum/ethdb"
@@ -74,7 +76,9 @@ type Ethereum struct {
 	config *ethconfig.Config
 
 	// Handlers
-	txPool *txpool.TxPool
+	txPool     *txpool.TxPool
+	legacyPool *legacypool.LegacyPool
+	condPool   *condpool.ConditionalPool
 
 	blockchain         *core.BlockChain
 	handler            *handler
@@ -94,6 +98,9 @@ type Ethereum struct {
 	bloomIndexer      *core.ChainIndexer             // Bloom indexer operating during block imports
 	closeBloomHandler chan struct{}
 
+	borBloomRequests chan chan *bloombits.Retrieval // Channel receiving bor bloom data retrieval requests
+	borBloomIndexer  *core.ChainIndexer             // Bor receipt bloom indexer, nil if not a bor chain
+
 	APIBackend *EthAPIBackend
 
 	miner     *miner.Miner
@@ -198,6 +205,16 @@ func New(stack *node.Node, config *ethconfig.Config) (*Ethereum, error) {
 	if err != nil {
 		return nil, err
 	}
+
+	if config.BorTrustedCheckpoint != nil {
+		borEngine, ok := engine.(*bor.Bor)
+		if !ok {
+			return nil, errors.New("trusted checkpoint requires bor consensus")
+		}
+
+		borEngine.SetTrustedCheckpoint(config.BorTrustedCheckpoint)
+		log.Info("Bootstrapping from trusted checkpoint", "checkpoint", config.BorTrustedCheckpoint)
+	}
 	// END: Bor changes
 
 	bcVersion := rawdb.ReadDatabaseVersion(chainDb)
@@ -229,9 +246,17 @@ func New(stack *node.Node, config *ethconfig.Config) (*Ethereum, error) {
 			TrieTimeLimit:       config.TrieTimeout,
 			SnapshotLimit:       config.SnapshotCache,
 			Preimages:           config.Preimages,
+			BorReceiptLimit:     config.BorReceiptLimit,
+			ChangeSets:          config.ChangeSets,
 		}
 	)
 
+	if config.BorReceiptLimit != 0 && config.TxLookupLimit == 0 {
+		log.Warn("Bor receipts never expire without a transaction index limit", "borreceiptlimit", config.BorReceiptLimit)
+	} else if config.BorReceiptLimit != 0 && config.BorReceiptLimit < config.TxLookupLimit {
+		log.Warn("Bor receipts are retained at least as long as the transaction index", "borreceiptlimit", config.BorReceiptLimit, "txlookuplimit", config.TxLookupLimit)
+	}
+
 	checker := whitelist.NewService(chainDb)
 
 	// check if Parallel EVM is enabled
@@ -255,6 +280,13 @@ func New(stack *node.Node, config *ethconfig.Config) (*Ethereum, error) {
 
 	eth.bloomIndexer.Start(eth.blockchain)
 
+	// BOR: index the bor receipt blooms, not covered by the header ones
+	if borConfig := eth.blockchain.Config().Bor; borConfig != nil {
+		eth.borBloomRequests = make(chan chan *bloombits.Retrieval)
+		eth.borBloomIndexer = core.NewBorBloomIndexer(chainDb, borConfig, params.BloomBitsBlocks, params.BloomConfirms)
+		eth.borBloomIndexer.Start(eth.blockchain)
+	}
+
 	if config.BlobPool.Datadir != "" {
 		config.BlobPool.Datadir = stack.ResolvePath(config.BlobPool.Datadir)
 	}
@@ -262,9 +294,13 @@ func New(stack *node.Node, config *ethconfig.Config) (*Ethereum, error) {
 	if config.TxPool.Journal != "" {
 		config.TxPool.Journal = stack.ResolvePath(config.TxPool.Journal)
 	}
-	legacyPool := legacypool.New(config.TxPool, eth.blockchain)
+	eth.legacyPool = legacypool.New(config.TxPool, eth.blockchain)
+
+	// Conditional transactions need to be picked up before the legacy pool
+	// would accept them as plain transactions.
+	eth.condPool = condpool.New(config.CondPool, eth.blockchain)
 
-	eth.txPool, err = txpool.New(new(big.Int).SetUint64(config.TxPool.PriceLimit), eth.blockchain, []txpool.SubPool{legacyPool})
+	eth.txPool, err = txpool.New(new(big.Int).SetUint64(config.TxPool.PriceLimit), eth.blockchain, []txpool.SubPool{eth.condPool, eth.legacyPool})
 	if err != nil {
 		return nil, err
 	}
@@ -283,10 +319,36 @@ func New(stack *node.Node, config *ethconfig.Config) (*Ethereum, error) {
 		EthAPI:         blockChainAPI,
 		checker:        checker,
 		txArrivalWait:  eth.p2pServer.TxArrivalWait,
+		topologyMode:   eth.p2pServer.TopologyMode,
 	}); err != nil {
 		return nil, err
 	}
 
+	if config.BorTrustedCheckpoint != nil {
+		if err := bor.VerifyTrustedCheckpoint(eth.blockchain, config.BorTrustedCheckpoint); err != nil {
+			return nil, err
+		}
+
+		eth.handler.downloader.SetTrustedCheckpoint(config.BorTrustedCheckpoint)
+	}
+
+	if borEngine, ok := eth.engine.(*bor.Bor); ok && borEngine.HeimdallClient != nil {
+		// Anchor the snap sync pivot to the latest milestone until one is whitelisted
+		eth.handler.downloader.SetPivotAnchor(func(ctx context.Context) (uint64, common.Hash, error) {
+			milestone, err := borEngine.HeimdallClient.FetchMilestone(ctx)
+			if err != nil {
+				return 0, common.Hash{}, err
+			}
+
+			return milestone.EndBlock.Uint64(), milestone.Hash, nil
+		})
+
+		// Establish the validator set at the pivot from the Heimdall spans
+		eth.handler.downloader.SetSnapshotSeeder(func(ctx context.Context, pivot *types.Header) error {
+			return borEngine.SeedSnapshot(ctx, eth.blockchain, pivot)
+		})
+	}
+
 	eth.miner = miner.New(eth, &config.Miner, eth.blockchain.Config(), eth.EventMux(), eth.engine, eth.isLocalBlock)
 	eth.miner.SetExtra(makeExtraData(config.Miner.ExtraData))
 
@@ -384,6 +446,12 @@ func (s *Ethereum) APIs() []rpc.API {
 		}, {
 			Namespace: "net",
 			Service:   s.netRPCService,
+		}, {
+			Namespace: "bor",
+			Service:   NewConditionalPoolAPI(s),
+		}, {
+			Namespace: "bor",
+			Service:   filters.NewStateSyncAPI(filterSystem),
 		},
 	}...)
 }
@@ -559,6 +627,12 @@ func (s *Ethereum) BlockChain() *core.BlockChain      { return s.blockchain }
 func (s *Ethereum) TxPool() *txpool.TxPool            { return s.txPool }
 func (s *Ethereum) EventMux() *event.TypeMux          { return s.eventMux }
 func (s *Ethereum) Engine() consensus.Engine          { return s.engine }
+func (s *Ethereum) LegacyPool() *legacypool.LegacyPool {
+	return s.legacyPool
+}
+func (s *Ethereum) ConditionalPool() *condpool.ConditionalPool {
+	return s.condPool
+}
 func (s *Ethereum) ChainDb() ethdb.Database {
 	return s.chainDb
 }
@@ -574,6 +648,23 @@ func (s *Ethereum) SyncMode() downloader.SyncMode {
 	return mode
 }
 
+// BackfillBorReceipts retrieves the missing bor receipts of the canonical
+// blocks in [from, to] from the connected peers, returning the number of bor
+// receipts written.
+func (s *Ethereum) BackfillBorReceipts(ctx context.Context, from uint64, to uint64) (int, error) {
+	return (*borSyncHandler)(s.handler).BackfillBorReceipts(ctx, from, to)
+}
+
+// PeerRating returns the rating of a connected `eth` peer, lowered by its
+// misbehaviour and latency.
+func (s *Ethereum) PeerRating(id string) (int, bool) {
+	if p := s.handler.peers.peer(id); p != nil {
+		return p.rating(), true
+	}
+
+	return 0, false
+}
+
 // SetAuthorized sets the authorized bool variable
 // denoting that consensus has been authorized while creation
 func (s *Ethereum) SetAuthorized(authorized bool) {
@@ -590,6 +681,10 @@ func (s *Ethereum) Protocols() []p2p.Protocol {
 		protos = append(protos, snap.MakeProtocols((*snapHandler)(s.handler), s.snapDialCandidates)...)
 	}
 
+	if s.blockchain.Config().Bor != nil {
+		protos = append(protos, borproto.MakeProtocols((*borSyncHandler)(s.handler))...)
+	}
+
 	return protos
 }
 
@@ -618,6 +713,9 @@ func (s *Ethereum) Start() error {
 	// Start the networking layer and the light server if requested
 	s.handler.Start(maxPeers)
 
+	// Resume an interrupted bor receipt reindex
+	s.blockchain.ResumeBorReindex()
+
 	go s.startCheckpointWhitelistService()
 	go s.startMilestoneWhitelistService()
 	go s.startNoAckMilestoneService()
@@ -825,6 +923,11 @@ func (s *Ethereum) Stop() error {
 
 	// Then stop everything else.
 	s.bloomIndexer.Close()
+
+	if s.borBloomIndexer != nil {
+		s.borBloomIndexer.Close()
+	}
+
 	close(s.closeBloomHandler)
 
 	// Close all bg processes
@@ -19,8 +19,11 @@ package eth
 import (
 	"time"
 
+	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/common/bitutil"
+	"github.com/ethereum/go-ethereum/core/bloombits"
 	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/ethdb"
 )
 
 const (
@@ -44,6 +47,17 @@ const (
 // startBloomHandlers starts a batch of goroutines to accept bloom bit database
 // retrievals from possibly a range of filters and serving the data to satisfy.
 func (eth *Ethereum) startBloomHandlers(sectionSize uint64) {
+	eth.serveBloomRequests(eth.bloomRequests, sectionSize, rawdb.ReadBloomBits)
+
+	// BOR: the bor receipts have a bloom bits index of their own
+	if eth.borBloomIndexer != nil {
+		eth.serveBloomRequests(eth.borBloomRequests, sectionSize, rawdb.ReadBorBloomBits)
+	}
+}
+
+// serveBloomRequests starts the goroutines serving the bloom bit retrievals
+// received on requests from the given bloom bits index.
+func (eth *Ethereum) serveBloomRequests(requests chan chan *bloombits.Retrieval, sectionSize uint64, readBloomBits func(ethdb.KeyValueReader, uint, uint64, common.Hash) ([]byte, error)) {
 	for i := 0; i < bloomServiceThreads; i++ {
 		go func() {
 			for {
@@ -51,13 +65,13 @@ func (eth *Ethereum) startBloomHandlers(sectionSize uint64) {
 				case <-eth.closeBloomHandler:
 					return
 
-				case request := <-eth.bloomRequests:
+				case request := <-requests:
 					task := <-request
 					task.Bitsets = make([][]byte, len(task.Sections))
 
 					for i, section := range task.Sections {
 						head := rawdb.ReadCanonicalHash(eth.chainDb, (section+1)*sectionSize-1)
-						if compVector, err := rawdb.ReadBloomBits(eth.chainDb, task.Bit, section, head); err == nil {
+						if compVector, err := readBloomBits(eth.chainDb, task.Bit, section, head); err == nil {
 							if blob, err := bitutil.DecompressBytes(compVector, int(sectionSize/8)); err == nil {
 								task.Bitsets[i] = blob
 							} else {
@@ -9,9 +9,11 @@ import (
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/consensus/bor"
 	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/core/bloombits"
 	"github.com/ethereum/go-ethereum/core/rawdb"
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/event"
+	"github.com/ethereum/go-ethereum/params"
 	"github.com/ethereum/go-ethereum/rpc"
 )
 
@@ -123,6 +125,26 @@ func (b *EthAPIBackend) GetBorBlockLogs(ctx context.Context, hash common.Hash) (
 	return receipt.Logs, nil
 }
 
+// BorBloomStatus returns the section size and the number of sections of the bor
+// receipt bloom bits index.
+func (b *EthAPIBackend) BorBloomStatus() (uint64, uint64) {
+	if b.eth.borBloomIndexer == nil {
+		return params.BloomBitsBlocks, 0
+	}
+
+	sections, _, _ := b.eth.borBloomIndexer.Sections()
+
+	return params.BloomBitsBlocks, sections
+}
+
+// ServiceBorFilter serves the bloom bits retrievals of a bor logs matcher session
+// from the bor receipt bloom bits index.
+func (b *EthAPIBackend) ServiceBorFilter(ctx context.Context, session *bloombits.MatcherSession) {
+	for i := 0; i < bloomFilterThreads; i++ {
+		go session.Multiplex(bloomRetrievalBatch, bloomRetrievalWait, b.eth.borBloomRequests)
+	}
+}
+
 // GetBorBlockTransaction returns bor block tx
 func (b *EthAPIBackend) GetBorBlockTransaction(ctx context.Context, hash common.Hash) (*types.Transaction, common.Hash, uint64, uint64, error) {
 	tx, blockHash, blockNumber, index := rawdb.ReadBorTransaction(b.eth.ChainDb(), hash)
@@ -0,0 +1,312 @@
+package downloader
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/log"
+)
+
+// pivotAnchorTimeout is the time allowed to retrieve the latest finalized
+// block from Heimdall.
+const pivotAnchorTimeout = 10 * time.Second
+
+var (
+	// errNoFinalizedBlock is returned if the snap sync pivot can't be anchored
+	// because no finalized block is known yet.
+	errNoFinalizedBlock = errors.New("no finalized block to anchor the pivot to")
+
+	// errStaleFinalizedBlock is returned if the latest finalized block is too
+	// far below the head for its state to be served by the network.
+	errStaleFinalizedBlock = errors.New("finalized block too old to anchor the pivot to")
+)
+
+// BorReceiptFetcher retrieves the bor receipts (state-sync transactions) of the
+// blocks imported by snap sync. They are not committed to by the headers, so
+// they have to be retrieved separately from the network.
+type BorReceiptFetcher interface {
+	// FetchBorReceipts returns the bor receipts of the given headers keyed by
+	// block hash. Blocks without a bor receipt are omitted.
+	FetchBorReceipts(headers []*types.Header) (map[common.Hash]*types.ReceiptForStorage, error)
+}
+
+// TrustedCheckpoint is a checkpoint of finalized blocks trusted by the operator,
+// which the headers synced from a peer have to contain.
+type TrustedCheckpoint interface {
+	// Bounds returns the block range of the checkpoint and the hash of its
+	// last block.
+	Bounds() (start uint64, end uint64, hash common.Hash)
+
+	// VerifyHeaders checks the headers of the checkpoint range against it.
+	VerifyHeaders(headers []*types.Header) error
+}
+
+// PivotAnchorFn retrieves the number and hash of the latest finalized block,
+// which is used to anchor the snap sync pivot when nothing is whitelisted yet.
+type PivotAnchorFn func(ctx context.Context) (uint64, common.Hash, error)
+
+// SnapshotSeedFn establishes the consensus snapshot at the snap sync pivot, so
+// the blocks above it are verified without gathering the headers back to
+// genesis.
+type SnapshotSeedFn func(ctx context.Context, pivot *types.Header) error
+
+// SetBorReceiptFetcher sets the retriever of the bor receipts of snap synced
+// blocks. It must be set before syncing starts.
+func (d *Downloader) SetBorReceiptFetcher(fetcher BorReceiptFetcher) {
+	d.borReceipts = fetcher
+}
+
+// SetPivotAnchor sets the retriever of the latest finalized block. It must be
+// set before syncing starts.
+func (d *Downloader) SetPivotAnchor(fn PivotAnchorFn) {
+	d.pivotAnchor = fn
+}
+
+// SetSnapshotSeeder sets the function establishing the consensus snapshot at
+// the snap sync pivot. It must be set before syncing starts.
+func (d *Downloader) SetSnapshotSeeder(fn SnapshotSeedFn) {
+	d.snapshotSeeder = fn
+}
+
+// SetTrustedCheckpoint sets the checkpoint snap sync bootstraps from. It must
+// be set before syncing starts.
+func (d *Downloader) SetTrustedCheckpoint(checkpoint TrustedCheckpoint) {
+	d.trustedCheckpoint = checkpoint
+}
+
+// verifyTrustedCheckpoint retrieves the headers of the trusted checkpoint range
+// from the peer and verifies them against the checkpoint, unless the local
+// chain already contains it.
+func (d *Downloader) verifyTrustedCheckpoint(p *peerConnection, latest *types.Header) error {
+	start, end, _ := d.trustedCheckpoint.Bounds()
+	if d.blockchain.CurrentSnapBlock().Number.Uint64() >= end {
+		return nil
+	}
+
+	if end > latest.Number.Uint64() {
+		return fmt.Errorf("%w: head %d below trusted checkpoint %d", errUnsyncedPeer, latest.Number, end)
+	}
+
+	headers := make([]*types.Header, 0, end-start+1)
+
+	for from := start; from <= end; {
+		count := MaxHeaderFetch
+		if remaining := end - from + 1; remaining < uint64(count) {
+			count = int(remaining)
+		}
+
+		batch, _, err := d.fetchHeadersByNumber(p, from, count, 0, false)
+		if err != nil {
+			return err
+		}
+
+		if len(batch) == 0 {
+			return fmt.Errorf("%w: no trusted checkpoint headers from %d", errBadPeer, from)
+		}
+
+		headers = append(headers, batch...)
+		from += uint64(len(batch))
+	}
+
+	if err := d.trustedCheckpoint.VerifyHeaders(headers); err != nil {
+		return fmt.Errorf("%w: %v", errInvalidChain, err)
+	}
+
+	log.Info("Verified trusted checkpoint", "start", start, "end", end, "peer", p.id)
+
+	return nil
+}
+
+// writeBorReceipts retrieves the bor receipts of the given blocks and stores
+// them ahead of the blocks themselves, so the blockchain moves them along with
+// the block data into the ancient store and indexes them. They are marked as
+// unverified, so they are not served until derived locally.
+func (d *Downloader) writeBorReceipts(blocks []*types.Block) error {
+	if d.borReceipts == nil || len(blocks) == 0 {
+		return nil
+	}
+
+	headers := make([]*types.Header, len(blocks))
+	for i, block := range blocks {
+		headers[i] = block.Header()
+	}
+
+	receipts, err := d.borReceipts.FetchBorReceipts(headers)
+	if err != nil {
+		return fmt.Errorf("failed to retrieve bor receipts: %w", err)
+	}
+
+	if len(receipts) == 0 {
+		return nil
+	}
+
+	var (
+		batch = d.stateDB.NewBatch()
+		first *uint64
+	)
+
+	for _, block := range blocks {
+		if receipt, ok := receipts[block.Hash()]; ok {
+			rawdb.WriteBorReceipt(batch, block.Hash(), block.NumberU64(), receipt)
+			rawdb.WriteBorReceiptUnverified(batch, block.Hash(), block.NumberU64())
+
+			if first == nil || block.NumberU64() < *first {
+				number := block.NumberU64()
+				first = &number
+			}
+		}
+	}
+
+	if err := batch.Write(); err != nil {
+		return err
+	}
+
+	// The blocks may have been indexed without their bor receipts already
+	if first != nil {
+		rawdb.MarkBorBloomStale(d.stateDB, *first)
+	}
+
+	return nil
+}
+
+// finalizedBlock returns the latest finalized block known to the node: the
+// latest whitelisted milestone or checkpoint, or failing that, the latest
+// finalized block reported by the pivot anchor or the trusted checkpoint.
+func (d *Downloader) finalizedBlock() (uint64, common.Hash, bool) {
+	var (
+		number uint64
+		hash   common.Hash
+		found  bool
+	)
+
+	if d.ChainValidator != nil {
+		if ok, n, h := d.GetWhitelistedMilestone(); ok {
+			number, hash, found = n, h, true
+		}
+
+		if ok, n, h := d.GetWhitelistedCheckpoint(); ok && (!found || n > number) {
+			number, hash, found = n, h, true
+		}
+	}
+
+	if !found && d.pivotAnchor != nil {
+		ctx, cancel := context.WithTimeout(context.Background(), pivotAnchorTimeout)
+		defer cancel()
+
+		if n, h, err := d.pivotAnchor(ctx); err != nil {
+			log.Warn("Failed to retrieve finalized block for snap sync pivot", "err", err)
+		} else {
+			number, hash, found = n, h, true
+		}
+	}
+
+	if !found && d.trustedCheckpoint != nil {
+		_, number, hash = d.trustedCheckpoint.Bounds()
+		found = true
+	}
+
+	return number, hash, found
+}
+
+// fetchFinalizedHeader retrieves the header of a finalized block from the peer,
+// which has to match the finalized hash.
+func (d *Downloader) fetchFinalizedHeader(p *peerConnection, number uint64, hash common.Hash) (*types.Header, error) {
+	headers, hashes, err := d.fetchHeadersByNumber(p, number, 1, 0, false)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(headers) != 1 || headers[0].Number.Uint64() != number {
+		return nil, fmt.Errorf("%w: finalized header %d not returned", errBadPeer, number)
+	}
+
+	if hashes[0] != hash {
+		return nil, fmt.Errorf("%w: finalized block %d mismatch: have %x, want %x", errInvalidChain, number, hashes[0], hash)
+	}
+
+	return headers[0], nil
+}
+
+// anchorPivot ties the snap sync pivot to the latest finalized block. The peer
+// has to agree on the finalized block, otherwise it's on a different fork. If
+// the finalized block is below the pivot by at most fsMinFullBlocks, the pivot
+// is moved onto it, so the synced state is final. The sync cycle fails if no
+// finalized block is known or it's older than that, as the pivot would not be
+// anchored. A pivot below the trusted checkpoint is moved onto its end block.
+// The consensus snapshot is then seeded at the pivot.
+func (d *Downloader) anchorPivot(p *peerConnection, latest *types.Header, pivot *types.Header) (*types.Header, error) {
+	if d.trustedCheckpoint != nil {
+		if err := d.verifyTrustedCheckpoint(p, latest); err != nil {
+			return nil, err
+		}
+	}
+
+	// Only Bor nodes backed by Heimdall or a trusted checkpoint know about
+	// finalized blocks to anchor the pivot to
+	if d.pivotAnchor == nil && d.trustedCheckpoint == nil {
+		return pivot, nil
+	}
+
+	number, hash, ok := d.finalizedBlock()
+	if !ok || number == 0 {
+		return nil, errNoFinalizedBlock
+	}
+
+	if number > latest.Number.Uint64() {
+		return nil, fmt.Errorf("%w: head %d below finalized block %d", errUnsyncedPeer, latest.Number, number)
+	}
+
+	finalized, err := d.fetchFinalizedHeader(p, number, hash)
+	if err != nil {
+		return nil, err
+	}
+
+	pivotNumber := pivot.Number.Uint64()
+
+	switch {
+	case number >= pivotNumber:
+		log.Debug("Snap sync pivot is finalized", "pivot", pivotNumber, "finalized", number)
+	case pivotNumber-number <= uint64(fsMinFullBlocks):
+		log.Info("Anchored snap sync pivot to finalized block", "number", number, "hash", hash, "pivot", pivotNumber)
+		pivot = finalized
+	default:
+		return nil, fmt.Errorf("%w: finalized block %d, pivot %d", errStaleFinalizedBlock, number, pivotNumber)
+	}
+
+	// The blocks up to the trusted checkpoint are not fully verified, so the
+	// pivot can't be below it
+	if d.trustedCheckpoint != nil {
+		if _, end, hash := d.trustedCheckpoint.Bounds(); pivot.Number.Uint64() < end {
+			if pivot, err = d.fetchFinalizedHeader(p, end, hash); err != nil {
+				return nil, err
+			}
+
+			log.Info("Anchored snap sync pivot to trusted checkpoint", "number", end, "hash", hash)
+		}
+	}
+
+	if d.snapshotSeeder != nil {
+		// Seeding may take a while, abort it if the sync is cancelled
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		go func() {
+			select {
+			case <-d.cancelCh:
+				cancel()
+			case <-ctx.Done():
+			}
+		}()
+
+		if err := d.snapshotSeeder(ctx, pivot); err != nil {
+			return nil, fmt.Errorf("failed to seed snapshot at pivot %d: %w", pivot.Number, err)
+		}
+	}
+
+	return pivot, nil
+}
@@ -0,0 +1,45 @@
+package downloader
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// Tests that the sync cycle fails instead of continuing with an unanchored
+// pivot if no finalized block is known.
+func TestAnchorPivotWithoutFinalizedBlock(t *testing.T) {
+	d := &Downloader{}
+	d.SetPivotAnchor(func(context.Context) (uint64, common.Hash, error) {
+		return 0, common.Hash{}, errors.New("heimdall unreachable")
+	})
+
+	latest := &types.Header{Number: big.NewInt(1000)}
+	pivot := &types.Header{Number: big.NewInt(int64(1000 - fsMinFullBlocks))}
+
+	if _, err := d.anchorPivot(nil, latest, pivot); !errors.Is(err, errNoFinalizedBlock) {
+		t.Fatalf("anchor error mismatch: have %v, want %v", err, errNoFinalizedBlock)
+	}
+}
+
+// Tests that the pivot of chains without finality, which have a whitelist
+// service but neither Heimdall nor a trusted checkpoint, is used as is.
+func TestAnchorPivotWithoutFinality(t *testing.T) {
+	d := &Downloader{ChainValidator: newWhitelistFake(nil)}
+
+	latest := &types.Header{Number: big.NewInt(1000)}
+	pivot := &types.Header{Number: big.NewInt(int64(1000 - fsMinFullBlocks))}
+
+	anchored, err := d.anchorPivot(nil, latest, pivot)
+	if err != nil {
+		t.Fatalf("failed to anchor pivot: %v", err)
+	}
+
+	if anchored != pivot {
+		t.Fatalf("pivot mismatch: have %d, want %d", anchored.Number, pivot.Number)
+	}
+}
@@ -149,6 +149,13 @@ type Downloader struct {
 
 	ethereum.ChainValidator
 
+	// Bor snap sync
+	borReceipts    BorReceiptFetcher // Retriever of the bor receipts of snap synced blocks (nil if not Bor)
+	pivotAnchor    PivotAnchorFn     // Retriever of the latest finalized block to anchor the pivot to
+	snapshotSeeder SnapshotSeedFn    // Establishes the consensus snapshot at the pivot (nil if not Bor)
+
+	trustedCheckpoint TrustedCheckpoint // Checkpoint the synced chain has to contain (nil if none)
+
 	// Testing hooks
 	syncInitHook     func(uint64, uint64)  // Method to call upon initiating a new sync run
 	bodyFetchHook    func([]*types.Header) // Method to call upon starting a block body fetch
@@ -519,6 +526,12 @@ func (d *Downloader) syncWithPeer(p *peerConnection, hash common.Hash, td, ttd *
 		if err != nil {
 			return err
 		}
+
+		if mode == SnapSync && pivot != nil {
+			if pivot, err = d.anchorPivot(p, latest, pivot); err != nil {
+				return err
+			}
+		}
 	} else {
 		// In beacon mode, use the skeleton chain to retrieve the headers from
 		latest, _, final, err = d.skeleton.Bounds()
@@ -1922,6 +1935,10 @@ func (d *Downloader) commitSnapSyncData(results []*fetchResult, stateSync *state
 		receipts[i] = result.Receipts
 	}
 
+	if err := d.writeBorReceipts(blocks); err != nil {
+		return err
+	}
+
 	if index, err := d.blockchain.InsertReceiptChain(blocks, receipts, d.ancientLimit); err != nil {
 		log.Debug("Downloaded item processing failed", "number", results[index].Header.Number, "hash", results[index].Header.Hash(), "err", err)
 		return fmt.Errorf("%w: %v", errInvalidChain, err)
@@ -1935,6 +1952,10 @@ func (d *Downloader) commitPivotBlock(result *fetchResult) error {
 	log.Debug("Committing snap sync pivot as new head", "number", block.Number(), "hash", block.Hash())
 
 	// Commit the pivot block as the new head, will require full sync from here on
+	if err := d.writeBorReceipts([]*types.Block{block}); err != nil {
+		return err
+	}
+
 	if _, err := d.blockchain.InsertReceiptChain([]*types.Block{block}, []types.Receipts{result.Receipts}, d.ancientLimit); err != nil {
 		return err
 	}
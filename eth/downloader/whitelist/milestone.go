@@ -26,6 +26,7 @@ type milestoneService interface {
 	finalityService
 
 	GetMilestoneIDsList() []string
+	GetLockedMilestone() (bool, uint64, common.Hash)
 	RemoveMilestoneID(milestoneId string)
 	LockMutex(endBlockNum uint64) bool
 	UnlockMutex(doLock bool, milestoneId string, endBlockNum uint64, endBlockHash common.Hash)
@@ -235,6 +236,15 @@ func (m *milestone) GetMilestoneIDsList() []string {
 	return keys
 }
 
+// GetLockedMilestone returns whether a sprint is currently locked for a
+// milestone vote along with the locked end block number and hash.
+func (m *milestone) GetLockedMilestone() (bool, uint64, common.Hash) {
+	m.finality.RLock()
+	defer m.finality.RUnlock()
+
+	return m.Locked, m.LockedMilestoneNumber, m.LockedMilestoneHash
+}
+
 // This is remove the milestoneIDs stored in the list.
 func (m *milestone) purgeMilestoneIDsList() {
 	m.LockedMilestoneIDs = make(map[string]struct{})
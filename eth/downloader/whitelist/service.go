@@ -141,6 +141,10 @@ func (s *Service) GetMilestoneIDsList() []string {
 	return s.milestoneService.GetMilestoneIDsList()
 }
 
+func (s *Service) GetLockedMilestone() (bool, uint64, common.Hash) {
+	return s.milestoneService.GetLockedMilestone()
+}
+
 func splitChain(current uint64, chain []*types.Header) ([]*types.Header, []*types.Header) {
 	var (
 		pastChain   []*types.Header
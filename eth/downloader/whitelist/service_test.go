@@ -162,6 +162,10 @@ func TestMilestone(t *testing.T) {
 	require.True(t, milestone.Locked, "expected true as final confirmation regarding the lock has been made")
 	require.Equal(t, len(milestone.LockedMilestoneIDs), 1, "expected 1 as previous milestonesIDs has been removed in previous step")
 
+	isLocked, lockedNumber, _ := s.GetLockedMilestone()
+	require.True(t, isLocked, "expected true as the sprint is locked")
+	require.Equal(t, lockedNumber, uint64(15), "Expected 15")
+
 	//Adding the milestone
 	s.ProcessMilestone(11, common.Hash{})
 
@@ -35,11 +35,13 @@ import (
 	"github.com/ethereum/go-ethereum/consensus/ethash"
 	"github.com/ethereum/go-ethereum/core"
 	"github.com/ethereum/go-ethereum/core/txpool/blobpool"
+	"github.com/ethereum/go-ethereum/core/txpool/condpool"
 	"github.com/ethereum/go-ethereum/core/txpool/legacypool"
 	"github.com/ethereum/go-ethereum/eth/downloader"
 	"github.com/ethereum/go-ethereum/eth/gasprice"
 	"github.com/ethereum/go-ethereum/ethdb"
 	"github.com/ethereum/go-ethereum/internal/ethapi"
+	"github.com/ethereum/go-ethereum/internal/grpcauth"
 	"github.com/ethereum/go-ethereum/log"
 	"github.com/ethereum/go-ethereum/miner"
 	"github.com/ethereum/go-ethereum/params"
@@ -80,6 +82,7 @@ var Defaults = Config{
 	Miner:              miner.DefaultConfig,
 	TxPool:             legacypool.DefaultConfig,
 	BlobPool:           blobpool.DefaultConfig,
+	CondPool:           condpool.DefaultConfig,
 	RPCGasCap:          50000000,
 	RPCEVMTimeout:      5 * time.Second,
 	GPO:                FullNodeGPO,
@@ -106,7 +109,10 @@ type Config struct {
 	NoPruning  bool // Whether to disable pruning and flush everything to disk
 	NoPrefetch bool // Whether to disable prefetching and only load state on demand
 
-	TxLookupLimit uint64 `toml:",omitempty"` // The maximum number of blocks from head whose tx indices are reserved.
+	TxLookupLimit   uint64 `toml:",omitempty"` // The maximum number of blocks from head whose tx indices are reserved.
+	BorReceiptLimit uint64 `toml:",omitempty"` // The maximum number of blocks from head whose bor receipts are reserved, never below TxLookupLimit. Ignored if TxLookupLimit is 0.
+
+	ChangeSets bool `toml:",omitempty"` // Whether to index the account and storage changes of the imported blocks
 
 	// RequiredBlocks is a set of block number -> hash mappings which must be in the
 	// canonical chain of all remote peers. Setting the option makes geth verify the
@@ -149,6 +155,7 @@ type Config struct {
 	// Transaction pool options
 	TxPool   legacypool.Config
 	BlobPool blobpool.Config
+	CondPool condpool.Config
 
 	// Gas Price Oracle options
 	GPO gasprice.Config
@@ -184,6 +191,14 @@ type Config struct {
 	// Address to connect to Heimdall gRPC server
 	HeimdallgRPCAddress string
 
+	// TLS client certificate, key and CA bundle for the Heimdall gRPC server
+	HeimdallgRPCTLSCert string
+	HeimdallgRPCTLSKey  string
+	HeimdallgRPCTLSCA   string
+
+	// Bearer token sent to the Heimdall gRPC server
+	HeimdallgRPCToken string
+
 	// Run heimdall service as a child process
 	RunHeimdall bool
 
@@ -196,6 +211,10 @@ type Config struct {
 	// Bor logs flag
 	BorLogs bool
 
+	// Heimdall checkpoint to bootstrap the chain from, headers up to its end
+	// block are anchored to it instead of being verified
+	BorTrustedCheckpoint *bor.TrustedCheckpoint `toml:",omitempty"`
+
 	// Parallel EVM (Block-STM) related config
 	ParallelEVM core.ParallelEVMConfig `toml:",omitempty"`
 
@@ -229,7 +248,11 @@ func CreateConsensusEngine(chainConfig *params.ChainConfig, ethConfig *Config, d
 			if ethConfig.RunHeimdall && ethConfig.UseHeimdallApp {
 				heimdallClient = heimdallapp.NewHeimdallAppClient()
 			} else if ethConfig.HeimdallgRPCAddress != "" {
-				heimdallClient = heimdallgrpc.NewHeimdallGRPCClient(ethConfig.HeimdallgRPCAddress)
+				heimdallClient = heimdallgrpc.NewHeimdallGRPCClient(ethConfig.HeimdallgRPCAddress, &grpcauth.TLSConfig{
+					CertFile: ethConfig.HeimdallgRPCTLSCert,
+					KeyFile:  ethConfig.HeimdallgRPCTLSKey,
+					CAFile:   ethConfig.HeimdallgRPCTLSCA,
+				}, ethConfig.HeimdallgRPCToken)
 			} else {
 				heimdallClient = heimdall.NewHeimdallClient(ethConfig.HeimdallURL)
 			}
@@ -7,8 +7,10 @@ import (
 	"time"
 
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/consensus/bor"
 	"github.com/ethereum/go-ethereum/core"
 	"github.com/ethereum/go-ethereum/core/txpool/blobpool"
+	"github.com/ethereum/go-ethereum/core/txpool/condpool"
 	"github.com/ethereum/go-ethereum/core/txpool/legacypool"
 	"github.com/ethereum/go-ethereum/eth/downloader"
 	"github.com/ethereum/go-ethereum/eth/gasprice"
@@ -26,6 +28,8 @@ func (c Config) MarshalTOML() (interface{}, error) {
 		NoPruning                            bool
 		NoPrefetch                           bool
 		TxLookupLimit                        uint64                 `toml:",omitempty"`
+		BorReceiptLimit                      uint64                 `toml:",omitempty"`
+		ChangeSets                           bool                   `toml:",omitempty"`
 		RequiredBlocks                       map[uint64]common.Hash `toml:"-"`
 		LightServ                            int                    `toml:",omitempty"`
 		LightIngress                         int                    `toml:",omitempty"`
@@ -51,6 +55,7 @@ func (c Config) MarshalTOML() (interface{}, error) {
 		Miner                                miner.Config
 		TxPool                               legacypool.Config
 		BlobPool                             blobpool.Config
+		CondPool                             condpool.Config
 		GPO                                  gasprice.Config
 		EnablePreimageRecording              bool
 		DocRoot                              string `toml:"-"`
@@ -62,10 +67,15 @@ func (c Config) MarshalTOML() (interface{}, error) {
 		HeimdallURL                          string
 		WithoutHeimdall                      bool
 		HeimdallgRPCAddress                  string
+		HeimdallgRPCTLSCert                  string
+		HeimdallgRPCTLSKey                   string
+		HeimdallgRPCTLSCA                    string
+		HeimdallgRPCToken                    string
 		RunHeimdall                          bool
 		RunHeimdallArgs                      string
 		UseHeimdallApp                       bool
 		BorLogs                              bool
+		BorTrustedCheckpoint                 *bor.TrustedCheckpoint `toml:",omitempty"`
 		ParallelEVM                          core.ParallelEVMConfig `toml:",omitempty"`
 		DevFakeAuthor                        bool                   `hcl:"devfakeauthor,optional" toml:"devfakeauthor,optional"`
 		OverrideVerkle                       *big.Int               `toml:",omitempty"`
@@ -79,6 +89,8 @@ func (c Config) MarshalTOML() (interface{}, error) {
 	enc.NoPruning = c.NoPruning
 	enc.NoPrefetch = c.NoPrefetch
 	enc.TxLookupLimit = c.TxLookupLimit
+	enc.BorReceiptLimit = c.BorReceiptLimit
+	enc.ChangeSets = c.ChangeSets
 	enc.RequiredBlocks = c.RequiredBlocks
 	enc.LightServ = c.LightServ
 	enc.LightIngress = c.LightIngress
@@ -104,6 +116,7 @@ func (c Config) MarshalTOML() (interface{}, error) {
 	enc.Miner = c.Miner
 	enc.TxPool = c.TxPool
 	enc.BlobPool = c.BlobPool
+	enc.CondPool = c.CondPool
 	enc.GPO = c.GPO
 	enc.EnablePreimageRecording = c.EnablePreimageRecording
 	enc.DocRoot = c.DocRoot
@@ -115,10 +128,15 @@ func (c Config) MarshalTOML() (interface{}, error) {
 	enc.HeimdallURL = c.HeimdallURL
 	enc.WithoutHeimdall = c.WithoutHeimdall
 	enc.HeimdallgRPCAddress = c.HeimdallgRPCAddress
+	enc.HeimdallgRPCTLSCert = c.HeimdallgRPCTLSCert
+	enc.HeimdallgRPCTLSKey = c.HeimdallgRPCTLSKey
+	enc.HeimdallgRPCTLSCA = c.HeimdallgRPCTLSCA
+	enc.HeimdallgRPCToken = c.HeimdallgRPCToken
 	enc.RunHeimdall = c.RunHeimdall
 	enc.RunHeimdallArgs = c.RunHeimdallArgs
 	enc.UseHeimdallApp = c.UseHeimdallApp
 	enc.BorLogs = c.BorLogs
+	enc.BorTrustedCheckpoint = c.BorTrustedCheckpoint
 	enc.ParallelEVM = c.ParallelEVM
 	enc.DevFakeAuthor = c.DevFakeAuthor
 	enc.OverrideVerkle = c.OverrideVerkle
@@ -136,6 +154,8 @@ func (c *Config) UnmarshalTOML(unmarshal func(interface{}) error) error {
 		NoPruning                            *bool
 		NoPrefetch                           *bool
 		TxLookupLimit                        *uint64                `toml:",omitempty"`
+		BorReceiptLimit                      *uint64                `toml:",omitempty"`
+		ChangeSets                           *bool                  `toml:",omitempty"`
 		RequiredBlocks                       map[uint64]common.Hash `toml:"-"`
 		LightServ                            *int                   `toml:",omitempty"`
 		LightIngress                         *int                   `toml:",omitempty"`
@@ -161,6 +181,7 @@ func (c *Config) UnmarshalTOML(unmarshal func(interface{}) error) error {
 		Miner                                *miner.Config
 		TxPool                               *legacypool.Config
 		BlobPool                             *blobpool.Config
+		CondPool                             *condpool.Config
 		GPO                                  *gasprice.Config
 		EnablePreimageRecording              *bool
 		DocRoot                              *string `toml:"-"`
@@ -172,10 +193,15 @@ func (c *Config) UnmarshalTOML(unmarshal func(interface{}) error) error {
 		HeimdallURL                          *string
 		WithoutHeimdall                      *bool
 		HeimdallgRPCAddress                  *string
+		HeimdallgRPCTLSCert                  *string
+		HeimdallgRPCTLSKey                   *string
+		HeimdallgRPCTLSCA                    *string
+		HeimdallgRPCToken                    *string
 		RunHeimdall                          *bool
 		RunHeimdallArgs                      *string
 		UseHeimdallApp                       *bool
 		BorLogs                              *bool
+		BorTrustedCheckpoint                 *bor.TrustedCheckpoint  `toml:",omitempty"`
 		ParallelEVM                          *core.ParallelEVMConfig `toml:",omitempty"`
 		DevFakeAuthor                        *bool                   `hcl:"devfakeauthor,optional" toml:"devfakeauthor,optional"`
 		OverrideVerkle                       *big.Int                `toml:",omitempty"`
@@ -208,6 +234,12 @@ func (c *Config) UnmarshalTOML(unmarshal func(interface{}) error) error {
 	if dec.TxLookupLimit != nil {
 		c.TxLookupLimit = *dec.TxLookupLimit
 	}
+	if dec.BorReceiptLimit != nil {
+		c.BorReceiptLimit = *dec.BorReceiptLimit
+	}
+	if dec.ChangeSets != nil {
+		c.ChangeSets = *dec.ChangeSets
+	}
 	if dec.RequiredBlocks != nil {
 		c.RequiredBlocks = dec.RequiredBlocks
 	}
@@ -283,6 +315,9 @@ func (c *Config) UnmarshalTOML(unmarshal func(interface{}) error) error {
 	if dec.BlobPool != nil {
 		c.BlobPool = *dec.BlobPool
 	}
+	if dec.CondPool != nil {
+		c.CondPool = *dec.CondPool
+	}
 	if dec.GPO != nil {
 		c.GPO = *dec.GPO
 	}
@@ -316,6 +351,18 @@ func (c *Config) UnmarshalTOML(unmarshal func(interface{}) error) error {
 	if dec.HeimdallgRPCAddress != nil {
 		c.HeimdallgRPCAddress = *dec.HeimdallgRPCAddress
 	}
+	if dec.HeimdallgRPCTLSCert != nil {
+		c.HeimdallgRPCTLSCert = *dec.HeimdallgRPCTLSCert
+	}
+	if dec.HeimdallgRPCTLSKey != nil {
+		c.HeimdallgRPCTLSKey = *dec.HeimdallgRPCTLSKey
+	}
+	if dec.HeimdallgRPCTLSCA != nil {
+		c.HeimdallgRPCTLSCA = *dec.HeimdallgRPCTLSCA
+	}
+	if dec.HeimdallgRPCToken != nil {
+		c.HeimdallgRPCToken = *dec.HeimdallgRPCToken
+	}
 	if dec.RunHeimdall != nil {
 		c.RunHeimdall = *dec.RunHeimdall
 	}
@@ -328,6 +375,9 @@ func (c *Config) UnmarshalTOML(unmarshal func(interface{}) error) error {
 	if dec.BorLogs != nil {
 		c.BorLogs = *dec.BorLogs
 	}
+	if dec.BorTrustedCheckpoint != nil {
+		c.BorTrustedCheckpoint = dec.BorTrustedCheckpoint
+	}
 	if dec.ParallelEVM != nil {
 		c.ParallelEVM = *dec.ParallelEVM
 	}
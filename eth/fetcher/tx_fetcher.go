@@ -28,6 +28,7 @@ import (
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/common/mclock"
+	"github.com/ethereum/go-ethereum/core"
 	"github.com/ethereum/go-ethereum/core/txpool"
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/log"
@@ -174,6 +175,11 @@ type TxFetcher struct {
 	addTxs   func([]*txpool.Transaction) []error // Insert a batch of transactions into local txpool
 	fetchTxs func(string, []common.Hash) error   // Retrieves a set of txs from a remote peer
 
+	invalidTxs func(string, int, int) // Reports a peer delivering invalid transactions, optional
+
+	announcedTxs func(string, []common.Hash)              // Reports the transactions announced by a peer, optional
+	deliveredTxs func(string, []*types.Transaction, bool) // Reports the transactions delivered by a peer, optional
+
 	step  chan struct{} // Notification channel when the fetcher loop iterates
 	clock mclock.Clock  // Time wrapper to simulate in tests
 	rand  *mrand.Rand   // Randomizer to use in tests instead of map range loops (soft-random)
@@ -215,12 +221,30 @@ func NewTxFetcherForTests(
 	}
 }
 
+// SetInvalidTxsHook sets the callback reporting the peers which deliver invalid
+// transactions, along with the number of invalid and delivered transactions.
+func (f *TxFetcher) SetInvalidTxsHook(hook func(peer string, invalid int, delivered int)) {
+	f.invalidTxs = hook
+}
+
+// SetPropagationHooks sets the callbacks reporting every transaction announced
+// or delivered by the peers, whether delivered on request or broadcast, to
+// measure how transactions propagate.
+func (f *TxFetcher) SetPropagationHooks(announced func(peer string, hashes []common.Hash), delivered func(peer string, txs []*types.Transaction, direct bool)) {
+	f.announcedTxs = announced
+	f.deliveredTxs = delivered
+}
+
 // Notify announces the fetcher of the potential availability of a new batch of
 // transactions in the network.
 func (f *TxFetcher) Notify(peer string, hashes []common.Hash) error {
 	// Keep track of all the announced transactions
 	txAnnounceInMeter.Mark(int64(len(hashes)))
 
+	if f.announcedTxs != nil {
+		f.announcedTxs(peer, hashes)
+	}
+
 	// Skip any transaction announcements that we already know of, or that we've
 	// previously marked as cheap and discarded. This check is of course racy,
 	// because multiple concurrent notifies will still manage to pass it, but it's
@@ -285,10 +309,15 @@ func (f *TxFetcher) Enqueue(peer string, txs []*types.Transaction, direct bool)
 	// Keep track of all the propagated transactions
 	inMeter.Mark(int64(len(txs)))
 
+	if f.deliveredTxs != nil {
+		f.deliveredTxs(peer, txs, direct)
+	}
+
 	// Push all the transactions into the pool, tracking underpriced ones to avoid
 	// re-requesting them and dropping the peer in case of malicious transfers.
 	var (
-		added = make([]common.Hash, 0, len(txs))
+		added   = make([]common.Hash, 0, len(txs))
+		invalid int
 	)
 	// proceed in batches
 	for i := 0; i < len(txs); i += 128 {
@@ -331,6 +360,10 @@ func (f *TxFetcher) Enqueue(peer string, txs []*types.Transaction, direct bool)
 
 			default:
 				otherreject++
+
+				if isInvalidTx(err) {
+					invalid++
+				}
 			}
 
 			added = append(added, batch[j].Hash())
@@ -346,6 +379,10 @@ func (f *TxFetcher) Enqueue(peer string, txs []*types.Transaction, direct bool)
 			log.Warn("Peer delivering stale transactions", "peer", peer, "rejected", otherreject)
 		}
 	}
+
+	if invalid > 0 && f.invalidTxs != nil {
+		f.invalidTxs(peer, invalid, len(txs))
+	}
 	select {
 	case f.cleanup <- &txDelivery{origin: peer, hashes: added, direct: direct}:
 		return nil
@@ -354,6 +391,16 @@ func (f *TxFetcher) Enqueue(peer string, txs []*types.Transaction, direct bool)
 	}
 }
 
+// isInvalidTx returns whether the transaction was rejected for being invalid
+// regardless of the pool and chain state, i.e. the peer should have known better
+// than relaying it.
+func isInvalidTx(err error) bool {
+	return errors.Is(err, txpool.ErrInvalidSender) || errors.Is(err, txpool.ErrNegativeValue) ||
+		errors.Is(err, txpool.ErrOversizedData) || errors.Is(err, core.ErrTxTypeNotSupported) ||
+		errors.Is(err, core.ErrIntrinsicGas) || errors.Is(err, core.ErrTipAboveFeeCap) ||
+		errors.Is(err, core.ErrFeeCapVeryHigh) || errors.Is(err, core.ErrTipVeryHigh)
+}
+
 // Drop should be called when a peer disconnects. It cleans up all the internal
 // data structures of the given node.
 func (f *TxFetcher) Drop(peer string) error {
@@ -18,6 +18,7 @@ package fetcher
 
 import (
 	"errors"
+	"fmt"
 	"math/big"
 	"math/rand"
 	"testing"
@@ -25,6 +26,7 @@ import (
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/common/mclock"
+	"github.com/ethereum/go-ethereum/core"
 	"github.com/ethereum/go-ethereum/core/txpool"
 	"github.com/ethereum/go-ethereum/core/types"
 )
@@ -914,6 +916,48 @@ func TestTransactionFetcherUnderpricedDedup(t *testing.T) {
 	})
 }
 
+// Tests that peers delivering invalid transactions are reported, but not the
+// ones delivering transactions rejected due to the local state.
+func TestTransactionFetcherInvalidTxs(t *testing.T) {
+	var reports []string
+
+	testTransactionFetcherParallel(t, txFetcherTest{
+		init: func() *TxFetcher {
+			f := NewTxFetcher(
+				func(common.Hash) bool { return false },
+				func(txs []*txpool.Transaction) []error {
+					errs := make([]error, len(txs))
+					for i, tx := range txs {
+						switch tx.Tx.Hash() {
+						case testTxsHashes[0]:
+							errs[i] = txpool.ErrInvalidSender
+						case testTxsHashes[1]:
+							errs[i] = core.ErrNonceTooLow
+						}
+					}
+					return errs
+				},
+				func(string, []common.Hash) error { return nil },
+				testTxArrivalWait,
+			)
+			f.SetInvalidTxsHook(func(peer string, invalid int, delivered int) {
+				reports = append(reports, fmt.Sprintf("%s:%d/%d", peer, invalid, delivered))
+			})
+
+			return f
+		},
+		steps: []interface{}{
+			doTxEnqueue{peer: "A", txs: []*types.Transaction{testTxs[0], testTxs[1], testTxs[2]}, direct: false},
+			doTxEnqueue{peer: "B", txs: []*types.Transaction{testTxs[1], testTxs[2]}, direct: false},
+			doFunc(func() {
+				if len(reports) != 1 || reports[0] != "A:1/3" {
+					t.Errorf("invalid transaction reports mismatch: have %v, want [A:1/3]", reports)
+				}
+			}),
+		},
+	})
+}
+
 // Tests that underpriced transactions don't get rescheduled after being rejected,
 // but at the same time there's a hard cap on the number of transactions that are
 // tracked.
@@ -57,6 +57,21 @@ func (mr *MockBackendMockRecorder) BloomStatus() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BloomStatus", reflect.TypeOf((*MockBackend)(nil).BloomStatus))
 }
 
+// BorBloomStatus mocks base method.
+func (m *MockBackend) BorBloomStatus() (uint64, uint64) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BorBloomStatus")
+	ret0, _ := ret[0].(uint64)
+	ret1, _ := ret[1].(uint64)
+	return ret0, ret1
+}
+
+// BorBloomStatus indicates an expected call of BorBloomStatus.
+func (mr *MockBackendMockRecorder) BorBloomStatus() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BorBloomStatus", reflect.TypeOf((*MockBackend)(nil).BorBloomStatus))
+}
+
 // ChainConfig mocks base method.
 func (m *MockBackend) ChainConfig() *params.ChainConfig {
 	m.ctrl.T.Helper()
@@ -219,6 +234,18 @@ func (mr *MockBackendMockRecorder) PendingBlockAndReceipts() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PendingBlockAndReceipts", reflect.TypeOf((*MockBackend)(nil).PendingBlockAndReceipts))
 }
 
+// ServiceBorFilter mocks base method.
+func (m *MockBackend) ServiceBorFilter(arg0 context.Context, arg1 *bloombits.MatcherSession) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "ServiceBorFilter", arg0, arg1)
+}
+
+// ServiceBorFilter indicates an expected call of ServiceBorFilter.
+func (mr *MockBackendMockRecorder) ServiceBorFilter(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ServiceBorFilter", reflect.TypeOf((*MockBackend)(nil).ServiceBorFilter), arg0, arg1)
+}
+
 // ServiceFilter mocks base method.
 func (m *MockBackend) ServiceFilter(arg0 context.Context, arg1 *bloombits.MatcherSession) {
 	m.ctrl.T.Helper()
@@ -369,6 +369,20 @@ func (mr *MockDatabaseMockRecorder) TruncateHead(arg0 interface{}) *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TruncateHead", reflect.TypeOf((*MockDatabase)(nil).TruncateHead), arg0)
 }
 
+// TruncateTableTail mocks base method.
+func (m *MockDatabase) TruncateTableTail(arg0 string, arg1 uint64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TruncateTableTail", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// TruncateTableTail indicates an expected call of TruncateTableTail.
+func (mr *MockDatabaseMockRecorder) TruncateTableTail(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TruncateTableTail", reflect.TypeOf((*MockDatabase)(nil).TruncateTableTail), arg0, arg1)
+}
+
 // TruncateTail mocks base method.
 func (m *MockDatabase) TruncateTail(arg0 uint64) (uint64, error) {
 	m.ctrl.T.Helper()
@@ -20,6 +20,8 @@ import (
 	"context"
 
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/bloombits"
+	"github.com/ethereum/go-ethereum/core/rawdb"
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/ethdb"
 	"github.com/ethereum/go-ethereum/params"
@@ -37,6 +39,8 @@ type BorBlockLogsFilter struct {
 
 	block      common.Hash // Block hash if filtering a single block
 	begin, end int64       // Range interval if filtering multiple blocks
+
+	matcher *bloombits.Matcher // Bor receipt bloom bits matcher, nil if the criteria match all logs
 }
 
 // NewBorBlockLogsRangeFilter creates a new filter which uses a bloom filter on blocks to
@@ -47,6 +51,18 @@ func NewBorBlockLogsRangeFilter(backend Backend, borConfig *params.BorConfig, be
 	filter.begin = begin
 	filter.end = end
 
+	// Wildcard criteria are served faster by walking the sprint start blocks
+	// than by matching every block of the indexed sections
+	filters := bloomFilters(addresses, topics)
+	for _, clause := range filters {
+		if len(clause) > 0 {
+			size, _ := backend.BorBloomStatus()
+			filter.matcher = bloombits.NewMatcher(size, filters)
+
+			break
+		}
+	}
+
 	return filter
 }
 
@@ -97,6 +113,11 @@ func (f *BorBlockLogsFilter) Logs(ctx context.Context) ([]*types.Log, error) {
 		f.begin = int64(head)
 	}
 
+	// Skip the expired bor receipts, the index may still cover them
+	if tail := rawdb.ReadBorReceiptTail(f.db); tail != nil && int64(*tail) > f.begin {
+		f.begin = int64(*tail)
+	}
+
 	// adjust begin for sprint
 	f.begin = currentSprintEnd(f.borConfig.CalculateSprint(uint64(f.begin)), f.begin)
 
@@ -105,8 +126,86 @@ func (f *BorBlockLogsFilter) Logs(ctx context.Context) ([]*types.Log, error) {
 		end = int64(head)
 	}
 
+	if f.begin > end {
+		return nil, nil
+	}
+
 	// Gather all indexed logs, and finish with non indexed ones
-	return f.unindexedLogs(ctx, uint64(end))
+	var logs []*types.Log
+
+	if size, sections := f.backend.BorBloomStatus(); f.matcher != nil && sections*size > uint64(f.begin) {
+		indexed := sections * size
+		if indexed > uint64(end) {
+			indexed = uint64(end) + 1
+		}
+
+		found, err := f.indexedLogs(ctx, indexed-1)
+		if err != nil {
+			return found, err
+		}
+
+		logs = found
+	}
+
+	rest, err := f.unindexedLogs(ctx, uint64(end))
+	logs = append(logs, rest...)
+
+	return logs, err
+}
+
+// indexedLogs returns the logs matching the filter criteria based on the bor
+// receipt bloom bits index.
+func (f *BorBlockLogsFilter) indexedLogs(ctx context.Context, end uint64) ([]*types.Log, error) {
+	// Create a matcher session and request servicing from the backend
+	matches := make(chan uint64, 64)
+
+	session, err := f.matcher.Start(ctx, uint64(f.begin), end, matches)
+	if err != nil {
+		return nil, err
+	}
+	defer session.Close()
+
+	f.backend.ServiceBorFilter(ctx, session)
+
+	var logs []*types.Log
+
+	for {
+		select {
+		case number, ok := <-matches:
+			// Abort if all matches have been fulfilled
+			if !ok {
+				err := session.Error()
+				if err == nil {
+					f.begin = int64(end) + 1
+				}
+
+				return logs, err
+			}
+
+			f.begin = int64(number) + 1
+
+			// Retrieve the suggested block and pull any truly matching logs
+			header, err := f.backend.HeaderByNumber(ctx, rpc.BlockNumber(number))
+			if header == nil || err != nil {
+				return logs, err
+			}
+
+			receipt, err := f.backend.GetBorBlockReceipt(ctx, header.Hash())
+			if receipt == nil || err != nil {
+				continue
+			}
+
+			found, err := f.borBlockLogs(ctx, receipt)
+			if err != nil {
+				return logs, err
+			}
+
+			logs = append(logs, found...)
+
+		case <-ctx.Done():
+			return logs, ctx.Err()
+		}
+	}
 }
 
 // unindexedLogs returns the logs matching the filter criteria based on raw block
@@ -114,6 +213,8 @@ func (f *BorBlockLogsFilter) Logs(ctx context.Context) ([]*types.Log, error) {
 func (f *BorBlockLogsFilter) unindexedLogs(ctx context.Context, end uint64) ([]*types.Log, error) {
 	var logs []*types.Log
 
+	// The indexed blocks end at a section boundary, which may not be a sprint start
+	f.begin = currentSprintEnd(f.borConfig.CalculateSprint(uint64(f.begin)), f.begin)
 	sprintLength := f.borConfig.CalculateSprint(uint64(f.begin))
 
 	for ; f.begin <= int64(end); f.begin = f.begin + int64(sprintLength) {
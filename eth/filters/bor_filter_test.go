@@ -70,6 +70,8 @@ func TestBorFilters(t *testing.T) {
 	// should return the following at all times
 	backend.EXPECT().ChainDb().Return(db).AnyTimes()
 	backend.EXPECT().HeaderByNumber(gomock.Any(), gomock.Any()).Return(newTestHeader(1), nil).AnyTimes()
+	backend.EXPECT().BorBloomStatus().Return(params.BloomBitsBlocks, uint64(0)).AnyTimes()
+	db.EXPECT().Get(gomock.Any()).Return(nil, nil).AnyTimes() // no bor receipts expired
 
 	// Block 1
 	backend.expectBorReceiptsFromMock([]*common.Hash{nil, &hash1, &hash2, &hash3, &hash4})
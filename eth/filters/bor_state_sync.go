@@ -0,0 +1,301 @@
+package filters
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+// Confirmation modes of the state-sync subscriptions.
+const (
+	StateSyncConfirmLatest    = "latest"    // State-syncs are delivered once in the canonical chain
+	StateSyncConfirmFinalized = "finalized" // State-syncs are delivered once finalized by a milestone
+)
+
+const (
+	// stateSyncReplayBatch is the maximum number of blocks scanned at once while
+	// replaying, so that subscribers receive the history progressively.
+	stateSyncReplayBatch = 16384
+
+	// stateSyncReorgDepth is the number of scanned sprint start blocks tracked
+	// to detect the reorgs removing delivered state-syncs.
+	stateSyncReorgDepth = 256
+)
+
+var (
+	errNoBorConfig         = errors.New("state-sync subscriptions require a bor chain")
+	errInvalidConfirmation = errors.New("invalid confirmation mode, expected latest or finalized")
+)
+
+// StateSyncCriteria are the arguments of a state-sync subscription.
+type StateSyncCriteria struct {
+	FromID       uint64           `json:"fromID"`       // First state-sync ID to deliver, zero for the live ones only
+	Contracts    []common.Address `json:"contracts"`    // Receiver contracts to deliver the state-syncs of, empty for all
+	Confirmation string           `json:"confirmation"` // Confirmation mode, latest if empty
+}
+
+// StateSync is a state-sync committed to, or removed from, the canonical chain.
+type StateSync struct {
+	ID          uint64         `json:"id"`
+	Contract    common.Address `json:"contract"`
+	Data        string         `json:"data"`
+	TxHash      common.Hash    `json:"txHash"`
+	BlockNumber uint64         `json:"blockNumber"`
+	BlockHash   common.Hash    `json:"blockHash"`
+	Removed     bool           `json:"removed"` // Whether the committing block was reorged out
+}
+
+// StateSyncAPI offers the subscriptions to the state-syncs committed by the
+// chain, in the bor namespace.
+type StateSyncAPI struct {
+	sys *FilterSystem
+}
+
+// NewStateSyncAPI returns a new StateSyncAPI instance.
+func NewStateSyncAPI(system *FilterSystem) *StateSyncAPI {
+	return &StateSyncAPI{sys: system}
+}
+
+// StateSyncs sends a notification for each state-sync committed by the chain
+// from the requested ID on, replaying the indexed history before switching to
+// the live state-syncs. State-syncs of blocks reorged out of the chain are
+// notified again as removed.
+func (api *StateSyncAPI) StateSyncs(ctx context.Context, crit StateSyncCriteria) (*rpc.Subscription, error) {
+	notifier, supported := rpc.NotifierFromContext(ctx)
+	if !supported {
+		return &rpc.Subscription{}, rpc.ErrNotificationsUnsupported
+	}
+
+	stream, err := newStateSyncStream(api.sys.backend, crit)
+	if err != nil {
+		return nil, err
+	}
+
+	rpcSub := notifier.CreateSubscription()
+
+	go func() {
+		// Subscribe before replaying, so no block is missed in between
+		chainEvents := make(chan core.ChainEvent, chainEvChanSize)
+		chainSub := api.sys.backend.SubscribeChainEvent(chainEvents)
+
+		defer chainSub.Unsubscribe()
+
+		notify := func(sync *StateSync) {
+			notifier.Notify(rpcSub.ID, sync)
+		}
+
+		for {
+			// Catch up with the chain, yielding between replay batches
+			more, err := stream.advance(context.Background(), notify)
+			if err != nil {
+				log.Warn("State-sync subscription failed", "err", err)
+				return
+			}
+
+			if more {
+				// Drop the chain events received while replaying, so that the
+				// chain feed never blocks on this subscription. The next batch
+				// scans up to the head anyway.
+				for drained := false; !drained; {
+					select {
+					case <-chainEvents:
+					case <-rpcSub.Err():
+						return
+					case <-notifier.Closed():
+						return
+					default:
+						drained = true
+					}
+				}
+
+				continue
+			}
+
+			select {
+			case <-chainEvents:
+			case <-rpcSub.Err():
+				return
+			case <-notifier.Closed():
+				return
+			}
+		}
+	}()
+
+	return rpcSub, nil
+}
+
+// stateSyncBlock is a sprint start block scanned by a state-sync stream.
+type stateSyncBlock struct {
+	number uint64
+	hash   common.Hash
+	syncs  []*types.StateSyncData
+}
+
+// stateSyncStream walks the canonical chain for the state-syncs matching a
+// subscription, detecting the reorgs of the already delivered ones.
+type stateSyncStream struct {
+	backend   Backend
+	crit      StateSyncCriteria
+	contracts map[common.Address]struct{}
+
+	next    uint64           // Next block number to scan
+	scanned []stateSyncBlock // Recently scanned sprint start blocks, for reorg detection
+}
+
+// newStateSyncStream creates a stream starting at the block which committed
+// the requested state-sync, or at the next block if live state-syncs only are
+// requested.
+func newStateSyncStream(backend Backend, crit StateSyncCriteria) (*stateSyncStream, error) {
+	if backend.ChainConfig().Bor == nil {
+		return nil, errNoBorConfig
+	}
+
+	switch crit.Confirmation {
+	case "":
+		crit.Confirmation = StateSyncConfirmLatest
+	case StateSyncConfirmLatest, StateSyncConfirmFinalized:
+	default:
+		return nil, errInvalidConfirmation
+	}
+
+	stream := &stateSyncStream{
+		backend:   backend,
+		crit:      crit,
+		contracts: make(map[common.Address]struct{}, len(crit.Contracts)),
+	}
+
+	for _, contract := range crit.Contracts {
+		stream.contracts[contract] = struct{}{}
+	}
+
+	db := backend.ChainDb()
+
+	if crit.FromID > 0 {
+		if number := rawdb.ReadStateSyncLookupEntry(db, crit.FromID); number != nil {
+			stream.next = *number
+			return stream, nil
+		}
+		// State-syncs committed before the index was built can't be replayed
+		if crit.FromID <= rawdb.ReadLastStateSyncID(db) {
+			return nil, fmt.Errorf("state-sync %d is not indexed", crit.FromID)
+		}
+	}
+
+	if head := backend.CurrentHeader(); head != nil {
+		stream.next = head.Number.Uint64() + 1
+	}
+
+	return stream, nil
+}
+
+// target returns the header up to which state-syncs are delivered, nil if none
+// is confirmed yet.
+func (s *stateSyncStream) target(ctx context.Context) *types.Header {
+	if s.crit.Confirmation == StateSyncConfirmFinalized {
+		header, err := s.backend.HeaderByNumber(ctx, rpc.FinalizedBlockNumber)
+		if err != nil {
+			return nil
+		}
+
+		return header
+	}
+
+	return s.backend.CurrentHeader()
+}
+
+// matches returns whether a state-sync is requested by the subscription.
+func (s *stateSyncStream) matches(sync *types.StateSyncData) bool {
+	if sync.ID < s.crit.FromID {
+		return false
+	}
+
+	if len(s.contracts) == 0 {
+		return true
+	}
+
+	_, ok := s.contracts[sync.Contract]
+
+	return ok
+}
+
+// advance notifies the state-syncs removed by reorgs since the last call, then
+// the ones committed up to the confirmation target, returning whether more
+// blocks remain to be scanned.
+func (s *stateSyncStream) advance(ctx context.Context, notify func(*StateSync)) (bool, error) {
+	var (
+		db     = s.backend.ChainDb()
+		config = s.backend.ChainConfig().Bor
+	)
+
+	// Roll back the scanned blocks which are not canonical anymore
+	for len(s.scanned) > 0 {
+		last := s.scanned[len(s.scanned)-1]
+		if rawdb.ReadCanonicalHash(db, last.number) == last.hash {
+			break
+		}
+
+		for i := len(last.syncs) - 1; i >= 0; i-- {
+			if s.matches(last.syncs[i]) {
+				notify(newStateSync(last.syncs[i], last.number, last.hash, true))
+			}
+		}
+
+		s.scanned = s.scanned[:len(s.scanned)-1]
+		s.next = last.number
+	}
+
+	head := s.target(ctx)
+	if head == nil {
+		return false, nil
+	}
+
+	end := head.Number.Uint64()
+	if end >= s.next+stateSyncReplayBatch {
+		end = s.next + stateSyncReplayBatch - 1
+	}
+
+	for ; s.next <= end; s.next++ {
+		number := s.next
+		if number == 0 || !config.IsSprintStart(number) {
+			continue
+		}
+
+		hash := rawdb.ReadCanonicalHash(db, number)
+		if hash == (common.Hash{}) {
+			return false, fmt.Errorf("canonical block %d not found", number)
+		}
+
+		block := stateSyncBlock{number: number, hash: hash, syncs: rawdb.ReadStateSyncs(db, hash, number)}
+
+		for _, sync := range block.syncs {
+			if s.matches(sync) {
+				notify(newStateSync(sync, number, hash, false))
+			}
+		}
+
+		if s.scanned = append(s.scanned, block); len(s.scanned) > stateSyncReorgDepth {
+			s.scanned = s.scanned[1:]
+		}
+	}
+
+	return s.next <= head.Number.Uint64(), nil
+}
+
+func newStateSync(sync *types.StateSyncData, number uint64, hash common.Hash, removed bool) *StateSync {
+	return &StateSync{
+		ID:          sync.ID,
+		Contract:    sync.Contract,
+		Data:        sync.Data,
+		TxHash:      sync.TxHash,
+		BlockNumber: number,
+		BlockHash:   hash,
+		Removed:     removed,
+	}
+}
@@ -0,0 +1,280 @@
+package filters
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/ethdb"
+	"github.com/ethereum/go-ethereum/params"
+)
+
+// stateSyncTestBackend is a test backend of a bor chain with four block sprints.
+type stateSyncTestBackend struct {
+	*testBackend
+	config *params.ChainConfig
+}
+
+func newStateSyncTestBackend() *stateSyncTestBackend {
+	config := *params.TestChainConfig
+	config.Bor = &params.BorConfig{Sprint: map[string]uint64{"0": 4}}
+
+	return &stateSyncTestBackend{
+		testBackend: &testBackend{db: rawdb.NewMemoryDatabase()},
+		config:      &config,
+	}
+}
+
+func (b *stateSyncTestBackend) ChainConfig() *params.ChainConfig {
+	return b.config
+}
+
+// writeStateSyncBlock writes a canonical head block committing the given state-syncs.
+func writeStateSyncBlock(db ethdb.Database, number uint64, fork byte, syncs ...*types.StateSyncData) common.Hash {
+	header := &types.Header{Number: new(big.Int).SetUint64(number), Extra: []byte{fork}}
+	if number > 0 {
+		header.ParentHash = rawdb.ReadCanonicalHash(db, number-1)
+	}
+
+	hash := header.Hash()
+
+	rawdb.WriteHeader(db, header)
+	rawdb.WriteCanonicalHash(db, hash, number)
+	rawdb.WriteHeadBlockHash(db, hash)
+
+	if len(syncs) > 0 {
+		rawdb.WriteStateSyncs(db, hash, number, syncs)
+
+		if last := syncs[len(syncs)-1].ID; last > rawdb.ReadLastStateSyncID(db) {
+			rawdb.WriteLastStateSyncID(db, last)
+		}
+	}
+
+	return hash
+}
+
+// collectStateSyncs advances a stream until caught up with the chain.
+func collectStateSyncs(t *testing.T, stream *stateSyncStream) []*StateSync {
+	t.Helper()
+
+	var syncs []*StateSync
+
+	for {
+		more, err := stream.advance(context.Background(), func(sync *StateSync) {
+			syncs = append(syncs, sync)
+		})
+		if err != nil {
+			t.Fatalf("failed to advance stream: %v", err)
+		}
+
+		if !more {
+			return syncs
+		}
+	}
+}
+
+func checkStateSyncs(t *testing.T, have []*StateSync, want []uint64, removed bool) {
+	t.Helper()
+
+	if len(have) != len(want) {
+		t.Fatalf("state-sync count mismatch: have %d, want %d", len(have), len(want))
+	}
+
+	for i, sync := range have {
+		if sync.ID != want[i] || sync.Removed != removed {
+			t.Errorf("state-sync %d mismatch: have %d (removed %v), want %d (removed %v)", i, sync.ID, sync.Removed, want[i], removed)
+		}
+	}
+}
+
+func TestStateSyncStream(t *testing.T) {
+	t.Parallel()
+
+	var (
+		backend   = newStateSyncTestBackend()
+		db        = backend.db
+		contractA = common.Address{0xa}
+		contractB = common.Address{0xb}
+	)
+
+	// Sprint start blocks 4 and 8 commit state-syncs, 12 none
+	for i := uint64(0); i <= 12; i++ {
+		switch i {
+		case 4:
+			writeStateSyncBlock(db, i, 0, &types.StateSyncData{ID: 1, Contract: contractA}, &types.StateSyncData{ID: 2, Contract: contractB})
+		case 8:
+			writeStateSyncBlock(db, i, 0, &types.StateSyncData{ID: 3, Contract: contractA})
+		default:
+			writeStateSyncBlock(db, i, 0)
+		}
+	}
+
+	// Replay from an ID, and for a contract set
+	stream, err := newStateSyncStream(backend, StateSyncCriteria{FromID: 2})
+	if err != nil {
+		t.Fatalf("failed to create stream: %v", err)
+	}
+
+	checkStateSyncs(t, collectStateSyncs(t, stream), []uint64{2, 3}, false)
+
+	filtered, err := newStateSyncStream(backend, StateSyncCriteria{FromID: 1, Contracts: []common.Address{contractA}})
+	if err != nil {
+		t.Fatalf("failed to create stream: %v", err)
+	}
+
+	checkStateSyncs(t, collectStateSyncs(t, filtered), []uint64{1, 3}, false)
+
+	// Live only streams start at the next block
+	live, err := newStateSyncStream(backend, StateSyncCriteria{})
+	if err != nil {
+		t.Fatalf("failed to create stream: %v", err)
+	}
+
+	checkStateSyncs(t, collectStateSyncs(t, live), nil, false)
+
+	// Newly committed state-syncs are delivered to all of them
+	for i := uint64(13); i <= 16; i++ {
+		if i == 16 {
+			writeStateSyncBlock(db, i, 0, &types.StateSyncData{ID: 4, Contract: contractA})
+		} else {
+			writeStateSyncBlock(db, i, 0)
+		}
+	}
+
+	checkStateSyncs(t, collectStateSyncs(t, stream), []uint64{4}, false)
+	checkStateSyncs(t, collectStateSyncs(t, filtered), []uint64{4}, false)
+	checkStateSyncs(t, collectStateSyncs(t, live), []uint64{4}, false)
+
+	// A reorg replacing the committing block notifies the removal first
+	for i := uint64(15); i <= 17; i++ {
+		if i == 16 {
+			writeStateSyncBlock(db, i, 1, &types.StateSyncData{ID: 4, Contract: contractB}, &types.StateSyncData{ID: 5, Contract: contractB})
+		} else {
+			writeStateSyncBlock(db, i, 1)
+		}
+	}
+
+	syncs := collectStateSyncs(t, stream)
+	checkStateSyncs(t, syncs[:1], []uint64{4}, true)
+	checkStateSyncs(t, syncs[1:], []uint64{4, 5}, false)
+
+	if syncs[0].BlockHash == syncs[1].BlockHash || syncs[1].Contract != contractB {
+		t.Errorf("reorged state-sync mismatch: removed %+v, added %+v", syncs[0], syncs[1])
+	}
+
+	checkStateSyncs(t, collectStateSyncs(t, filtered), []uint64{4}, true)
+}
+
+func TestStateSyncStreamFinalized(t *testing.T) {
+	t.Parallel()
+
+	var (
+		backend = newStateSyncTestBackend()
+		db      = backend.db
+	)
+
+	for i := uint64(0); i <= 8; i++ {
+		if i == 4 || i == 8 {
+			writeStateSyncBlock(db, i, 0, &types.StateSyncData{ID: i / 4})
+		} else {
+			writeStateSyncBlock(db, i, 0)
+		}
+	}
+
+	stream, err := newStateSyncStream(backend, StateSyncCriteria{FromID: 1, Confirmation: StateSyncConfirmFinalized})
+	if err != nil {
+		t.Fatalf("failed to create stream: %v", err)
+	}
+
+	// Nothing is delivered before a milestone, then up to the milestone only
+	checkStateSyncs(t, collectStateSyncs(t, stream), nil, false)
+
+	rawdb.WriteFinalizedBlockHash(db, rawdb.ReadCanonicalHash(db, 6))
+	checkStateSyncs(t, collectStateSyncs(t, stream), []uint64{1}, false)
+
+	rawdb.WriteFinalizedBlockHash(db, rawdb.ReadCanonicalHash(db, 8))
+	checkStateSyncs(t, collectStateSyncs(t, stream), []uint64{2}, false)
+}
+
+func TestStateSyncStreamCriteria(t *testing.T) {
+	t.Parallel()
+
+	backend := newStateSyncTestBackend()
+	writeStateSyncBlock(backend.db, 0, 0)
+	rawdb.WriteLastStateSyncID(backend.db, 10)
+
+	if _, err := newStateSyncStream(backend, StateSyncCriteria{Confirmation: "safe"}); err != errInvalidConfirmation {
+		t.Errorf("wrong error for invalid confirmation: %v", err)
+	}
+
+	// State-syncs committed before the index was built can't be replayed
+	if _, err := newStateSyncStream(backend, StateSyncCriteria{FromID: 5}); err == nil {
+		t.Error("stream created from an unindexed state-sync")
+	}
+
+	if _, err := newStateSyncStream(backend, StateSyncCriteria{FromID: 11}); err != nil {
+		t.Errorf("failed to create stream from a future state-sync: %v", err)
+	}
+
+	backend.config.Bor = nil
+	if _, err := newStateSyncStream(backend, StateSyncCriteria{}); err != errNoBorConfig {
+		t.Errorf("wrong error for non-bor chain: %v", err)
+	}
+}
+
+// Tests that the state-syncs of frozen blocks are still replayed.
+func TestStateSyncStreamFrozen(t *testing.T) {
+	t.Parallel()
+
+	db, err := rawdb.NewDatabaseWithFreezer(rawdb.NewMemoryDatabase(), t.TempDir(), "", false)
+	if err != nil {
+		t.Fatalf("failed to create database: %v", err)
+	}
+	defer db.Close()
+
+	backend := newStateSyncTestBackend()
+	backend.db = db
+
+	for i := uint64(0); i <= 12; i++ {
+		switch i {
+		case 4:
+			writeStateSyncBlock(db, i, 0, &types.StateSyncData{ID: 1}, &types.StateSyncData{ID: 2})
+		case 8:
+			writeStateSyncBlock(db, i, 0, &types.StateSyncData{ID: 3})
+		default:
+			writeStateSyncBlock(db, i, 0)
+		}
+	}
+
+	// Move the blocks up to 8 into the freezer and wipe them from the key-value
+	// store, as the chain freezer does
+	var (
+		blocks   []*types.Block
+		receipts []types.Receipts
+	)
+
+	for i := uint64(0); i <= 8; i++ {
+		hash := rawdb.ReadCanonicalHash(db, i)
+		blocks = append(blocks, types.NewBlockWithHeader(rawdb.ReadHeader(db, hash, i)))
+		receipts = append(receipts, nil)
+	}
+
+	if _, err := rawdb.WriteAncientBlocks(db, blocks, receipts, receipts, big.NewInt(0)); err != nil {
+		t.Fatalf("failed to freeze blocks: %v", err)
+	}
+
+	for i := uint64(1); i <= 8; i++ {
+		rawdb.DeleteBlockWithoutNumber(db, blocks[i].Hash(), i)
+		rawdb.DeleteCanonicalHash(db, i)
+	}
+
+	stream, err := newStateSyncStream(backend, StateSyncCriteria{FromID: 1})
+	if err != nil {
+		t.Fatalf("failed to create stream: %v", err)
+	}
+
+	checkStateSyncs(t, collectStateSyncs(t, stream), []uint64{1, 2, 3}, false)
+}
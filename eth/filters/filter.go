@@ -43,29 +43,7 @@ type Filter struct {
 // NewRangeFilter creates a new filter which uses a bloom filter on blocks to
 // figure out whether a particular block is interesting or not.
 func (sys *FilterSystem) NewRangeFilter(begin, end int64, addresses []common.Address, topics [][]common.Hash) *Filter {
-	// Flatten the address and topic filter clauses into a single bloombits filter
-	// system. Since the bloombits are not positional, nil topics are permitted,
-	// which get flattened into a nil byte slice.
-	var filters [][][]byte
-
-	if len(addresses) > 0 {
-		filter := make([][]byte, len(addresses))
-		for i, address := range addresses {
-			filter[i] = address.Bytes()
-		}
-
-		filters = append(filters, filter)
-	}
-
-	for _, topicList := range topics {
-		filter := make([][]byte, len(topicList))
-		for i, topic := range topicList {
-			filter[i] = topic.Bytes()
-		}
-
-		filters = append(filters, filter)
-	}
-
+	filters := bloomFilters(addresses, topics)
 	size, _ := sys.backend.BloomStatus()
 
 	// Create a generic filter and convert it into a range filter
@@ -88,6 +66,33 @@ func (sys *FilterSystem) NewBlockFilter(block common.Hash, addresses []common.Ad
 	return filter
 }
 
+// bloomFilters flattens the address and topic filter clauses into a single
+// bloombits filter system. Since the bloombits are not positional, nil topics
+// are permitted, which get flattened into a nil byte slice.
+func bloomFilters(addresses []common.Address, topics [][]common.Hash) [][][]byte {
+	var filters [][][]byte
+
+	if len(addresses) > 0 {
+		filter := make([][]byte, len(addresses))
+		for i, address := range addresses {
+			filter[i] = address.Bytes()
+		}
+
+		filters = append(filters, filter)
+	}
+
+	for _, topicList := range topics {
+		filter := make([][]byte, len(topicList))
+		for i, topic := range topicList {
+			filter[i] = topic.Bytes()
+		}
+
+		filters = append(filters, filter)
+	}
+
+	return filters
+}
+
 // newFilter creates a generic filter that can either filter based on a block hash,
 // or based on range queries. The search criteria needs to be explicitly set.
 func newFilter(sys *FilterSystem, addresses []common.Address, topics [][]common.Hash) *Filter {
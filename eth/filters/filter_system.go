@@ -44,6 +44,7 @@ import (
 type Config struct {
 	LogCacheSize int           // maximum number of cached blocks (default: 32)
 	Timeout      time.Duration // how long filters stay active (default: 5min)
+	BorLogs      bool          // whether log queries include the bor state-sync logs
 }
 
 func (cfg Config) withDefaults() Config {
@@ -81,6 +82,8 @@ type Backend interface {
 
 	BloomStatus() (uint64, uint64)
 	ServiceFilter(ctx context.Context, session *bloombits.MatcherSession)
+	BorBloomStatus() (uint64, uint64)
+	ServiceBorFilter(ctx context.Context, session *bloombits.MatcherSession)
 }
 
 // FilterSystem holds resources shared by all filters.
@@ -101,6 +104,11 @@ func NewFilterSystem(backend Backend, config Config) *FilterSystem {
 	}
 }
 
+// BorLogs reports whether log queries include the bor state-sync logs.
+func (sys *FilterSystem) BorLogs() bool {
+	return sys.cfg.BorLogs
+}
+
 type logCacheElem struct {
 	logs []*types.Log
 	body atomic.Value
@@ -32,6 +32,10 @@ func (b *TestBackend) BloomStatus() (uint64, uint64) {
 	return params.BloomBitsBlocks, b.sections
 }
 
+func (b *TestBackend) BorBloomStatus() (uint64, uint64) {
+	return params.BloomBitsBlocks, b.sections
+}
+
 func (b *TestBackend) GetBorBlockReceipt(ctx context.Context, hash common.Hash) (*types.Receipt, error) {
 	number := rawdb.ReadHeaderNumber(b.DB, hash)
 	if number == nil {
@@ -188,3 +192,28 @@ func (b *TestBackend) GetBody(context.Context, common.Hash, rpc.BlockNumber) (*t
 func (b *TestBackend) PendingBlockAndReceipts() (*types.Block, types.Receipts) {
 	panic("not implemented")
 }
+
+func (b *TestBackend) ServiceBorFilter(ctx context.Context, session *bloombits.MatcherSession) {
+	requests := make(chan chan *bloombits.Retrieval)
+
+	go session.Multiplex(16, 0, requests)
+	go func() {
+		for {
+			// Wait for a service request or a shutdown
+			select {
+			case <-ctx.Done():
+				return
+
+			case request := <-requests:
+				task := <-request
+
+				task.Bitsets = make([][]byte, len(task.Sections))
+				for i, section := range task.Sections {
+					head := rawdb.ReadCanonicalHash(b.DB, (section+1)*params.BloomBitsBlocks-1)
+					task.Bitsets[i], _ = rawdb.ReadBorBloomBits(b.DB, task.Bit, section, head)
+				}
+				request <- task
+			}
+		}
+	}()
+}
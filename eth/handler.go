@@ -34,6 +34,7 @@ import (
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/eth/downloader"
 	"github.com/ethereum/go-ethereum/eth/fetcher"
+	borproto "github.com/ethereum/go-ethereum/eth/protocols/bor"
 	"github.com/ethereum/go-ethereum/eth/protocols/eth"
 	"github.com/ethereum/go-ethereum/eth/protocols/snap"
 	"github.com/ethereum/go-ethereum/ethdb"
@@ -49,6 +50,9 @@ const (
 	// The number is referenced from the size of tx pool.
 	txChanSize = 4096
 
+	// chainEventChanSize is the size of channel listening to ChainEvent.
+	chainEventChanSize = 10
+
 	// txMaxBroadcastSize is the max size of a transaction that will be broadcasted.
 	// All transactions with a higher size will be announced and need to be fetched
 	// by the peer.
@@ -94,6 +98,7 @@ type handlerConfig struct {
 	BloomCache     uint64              // Megabytes to alloc for snap sync bloom
 	EventMux       *event.TypeMux      // Legacy event mux, deprecate for `feed`
 	txArrivalWait  time.Duration       // Maximum duration to wait for an announced tx before requesting it
+	topologyMode   string              // Sentry topology role of the node, empty if none
 	checker        ethereum.ChainValidator
 	RequiredBlocks map[uint64]common.Hash // Hard coded map of required block hashes for sync challenges
 	EthAPI         *ethapi.BlockChainAPI  // EthAPI to interact
@@ -111,12 +116,24 @@ type handler struct {
 	chain    *core.BlockChain
 	maxPeers int
 
+	topologyMode string // Sentry topology role of the node, empty if none
+
+	checker ethereum.ChainValidator // Whitelisted checkpoints and milestones to rate peers against
+
+	txProp *txPropagationTracker // Propagation records of the recently seen transactions
+
 	downloader   *downloader.Downloader
 	blockFetcher *fetcher.BlockFetcher
 	txFetcher    *fetcher.TxFetcher
 	peers        *peerSet
 	merger       *consensus.Merger
 
+	borPeers     map[string]*borproto.Peer // Peers serving bor receipts on the `bor` protocol
+	borPeersLock sync.RWMutex              // Lock protecting the bor peers and the bor receipt gap
+
+	borGap         *borReceiptGap // Blocks whose bor receipts no peer served during snap sync
+	borBackfilling atomic.Bool    // Whether the bor receipt gap is being backfilled
+
 	ethAPI *ethapi.BlockChainAPI // EthAPI to interact
 
 	eventMux      *event.TypeMux
@@ -151,8 +168,12 @@ func newHandler(config *handlerConfig) (*handler, error) {
 		txpool:         config.TxPool,
 		chain:          config.Chain,
 		peers:          newPeerSet(),
+		borPeers:       make(map[string]*borproto.Peer),
 		merger:         config.Merger,
 		ethAPI:         config.EthAPI,
+		topologyMode:   config.topologyMode,
+		checker:        config.checker,
+		txProp:         newTxPropagationTracker(),
 		requiredBlocks: config.RequiredBlocks,
 		quitSync:       make(chan struct{}),
 		handlerDoneCh:  make(chan struct{}),
@@ -190,6 +211,9 @@ func newHandler(config *handlerConfig) (*handler, error) {
 		if h.snapSync.Load() {
 			log.Info("Snap sync complete, auto disabling")
 			h.snapSync.Store(false)
+
+			// Retrieve the bor receipts no peer served while syncing
+			go (*borSyncHandler)(h).backfillBorGap()
 		}
 		// If we've successfully finished a sync cycle, accept transactions from
 		// the network
@@ -197,6 +221,12 @@ func newHandler(config *handlerConfig) (*handler, error) {
 	}
 	// Construct the downloader (long sync)
 	h.downloader = downloader.New(config.Database, h.eventMux, h.chain, nil, h.removePeer, success, config.checker)
+	if h.chain.Config().Bor != nil {
+		// Bor receipts aren't part of the block receipts, snap sync retrieves
+		// them separately over the `bor` protocol
+		h.downloader.SetBorReceiptFetcher((*borSyncHandler)(h))
+	}
+
 	if ttd := h.chain.Config().TerminalTotalDifficulty; ttd != nil {
 		if h.chain.Config().TerminalTotalDifficultyPassed {
 			log.Info("Chain post-merge, sync via beacon client")
@@ -295,7 +325,7 @@ func newHandler(config *handlerConfig) (*handler, error) {
 
 		return n, err
 	}
-	h.blockFetcher = fetcher.NewBlockFetcher(false, nil, h.chain.GetBlockByHash, validator, h.BroadcastBlock, heighter, nil, inserter, h.removePeer)
+	h.blockFetcher = fetcher.NewBlockFetcher(false, nil, h.chain.GetBlockByHash, validator, h.BroadcastBlock, heighter, nil, inserter, h.dropInvalidPeer)
 
 	fetchTx := func(peer string, hashes []common.Hash) error {
 		p := h.peers.peer(peer)
@@ -309,6 +339,8 @@ func newHandler(config *handlerConfig) (*handler, error) {
 		return h.txpool.Add(txs, false, false)
 	}
 	h.txFetcher = fetcher.NewTxFetcher(h.txpool.Has, addTxs, fetchTx, config.txArrivalWait)
+	h.txFetcher.SetInvalidTxsHook(h.penaliseInvalidTxs)
+	h.txFetcher.SetPropagationHooks(h.txProp.announced, h.txProp.delivered)
 	h.chainSync = newChainSyncer(h)
 
 	return h, nil
@@ -395,6 +427,11 @@ func (h *handler) runEthPeer(peer *eth.Peer, handler eth.Handler) error {
 		if reject || h.peers.len() >= h.maxPeers {
 			return p2p.DiscTooManyPeers
 		}
+		// Refuse peers evicted for misbehaviour until their reputation recovers
+		if h.peers.score(peer.ID()).current() <= scoreEvict {
+			peer.Log().Debug("Refusing low rated peer")
+			return p2p.DiscUselessPeer
+		}
 	}
 	peer.Log().Debug("Ethereum peer connected", "name", peer.Name())
 
@@ -409,6 +446,12 @@ func (h *handler) runEthPeer(peer *eth.Peer, handler eth.Handler) error {
 	if p == nil {
 		return errors.New("peer dropped during handling")
 	}
+
+	if remoteHead, _ := peer.Head(); remoteHead != (common.Hash{}) {
+		if header := h.chain.GetHeaderByHash(remoteHead); header != nil {
+			p.score.markHead(header.Number.Uint64())
+		}
+	}
 	// Register the peer in the downloader. If the downloader considers it banned, we disconnect
 	if err := h.downloader.RegisterPeer(peer.ID(), peer.Version(), peer); err != nil {
 		peer.Log().Error("Failed to register peer in eth syncer", "err", err)
@@ -535,6 +578,7 @@ func (h *handler) unregisterPeer(id string) {
 
 	h.downloader.UnregisterPeer(id)
 	h.txFetcher.Drop(id)
+	h.txProp.dropPeer(id)
 
 	if err := h.peers.unregisterPeer(id); err != nil {
 		logger.Error("Ethereum peer removal failed", "err", err)
@@ -564,6 +608,14 @@ func (h *handler) Start(maxPeers int) {
 	// start peer handler tracker
 	h.wg.Add(1)
 	go h.protoTracker()
+
+	// start peer rating
+	h.wg.Add(1)
+	go h.peerScoreLoop()
+
+	// track the inclusion of the propagated transactions
+	h.wg.Add(1)
+	go h.txInclusionLoop()
 }
 
 func (h *handler) Stop() {
@@ -600,7 +652,7 @@ func (h *handler) BroadcastBlock(block *types.Block, propagate bool) {
 	}
 
 	hash := block.Hash()
-	peers := h.peers.peersWithoutBlock(hash)
+	peers := h.filterTopologyPeers(h.peers.peersWithoutBlock(hash))
 
 	// If propagation is requested, send to a subset of the peer
 	if propagate {
@@ -612,10 +664,22 @@ func (h *handler) BroadcastBlock(block *types.Block, propagate bool) {
 			log.Error("Propagating dangling block", "number", block.Number(), "hash", hash)
 			return
 		}
-		// Send the block to a subset of our peers
+		// Send the block to a subset of our peers, and to all the validators
+		// behind a sentry so that they are never delayed by an announcement
 		transfer := peers[:int(math.Sqrt(float64(len(peers))))]
+		if h.topologyMode == p2p.TopologySentry {
+			for _, peer := range peers[len(transfer):] {
+				if peer.Peer.IsValidator() {
+					transfer = append(transfer, peer)
+				}
+			}
+		}
+
+		// The peers are not going to announce the block back, track it as
+		// their head so that they are not rated as stale
 		for _, peer := range transfer {
 			peer.AsyncSendNewBlock(block, td)
+			peer.score.markHead(block.NumberU64())
 		}
 
 		log.Trace("Propagated block", "hash", hash, "recipients", len(transfer), "duration", common.PrettyDuration(time.Since(block.ReceivedAt)))
@@ -626,6 +690,7 @@ func (h *handler) BroadcastBlock(block *types.Block, propagate bool) {
 	if h.chain.HasBlock(hash, block.NumberU64()) {
 		for _, peer := range peers {
 			peer.AsyncSendNewBlockHash(block)
+			peer.score.markHead(block.NumberU64())
 		}
 
 		log.Trace("Announced block", "hash", hash, "recipients", len(peers), "duration", common.PrettyDuration(time.Since(block.ReceivedAt)))
@@ -649,7 +714,7 @@ func (h *handler) BroadcastTransactions(txs types.Transactions) {
 	)
 	// Broadcast transactions to a batch of peers not knowing about it
 	for _, tx := range txs {
-		peers := h.peers.peersWithoutTransaction(tx.Hash())
+		peers := h.filterTopologyPeers(h.peers.peersWithoutTransaction(tx.Hash()))
 
 		var numDirect int
 		if tx.Size() <= txMaxBroadcastSize {
@@ -663,6 +728,8 @@ func (h *handler) BroadcastTransactions(txs types.Transactions) {
 		for _, peer := range peers[numDirect:] {
 			annos[peer] = append(annos[peer], tx.Hash())
 		}
+
+		h.txProp.propagated(tx.Hash(), numDirect, len(peers)-numDirect)
 	}
 
 	for peer, hashes := range txset {
@@ -682,6 +749,46 @@ func (h *handler) BroadcastTransactions(txs types.Transactions) {
 		"tx packs", directPeers, "broadcast txs", directCount)
 }
 
+// filterTopologyPeers drops the peers a validator must not send blocks or
+// transactions to, i.e. everything but its sentries.
+func (h *handler) filterTopologyPeers(peers []*ethPeer) []*ethPeer {
+	if h.topologyMode != p2p.TopologyValidator {
+		return peers
+	}
+
+	sentries := peers[:0]
+
+	for _, peer := range peers {
+		if peer.Peer.IsSentry() {
+			sentries = append(sentries, peer)
+		}
+	}
+
+	return sentries
+}
+
+// txInclusionLoop records the inclusion of the propagated transactions in the
+// canonical chain.
+func (h *handler) txInclusionLoop() {
+	defer h.wg.Done()
+
+	chainCh := make(chan core.ChainEvent, chainEventChanSize)
+	sub := h.chain.SubscribeChainEvent(chainCh)
+
+	defer sub.Unsubscribe()
+
+	for {
+		select {
+		case ev := <-chainCh:
+			h.txProp.included(ev.Block)
+		case <-sub.Err():
+			return
+		case <-h.quitSync:
+			return
+		}
+	}
+}
+
 // minedBroadcastLoop sends mined blocks to connected peers.
 func (h *handler) minedBroadcastLoop() {
 	defer h.wg.Done()
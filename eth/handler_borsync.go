@@ -0,0 +1,405 @@
+package eth
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/core/types"
+	borproto "github.com/ethereum/go-ethereum/eth/protocols/bor"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/p2p"
+	"github.com/ethereum/go-ethereum/p2p/enode"
+	"github.com/ethereum/go-ethereum/params"
+	"github.com/ethereum/go-ethereum/rlp"
+)
+
+const (
+	// maxBorReceiptsFetch is the number of bor receipts requested at once.
+	maxBorReceiptsFetch = 256
+
+	// maxBorReceiptsRangeFetch is the number of blocks whose bor receipts are
+	// requested at once by a range query.
+	maxBorReceiptsRangeFetch = 4096
+
+	// borReceiptsTimeout is the time allowed for a peer to answer a bor
+	// receipt request.
+	borReceiptsTimeout = 10 * time.Second
+)
+
+// errNoBorPeers is returned if bor receipts are needed but no connected peer
+// is able to serve them.
+var errNoBorPeers = errors.New("no peers serving bor receipts")
+
+// borReceiptGap is a range of blocks whose bor receipts could not be retrieved
+// during snap sync, to be backfilled once a peer serves them.
+type borReceiptGap struct {
+	from uint64
+	to   uint64
+}
+
+// borSyncHandler implements the borproto.Backend interface to serve the bor
+// receipts of the local chain, and retrieves them from remote peers during
+// snap sync.
+type borSyncHandler handler
+
+func (h *borSyncHandler) Chain() *core.BlockChain { return h.chain }
+
+// RunPeer is invoked when a peer joins on the `bor` protocol.
+func (h *borSyncHandler) RunPeer(peer *borproto.Peer, hand borproto.Handler) error {
+	if !(*handler)(h).incHandlers() {
+		return p2p.DiscQuitting
+	}
+	defer (*handler)(h).decHandlers()
+
+	h.borPeersLock.Lock()
+	h.borPeers[peer.ID()] = peer
+	h.borPeersLock.Unlock()
+
+	defer func() {
+		h.borPeersLock.Lock()
+		delete(h.borPeers, peer.ID())
+		h.borPeersLock.Unlock()
+	}()
+
+	// Peers serving bor receipt ranges may fill the gap left by snap sync
+	if peer.Version() >= borproto.BOR2 {
+		go h.backfillBorGap()
+	}
+
+	return hand(peer)
+}
+
+// PeerInfo retrieves all known `bor` information about a peer.
+func (h *borSyncHandler) PeerInfo(id enode.ID) interface{} {
+	h.borPeersLock.RLock()
+	defer h.borPeersLock.RUnlock()
+
+	if p, ok := h.borPeers[id.String()]; ok {
+		return map[string]interface{}{"version": p.Version()}
+	}
+
+	return nil
+}
+
+// FetchBorReceipts retrieves the bor receipts of the sprint start blocks among
+// the given headers from the connected `bor` peers. Peers failing to answer are
+// penalised and skipped in favour of the next one. The retrieved receipts are
+// not committed to by the headers, so they must be stored as unverified.
+//
+// If no peer serves them, the blocks are synced without their bor receipts,
+// which are backfilled once a peer able to serve them connects.
+func (h *borSyncHandler) FetchBorReceipts(headers []*types.Header) (map[common.Hash]*types.ReceiptForStorage, error) {
+	config := h.chain.Config().Bor
+
+	var hashes []common.Hash
+
+	for _, header := range headers {
+		if config.IsSprintStart(header.Number.Uint64()) {
+			hashes = append(hashes, header.Hash())
+		}
+	}
+
+	receipts := make(map[common.Hash]*types.ReceiptForStorage)
+
+	for len(hashes) > 0 {
+		batch := hashes
+		if len(batch) > maxBorReceiptsFetch {
+			batch = batch[:maxBorReceiptsFetch]
+		}
+
+		res, err := h.fetchBorReceiptBatch(batch)
+		if errors.Is(err, errNoBorPeers) {
+			from, to := headers[0].Number.Uint64(), headers[len(headers)-1].Number.Uint64()
+			if h.addBorGap(from, to) {
+				log.Warn("No peer serving bor receipts, deferring them", "from", from, "to", to)
+			} else {
+				log.Debug("No peer serving bor receipts, deferring them", "from", from, "to", to, "missing", len(hashes))
+			}
+
+			break
+		}
+
+		if err != nil {
+			return nil, err
+		}
+
+		for i, receipt := range res {
+			if receipt != nil {
+				receipts[batch[i]] = receipt
+			}
+		}
+
+		hashes = hashes[len(res):]
+	}
+
+	return receipts, nil
+}
+
+// fetchBorReceiptBatch retrieves the bor receipts of a batch of blocks from the
+// first peer serving any of them. A peer answering without a single receipt is
+// not trusted to be right, the blocks are only considered to have no bor
+// receipt if no other peer serves one.
+func (h *borSyncHandler) fetchBorReceiptBatch(batch []common.Hash) ([]*types.ReceiptForStorage, error) {
+	var empty []*types.ReceiptForStorage
+
+	for _, peer := range h.borPeerList() {
+		ctx, cancel := context.WithTimeout(context.Background(), borReceiptsTimeout)
+		res, err := peer.RequestBorReceipts(ctx, batch)
+
+		cancel()
+
+		if err == nil && len(res) == 0 {
+			err = errors.New("empty response")
+		}
+
+		if err != nil {
+			peer.Log().Debug("Failed to retrieve bor receipts", "err", err)
+			(*handler)(h).penalisePeer(peer.ID(), scoreFailedBorRequest, "failed bor receipt request")
+
+			continue
+		}
+
+		for _, receipt := range res {
+			if receipt != nil {
+				return res, nil
+			}
+		}
+
+		if empty == nil || len(res) < len(empty) {
+			empty = res
+		}
+	}
+
+	if empty == nil {
+		return nil, errNoBorPeers
+	}
+
+	return empty, nil
+}
+
+// BackfillBorReceipts retrieves the missing bor receipts of the canonical
+// blocks in [from, to] from the connected `bor` peers and stores them along
+// with their bor tx lookup entries. Only receipts of blocks matching the local
+// canonical chain are accepted. It returns the number of bor receipts written.
+func (h *borSyncHandler) BackfillBorReceipts(ctx context.Context, from uint64, to uint64) (int, error) {
+	var (
+		db      = h.chain.DB()
+		config  = h.chain.Config().Bor
+		written int
+		start   = time.Now()
+		logged  = time.Now()
+	)
+
+	if config == nil {
+		return 0, errors.New("bor receipts are only available on bor chains")
+	}
+
+	if tail := rawdb.ReadBorReceiptTail(db); tail != nil && from < *tail {
+		from = *tail
+	}
+
+	if head := h.chain.CurrentBlock().Number.Uint64(); to > head {
+		to = head
+	}
+
+	for next := from; next <= to; {
+		amount := to - next + 1
+		if amount > maxBorReceiptsRangeFetch {
+			amount = maxBorReceiptsRangeFetch
+		}
+
+		served := false
+
+		for _, peer := range h.borPeerList() {
+			if peer.Version() < borproto.BOR2 {
+				continue
+			}
+
+			reqCtx, cancel := context.WithTimeout(ctx, borReceiptsTimeout)
+			last, entries, err := peer.RequestBorReceiptsRange(reqCtx, next, amount)
+
+			cancel()
+
+			if err == nil && last < next {
+				err = errors.New("range not available")
+			}
+
+			if err != nil {
+				if ctx.Err() != nil {
+					return written, ctx.Err()
+				}
+
+				peer.Log().Debug("Failed to retrieve bor receipt range", "origin", next, "err", err)
+
+				continue
+			}
+
+			n, err := h.writeBorReceipts(config, entries)
+			if err != nil {
+				return written, err
+			}
+
+			written += n
+			next, served = last+1, true
+
+			break
+		}
+
+		if !served {
+			log.Warn("Unable to backfill bor receipts", "next", next, "to", to, "peers", len(h.borPeerList()))
+			return written, fmt.Errorf("%w: block %d", errNoBorPeers, next)
+		}
+
+		if time.Since(logged) > 8*time.Second {
+			log.Info("Backfilling bor receipts", "next", next, "to", to, "written", written, "elapsed", common.PrettyDuration(time.Since(start)))
+			logged = time.Now()
+		}
+	}
+
+	log.Info("Backfilled bor receipts", "from", from, "to", to, "written", written, "elapsed", common.PrettyDuration(time.Since(start)))
+
+	return written, nil
+}
+
+// writeBorReceipts stores the bor receipts of a range response which are
+// missing locally, marked as unverified. Entries not matching a local canonical
+// sprint start block are ignored.
+func (h *borSyncHandler) writeBorReceipts(config *params.BorConfig, entries []*borproto.BorReceiptEntry) (int, error) {
+	var (
+		db      = h.chain.DB()
+		batch   = db.NewBatch()
+		written int
+		first   *uint64
+	)
+
+	indexTail := rawdb.ReadTxIndexTail(db)
+
+	for _, entry := range entries {
+		if entry.Number == 0 || !config.IsSprintStart(entry.Number) {
+			continue
+		}
+
+		if rawdb.ReadCanonicalHash(db, entry.Number) != entry.Hash {
+			continue
+		}
+
+		if len(rawdb.ReadBorReceiptRLP(db, entry.Hash, entry.Number)) > 0 {
+			continue
+		}
+
+		receipt := new(types.ReceiptForStorage)
+		if err := rlp.DecodeBytes(entry.Receipt, receipt); err != nil {
+			log.Debug("Dropping invalid bor receipt", "number", entry.Number, "err", err)
+			continue
+		}
+
+		rawdb.WriteBorReceipt(batch, entry.Hash, entry.Number, receipt)
+		rawdb.WriteBorReceiptUnverified(batch, entry.Hash, entry.Number)
+
+		if indexTail == nil || entry.Number >= *indexTail {
+			rawdb.WriteBorTxLookupEntry(batch, entry.Hash, entry.Number)
+		}
+
+		if first == nil || entry.Number < *first {
+			number := entry.Number
+			first = &number
+		}
+
+		written++
+	}
+
+	if err := batch.Write(); err != nil {
+		return written, err
+	}
+
+	// The blocks were indexed without their bor receipts
+	if first != nil {
+		rawdb.MarkBorBloomStale(db, *first)
+	}
+
+	return written, nil
+}
+
+// addBorGap records that the bor receipts of the blocks in [from, to] are
+// missing, extending the gap to backfill. It returns whether there was no gap
+// before.
+func (h *borSyncHandler) addBorGap(from uint64, to uint64) bool {
+	h.borPeersLock.Lock()
+	defer h.borPeersLock.Unlock()
+
+	if h.borGap == nil {
+		h.borGap = &borReceiptGap{from: from, to: to}
+		return true
+	}
+
+	if from < h.borGap.from {
+		h.borGap.from = from
+	}
+
+	if to > h.borGap.to {
+		h.borGap.to = to
+	}
+
+	return false
+}
+
+// backfillBorGap retrieves the bor receipts missing since snap sync, if any,
+// from the connected peers. The gap is kept if they fail to serve it, or if
+// snap sync is still running, as its blocks are not canonical yet.
+func (h *borSyncHandler) backfillBorGap() {
+	if h.snapSync.Load() || !h.borBackfilling.CompareAndSwap(false, true) {
+		return
+	}
+	defer h.borBackfilling.Store(false)
+
+	h.borPeersLock.Lock()
+	gap := h.borGap
+	h.borGap = nil
+	h.borPeersLock.Unlock()
+
+	if gap == nil {
+		return
+	}
+
+	if !(*handler)(h).incHandlers() {
+		return
+	}
+	defer (*handler)(h).decHandlers()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	go func() {
+		select {
+		case <-h.quitSync:
+			cancel()
+		case <-ctx.Done():
+		}
+	}()
+
+	if _, err := h.BackfillBorReceipts(ctx, gap.from, gap.to); err != nil {
+		log.Debug("Failed to backfill bor receipts", "from", gap.from, "to", gap.to, "err", err)
+		h.addBorGap(gap.from, gap.to)
+	}
+}
+
+// borPeerList returns the connected `bor` peers in random order.
+func (h *borSyncHandler) borPeerList() []*borproto.Peer {
+	h.borPeersLock.RLock()
+	defer h.borPeersLock.RUnlock()
+
+	peers := make([]*borproto.Peer, 0, len(h.borPeers))
+	for _, p := range h.borPeers {
+		peers = append(peers, p)
+	}
+
+	rand.Shuffle(len(peers), func(i, j int) { peers[i], peers[j] = peers[j], peers[i] })
+
+	return peers
+}
@@ -0,0 +1,50 @@
+package eth
+
+import (
+	"testing"
+
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// Tests that bor receipts no peer serves don't fail the sync, but are recorded
+// to be backfilled later.
+func TestFetchBorReceiptsWithoutPeers(t *testing.T) {
+	t.Parallel()
+
+	handler := newTestHandlerWithBlocks(64)
+	defer handler.close()
+
+	h := (*borSyncHandler)(handler.handler)
+
+	fetch := func(from uint64, to uint64) {
+		t.Helper()
+
+		var headers []*types.Header
+		for number := from; number <= to; number++ {
+			headers = append(headers, handler.chain.GetHeaderByNumber(number))
+		}
+
+		receipts, err := h.FetchBorReceipts(headers)
+		if err != nil {
+			t.Fatalf("failed to fetch bor receipts: %v", err)
+		}
+
+		if len(receipts) != 0 {
+			t.Fatalf("unexpected bor receipts: %d", len(receipts))
+		}
+	}
+
+	fetch(17, 32)
+	fetch(1, 16)
+
+	if gap := h.borGap; gap == nil || gap.from != 1 || gap.to != 32 {
+		t.Fatalf("unexpected bor receipt gap: %+v", gap)
+	}
+
+	// The gap is kept as long as no peer serves it
+	h.backfillBorGap()
+
+	if gap := h.borGap; gap == nil || gap.from != 1 || gap.to != 32 {
+		t.Fatalf("unexpected bor receipt gap after backfill: %+v", gap)
+	}
+}
@@ -108,6 +108,12 @@ func (h *ethHandler) handleBlockAnnounces(peer *eth.Peer, hashes []common.Hash,
 		}
 	}
 
+	if p := h.peers.peer(peer.ID()); p != nil {
+		for _, number := range numbers {
+			p.score.markHead(number)
+		}
+	}
+
 	for i := 0; i < len(unknownHashes); i++ {
 		h.blockFetcher.Notify(peer.ID(), unknownHashes[i], unknownNumbers[i], time.Now(), peer.RequestOneHeader, peer.RequestBodies)
 	}
@@ -126,6 +132,21 @@ func (h *ethHandler) handleBlockBroadcast(peer *eth.Peer, block *types.Block, td
 		return nil
 		// return errors.New("unexpected block announces")
 	}
+	// Drop blocks conflicting with the whitelisted checkpoint or milestone,
+	// penalising the peer only if the block replaces a whitelisted one
+	if h.checker != nil && !h.chain.HasBlock(block.Hash(), block.NumberU64()) {
+		if valid, _ := h.checker.IsValidChain(h.chain.CurrentHeader(), []*types.Header{block.Header()}); !valid {
+			if (*handler)(h).conflictsWithWhitelist(block.Header()) {
+				(*handler)(h).penalisePeer(peer.ID(), scoreNonWhitelisted, "non-whitelisted block")
+			}
+
+			return nil
+		}
+	}
+
+	if p := h.peers.peer(peer.ID()); p != nil {
+		p.score.markHead(block.NumberU64())
+	}
 	// Schedule the block for import
 	h.blockFetcher.Enqueue(peer.ID(), block)
 
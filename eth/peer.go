@@ -25,12 +25,14 @@ import (
 // about a connected peer.
 type ethPeerInfo struct {
 	Version uint `json:"version"` // Ethereum protocol version negotiated
+	Score   int  `json:"score"`   // Reputation of the peer, lowered by misbehaviour and latency
 }
 
 // ethPeer is a wrapper around eth.Peer to maintain a few extra metadata.
 type ethPeer struct {
 	*eth.Peer
-	snapExt *snapPeer // Satellite `snap` connection
+	snapExt *snapPeer  // Satellite `snap` connection
+	score   *peerScore // Reputation of the peer, kept across reconnects
 }
 
 // info gathers and returns some `eth` protocol metadata known about a peer.
@@ -38,9 +40,16 @@ type ethPeer struct {
 func (p *ethPeer) info() *ethPeerInfo {
 	return &ethPeerInfo{
 		Version: p.Version(),
+		Score:   p.rating(),
 	}
 }
 
+// rating returns the reputation of the peer lowered by its latency, used to
+// prioritise and evict peers.
+func (p *ethPeer) rating() int {
+	return p.score.current() - latencyPenalty(p.Latency())
+}
+
 // snapPeerInfo represents a short summary of the `snap` sub-protocol metadata known
 // about a connected peer.
 type snapPeerInfo struct {
@@ -0,0 +1,236 @@
+package eth
+
+import (
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/p2p"
+)
+
+// Reputation adjustments for the behaviour of a peer. A peer starts with the
+// maximum score, which is lowered by its misbehaviour and slowly recovers.
+const (
+	scoreMax   = 100  // Score of a well behaving peer
+	scoreMin   = -100 // Lowest score a peer can fall to
+	scoreEvict = 0    // Peers scoring at or below are disconnected and refused
+
+	scoreInvalidBlock   = -60 // Block or header failing consensus verification
+	scoreNonWhitelisted = -40 // Chain conflicting with the whitelisted checkpoint or milestone
+	scoreStaleHead      = -10 // Head far behind the latest milestone, per check
+	scoreSpamTxs        = -10 // Delivery made mostly of invalid transactions
+	scoreInvalidTxs     = -2  // Delivery containing invalid transactions
+
+	scoreFailedBorRequest = -10 // Bor receipt request failed, timed out or answered empty
+
+	scoreRecoveryInterval = time.Minute // Time for a penalised peer to recover one point
+
+	scoreLatencyUnit = 100 * time.Millisecond // Latency costing a peer one point
+	scoreLatencyMax  = 20                     // Maximum points a slow peer loses
+)
+
+const (
+	// scoreCacheSize is the number of peers to remember the reputation of, so
+	// that misbehaving peers can't reset their score by reconnecting.
+	scoreCacheSize = 1024
+
+	// scoreCheckInterval is the interval to check the heads of the peers and to
+	// evict the lowest scoring ones.
+	scoreCheckInterval = time.Minute
+
+	// staleHeadDistance is the number of blocks a peer's head may be behind the
+	// latest milestone before being considered stale.
+	staleHeadDistance = 64
+)
+
+// peerScore is the reputation of a peer, built from its behaviour over the
+// `eth` protocol.
+type peerScore struct {
+	reputation int       // Score before recovery, lowered by penalties
+	updated    time.Time // Time the reputation was last updated
+	head       uint64    // Highest block number the peer is known to have
+
+	lock sync.Mutex
+}
+
+func newPeerScore() *peerScore {
+	return &peerScore{reputation: scoreMax, updated: time.Now()}
+}
+
+// recover raises the reputation by the points recovered since the last update.
+// The caller must hold the lock.
+func (s *peerScore) recover() {
+	if elapsed := time.Since(s.updated); elapsed >= scoreRecoveryInterval {
+		points := int(elapsed / scoreRecoveryInterval)
+		if s.reputation += points; s.reputation > scoreMax {
+			s.reputation = scoreMax
+		}
+
+		s.updated = s.updated.Add(time.Duration(points) * scoreRecoveryInterval)
+	}
+}
+
+// penalise lowers the reputation of the peer, returning the new reputation.
+func (s *peerScore) penalise(penalty int) int {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	s.recover()
+
+	if s.reputation += penalty; s.reputation < scoreMin {
+		s.reputation = scoreMin
+	}
+
+	return s.reputation
+}
+
+// current returns the reputation of the peer after recovery.
+func (s *peerScore) current() int {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	s.recover()
+
+	return s.reputation
+}
+
+// markHead records a block number the peer is known to have.
+func (s *peerScore) markHead(number uint64) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	if number > s.head {
+		s.head = number
+	}
+}
+
+// knownHead returns the highest block number the peer is known to have.
+func (s *peerScore) knownHead() uint64 {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	return s.head
+}
+
+// latencyPenalty returns the points a peer loses for the given latency.
+func latencyPenalty(latency time.Duration) int {
+	if points := int(latency / scoreLatencyUnit); points < scoreLatencyMax {
+		return points
+	}
+
+	return scoreLatencyMax
+}
+
+// penalisePeer lowers the reputation of a peer for its misbehaviour, evicting
+// it if its rating falls to the eviction threshold.
+func (h *handler) penalisePeer(id string, penalty int, reason string) {
+	reputation := h.peers.score(id).penalise(penalty)
+	log.Debug("Penalised peer", "peer", id, "reason", reason, "penalty", penalty, "reputation", reputation)
+
+	if p := h.peers.peer(id); p != nil && p.rating() <= scoreEvict {
+		h.evictPeer(p, reason)
+	}
+}
+
+// penaliseInvalidTxs lowers the reputation of a peer which delivered invalid
+// transactions, the more if they make up most of the delivery.
+func (h *handler) penaliseInvalidTxs(id string, invalid int, delivered int) {
+	if invalid == 0 {
+		return
+	}
+
+	if 2*invalid > delivered {
+		h.penalisePeer(id, scoreSpamTxs, "mostly invalid transactions")
+		return
+	}
+
+	h.penalisePeer(id, scoreInvalidTxs, "invalid transactions")
+}
+
+// conflictsWithWhitelist reports whether the header is at the number of the
+// whitelisted checkpoint or milestone, but with another hash. Unknown blocks
+// elsewhere below them may just be stale, and don't prove a conflicting chain.
+func (h *handler) conflictsWithWhitelist(header *types.Header) bool {
+	if h.checker == nil {
+		return false
+	}
+
+	number := header.Number.Uint64()
+
+	for _, whitelisted := range []func() (bool, uint64, common.Hash){h.checker.GetWhitelistedCheckpoint, h.checker.GetWhitelistedMilestone} {
+		if exists, n, hash := whitelisted(); exists && n == number && hash != header.Hash() {
+			return true
+		}
+	}
+
+	return false
+}
+
+// dropInvalidPeer penalises and disconnects a peer which delivered invalid
+// blocks or headers.
+func (h *handler) dropInvalidPeer(id string) {
+	h.penalisePeer(id, scoreInvalidBlock, "invalid block")
+	h.removePeer(id)
+}
+
+// evictPeer disconnects a low rated peer, unless it was explicitly configured
+// by the user.
+func (h *handler) evictPeer(p *ethPeer, reason string) {
+	if info := p.Peer.Info(); info.Network.Trusted || info.Network.Static {
+		return
+	}
+
+	p.Log().Debug("Evicting low rated peer", "rating", p.rating(), "reason", reason)
+	p.Peer.Disconnect(p2p.DiscUselessPeer)
+}
+
+// peerScoreLoop periodically penalises the peers stuck behind the latest
+// milestone and evicts the lowest rated ones.
+func (h *handler) peerScoreLoop() {
+	defer h.wg.Done()
+
+	ticker := time.NewTicker(scoreCheckInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			h.checkPeerScores()
+		case <-h.quitSync:
+			return
+		}
+	}
+}
+
+// checkPeerScores penalises the peers whose head is far behind the latest
+// milestone, and evicts the peers rated at or below the eviction threshold.
+func (h *handler) checkPeerScores() {
+	var milestone uint64
+	if h.checker != nil {
+		if exists, number, _ := h.checker.GetWhitelistedMilestone(); exists {
+			milestone = number
+		}
+	}
+
+	for _, p := range h.peers.allPeers() {
+		// Catch up with the head the peer advertised last
+		if hash, _ := p.Head(); hash != (common.Hash{}) {
+			if header := h.chain.GetHeaderByHash(hash); header != nil {
+				p.score.markHead(header.Number.Uint64())
+			}
+		}
+
+		// Peers with an unknown head might be ahead or on another fork, only
+		// rate the ones known to be lagging
+		if head := p.score.knownHead(); head > 0 && head+staleHeadDistance < milestone {
+			p.score.penalise(scoreStaleHead)
+			p.Log().Debug("Penalised stale peer", "head", head, "milestone", milestone)
+		}
+
+		if p.rating() <= scoreEvict {
+			h.evictPeer(p, "low rating")
+		}
+	}
+}
@@ -0,0 +1,181 @@
+package eth
+
+import (
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/eth/downloader/whitelist"
+	"github.com/ethereum/go-ethereum/eth/protocols/eth"
+	"github.com/ethereum/go-ethereum/p2p"
+	"github.com/ethereum/go-ethereum/p2p/enode"
+)
+
+func TestPeerScorePenaliseRecover(t *testing.T) {
+	t.Parallel()
+
+	score := newPeerScore()
+	if have := score.current(); have != scoreMax {
+		t.Fatalf("initial score mismatch: have %d, want %d", have, scoreMax)
+	}
+
+	if have := score.penalise(scoreInvalidBlock); have != scoreMax+scoreInvalidBlock {
+		t.Fatalf("penalised score mismatch: have %d, want %d", have, scoreMax+scoreInvalidBlock)
+	}
+
+	for i := 0; i < 10; i++ {
+		score.penalise(scoreInvalidBlock)
+	}
+
+	if have := score.current(); have != scoreMin {
+		t.Fatalf("score not capped: have %d, want %d", have, scoreMin)
+	}
+
+	// Rewind the last update to recover some points
+	score.updated = score.updated.Add(-5*scoreRecoveryInterval - scoreRecoveryInterval/2)
+	if have := score.current(); have != scoreMin+5 {
+		t.Fatalf("recovered score mismatch: have %d, want %d", have, scoreMin+5)
+	}
+
+	score.updated = score.updated.Add(-1000 * scoreRecoveryInterval)
+	if have := score.current(); have != scoreMax {
+		t.Fatalf("recovered score not capped: have %d, want %d", have, scoreMax)
+	}
+}
+
+func TestLatencyPenalty(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		latency time.Duration
+		want    int
+	}{
+		{0, 0},
+		{scoreLatencyUnit - 1, 0},
+		{3 * scoreLatencyUnit, 3},
+		{time.Hour, scoreLatencyMax},
+	}
+
+	for _, tt := range tests {
+		if have := latencyPenalty(tt.latency); have != tt.want {
+			t.Errorf("latency %v: penalty mismatch: have %d, want %d", tt.latency, have, tt.want)
+		}
+	}
+}
+
+// Tests that the reputation of a peer survives its reconnection, so that
+// misbehaving peers can't reset it.
+func TestPeerScoreReconnect(t *testing.T) {
+	t.Parallel()
+
+	var (
+		ps   = newPeerSet()
+		p2p1 = p2p.NewPeer(enode.ID{1}, "", nil)
+		peer = eth.NewPeer(eth.ETH68, p2p1, nil, nil)
+	)
+
+	defer peer.Close()
+
+	if err := ps.registerPeer(peer, nil); err != nil {
+		t.Fatalf("failed to register peer: %v", err)
+	}
+
+	ps.score(peer.ID()).penalise(scoreNonWhitelisted)
+
+	if err := ps.unregisterPeer(peer.ID()); err != nil {
+		t.Fatalf("failed to unregister peer: %v", err)
+	}
+
+	if err := ps.registerPeer(peer, nil); err != nil {
+		t.Fatalf("failed to register peer: %v", err)
+	}
+
+	if have, want := ps.peer(peer.ID()).rating(), scoreMax+scoreNonWhitelisted; have != want {
+		t.Fatalf("rating mismatch after reconnect: have %d, want %d", have, want)
+	}
+}
+
+// Tests that block propagation targets the best rated peers first.
+func TestPeersWithoutBlockRating(t *testing.T) {
+	t.Parallel()
+
+	ps := newPeerSet()
+
+	for i := byte(1); i <= 3; i++ {
+		peer := eth.NewPeer(eth.ETH68, p2p.NewPeer(enode.ID{i}, "", nil), nil, nil)
+		defer peer.Close()
+
+		if err := ps.registerPeer(peer, nil); err != nil {
+			t.Fatalf("failed to register peer: %v", err)
+		}
+	}
+
+	ps.score(enode.ID{1}.String()).penalise(scoreNonWhitelisted)
+	ps.score(enode.ID{2}.String()).penalise(scoreStaleHead)
+
+	peers := ps.peersWithoutBlock([32]byte{})
+	if len(peers) != 3 {
+		t.Fatalf("peer count mismatch: have %d, want 3", len(peers))
+	}
+
+	for i, id := range []enode.ID{{3}, {2}, {1}} {
+		if peers[i].ID() != id.String() {
+			t.Errorf("peer %d mismatch: have %s, want %s", i, peers[i].ID(), id)
+		}
+	}
+}
+
+// Tests that peers delivering mostly invalid transactions are penalised more
+// than the ones delivering a few.
+func TestPenaliseInvalidTxs(t *testing.T) {
+	t.Parallel()
+
+	h := &handler{peers: newPeerSet()}
+
+	peer := eth.NewPeer(eth.ETH68, p2p.NewPeer(enode.ID{1}, "", nil), nil, nil)
+	defer peer.Close()
+
+	if err := h.peers.registerPeer(peer, nil); err != nil {
+		t.Fatalf("failed to register peer: %v", err)
+	}
+
+	h.penaliseInvalidTxs(peer.ID(), 0, 10)
+	h.penaliseInvalidTxs(peer.ID(), 5, 10)
+
+	if have, want := h.peers.score(peer.ID()).current(), scoreMax+scoreInvalidTxs; have != want {
+		t.Fatalf("score mismatch: have %d, want %d", have, want)
+	}
+
+	h.penaliseInvalidTxs(peer.ID(), 6, 10)
+
+	if have, want := h.peers.score(peer.ID()).current(), scoreMax+scoreInvalidTxs+scoreSpamTxs; have != want {
+		t.Fatalf("score mismatch: have %d, want %d", have, want)
+	}
+}
+
+// Tests that only blocks replacing a whitelisted one are conflicting.
+func TestConflictsWithWhitelist(t *testing.T) {
+	t.Parallel()
+
+	whitelisted := &types.Header{Number: big.NewInt(10)}
+
+	h := &handler{checker: whitelist.NewService(rawdb.NewMemoryDatabase())}
+	h.checker.ProcessMilestone(10, whitelisted.Hash())
+
+	tests := []struct {
+		header *types.Header
+		want   bool
+	}{
+		{whitelisted, false},
+		{&types.Header{Number: big.NewInt(10), Extra: []byte{0x01}}, true},
+		{&types.Header{Number: big.NewInt(9), Extra: []byte{0x01}}, false},
+	}
+
+	for i, tt := range tests {
+		if have := h.conflictsWithWhitelist(tt.header); have != tt.want {
+			t.Errorf("test %d: conflict mismatch: have %v, want %v", i, have, tt.want)
+		}
+	}
+}
@@ -19,9 +19,11 @@ package eth
 import (
 	"errors"
 	"math/big"
+	"sort"
 	"sync"
 
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/lru"
 	"github.com/ethereum/go-ethereum/eth/protocols/eth"
 	"github.com/ethereum/go-ethereum/eth/protocols/snap"
 	"github.com/ethereum/go-ethereum/p2p"
@@ -54,6 +56,8 @@ type peerSet struct {
 	snapWait map[string]chan *snap.Peer // Peers connected on `eth` waiting for their snap extension
 	snapPend map[string]*snap.Peer      // Peers connected on the `snap` protocol, but not yet on `eth`
 
+	scores lru.BasicLRU[string, *peerScore] // Reputation of the current and recently seen peers
+
 	lock   sync.RWMutex
 	closed bool
 }
@@ -64,6 +68,7 @@ func newPeerSet() *peerSet {
 		peers:    make(map[string]*ethPeer),
 		snapWait: make(map[string]chan *snap.Peer),
 		snapPend: make(map[string]*snap.Peer),
+		scores:   lru.NewBasicLRU[string, *peerScore](scoreCacheSize),
 	}
 }
 
@@ -154,7 +159,8 @@ func (ps *peerSet) registerPeer(peer *eth.Peer, ext *snap.Peer) error {
 	}
 
 	eth := &ethPeer{
-		Peer: peer,
+		Peer:  peer,
+		score: ps.peerScore(id),
 	}
 	if ext != nil {
 		eth.snapExt = &snapPeer{ext}
@@ -194,20 +200,50 @@ func (ps *peerSet) peer(id string) *ethPeer {
 	return ps.peers[id]
 }
 
+// score retrieves the reputation of the peer with the given id, which is also
+// remembered for recently disconnected peers.
+func (ps *peerSet) score(id string) *peerScore {
+	ps.lock.Lock()
+	defer ps.lock.Unlock()
+
+	return ps.peerScore(id)
+}
+
+// peerScore retrieves or creates the reputation of a peer. The caller must hold
+// the lock.
+func (ps *peerSet) peerScore(id string) *peerScore {
+	score, ok := ps.scores.Get(id)
+	if !ok {
+		score = newPeerScore()
+		ps.scores.Add(id, score)
+	}
+
+	return score
+}
+
 // peersWithoutBlock retrieves a list of peers that do not have a given block in
-// their set of known hashes so it might be propagated to them.
+// their set of known hashes so it might be propagated to them. The peers are
+// sorted by decreasing rating, so that the best ones get the block first.
 func (ps *peerSet) peersWithoutBlock(hash common.Hash) []*ethPeer {
 	ps.lock.RLock()
 	defer ps.lock.RUnlock()
 
-	list := make([]*ethPeer, 0, len(ps.peers))
+	var (
+		list    = make([]*ethPeer, 0, len(ps.peers))
+		ratings = make(map[*ethPeer]int, len(ps.peers))
+	)
 
 	for _, p := range ps.peers {
 		if !p.KnownBlock(hash) {
 			list = append(list, p)
+			ratings[p] = p.rating()
 		}
 	}
 
+	sort.SliceStable(list, func(i, j int) bool {
+		return ratings[list[i]] > ratings[list[j]]
+	})
+
 	return list
 }
 
@@ -266,6 +302,19 @@ func (ps *peerSet) peerWithHighestTD() *eth.Peer {
 	return bestPeer
 }
 
+// allPeers retrieves all the registered peers.
+func (ps *peerSet) allPeers() []*ethPeer {
+	ps.lock.RLock()
+	defer ps.lock.RUnlock()
+
+	list := make([]*ethPeer, 0, len(ps.peers))
+	for _, p := range ps.peers {
+		list = append(list, p)
+	}
+
+	return list
+}
+
 // close disconnects all peers.
 func (ps *peerSet) close() {
 	ps.lock.Lock()
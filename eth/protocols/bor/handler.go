@@ -0,0 +1,251 @@
+package bor
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/metrics"
+	"github.com/ethereum/go-ethereum/p2p"
+	"github.com/ethereum/go-ethereum/p2p/enode"
+	"github.com/ethereum/go-ethereum/rlp"
+)
+
+const (
+	// softResponseLimit is the target maximum size of replies to data retrievals.
+	softResponseLimit = 2 * 1024 * 1024
+
+	// maxBorReceiptsServe is the maximum number of bor receipts to serve. This
+	// number is there to limit the number of disk lookups.
+	maxBorReceiptsServe = 1024
+
+	// maxBorReceiptsRangeServe is the maximum number of blocks a bor receipt
+	// range query may scan.
+	maxBorReceiptsRangeServe = 32768
+)
+
+// Handler is a callback to invoke from an outside runner after the boilerplate
+// exchanges have passed.
+type Handler func(peer *Peer) error
+
+// Backend defines the data retrieval methods to serve remote requests and the
+// callback methods to invoke on remote deliveries.
+type Backend interface {
+	// Chain retrieves the blockchain object to serve data.
+	Chain() *core.BlockChain
+
+	// RunPeer is invoked when a peer joins on the `bor` protocol. The handler
+	// should do any peer maintenance work. If all is passed, control should be
+	// given back to the `handler` to process the inbound messages going forward.
+	RunPeer(peer *Peer, handler Handler) error
+
+	// PeerInfo retrieves all known `bor` information about a peer.
+	PeerInfo(id enode.ID) interface{}
+}
+
+// MakeProtocols constructs the P2P protocol definitions for `bor`.
+func MakeProtocols(backend Backend) []p2p.Protocol {
+	protocols := make([]p2p.Protocol, len(ProtocolVersions))
+
+	for i, version := range ProtocolVersions {
+		version := version // Closure
+
+		protocols[i] = p2p.Protocol{
+			Name:    ProtocolName,
+			Version: version,
+			Length:  protocolLengths[version],
+			Run: func(p *p2p.Peer, rw p2p.MsgReadWriter) error {
+				return backend.RunPeer(NewPeer(version, p, rw), func(peer *Peer) error {
+					return Handle(backend, peer)
+				})
+			},
+			NodeInfo: func() interface{} {
+				return nodeInfo(backend.Chain())
+			},
+			PeerInfo: func(id enode.ID) interface{} {
+				return backend.PeerInfo(id)
+			},
+		}
+	}
+
+	return protocols
+}
+
+// Handle is the callback invoked to manage the life cycle of a `bor` peer.
+// When this function terminates, the peer is disconnected.
+func Handle(backend Backend, peer *Peer) error {
+	defer peer.close()
+
+	for {
+		if err := HandleMessage(backend, peer); err != nil {
+			peer.Log().Debug("Message handling failed in `bor`", "err", err)
+			return err
+		}
+	}
+}
+
+// HandleMessage is invoked whenever an inbound message is received from a
+// remote peer on the `bor` protocol. The remote connection is torn down upon
+// returning any error.
+func HandleMessage(backend Backend, peer *Peer) error {
+	// Read the next message from the remote peer, and ensure it's fully consumed
+	msg, err := peer.rw.ReadMsg()
+	if err != nil {
+		return err
+	}
+
+	if msg.Size > maxMessageSize {
+		return fmt.Errorf("%w: %v > %v", errMsgTooLarge, msg.Size, maxMessageSize)
+	}
+
+	defer msg.Discard()
+
+	// Track the amount of time it takes to serve the request and run the handler
+	if metrics.Enabled {
+		h := fmt.Sprintf("%s/%s/%d/%#02x", p2p.HandleHistName, ProtocolName, peer.Version(), msg.Code)
+		defer func(start time.Time) {
+			sampler := func() metrics.Sample {
+				return metrics.ResettingSample(
+					metrics.NewExpDecaySample(1028, 0.015),
+				)
+			}
+			metrics.GetOrRegisterHistogramLazy(h, nil, sampler).Update(time.Since(start).Microseconds())
+		}(time.Now())
+	}
+	// Handle the message depending on its contents
+	switch msg.Code {
+	case GetBorReceiptsMsg:
+		// Decode the bor receipt retrieval request
+		var req GetBorReceiptsPacket
+		if err := msg.Decode(&req); err != nil {
+			return fmt.Errorf("%w: message %v: %v", errDecode, msg, err)
+		}
+		// Service the request, potentially returning nothing in case of errors
+		return peer.ReplyBorReceipts(req.ID, ServiceGetBorReceiptsQuery(backend.Chain(), req.Hashes))
+
+	case BorReceiptsMsg:
+		// A batch of bor receipts arrived to one of our previous requests
+		res := new(BorReceiptsPacket)
+		if err := msg.Decode(res); err != nil {
+			return fmt.Errorf("%w: message %v: %v", errDecode, msg, err)
+		}
+
+		peer.deliver(res.ID, res)
+
+		return nil
+
+	case GetBorReceiptsRangeMsg:
+		// Decode the bor receipt range retrieval request
+		var req GetBorReceiptsRangePacket
+		if err := msg.Decode(&req); err != nil {
+			return fmt.Errorf("%w: message %v: %v", errDecode, msg, err)
+		}
+		// Service the request, potentially returning nothing in case of errors
+		last, receipts := ServiceGetBorReceiptsRangeQuery(backend.Chain(), req.Origin, req.Amount)
+
+		return peer.ReplyBorReceiptsRange(req.ID, last, receipts)
+
+	case BorReceiptsRangeMsg:
+		// A range of bor receipts arrived to one of our previous requests
+		res := new(BorReceiptsRangePacket)
+		if err := msg.Decode(res); err != nil {
+			return fmt.Errorf("%w: message %v: %v", errDecode, msg, err)
+		}
+
+		peer.deliver(res.ID, res)
+
+		return nil
+
+	default:
+		return fmt.Errorf("%w: %v", errInvalidMsgCode, msg.Code)
+	}
+}
+
+// ServiceGetBorReceiptsQuery assembles the response to a bor receipt query.
+// Bor receipts retrieved from the network and not derived locally are not
+// served. It is exposed to allow external packages to test protocol behavior.
+func ServiceGetBorReceiptsQuery(chain *core.BlockChain, hashes []common.Hash) []rlp.RawValue {
+	var (
+		bytes    int
+		receipts []rlp.RawValue
+	)
+
+	for lookups, hash := range hashes {
+		if bytes >= softResponseLimit || lookups >= maxBorReceiptsServe {
+			break
+		}
+
+		receipt := rlp.RawValue(rlp.EmptyString)
+
+		if number := rawdb.ReadHeaderNumber(chain.DB(), hash); number != nil {
+			if data := rawdb.ReadBorReceiptRLP(chain.DB(), hash, *number); len(data) > 0 && !rawdb.IsBorReceiptUnverified(chain.DB(), hash, *number) {
+				receipt = data
+			}
+		}
+
+		receipts = append(receipts, receipt)
+		bytes += len(receipt)
+	}
+
+	return receipts
+}
+
+// ServiceGetBorReceiptsRangeQuery assembles the response to a bor receipt
+// range query, returning the number of the last block covered and the bor
+// receipts of the canonical blocks in [origin, last], except the unverified
+// ones. Only sprint start blocks are looked up on Bor chains. It is exposed to allow external packages to
+// test protocol behavior.
+func ServiceGetBorReceiptsRangeQuery(chain *core.BlockChain, origin uint64, amount uint64) (uint64, []*BorReceiptEntry) {
+	var (
+		config   = chain.Config().Bor
+		head     = chain.CurrentBlock().Number.Uint64()
+		bytes    int
+		receipts []*BorReceiptEntry
+	)
+
+	if amount > maxBorReceiptsRangeServe {
+		amount = maxBorReceiptsRangeServe
+	}
+
+	if amount == 0 || origin > head {
+		return origin - 1, nil
+	}
+
+	last := origin + amount - 1
+	if last < origin || last > head {
+		last = head
+	}
+
+	for number := origin; number <= last; number++ {
+		if bytes >= softResponseLimit || len(receipts) >= maxBorReceiptsServe {
+			return number - 1, receipts
+		}
+
+		if config != nil && (number == 0 || !config.IsSprintStart(number)) {
+			continue
+		}
+
+		hash := rawdb.ReadCanonicalHash(chain.DB(), number)
+		if hash == (common.Hash{}) {
+			continue
+		}
+
+		if data := rawdb.ReadBorReceiptRLP(chain.DB(), hash, number); len(data) > 0 && !rawdb.IsBorReceiptUnverified(chain.DB(), hash, number) {
+			receipts = append(receipts, &BorReceiptEntry{Number: number, Hash: hash, Receipt: data})
+			bytes += len(data)
+		}
+	}
+
+	return last, receipts
+}
+
+// NodeInfo represents a short summary of the `bor` sub-protocol metadata
+// known about the host peer.
+type NodeInfo struct{}
+
+// nodeInfo retrieves some `bor` protocol metadata about the running host node.
+func nodeInfo(chain *core.BlockChain) *NodeInfo {
+	return &NodeInfo{}
+}
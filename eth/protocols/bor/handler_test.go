@@ -0,0 +1,228 @@
+package bor
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/consensus/ethash"
+	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/core/vm"
+	"github.com/ethereum/go-ethereum/p2p"
+	"github.com/ethereum/go-ethereum/p2p/enode"
+	"github.com/ethereum/go-ethereum/params"
+	"github.com/ethereum/go-ethereum/rlp"
+)
+
+// testBackend is a mock implementation of the live Ethereum message handler.
+type testBackend struct {
+	chain *core.BlockChain
+}
+
+func (b *testBackend) Chain() *core.BlockChain { return b.chain }
+
+func (b *testBackend) RunPeer(peer *Peer, handler Handler) error {
+	return handler(peer)
+}
+
+func (b *testBackend) PeerInfo(enode.ID) interface{} { return nil }
+
+// newTestBackend creates a chain with the given number of blocks and stores a
+// bor receipt for each even block.
+func newTestBackend(t *testing.T, blocks int) (*testBackend, []*types.Block) {
+	t.Helper()
+
+	var (
+		db     = rawdb.NewMemoryDatabase()
+		engine = ethash.NewFaker()
+		gspec  = &core.Genesis{Config: params.TestChainConfig}
+	)
+
+	chain, err := core.NewBlockChain(db, nil, gspec, nil, engine, vm.Config{}, nil, nil, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	t.Cleanup(chain.Stop)
+
+	_, bs, _ := core.GenerateChainWithGenesis(gspec, engine, blocks, nil)
+	if _, err := chain.InsertChain(bs); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, block := range bs {
+		if block.NumberU64()%2 == 0 {
+			rawdb.WriteBorReceipt(db, block.Hash(), block.NumberU64(), testBorReceipt(block.NumberU64()))
+		}
+	}
+
+	return &testBackend{chain: chain}, bs
+}
+
+func testBorReceipt(number uint64) *types.ReceiptForStorage {
+	return &types.ReceiptForStorage{
+		Status: types.ReceiptStatusSuccessful,
+		Logs: []*types.Log{{
+			Address: common.HexToAddress("0x0000000000000000000000000000000000001001"),
+			Data:    []byte{byte(number)},
+		}},
+	}
+}
+
+func TestServiceGetBorReceiptsQuery(t *testing.T) {
+	t.Parallel()
+
+	backend, blocks := newTestBackend(t, 8)
+
+	hashes := []common.Hash{blocks[1].Hash(), blocks[2].Hash(), {0xde, 0xad}}
+	receipts := ServiceGetBorReceiptsQuery(backend.chain, hashes)
+
+	if len(receipts) != len(hashes) {
+		t.Fatalf("receipt count mismatch: have %d, want %d", len(receipts), len(hashes))
+	}
+
+	want, _ := rlp.EncodeToBytes(testBorReceipt(2))
+	if string(receipts[0]) != string(want) {
+		t.Errorf("receipt 0 mismatch: have %x, want %x", receipts[0], want)
+	}
+
+	for _, i := range []int{1, 2} {
+		if string(receipts[i]) != string(rlp.EmptyString) {
+			t.Errorf("receipt %d mismatch: have %x, want empty", i, receipts[i])
+		}
+	}
+}
+
+func TestServiceUnverifiedBorReceipts(t *testing.T) {
+	t.Parallel()
+
+	backend, blocks := newTestBackend(t, 8)
+
+	// Bor receipts retrieved from the network are not served
+	rawdb.WriteBorReceiptUnverified(backend.chain.DB(), blocks[3].Hash(), 4)
+
+	receipts := ServiceGetBorReceiptsQuery(backend.chain, []common.Hash{blocks[3].Hash(), blocks[7].Hash()})
+	if string(receipts[0]) != string(rlp.EmptyString) {
+		t.Errorf("unverified bor receipt served: %x", receipts[0])
+	}
+
+	if string(receipts[1]) == string(rlp.EmptyString) {
+		t.Errorf("verified bor receipt not served")
+	}
+
+	last, entries := ServiceGetBorReceiptsRangeQuery(backend.chain, 1, 8)
+	if last != 8 || len(entries) != 1 || entries[0].Number != 8 {
+		t.Fatalf("range reply mismatch: last %d, entries %v", last, entries)
+	}
+}
+
+func TestRequestBorReceipts(t *testing.T) {
+	t.Parallel()
+
+	backend, blocks := newTestBackend(t, 8)
+
+	app, net := p2p.MsgPipe()
+	defer app.Close()
+	defer net.Close()
+
+	local := NewFakePeer(BOR1, "0x0000000000000000000000000000000000000000000000000000000000000001", app)
+	remote := NewFakePeer(BOR1, "0x0000000000000000000000000000000000000000000000000000000000000002", net)
+
+	go Handle(backend, local)  // Delivers the responses of the remote side
+	go Handle(backend, remote) // Serves the requests of the local side
+
+	hashes := make([]common.Hash, len(blocks))
+	for i, block := range blocks {
+		hashes[i] = block.Hash()
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	receipts, err := local.RequestBorReceipts(ctx, hashes)
+	if err != nil {
+		t.Fatalf("failed to request bor receipts: %v", err)
+	}
+
+	if len(receipts) != len(hashes) {
+		t.Fatalf("receipt count mismatch: have %d, want %d", len(receipts), len(hashes))
+	}
+
+	for i, receipt := range receipts {
+		number := blocks[i].NumberU64()
+
+		switch {
+		case number%2 == 1 && receipt != nil:
+			t.Errorf("block %d: unexpected bor receipt", number)
+		case number%2 == 0 && receipt == nil:
+			t.Errorf("block %d: missing bor receipt", number)
+		case number%2 == 0 && receipt.Logs[0].Data[0] != byte(number):
+			t.Errorf("block %d: bor receipt mismatch", number)
+		}
+	}
+}
+
+func TestRequestBorReceiptsRange(t *testing.T) {
+	t.Parallel()
+
+	backend, blocks := newTestBackend(t, 8)
+
+	app, net := p2p.MsgPipe()
+	defer app.Close()
+	defer net.Close()
+
+	local := NewFakePeer(BOR2, "0x0000000000000000000000000000000000000000000000000000000000000001", app)
+	remote := NewFakePeer(BOR2, "0x0000000000000000000000000000000000000000000000000000000000000002", net)
+
+	go Handle(backend, local)  // Delivers the responses of the remote side
+	go Handle(backend, remote) // Serves the requests of the local side
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	// The range is capped at the remote head
+	last, entries, err := local.RequestBorReceiptsRange(ctx, 3, 100)
+	if err != nil {
+		t.Fatalf("failed to request bor receipt range: %v", err)
+	}
+
+	if last != 8 {
+		t.Fatalf("covered range mismatch: have last %d, want 8", last)
+	}
+
+	// Only sprint start blocks are served, block 6 is skipped
+	if len(entries) != 2 {
+		t.Fatalf("entry count mismatch: have %d, want 2", len(entries))
+	}
+
+	for i, entry := range entries {
+		number := uint64(4 + 4*i)
+		if entry.Number != number || entry.Hash != blocks[number-1].Hash() {
+			t.Errorf("entry %d: have block %d %x, want %d %x", i, entry.Number, entry.Hash, number, blocks[number-1].Hash())
+		}
+
+		want, _ := rlp.EncodeToBytes(testBorReceipt(number))
+		if string(entry.Receipt) != string(want) {
+			t.Errorf("entry %d: bor receipt mismatch", i)
+		}
+	}
+
+	// Ranges beyond the remote head are not covered
+	last, entries, err = local.RequestBorReceiptsRange(ctx, 9, 10)
+	if err != nil {
+		t.Fatalf("failed to request bor receipt range: %v", err)
+	}
+
+	if last >= 9 || len(entries) != 0 {
+		t.Fatalf("unexpected reply beyond the head: last %d, %d entries", last, len(entries))
+	}
+
+	// Peers on the first protocol version can't serve ranges
+	old := NewFakePeer(BOR1, "0x0000000000000000000000000000000000000000000000000000000000000003", app)
+	if _, _, err := old.RequestBorReceiptsRange(ctx, 0, 10); err == nil {
+		t.Fatalf("range requested from a bor/1 peer")
+	}
+}
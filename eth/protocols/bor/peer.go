@@ -0,0 +1,209 @@
+package bor
+
+import (
+	"context"
+	"errors"
+	"math/rand"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/p2p"
+	"github.com/ethereum/go-ethereum/rlp"
+)
+
+// errPeerClosed is returned for requests in flight when the peer disconnects.
+var errPeerClosed = errors.New("peer closed")
+
+// Peer is a collection of relevant information we have about a `bor` peer.
+type Peer struct {
+	id string // Unique ID for the peer, cached
+
+	*p2p.Peer                   // The embedded P2P package peer
+	rw        p2p.MsgReadWriter // Input/output streams for bor
+	version   uint              // Protocol version negotiated
+
+	pending map[uint64]chan Packet // Requests waiting for a response
+	closed  bool                   // Whether the peer disconnected
+	lock    sync.Mutex             // Lock protecting the pending requests
+
+	logger log.Logger // Contextual logger with the peer id injected
+}
+
+// NewPeer create a wrapper for a network connection and negotiated  protocol
+// version.
+func NewPeer(version uint, p *p2p.Peer, rw p2p.MsgReadWriter) *Peer {
+	id := p.ID().String()
+
+	return &Peer{
+		id:      id,
+		Peer:    p,
+		rw:      rw,
+		version: version,
+		pending: make(map[uint64]chan Packet),
+		logger:  log.New("peer", id[:8]),
+	}
+}
+
+// NewFakePeer create a fake bor peer without a backing p2p peer, for testing purposes.
+func NewFakePeer(version uint, id string, rw p2p.MsgReadWriter) *Peer {
+	return &Peer{
+		id:      id,
+		rw:      rw,
+		version: version,
+		pending: make(map[uint64]chan Packet),
+		logger:  log.New("peer", id[:8]),
+	}
+}
+
+// ID retrieves the peer's unique identifier.
+func (p *Peer) ID() string {
+	return p.id
+}
+
+// Version retrieves the peer's negotiated `bor` protocol version.
+func (p *Peer) Version() uint {
+	return p.version
+}
+
+// Log overrides the P2P logger with the higher level one containing only the id.
+func (p *Peer) Log() log.Logger {
+	return p.logger
+}
+
+// RequestBorReceipts fetches the bor receipts of a batch of blocks, waiting
+// for the response until the context is cancelled. Blocks without a bor
+// receipt have a nil entry. The returned list may be shorter than the
+// requested one if the remote side truncated the reply.
+func (p *Peer) RequestBorReceipts(ctx context.Context, hashes []common.Hash) ([]*types.ReceiptForStorage, error) {
+	id := rand.Uint64()
+
+	p.logger.Trace("Fetching bor receipts", "reqid", id, "hashes", len(hashes))
+
+	packet, err := p.request(ctx, id, &GetBorReceiptsPacket{ID: id, Hashes: hashes})
+	if err != nil {
+		return nil, err
+	}
+
+	res, ok := packet.(*BorReceiptsPacket)
+	if !ok || len(res.Receipts) > len(hashes) {
+		return nil, errBadRequest
+	}
+
+	return res.Unpack()
+}
+
+// RequestBorReceiptsRange fetches the bor receipts of the canonical blocks in
+// [origin, origin+amount), waiting for the response until the context is
+// cancelled. It returns the number of the last block covered by the reply,
+// which may be below the end of the requested range, and the bor receipts of
+// the covered blocks keyed by block number.
+func (p *Peer) RequestBorReceiptsRange(ctx context.Context, origin uint64, amount uint64) (uint64, []*BorReceiptEntry, error) {
+	if p.version < BOR2 {
+		return 0, nil, errInvalidMsgCode
+	}
+
+	id := rand.Uint64()
+
+	p.logger.Trace("Fetching bor receipt range", "reqid", id, "origin", origin, "amount", amount)
+
+	packet, err := p.request(ctx, id, &GetBorReceiptsRangePacket{ID: id, Origin: origin, Amount: amount})
+	if err != nil {
+		return 0, nil, err
+	}
+
+	res, ok := packet.(*BorReceiptsRangePacket)
+	if !ok || res.Last >= origin+amount {
+		return 0, nil, errBadRequest
+	}
+
+	for i, entry := range res.Receipts {
+		if entry.Number < origin || entry.Number > res.Last || (i > 0 && entry.Number <= res.Receipts[i-1].Number) {
+			return 0, nil, errBadRequest
+		}
+	}
+
+	return res.Last, res.Receipts, nil
+}
+
+// request sends a query to the remote peer and waits for the matching response
+// until the context is cancelled.
+func (p *Peer) request(ctx context.Context, id uint64, req Packet) (Packet, error) {
+	resCh := make(chan Packet, 1)
+
+	p.lock.Lock()
+	if p.closed {
+		p.lock.Unlock()
+		return nil, errPeerClosed
+	}
+	p.pending[id] = resCh
+	p.lock.Unlock()
+
+	defer func() {
+		p.lock.Lock()
+		delete(p.pending, id)
+		p.lock.Unlock()
+	}()
+
+	if err := p2p.Send(p.rw, uint64(req.Kind()), req); err != nil {
+		return nil, err
+	}
+
+	select {
+	case res, ok := <-resCh:
+		if !ok {
+			return nil, errPeerClosed
+		}
+
+		return res, nil
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+// ReplyBorReceipts is the response to GetBorReceipts.
+func (p *Peer) ReplyBorReceipts(id uint64, receipts []rlp.RawValue) error {
+	return p2p.Send(p.rw, BorReceiptsMsg, &BorReceiptsPacket{
+		ID:       id,
+		Receipts: receipts,
+	})
+}
+
+// ReplyBorReceiptsRange is the response to GetBorReceiptsRange.
+func (p *Peer) ReplyBorReceiptsRange(id uint64, last uint64, receipts []*BorReceiptEntry) error {
+	return p2p.Send(p.rw, BorReceiptsRangeMsg, &BorReceiptsRangePacket{
+		ID:       id,
+		Last:     last,
+		Receipts: receipts,
+	})
+}
+
+// deliver hands a response over to the request waiting for it. Responses to
+// requests that already timed out are dropped.
+func (p *Peer) deliver(id uint64, res Packet) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	if resCh, ok := p.pending[id]; ok {
+		resCh <- res
+		delete(p.pending, id)
+
+		return
+	}
+
+	p.logger.Debug("Dropping unrequested bor response", "reqid", id, "type", res.Name())
+}
+
+// close fails all requests in flight, called when the peer disconnects.
+func (p *Peer) close() {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	for id, resCh := range p.pending {
+		close(resCh)
+		delete(p.pending, id)
+	}
+
+	p.closed = true
+}
@@ -0,0 +1,127 @@
+package bor
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/rlp"
+)
+
+// Constants to match up protocol versions and messages
+const (
+	BOR1 = 1
+	BOR2 = 2
+)
+
+// ProtocolName is the official short name of the `bor` protocol used during
+// devp2p capability negotiation.
+const ProtocolName = "bor"
+
+// ProtocolVersions are the supported versions of the `bor` protocol (first
+// is primary).
+var ProtocolVersions = []uint{BOR2, BOR1}
+
+// protocolLengths are the number of implemented message corresponding to
+// different protocol versions.
+var protocolLengths = map[uint]uint64{BOR2: 4, BOR1: 2}
+
+// maxMessageSize is the maximum cap on the size of a protocol message.
+const maxMessageSize = 10 * 1024 * 1024
+
+const (
+	GetBorReceiptsMsg = 0x00
+	BorReceiptsMsg    = 0x01
+
+	// Protocol messages introduced in bor/2
+	GetBorReceiptsRangeMsg = 0x02
+	BorReceiptsRangeMsg    = 0x03
+)
+
+var (
+	errMsgTooLarge    = errors.New("message too long")
+	errDecode         = errors.New("invalid message")
+	errInvalidMsgCode = errors.New("invalid message code")
+	errBadRequest     = errors.New("bad request")
+)
+
+// Packet represents a p2p message in the `bor` protocol.
+type Packet interface {
+	Name() string // Name returns a string corresponding to the message type.
+	Kind() byte   // Kind returns the message type.
+}
+
+// GetBorReceiptsPacket represents a bor receipt query.
+type GetBorReceiptsPacket struct {
+	ID     uint64        // Request ID to match up responses with
+	Hashes []common.Hash // Block hashes for which to retrieve bor receipts
+}
+
+// BorReceiptsPacket is the response to GetBorReceiptsPacket. The receipts are
+// in the order of the requested hashes, the list may be truncated if the reply
+// would grow too large. An empty string entry means the block has no bor
+// receipt (or is unknown to the remote peer).
+type BorReceiptsPacket struct {
+	ID       uint64         // ID of the request this is a response for
+	Receipts []rlp.RawValue // Bor receipts in storage format
+}
+
+// Unpack decodes the bor receipts of the response, returning nil for blocks
+// without a bor receipt.
+func (p *BorReceiptsPacket) Unpack() ([]*types.ReceiptForStorage, error) {
+	receipts := make([]*types.ReceiptForStorage, len(p.Receipts))
+
+	for i, data := range p.Receipts {
+		if len(data) == 0 || bytes.Equal(data, rlp.EmptyString) {
+			continue
+		}
+
+		receipt := new(types.ReceiptForStorage)
+		if err := rlp.DecodeBytes(data, receipt); err != nil {
+			return nil, fmt.Errorf("%w: bor receipt %d: %v", errDecode, i, err)
+		}
+
+		receipts[i] = receipt
+	}
+
+	return receipts, nil
+}
+
+// GetBorReceiptsRangePacket represents a query for the bor receipts of a
+// range of canonical blocks.
+type GetBorReceiptsRangePacket struct {
+	ID     uint64 // Request ID to match up responses with
+	Origin uint64 // Number of the first block of the range
+	Amount uint64 // Number of blocks in the range
+}
+
+// BorReceiptEntry is a bor receipt of a canonical block in a range response.
+type BorReceiptEntry struct {
+	Number  uint64       // Number of the block
+	Hash    common.Hash  // Hash of the block, to be checked against the local chain
+	Receipt rlp.RawValue // Bor receipt in storage format
+}
+
+// BorReceiptsRangePacket is the response to GetBorReceiptsRangePacket. Only
+// blocks having a bor receipt are listed, in ascending order. The reply may
+// cover less than the requested range if it would grow too large, Last is the
+// number of the last block it covers.
+type BorReceiptsRangePacket struct {
+	ID       uint64             // ID of the request this is a response for
+	Last     uint64             // Number of the last block covered by the reply
+	Receipts []*BorReceiptEntry // Bor receipts of the covered blocks
+}
+
+func (*GetBorReceiptsPacket) Name() string { return "GetBorReceipts" }
+func (*GetBorReceiptsPacket) Kind() byte   { return GetBorReceiptsMsg }
+
+func (*BorReceiptsPacket) Name() string { return "BorReceipts" }
+func (*BorReceiptsPacket) Kind() byte   { return BorReceiptsMsg }
+
+func (*GetBorReceiptsRangePacket) Name() string { return "GetBorReceiptsRange" }
+func (*GetBorReceiptsRangePacket) Kind() byte   { return GetBorReceiptsRangeMsg }
+
+func (*BorReceiptsRangePacket) Name() string { return "BorReceiptsRange" }
+func (*BorReceiptsRangePacket) Kind() byte   { return BorReceiptsRangeMsg }
@@ -246,6 +246,10 @@ func (p *Peer) dispatcher() {
 				res.Time = res.recv.Sub(res.Req.Sent)
 				resOp.fail <- nil
 
+				if res.code == BlockHeadersMsg || res.code == BlockBodiesMsg {
+					p.trackLatency(res.Time)
+				}
+
 				// Stop tracking the request, the response dispatcher will deliver
 				delete(pending, res.id)
 			}
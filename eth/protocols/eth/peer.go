@@ -20,6 +20,7 @@ import (
 	"math/big"
 	"math/rand"
 	"sync"
+	"time"
 
 	mapset "github.com/deckarep/golang-set/v2"
 	"github.com/ethereum/go-ethereum/common"
@@ -54,6 +55,10 @@ const (
 	// dropping broadcasts. Similarly to block propagations, there's no point to queue
 	// above some healthy uncle limit, so use that.
 	maxQueuedBlockAnns = 4
+
+	// latencyAverageWeight is the inverse weight of a new measurement in the
+	// moving average of the peer's latency.
+	latencyAverageWeight = 8
 )
 
 // max is a helper function which returns the larger of the two given integers.
@@ -75,6 +80,8 @@ type Peer struct {
 	head common.Hash // Latest advertised head block hash
 	td   *big.Int    // Latest advertised head block total difficulty
 
+	latency time.Duration // Moving average of the time taken to serve block data
+
 	knownBlocks     *knownCache            // Set of block hashes known to be known by this peer
 	queuedBlocks    chan *blockPropagation // Queue of blocks to broadcast to the peer
 	queuedBlockAnns chan *types.Block      // Queue of blocks to announce to the peer
@@ -156,6 +163,29 @@ func (p *Peer) SetHead(hash common.Hash, td *big.Int) {
 	p.td.Set(td)
 }
 
+// Latency retrieves the moving average of the time the peer took to serve the
+// block headers and bodies requested from it.
+func (p *Peer) Latency() time.Duration {
+	p.lock.RLock()
+	defer p.lock.RUnlock()
+
+	return p.latency
+}
+
+// trackLatency folds the serving time of a block data request into the moving
+// average of the peer's latency.
+func (p *Peer) trackLatency(elapsed time.Duration) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	if p.latency == 0 {
+		p.latency = elapsed
+		return
+	}
+
+	p.latency += (elapsed - p.latency) / latencyAverageWeight
+}
+
 // KnownBlock returns whether peer is known to already have a block.
 func (p *Peer) KnownBlock(hash common.Hash) bool {
 	return p.knownBlocks.Contains(hash)
@@ -24,6 +24,7 @@ import (
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/core/rawdb"
 	"github.com/ethereum/go-ethereum/eth/downloader"
+	"github.com/ethereum/go-ethereum/eth/downloader/whitelist"
 	"github.com/ethereum/go-ethereum/eth/protocols/eth"
 	"github.com/ethereum/go-ethereum/log"
 )
@@ -220,30 +221,6 @@ func (cs *chainSyncer) modeAndLocalHead() (downloader.SyncMode, *big.Int) {
 	td := cs.handler.chain.GetTd(head.Hash(), head.Number.Uint64())
 
 	return downloader.FullSync, td
-	// TODO(snap): Uncomment when we have snap sync working
-	// If we're in snap sync mode, return that directly
-	//
-	//	if atomic.LoadUint32(&cs.handler.snapSync) == 1 {
-	//		block := cs.handler.chain.CurrentFastBlock()
-	//		td := cs.handler.chain.GetTd(block.Hash(), block.NumberU64())
-	//		return downloader.SnapSync, td
-	//	}
-	//
-	// // We are probably in full sync, but we might have rewound to before the
-	// // snap sync pivot, check if we should reenable
-	//
-	//	if pivot := rawdb.ReadLastPivotNumber(cs.handler.database); pivot != nil {
-	//		if head := cs.handler.chain.CurrentBlock(); head.NumberU64() < *pivot {
-	//			block := cs.handler.chain.CurrentFastBlock()
-	//			td := cs.handler.chain.GetTd(block.Hash(), block.NumberU64())
-	//			return downloader.SnapSync, td
-	//		}
-	//	}
-	//
-	// // Nope, we're really full syncing
-	// head := cs.handler.chain.CurrentBlock()
-	// td := cs.handler.chain.GetTd(head.Hash(), head.NumberU64())
-	// return downloader.FullSync, td
 }
 
 // startSync launches doSync in a new goroutine.
@@ -275,6 +252,10 @@ func (h *handler) doSync(op *chainSyncOp) error {
 	// Run the sync cycle, and disable snap sync if we're past the pivot block
 	err := h.downloader.LegacySync(op.peer.ID(), op.head, op.td, h.chain.Config().TerminalTotalDifficulty, op.mode)
 	if err != nil {
+		if errors.Is(err, whitelist.ErrMismatch) {
+			h.penalisePeer(op.peer.ID(), scoreNonWhitelisted, "non-whitelisted chain")
+		}
+
 		return err
 	}
 	if h.snapSync.Load() {
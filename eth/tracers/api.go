@@ -202,6 +202,9 @@ type TraceConfig struct {
 	TracerConfig    json.RawMessage
 	BorTraceEnabled *bool
 	BorTx           *bool
+	// SystemCalls appends the traces of the span and state-sync system calls
+	// made when finalising the block to the block trace results.
+	SystemCalls *bool
 }
 
 // TraceCallConfig is the config for traceCall API. It holds one more
@@ -222,9 +225,11 @@ type StdTraceConfig struct {
 
 // txTraceResult is the result of a single transaction trace.
 type txTraceResult struct {
-	TxHash common.Hash `json:"txHash"`           // transaction hash
-	Result interface{} `json:"result,omitempty"` // Trace results produced by the tracer
-	Error  string      `json:"error,omitempty"`  // Trace failure produced by the tracer
+	TxHash     common.Hash     `json:"txHash"`               // transaction hash
+	Result     interface{}     `json:"result,omitempty"`     // Trace results produced by the tracer
+	Error      string          `json:"error,omitempty"`      // Trace failure produced by the tracer
+	SystemCall string          `json:"systemCall,omitempty"` // Kind of the system call, if the trace is of one
+	Contract   *common.Address `json:"contract,omitempty"`   // Contract targeted by the system call
 }
 
 // blockTraceTask represents a single block trace task when an entire chain is
@@ -971,10 +976,20 @@ txloop:
 	}
 
 	if !*config.BorTraceEnabled && stateSyncPresent {
-		return results[:len(results)-1], nil
-	} else {
-		return results, nil
+		results = results[:len(results)-1]
+	}
+
+	// Append the span and state-sync system calls on top of the block's state
+	if config.SystemCalls != nil && *config.SystemCalls {
+		calls, err := api.traceSystemCalls(ctx, block, statedb, config, systemCallSpan, systemCallStateSync, systemCallOther)
+		if err != nil {
+			return nil, err
+		}
+
+		results = append(results, calls...)
 	}
+
+	return results, nil
 }
 
 // standardTraceBlockToFile configures a new tracer which uses standard JSON output,
@@ -1159,15 +1174,19 @@ func (api *API) TraceTransaction(ctx context.Context, hash common.Hash, config *
 
 	tx, blockHash, blockNumber, index, err := api.backend.GetTransaction(ctx, hash)
 	if tx == nil {
-		// For BorTransaction, there will be no trace available
-		tx, _, _, _ = rawdb.ReadBorTransaction(api.backend.ChainDb(), hash)
-		if tx != nil {
-			return &ethapi.ExecutionResult{
-				StructLogs: make([]ethapi.StructLogRes, 0),
-			}, nil
-		} else {
+		tx, blockHash, blockNumber, _ = rawdb.ReadBorTransaction(api.backend.ChainDb(), hash)
+		if tx == nil {
 			return nil, errTxNotFound
 		}
+		// State-sync transactions are traced through the system calls making
+		// them up, if the engine can re-execute them
+		if _, ok := api.backend.Engine().(systemCallEngine); ok {
+			return api.traceStateSyncTransaction(ctx, blockHash, blockNumber, config)
+		}
+
+		return &ethapi.ExecutionResult{
+			StructLogs: make([]ethapi.StructLogRes, 0),
+		}, nil
 	}
 
 	if err != nil {
@@ -1294,16 +1313,8 @@ func (api *API) traceTx(ctx context.Context, message *core.Message, txctx *Conte
 		txContext = core.NewEVMTxContext(message)
 	)
 
-	if config == nil {
-		config = &TraceConfig{}
-	}
-	// Default tracer is the struct logger
-	tracer = logger.NewStructLogger(config.Config)
-	if config.Tracer != nil {
-		tracer, err = DefaultDirectory.New(*config.Tracer, txctx, config.TracerConfig)
-		if err != nil {
-			return nil, err
-		}
+	if tracer, err = api.newTracer(txctx, config); err != nil {
+		return nil, err
 	}
 
 	vmenv := vm.NewEVM(vmctx, txContext, statedb, api.backend.ChainConfig(), vm.Config{Tracer: tracer, NoBaseFee: true})
@@ -1352,6 +1363,21 @@ func (api *API) traceTx(ctx context.Context, message *core.Message, txctx *Conte
 	return tracer.GetResult()
 }
 
+// newTracer creates the tracer requested by the config, the struct logger by
+// default.
+func (api *API) newTracer(txctx *Context, config *TraceConfig) (Tracer, error) {
+	if config == nil || config.Tracer == nil {
+		var cfg *logger.Config
+		if config != nil {
+			cfg = config.Config
+		}
+
+		return logger.NewStructLogger(cfg), nil
+	}
+
+	return DefaultDirectory.New(*config.Tracer, txctx, config.TracerConfig)
+}
+
 // APIs return the collection of RPC services the tracer package offers.
 func APIs(backend Backend) []rpc.API {
 	// Append all the local APIs and return
@@ -1360,6 +1386,10 @@ func APIs(backend Backend) []rpc.API {
 			Namespace: "debug",
 			Service:   NewAPI(backend),
 		},
+		{
+			Namespace: "trace",
+			Service:   NewTraceAPI(backend),
+		},
 	}
 }
 
@@ -2,7 +2,9 @@ package tracers
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
+	"io"
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/consensus/bor/statefull"
@@ -39,34 +41,53 @@ func (api *API) traceBorBlock(ctx context.Context, block *types.Block, config *T
 		return nil, fmt.Errorf("genesis is not traceable")
 	}
 
+	// block object cannot be converted to JSON since much of the fields are non-public
 	res := &BlockTraceResult{
-		Block: block,
+		Block: ethapi.RPCMarshalBlock(block, true, true, api.backend.ChainConfig(), api.backend.ChainDb()),
 	}
 
-	// block object cannot be converted to JSON since much of the fields are non-public
-	blockFields := ethapi.RPCMarshalBlock(block, true, true, api.backend.ChainConfig(), api.backend.ChainDb())
+	err := api.streamBorBlock(ctx, block, config, func(_ int, _ *types.Transaction, txRes *TxTraceResult) error {
+		res.Transactions = append(res.Transactions, txRes)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
 
-	res.Block = blockFields
+	return res, nil
+}
+
+// streamBorBlock traces the transactions of a block one after the other with
+// the struct logger, the bor state-sync transaction included, handing each
+// trace over as soon as it completes so that it needn't be retained.
+func (api *API) streamBorBlock(ctx context.Context, block *types.Block, config *TraceConfig, emit func(index int, tx *types.Transaction, res *TxTraceResult) error) error {
+	if block.NumberU64() == 0 {
+		return fmt.Errorf("genesis is not traceable")
+	}
+
+	if config == nil {
+		config = &TraceConfig{}
+	}
 
 	parent, err := api.blockByNumberAndHash(ctx, rpc.BlockNumber(block.NumberU64()-1), block.ParentHash())
 	if err != nil {
-		return nil, err
+		return err
 	}
 
 	reexec := defaultTraceReexec
-	if config != nil && config.Reexec != nil {
+	if config.Reexec != nil {
 		reexec = *config.Reexec
 	}
 
 	// TODO: discuss consequences of setting preferDisk false.
 	statedb, release, err := api.backend.StateAtBlock(ctx, parent, reexec, nil, true, false)
 	if err != nil {
-		return nil, err
+		return err
 	}
 
 	defer release()
 
-	// Execute all the transaction contained within the block concurrently
+	// Execute all the transaction contained within the block sequentially
 	var (
 		signer                = types.MakeSigner(api.backend.ChainConfig(), block.Number(), block.Time())
 		txs, stateSyncPresent = api.getAllBlockTransactions(ctx, block)
@@ -88,7 +109,10 @@ func (api *API) traceBorBlock(ctx context.Context, block *types.Block, config *T
 		// Not sure if we need to do this
 		statedb.SetTxContext(tx.Hash(), indx)
 
-		var execRes *core.ExecutionResult
+		var (
+			execRes *core.ExecutionResult
+			err     error
+		)
 
 		if borTx {
 			callmsg := prepareCallMessage(*message)
@@ -123,14 +147,17 @@ func (api *API) traceBorBlock(ctx context.Context, block *types.Block, config *T
 	}
 
 	for indx, tx := range txs {
-		if stateSyncPresent && indx == len(txs)-1 {
-			res.Transactions = append(res.Transactions, traceTxn(indx, tx, true))
-		} else {
-			res.Transactions = append(res.Transactions, traceTxn(indx, tx, false))
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		borTx := stateSyncPresent && indx == len(txs)-1
+		if err := emit(indx, tx, traceTxn(indx, tx, borTx)); err != nil {
+			return err
 		}
 	}
 
-	return res, nil
+	return nil
 }
 
 type TraceBlockRequest struct {
@@ -144,6 +171,15 @@ type TraceBlockRequest struct {
 func (api *API) TraceBorBlock(req *TraceBlockRequest) (*BlockTraceResult, error) {
 	ctx := context.Background()
 
+	block, err := api.borBlockOfRequest(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	return api.traceBorBlock(ctx, block, req.Config)
+}
+
+func (api *API) borBlockOfRequest(ctx context.Context, req *TraceBlockRequest) (*types.Block, error) {
 	var blockNumber rpc.BlockNumber
 	if req.Number == -1 {
 		blockNumber = rpc.LatestBlockNumber
@@ -153,10 +189,131 @@ func (api *API) TraceBorBlock(req *TraceBlockRequest) (*BlockTraceResult, error)
 
 	log.Debug("Tracing Bor Block", "block number", blockNumber)
 
-	block, err := api.blockByNumber(ctx, blockNumber)
+	return api.blockByNumber(ctx, blockNumber)
+}
+
+// WriteBorBlockTrace writes the JSON encoding of the TraceBorBlock result to w,
+// each transaction trace being written as soon as it completes instead of the
+// whole result being built in memory. It is a function rather than a method so
+// that it isn't exposed over RPC.
+func WriteBorBlockTrace(ctx context.Context, api *API, req *TraceBlockRequest, w io.Writer) error {
+	block, err := api.borBlockOfRequest(ctx, req)
+	if err != nil {
+		return err
+	}
+
+	if block.NumberU64() == 0 {
+		return fmt.Errorf("genesis is not traceable")
+	}
+
+	// The encoding matches the one of BlockTraceResult, transactions first
+	written := 0
+
+	err = api.streamBorBlock(ctx, block, req.Config, func(_ int, _ *types.Transaction, res *TxTraceResult) error {
+		data, err := json.Marshal(res)
+		if err != nil {
+			return err
+		}
+
+		prefix := ","
+		if written == 0 {
+			prefix = `{"transactions":[`
+		}
+
+		written++
+
+		if _, err := io.WriteString(w, prefix); err != nil {
+			return err
+		}
+
+		_, err = w.Write(data)
+
+		return err
+	})
+	if err != nil {
+		return err
+	}
+
+	data, err := json.Marshal(ethapi.RPCMarshalBlock(block, true, true, api.backend.ChainConfig(), api.backend.ChainDb()))
+	if err != nil {
+		return err
+	}
+
+	suffix := `],"block":`
+	if written == 0 {
+		suffix = `{"block":`
+	}
+
+	if _, err := io.WriteString(w, suffix); err != nil {
+		return err
+	}
+
+	if _, err := w.Write(data); err != nil {
+		return err
+	}
+
+	_, err = io.WriteString(w, "}")
+
+	return err
+}
+
+// BorBlockTraceEvent is a notification of a streamed block trace, sent for each
+// transaction as its trace completes, then once more with Done set.
+type BorBlockTraceEvent struct {
+	TxIndex int         `json:"txIndex"`
+	TxHash  common.Hash `json:"txHash"`
+	*TxTraceResult
+	Done bool `json:"done"` // Whether all the transactions of the block were traced
+}
+
+// TraceBorBlockStream traces the transactions of a block with the struct logger,
+// the bor state-sync transaction included, and sends each trace as a
+// notification as soon as it completes. Only a single transaction trace is held
+// in memory at a time, whatever the size of the block.
+func (api *API) TraceBorBlockStream(ctx context.Context, number rpc.BlockNumber, config *TraceConfig) (*rpc.Subscription, error) {
+	notifier, supported := rpc.NotifierFromContext(ctx)
+	if !supported {
+		return &rpc.Subscription{}, rpc.ErrNotificationsUnsupported
+	}
+
+	block, err := api.blockByNumber(ctx, number)
 	if err != nil {
 		return nil, err
 	}
 
-	return api.traceBorBlock(ctx, block, req.Config)
+	if block.NumberU64() == 0 {
+		return nil, fmt.Errorf("genesis is not traceable")
+	}
+
+	sub := notifier.CreateSubscription()
+
+	go func() {
+		// Abort tracing as soon as the subscriber goes away
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		go func() {
+			select {
+			case <-sub.Err():
+			case <-notifier.Closed():
+			case <-ctx.Done():
+			}
+			cancel()
+		}()
+
+		last := -1
+
+		err := api.streamBorBlock(ctx, block, config, func(index int, tx *types.Transaction, res *TxTraceResult) error {
+			last = index
+			return notifier.Notify(sub.ID, &BorBlockTraceEvent{TxIndex: index, TxHash: tx.Hash(), TxTraceResult: res})
+		})
+		if err != nil {
+			log.Debug("Streamed block trace aborted", "number", block.NumberU64(), "err", err)
+			return
+		}
+
+		_ = notifier.Notify(sub.ID, &BorBlockTraceEvent{TxIndex: last + 1, Done: true})
+	}()
+
+	return sub, nil
 }
@@ -0,0 +1,149 @@
+package tracers
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/eth/tracers/logger"
+	"github.com/ethereum/go-ethereum/params"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+// newBorTraceTestBackend creates a chain whose first block holds three transfers.
+func newBorTraceTestBackend(t *testing.T) *testBackend {
+	t.Helper()
+
+	accounts := newAccounts(2)
+	genesis := &core.Genesis{
+		Alloc: core.GenesisAlloc{
+			accounts[0].addr: {Balance: big.NewInt(params.Ether)},
+		},
+	}
+
+	return newTestBackend(t, 2, genesis, func(i int, b *core.BlockGen) {
+		if i > 0 {
+			return
+		}
+
+		for nonce := uint64(0); nonce < 3; nonce++ {
+			tx, _ := types.SignTx(types.NewTransaction(nonce, accounts[1].addr, big.NewInt(1000), params.TxGas, b.BaseFee(), nil), types.HomesteadSigner{}, accounts[0].key)
+			b.AddTx(tx)
+		}
+	})
+}
+
+func TestWriteBorBlockTrace(t *testing.T) {
+	t.Parallel()
+
+	backend := newBorTraceTestBackend(t)
+	defer backend.teardown()
+
+	api := NewAPI(backend)
+
+	for _, number := range []int64{1, 2} {
+		req := &TraceBlockRequest{Number: number, Config: &TraceConfig{Config: &logger.Config{EnableMemory: true}}}
+
+		res, err := api.TraceBorBlock(req)
+		if err != nil {
+			t.Fatalf("block %d: failed to trace: %v", number, err)
+		}
+
+		want, err := json.Marshal(res)
+		if err != nil {
+			t.Fatalf("block %d: failed to marshal trace: %v", number, err)
+		}
+
+		// The streamed encoding matches the one built in memory
+		var have bytes.Buffer
+		if err := WriteBorBlockTrace(context.Background(), api, req, &have); err != nil {
+			t.Fatalf("block %d: failed to write trace: %v", number, err)
+		}
+
+		if !bytes.Equal(have.Bytes(), want) {
+			t.Errorf("block %d: streamed trace mismatch:\nhave %s\nwant %s", number, have.Bytes(), want)
+		}
+	}
+}
+
+func TestStreamBorBlockAbort(t *testing.T) {
+	t.Parallel()
+
+	backend := newBorTraceTestBackend(t)
+	defer backend.teardown()
+
+	var (
+		api    = NewAPI(backend)
+		block  = backend.chain.GetBlockByNumber(1)
+		abort  = errors.New("abort")
+		traced int
+	)
+
+	// Tracing stops at the first failed emission
+	err := api.streamBorBlock(context.Background(), block, nil, func(index int, tx *types.Transaction, res *TxTraceResult) error {
+		if tx.Hash() != block.Transactions()[index].Hash() || res.Error != "" {
+			t.Errorf("transaction %d trace mismatch: %+v", index, res)
+		}
+
+		traced++
+
+		return abort
+	})
+	if err != abort || traced != 1 {
+		t.Errorf("aborted stream mismatch: err %v, traced %d", err, traced)
+	}
+}
+
+func TestTraceBorBlockStream(t *testing.T) {
+	t.Parallel()
+
+	backend := newBorTraceTestBackend(t)
+	defer backend.teardown()
+
+	server := rpc.NewServer("", 0, 0)
+	defer server.Stop()
+
+	if err := server.RegisterName("debug", NewAPI(backend)); err != nil {
+		t.Fatalf("failed to register api: %v", err)
+	}
+
+	client := rpc.DialInProc(server)
+	defer client.Close()
+
+	events := make(chan *BorBlockTraceEvent)
+
+	sub, err := client.Subscribe(context.Background(), "debug", events, "traceBorBlockStream", rpc.BlockNumber(1), nil)
+	if err != nil {
+		t.Fatalf("failed to subscribe: %v", err)
+	}
+	defer sub.Unsubscribe()
+
+	block := backend.chain.GetBlockByNumber(1)
+
+	for i := 0; i <= len(block.Transactions()); i++ {
+		select {
+		case event := <-events:
+			if i == len(block.Transactions()) {
+				if !event.Done || event.TxIndex != i {
+					t.Errorf("final event mismatch: %+v", event)
+				}
+
+				continue
+			}
+
+			if event.Done || event.TxIndex != i || event.TxHash != block.Transactions()[i].Hash() || event.TxTraceResult == nil || event.Result == nil {
+				t.Errorf("event %d mismatch: %+v", i, event)
+			}
+		case err := <-sub.Err():
+			t.Fatalf("subscription failed: %v", err)
+		case <-time.After(5 * time.Second):
+			t.Fatalf("event %d timed out", i)
+		}
+	}
+}
@@ -0,0 +1,476 @@
+package tracers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+const (
+	// traceFilterMaxReexec is the maximum number of blocks trace_filter
+	// re-executes outside of the trace index.
+	traceFilterMaxReexec = 100
+
+	// traceFilterMaxCount is the maximum number of traces trace_filter returns,
+	// which is also the default if no count is given.
+	traceFilterMaxCount = 10000
+)
+
+var errTraceTypeUnsupported = errors.New("only the trace type is supported")
+
+// TraceFilterArgs are the arguments of trace_filter.
+type TraceFilterArgs struct {
+	FromBlock   *rpc.BlockNumber `json:"fromBlock"`   // First block to filter, the genesis if nil
+	ToBlock     *rpc.BlockNumber `json:"toBlock"`     // Last block to filter, the head if nil
+	FromAddress []common.Address `json:"fromAddress"` // Origins to filter the traces of, any if empty
+	ToAddress   []common.Address `json:"toAddress"`   // Targets to filter the traces of, any if empty
+	After       *uint64          `json:"after"`       // Number of matching traces to skip
+	Count       *uint64          `json:"count"`       // Maximum number of traces to return, traceFilterMaxCount if nil
+}
+
+// TraceReplayResult is the result of replaying a transaction with
+// trace_replayBlockTransactions. Only the trace type is produced, the state
+// and VM trace diffs are always null.
+type TraceReplayResult struct {
+	Output          hexutil.Bytes     `json:"output"`
+	StateDiff       interface{}       `json:"stateDiff"`
+	Trace           []json.RawMessage `json:"trace"`
+	VmTrace         interface{}       `json:"vmTrace"`
+	TransactionHash common.Hash       `json:"transactionHash"`
+}
+
+// TraceAPI offers the Parity style flat call traces in the trace namespace,
+// served from the trace index when it covers the requested blocks.
+type TraceAPI struct {
+	api   *API
+	index *traceIndex
+}
+
+// NewTraceAPI creates a new TraceAPI instance.
+func NewTraceAPI(backend Backend) *TraceAPI {
+	return &TraceAPI{
+		api:   NewAPI(backend),
+		index: newTraceIndex(backend.ChainDb()),
+	}
+}
+
+// flatBlockTraces traces a block with the flat call tracer, including the bor
+// system calls, and returns the traces of all its calls in execution order.
+func (api *API) flatBlockTraces(ctx context.Context, block *types.Block) ([]json.RawMessage, error) {
+	results, err := api.traceBlock(ctx, block, &TraceConfig{
+		Tracer:          &flatTraceTracer,
+		TracerConfig:    flatTraceConfig,
+		BorTraceEnabled: newBoolPtr(false),
+		SystemCalls:     newBoolPtr(true),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var traces []json.RawMessage
+
+	for _, res := range results {
+		if res.Error != "" {
+			return nil, fmt.Errorf("transaction %s: %s", res.TxHash, res.Error)
+		}
+
+		var txTraces []json.RawMessage
+		if err := json.Unmarshal(res.Result.(json.RawMessage), &txTraces); err != nil {
+			return nil, err
+		}
+
+		traces = append(traces, txTraces...)
+	}
+
+	return traces, nil
+}
+
+// blockTraces returns the flat traces of a block, from the index if present.
+func (api *TraceAPI) blockTraces(ctx context.Context, block *types.Block) ([]json.RawMessage, error) {
+	if traces, ok := api.index.blockTraces(block.NumberU64(), block.Hash()); ok {
+		return traces, nil
+	}
+
+	return api.api.flatBlockTraces(ctx, block)
+}
+
+// Block returns the flat traces of all the calls made in a block.
+func (api *TraceAPI) Block(ctx context.Context, number rpc.BlockNumber) ([]json.RawMessage, error) {
+	block, err := api.api.blockByNumber(ctx, number)
+	if err != nil {
+		return nil, err
+	}
+
+	return api.blockTraces(ctx, block)
+}
+
+// Transaction returns the flat traces of all the calls made by a transaction,
+// the state-sync calls for a bor state-sync transaction.
+func (api *TraceAPI) Transaction(ctx context.Context, hash common.Hash) ([]json.RawMessage, error) {
+	result, err := api.api.TraceTransaction(ctx, hash, &TraceConfig{
+		Tracer:       &flatTraceTracer,
+		TracerConfig: flatTraceConfig,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		traces  []json.RawMessage
+		results []*txTraceResult
+	)
+
+	switch result := result.(type) {
+	case json.RawMessage:
+		results = []*txTraceResult{{TxHash: hash, Result: result}}
+	case []*txTraceResult:
+		results = result
+	default:
+		return nil, fmt.Errorf("transaction %s has no call traces", hash)
+	}
+
+	for _, res := range results {
+		if res.Error != "" {
+			return nil, errors.New(res.Error)
+		}
+
+		var txTraces []json.RawMessage
+		if err := json.Unmarshal(res.Result.(json.RawMessage), &txTraces); err != nil {
+			return nil, err
+		}
+
+		traces = append(traces, txTraces...)
+	}
+
+	return traces, nil
+}
+
+// Get returns the flat trace of a transaction's call at the given trace address,
+// null if the transaction made no such call.
+func (api *TraceAPI) Get(ctx context.Context, hash common.Hash, indices []hexutil.Uint64) (json.RawMessage, error) {
+	encoded, err := api.Transaction(ctx, hash)
+	if err != nil {
+		return nil, err
+	}
+
+	traces, err := newFlatTraces(encoded)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, trace := range traces {
+		if equalTraceAddress(trace.fields.TraceAddress, indices) {
+			return trace.RawMessage, nil
+		}
+	}
+
+	return nil, nil
+}
+
+// ReplayBlockTransactions returns the flat traces of a block grouped by
+// transaction, the bor system calls making up the last one.
+func (api *TraceAPI) ReplayBlockTransactions(ctx context.Context, blockNrOrHash rpc.BlockNumberOrHash, traceTypes []string) ([]*TraceReplayResult, error) {
+	for _, typ := range traceTypes {
+		if typ != "trace" {
+			return nil, fmt.Errorf("%w: %s", errTraceTypeUnsupported, typ)
+		}
+	}
+
+	var (
+		block *types.Block
+		err   error
+	)
+
+	if hash, ok := blockNrOrHash.Hash(); ok {
+		block, err = api.api.blockByHash(ctx, hash)
+	} else if number, ok := blockNrOrHash.Number(); ok {
+		block, err = api.api.blockByNumber(ctx, number)
+	} else {
+		return nil, errors.New("invalid arguments; neither block nor hash specified")
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	encoded, err := api.blockTraces(ctx, block)
+	if err != nil {
+		return nil, err
+	}
+
+	traces, err := newFlatTraces(encoded)
+	if err != nil {
+		return nil, err
+	}
+
+	var results []*TraceReplayResult
+
+	for _, trace := range traces {
+		var hash common.Hash
+		if trace.fields.TransactionHash != nil {
+			hash = *trace.fields.TransactionHash
+		}
+
+		// Traces of a transaction are consecutive, starting with its top call
+		if len(results) == 0 || results[len(results)-1].TransactionHash != hash {
+			result := &TraceReplayResult{Output: hexutil.Bytes{}, TransactionHash: hash}
+			if trace.fields.Result != nil && trace.fields.Result.Output != nil {
+				result.Output = trace.fields.Result.Output
+			}
+
+			results = append(results, result)
+		}
+
+		result := results[len(results)-1]
+		result.Trace = append(result.Trace, trace.RawMessage)
+	}
+
+	return results, nil
+}
+
+// Filter returns the flat traces of the calls in a block range matching the
+// origin and target addresses. Indexed blocks are looked up by address, the
+// others are re-executed, up to traceFilterMaxReexec of them. At most
+// traceFilterMaxCount traces are returned.
+func (api *TraceAPI) Filter(ctx context.Context, args TraceFilterArgs) ([]json.RawMessage, error) {
+	count := uint64(traceFilterMaxCount)
+	if args.Count != nil {
+		if *args.Count > traceFilterMaxCount {
+			return nil, fmt.Errorf("count %d exceeds the maximum of %d traces", *args.Count, traceFilterMaxCount)
+		}
+
+		count = *args.Count
+	}
+
+	from, to, err := api.filterRange(ctx, args)
+	if err != nil {
+		return nil, err
+	}
+
+	numbers, err := api.filterBlocks(args, from, to)
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		fromAddrs = make(map[common.Address]struct{}, len(args.FromAddress))
+		toAddrs   = make(map[common.Address]struct{}, len(args.ToAddress))
+		after     uint64
+		traces    []json.RawMessage
+	)
+
+	for _, addr := range args.FromAddress {
+		fromAddrs[addr] = struct{}{}
+	}
+
+	for _, addr := range args.ToAddress {
+		toAddrs[addr] = struct{}{}
+	}
+
+	if args.After != nil {
+		after = *args.After
+	}
+
+	for _, number := range numbers {
+		if uint64(len(traces)) >= count {
+			break
+		}
+
+		block, err := api.api.blockByNumber(ctx, rpc.BlockNumber(number))
+		if err != nil {
+			return nil, err
+		}
+
+		encoded, err := api.blockTraces(ctx, block)
+		if err != nil {
+			return nil, err
+		}
+
+		blockTraces, err := newFlatTraces(encoded)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, trace := range blockTraces {
+			if !matchesTraceAddress(fromAddrs, trace.from()) || !matchesTraceAddress(toAddrs, trace.to()) {
+				continue
+			}
+
+			if after > 0 {
+				after--
+				continue
+			}
+
+			if uint64(len(traces)) >= count {
+				break
+			}
+
+			traces = append(traces, trace.RawMessage)
+		}
+	}
+
+	return traces, nil
+}
+
+// filterRange resolves the block range of a trace filter.
+func (api *TraceAPI) filterRange(ctx context.Context, args TraceFilterArgs) (uint64, uint64, error) {
+	resolve := func(number *rpc.BlockNumber, fallback rpc.BlockNumber) (uint64, error) {
+		if number == nil {
+			number = &fallback
+		}
+
+		header, err := api.api.backend.HeaderByNumber(ctx, *number)
+		if err != nil {
+			return 0, err
+		}
+
+		if header == nil {
+			return 0, fmt.Errorf("block #%d not found", *number)
+		}
+
+		return header.Number.Uint64(), nil
+	}
+
+	from, err := resolve(args.FromBlock, 0)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	to, err := resolve(args.ToBlock, rpc.LatestBlockNumber)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	if from > to {
+		return 0, 0, fmt.Errorf("invalid block range %d-%d", from, to)
+	}
+
+	// Genesis has no traces
+	if from == 0 {
+		from = 1
+	}
+
+	return from, to, nil
+}
+
+// filterBlocks returns the numbers of the blocks in [from, to] which may contain
+// traces matching a filter, looking up the indexed blocks by address.
+func (api *TraceAPI) filterBlocks(args TraceFilterArgs, from uint64, to uint64) ([]uint64, error) {
+	var (
+		numbers  []uint64
+		progress = api.index.progress()
+		reexec   uint64
+	)
+
+	// Blocks outside of the index are all candidates
+	indexFrom, indexTo := to+1, to
+	if progress != nil && progress.Next > progress.Tail && progress.Tail <= to && progress.Next > from {
+		indexFrom, indexTo = max(from, progress.Tail), min(to, progress.Next-1)
+	}
+
+	for number := from; number <= to; number++ {
+		if number == indexFrom {
+			numbers = append(numbers, api.indexedBlocks(args, indexFrom, indexTo)...)
+			number = indexTo
+
+			continue
+		}
+
+		if reexec++; reexec > traceFilterMaxReexec {
+			return nil, fmt.Errorf("block range exceeds the trace index by more than %d blocks", traceFilterMaxReexec)
+		}
+
+		numbers = append(numbers, number)
+	}
+
+	return numbers, nil
+}
+
+// indexedBlocks returns the numbers of the indexed blocks in [from, to] with
+// traces from and to the filtered addresses.
+func (api *TraceAPI) indexedBlocks(args TraceFilterArgs, from uint64, to uint64) []uint64 {
+	lookup := func(prefix []byte, addrs []common.Address) map[uint64]struct{} {
+		if len(addrs) == 0 {
+			return nil
+		}
+
+		numbers := make(map[uint64]struct{})
+
+		for _, addr := range addrs {
+			for _, number := range api.index.blocks(prefix, addr, from, to) {
+				numbers[number] = struct{}{}
+			}
+		}
+
+		return numbers
+	}
+
+	var (
+		fromBlocks = lookup(traceIndexFromPrefix, args.FromAddress)
+		toBlocks   = lookup(traceIndexToPrefix, args.ToAddress)
+		numbers    []uint64
+	)
+
+	switch {
+	case fromBlocks == nil && toBlocks == nil:
+		for number := from; number <= to; number++ {
+			numbers = append(numbers, number)
+		}
+
+		return numbers
+	case fromBlocks == nil:
+		fromBlocks = toBlocks
+	case toBlocks != nil:
+		for number := range fromBlocks {
+			if _, ok := toBlocks[number]; !ok {
+				delete(fromBlocks, number)
+			}
+		}
+	}
+
+	for number := range fromBlocks {
+		numbers = append(numbers, number)
+	}
+
+	sort.Slice(numbers, func(i, j int) bool { return numbers[i] < numbers[j] })
+
+	return numbers
+}
+
+// matchesTraceAddress returns whether an address is in a filtered set, any
+// address matching an empty one.
+func matchesTraceAddress(addrs map[common.Address]struct{}, addr *common.Address) bool {
+	if len(addrs) == 0 {
+		return true
+	}
+
+	if addr == nil {
+		return false
+	}
+
+	_, ok := addrs[*addr]
+
+	return ok
+}
+
+// equalTraceAddress returns whether a trace address matches the given indices.
+func equalTraceAddress(address []int, indices []hexutil.Uint64) bool {
+	if len(address) != len(indices) {
+		return false
+	}
+
+	for i, index := range indices {
+		if address[i] != int(index) {
+			return false
+		}
+	}
+
+	return true
+}
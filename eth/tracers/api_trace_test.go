@@ -0,0 +1,291 @@
+package tracers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/core/vm"
+	"github.com/ethereum/go-ethereum/params"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+// The flat call tracer lives in the native package, which can't be imported
+// here, so a minimal one producing the same format stands in for it.
+func init() {
+	DefaultDirectory.Register(flatTraceTracer, newTestFlatTracer, false)
+}
+
+type testFlatFrame struct {
+	Action struct {
+		From  common.Address `json:"from"`
+		To    common.Address `json:"to"`
+		Input hexutil.Bytes  `json:"input"`
+	} `json:"action"`
+	Result struct {
+		Output hexutil.Bytes `json:"output"`
+	} `json:"result"`
+	TraceAddress    []int       `json:"traceAddress"`
+	TransactionHash common.Hash `json:"transactionHash"`
+	Type            string      `json:"type"`
+}
+
+// testFlatTracer records the calls of a transaction as flat frames.
+type testFlatTracer struct {
+	txHash common.Hash
+	frames []*testFlatFrame
+	stack  []*testFlatFrame // Open frames
+	calls  []int            // Number of subcalls of the open frames
+}
+
+func newTestFlatTracer(ctx *Context, _ json.RawMessage) (Tracer, error) {
+	return &testFlatTracer{txHash: ctx.TxHash}, nil
+}
+
+func (t *testFlatTracer) enter(from common.Address, to common.Address, input []byte) {
+	frame := &testFlatFrame{TraceAddress: []int{}, TransactionHash: t.txHash, Type: "call"}
+	frame.Action.From, frame.Action.To, frame.Action.Input = from, to, input
+
+	if len(t.stack) > 0 {
+		parent := t.stack[len(t.stack)-1]
+		frame.TraceAddress = append(append([]int{}, parent.TraceAddress...), t.calls[len(t.calls)-1])
+		t.calls[len(t.calls)-1]++
+	}
+
+	t.frames = append(t.frames, frame)
+	t.stack = append(t.stack, frame)
+	t.calls = append(t.calls, 0)
+}
+
+func (t *testFlatTracer) exit(output []byte) {
+	t.stack[len(t.stack)-1].Result.Output = output
+	t.stack = t.stack[:len(t.stack)-1]
+	t.calls = t.calls[:len(t.calls)-1]
+}
+
+func (t *testFlatTracer) CaptureTxStart(uint64) {}
+func (t *testFlatTracer) CaptureTxEnd(uint64)   {}
+
+func (t *testFlatTracer) CaptureStart(_ *vm.EVM, from common.Address, to common.Address, _ bool, input []byte, _ uint64, _ *big.Int) {
+	t.enter(from, to, input)
+}
+
+func (t *testFlatTracer) CaptureEnd(output []byte, _ uint64, _ error) {
+	t.exit(output)
+}
+
+func (t *testFlatTracer) CaptureEnter(_ vm.OpCode, from common.Address, to common.Address, input []byte, _ uint64, _ *big.Int) {
+	t.enter(from, to, input)
+}
+
+func (t *testFlatTracer) CaptureExit(output []byte, _ uint64, _ error) {
+	t.exit(output)
+}
+
+func (t *testFlatTracer) CaptureState(uint64, vm.OpCode, uint64, uint64, *vm.ScopeContext, []byte, int, error) {
+}
+
+func (t *testFlatTracer) CaptureFault(uint64, vm.OpCode, uint64, uint64, *vm.ScopeContext, int, error) {
+}
+
+func (t *testFlatTracer) GetResult() (json.RawMessage, error) {
+	return json.Marshal(t.frames)
+}
+
+func (t *testFlatTracer) Stop(error) {}
+
+// newTraceTestBackend creates a chain of four blocks, each with a transfer to
+// one of two recipients in turn, along with the bor system calls.
+func newTraceTestBackend(t *testing.T) (*testBackend, []common.Address) {
+	t.Helper()
+
+	accounts := newAccounts(3)
+	genesis := &core.Genesis{
+		Alloc: core.GenesisAlloc{
+			accounts[0].addr: {Balance: big.NewInt(params.Ether)},
+		},
+	}
+
+	backend := newSystemCallTestChain(t, 4, genesis, func(i int, b *core.BlockGen) {
+		tx, _ := types.SignTx(types.NewTransaction(uint64(i), accounts[1+i%2].addr, big.NewInt(1000), params.TxGas, b.BaseFee(), nil), types.HomesteadSigner{}, accounts[0].key)
+		b.AddTx(tx)
+	})
+
+	return backend, []common.Address{accounts[0].addr, accounts[1].addr, accounts[2].addr}
+}
+
+func decodeTestFlatFrames(t *testing.T, traces []json.RawMessage) []*testFlatFrame {
+	t.Helper()
+
+	frames := make([]*testFlatFrame, len(traces))
+	for i, trace := range traces {
+		if err := json.Unmarshal(trace, &frames[i]); err != nil {
+			t.Fatalf("failed to decode trace %d: %v", i, err)
+		}
+	}
+
+	return frames
+}
+
+func TestTraceAPIBlock(t *testing.T) {
+	t.Parallel()
+
+	backend, addrs := newTraceTestBackend(t)
+	defer backend.teardown()
+
+	api := NewTraceAPI(backend)
+
+	// Block traces include the span and state-sync system calls
+	traces, err := api.Block(context.Background(), rpc.BlockNumber(1))
+	if err != nil {
+		t.Fatalf("failed to trace block: %v", err)
+	}
+
+	frames := decodeTestFlatFrames(t, traces)
+	if len(frames) != 4 {
+		t.Fatalf("trace count mismatch: have %d, want 4", len(frames))
+	}
+
+	if frames[0].Action.From != addrs[0] || frames[0].Action.To != addrs[1] {
+		t.Errorf("transaction trace mismatch: %+v", frames[0])
+	}
+
+	if frames[1].Action.To != testValidatorContract || frames[2].Action.To != testStateReceiverContract || frames[3].Action.To != testStateReceiverContract {
+		t.Errorf("system call traces mismatch: %+v %+v %+v", frames[1], frames[2], frames[3])
+	}
+
+	// Replaying groups the traces by transaction
+	results, err := api.ReplayBlockTransactions(context.Background(), rpc.BlockNumberOrHashWithNumber(1), []string{"trace"})
+	if err != nil {
+		t.Fatalf("failed to replay block: %v", err)
+	}
+
+	block := backend.chain.GetBlockByNumber(1)
+	borTxHash := types.GetDerivedBorTxHash(types.BorReceiptKey(1, block.Hash()))
+
+	if len(results) != 2 || results[0].TransactionHash != block.Transactions()[0].Hash() || len(results[0].Trace) != 1 {
+		t.Fatalf("transaction replay mismatch: %+v", results)
+	}
+
+	if results[1].TransactionHash != borTxHash || len(results[1].Trace) != 3 || hexutil.Encode(results[1].Output) != hexutil.Encode(common.LeftPadBytes([]byte{1}, 32)) {
+		t.Errorf("system call replay mismatch: %+v", results[1])
+	}
+
+	if _, err := api.ReplayBlockTransactions(context.Background(), rpc.BlockNumberOrHashWithNumber(1), []string{"trace", "stateDiff"}); !errors.Is(err, errTraceTypeUnsupported) {
+		t.Errorf("wrong error for unsupported trace type: %v", err)
+	}
+
+	// Transactions are traced individually
+	hash := block.Transactions()[0].Hash()
+
+	traces, err = api.Transaction(context.Background(), hash)
+	if err != nil {
+		t.Fatalf("failed to trace transaction: %v", err)
+	}
+
+	if len(traces) != 1 {
+		t.Fatalf("transaction trace count mismatch: have %d, want 1", len(traces))
+	}
+
+	trace, err := api.Get(context.Background(), hash, nil)
+	if err != nil || string(trace) != string(traces[0]) {
+		t.Errorf("top call trace mismatch: have %s (%v), want %s", trace, err, traces[0])
+	}
+
+	if trace, err := api.Get(context.Background(), hash, []hexutil.Uint64{0}); err != nil || trace != nil {
+		t.Errorf("missing call trace mismatch: have %s (%v), want null", trace, err)
+	}
+}
+
+func TestTraceAPIFilter(t *testing.T) {
+	t.Parallel()
+
+	backend, addrs := newTraceTestBackend(t)
+	defer backend.teardown()
+
+	var (
+		api     = NewTraceAPI(backend)
+		indexer = NewTraceIndexer(backend, 2)
+		ctx     = context.Background()
+	)
+
+	filter := func(args TraceFilterArgs) []*testFlatFrame {
+		t.Helper()
+
+		traces, err := api.Filter(ctx, args)
+		if err != nil {
+			t.Fatalf("failed to filter traces: %v", err)
+		}
+
+		return decodeTestFlatFrames(t, traces)
+	}
+
+	// Without the index, blocks are re-executed
+	if frames := filter(TraceFilterArgs{ToAddress: []common.Address{addrs[1]}}); len(frames) != 2 {
+		t.Fatalf("unindexed trace count mismatch: have %d, want 2", len(frames))
+	}
+
+	// Index blocks 2 to 4, the first one staying re-executed
+	if more, err := indexer.indexBatch(ctx); err != nil || more {
+		t.Fatalf("failed to index traces: more %v, err %v", more, err)
+	}
+
+	if progress := api.index.progress(); progress == nil || progress.Tail != 2 || progress.Next != 5 {
+		t.Fatalf("index progress mismatch: %+v", progress)
+	}
+
+	if numbers := api.index.blocks(traceIndexToPrefix, addrs[2], 0, 10); len(numbers) != 2 || numbers[0] != 2 || numbers[1] != 4 {
+		t.Errorf("indexed blocks mismatch: %v", numbers)
+	}
+
+	if numbers := api.index.blocks(traceIndexToPrefix, testStateReceiverContract, 3, 3); len(numbers) != 1 || numbers[0] != 3 {
+		t.Errorf("indexed system call blocks mismatch: %v", numbers)
+	}
+
+	frames := filter(TraceFilterArgs{FromAddress: []common.Address{addrs[0]}, ToAddress: []common.Address{addrs[1]}})
+	if len(frames) != 2 || frames[0].Action.To != addrs[1] || frames[1].Action.To != addrs[1] {
+		t.Errorf("filtered traces mismatch: %+v", frames)
+	}
+
+	if frames := filter(TraceFilterArgs{FromAddress: []common.Address{addrs[1]}, ToAddress: []common.Address{addrs[2]}}); len(frames) != 0 {
+		t.Errorf("mismatching traces returned: %+v", frames)
+	}
+
+	// State-sync system calls are indexed as well
+	var (
+		from  = rpc.BlockNumber(2)
+		after = uint64(1)
+		count = uint64(2)
+	)
+
+	frames = filter(TraceFilterArgs{FromBlock: &from, ToAddress: []common.Address{testStateReceiverContract}, After: &after, Count: &count})
+	if len(frames) != 2 || frames[0].Action.To != testStateReceiverContract {
+		t.Errorf("paginated system call traces mismatch: %+v", frames)
+	}
+
+	block := backend.chain.GetBlockByNumber(3)
+	if frames[1].TransactionHash != types.GetDerivedBorTxHash(types.BorReceiptKey(3, block.Hash())) {
+		t.Errorf("paginated system call trace mismatch: %+v", frames[1])
+	}
+
+	// Counts above the maximum are rejected
+	count = traceFilterMaxCount + 1
+
+	if _, err := api.Filter(ctx, TraceFilterArgs{Count: &count}); err == nil {
+		t.Errorf("trace count above the maximum accepted")
+	}
+
+	// Indexed block traces are served from the index
+	indexed, _ := api.index.blockTraces(block.NumberU64(), block.Hash())
+
+	traces, err := api.Block(ctx, rpc.BlockNumber(3))
+	if err != nil || len(traces) != len(indexed) || string(traces[0]) != string(indexed[0]) {
+		t.Errorf("indexed block traces mismatch: have %d (%v), want %d", len(traces), err, len(indexed))
+	}
+}
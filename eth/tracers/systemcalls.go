@@ -0,0 +1,227 @@
+package tracers
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/consensus"
+	"github.com/ethereum/go-ethereum/consensus/bor/statefull"
+	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/core/state"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/core/vm"
+	"github.com/ethereum/go-ethereum/params"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+// Kinds of the system calls made by the consensus engine when finalising a block.
+const (
+	systemCallSpan      = "span"       // Span commitment to the validator set contract
+	systemCallStateSync = "state-sync" // State-sync commitment to the state receiver contract
+	systemCallOther     = "system"     // Call to any other contract
+)
+
+// systemCallEngine is implemented by the consensus engines able to trace the
+// system calls they make when finalising a block.
+type systemCallEngine interface {
+	TraceSystemCalls(chain consensus.ChainHeaderReader, header *types.Header, state *state.StateDB, tracer statefull.SystemCallTracer) error
+}
+
+// systemCallTracer traces the system calls of a block with a fresh tracer per
+// call, collecting the results of the requested kinds.
+type systemCallTracer struct {
+	api    *API
+	config *TraceConfig
+	txctx  *Context
+	kinds  map[string]bool
+
+	tracer  Tracer           // Tracer of the system call in progress
+	results []*txTraceResult // Trace results of the traced system calls
+	err     error            // Error creating a tracer, aborting the tracing
+}
+
+// kind returns the kind of a system call based on its target contract.
+func (t *systemCallTracer) kind(to common.Address) string {
+	config := t.api.backend.ChainConfig().Bor
+	if config == nil {
+		return systemCallOther
+	}
+
+	switch to {
+	case common.HexToAddress(config.ValidatorContract):
+		return systemCallSpan
+	case common.HexToAddress(config.StateReceiverContract):
+		return systemCallStateSync
+	default:
+		return systemCallOther
+	}
+}
+
+// SystemCallStart implements statefull.SystemCallTracer, creating the tracer of
+// the system call.
+func (t *systemCallTracer) SystemCallStart(msg statefull.Callmsg) vm.EVMLogger {
+	if t.err != nil || !t.kinds[t.kind(*msg.To())] {
+		return nil
+	}
+
+	if t.tracer, t.err = t.api.newTracer(t.txctx, t.config); t.err != nil {
+		return nil
+	}
+
+	return t.tracer
+}
+
+// SystemCallEnd implements statefull.SystemCallTracer, collecting the result of
+// the system call.
+func (t *systemCallTracer) SystemCallEnd(msg statefull.Callmsg, _ uint64, _ []byte, _ error) {
+	if t.tracer == nil {
+		return
+	}
+
+	res := &txTraceResult{
+		TxHash:     t.txctx.TxHash,
+		SystemCall: t.kind(*msg.To()),
+		Contract:   msg.To(),
+	}
+
+	if result, err := t.tracer.GetResult(); err != nil {
+		res.Error = err.Error()
+	} else {
+		res.Result = result
+	}
+
+	t.results = append(t.results, res)
+	t.tracer = nil
+}
+
+// traceSystemCalls traces the system calls the consensus engine makes when
+// finalising a block, on top of the given state after the block's transactions.
+// Only the calls of the given kinds are returned, the others are still applied.
+func (api *API) traceSystemCalls(ctx context.Context, block *types.Block, statedb *state.StateDB, config *TraceConfig, kinds ...string) ([]*txTraceResult, error) {
+	engine, ok := api.backend.Engine().(systemCallEngine)
+	if !ok {
+		return nil, nil
+	}
+
+	// System calls are accounted to the derived state-sync transaction
+	var (
+		txHash = types.GetDerivedBorTxHash(types.BorReceiptKey(block.NumberU64(), block.Hash()))
+		txctx  = &Context{
+			BlockHash:   block.Hash(),
+			BlockNumber: block.Number(),
+			TxIndex:     len(block.Transactions()),
+			TxHash:      txHash,
+		}
+		tracer = &systemCallTracer{
+			api:    api,
+			config: config,
+			txctx:  txctx,
+			kinds:  make(map[string]bool, len(kinds)),
+		}
+	)
+
+	for _, kind := range kinds {
+		tracer.kinds[kind] = true
+	}
+
+	statedb.SetTxContext(txHash, txctx.TxIndex)
+
+	if err := engine.TraceSystemCalls(&chainHeaderReader{ctx: ctx, backend: api.backend}, block.Header(), statedb, tracer); err != nil {
+		return nil, fmt.Errorf("tracing system calls failed: %w", err)
+	}
+
+	if tracer.err != nil {
+		return nil, tracer.err
+	}
+
+	return tracer.results, nil
+}
+
+// traceStateSyncTransaction traces the state-sync system calls of a block, which
+// make up its derived state-sync transaction, returning one result per call.
+func (api *API) traceStateSyncTransaction(ctx context.Context, blockHash common.Hash, blockNumber uint64, config *TraceConfig) (interface{}, error) {
+	block, err := api.blockByNumberAndHash(ctx, rpc.BlockNumber(blockNumber), blockHash)
+	if err != nil {
+		return nil, err
+	}
+
+	parent, err := api.blockByNumberAndHash(ctx, rpc.BlockNumber(blockNumber-1), block.ParentHash())
+	if err != nil {
+		return nil, err
+	}
+
+	reexec := defaultTraceReexec
+	if config.Reexec != nil {
+		reexec = *config.Reexec
+	}
+
+	statedb, release, err := api.backend.StateAtBlock(ctx, parent, reexec, nil, true, false)
+	if err != nil {
+		return nil, err
+	}
+
+	defer release()
+
+	// Recompute the block's transactions, the system calls run after them
+	var (
+		chainConfig = api.backend.ChainConfig()
+		signer      = types.MakeSigner(chainConfig, block.Number(), block.Time())
+		blockCtx    = core.NewEVMBlockContext(block.Header(), api.chainContext(ctx), nil)
+	)
+
+	for i, tx := range block.Transactions() {
+		msg, _ := core.TransactionToMessage(tx, signer, block.BaseFee())
+		vmenv := vm.NewEVM(blockCtx, core.NewEVMTxContext(msg), statedb, chainConfig, vm.Config{})
+		statedb.SetTxContext(tx.Hash(), i)
+		// nolint : contextcheck
+		if _, err := core.ApplyMessage(vmenv, msg, new(core.GasPool).AddGas(msg.GasLimit), context.Background()); err != nil {
+			return nil, fmt.Errorf("transaction %#x failed: %w", tx.Hash(), err)
+		}
+		// Only delete empty objects if EIP158/161 (a.k.a Spurious Dragon) is in effect
+		statedb.Finalise(chainConfig.IsEIP158(block.Number()))
+	}
+
+	return api.traceSystemCalls(ctx, block, statedb, config, systemCallStateSync)
+}
+
+// chainHeaderReader adapts the backend to the header reader the consensus engine
+// needs to re-execute system calls.
+type chainHeaderReader struct {
+	ctx     context.Context
+	backend Backend
+}
+
+func (r *chainHeaderReader) Config() *params.ChainConfig {
+	return r.backend.ChainConfig()
+}
+
+func (r *chainHeaderReader) CurrentHeader() *types.Header {
+	header, _ := r.backend.HeaderByNumber(r.ctx, rpc.LatestBlockNumber)
+	return header
+}
+
+func (r *chainHeaderReader) GetHeader(hash common.Hash, number uint64) *types.Header {
+	header, err := r.backend.HeaderByHash(r.ctx, hash)
+	if err != nil || header == nil || header.Number.Uint64() != number {
+		return nil
+	}
+
+	return header
+}
+
+func (r *chainHeaderReader) GetHeaderByNumber(number uint64) *types.Header {
+	header, _ := r.backend.HeaderByNumber(r.ctx, rpc.BlockNumber(number))
+	return header
+}
+
+func (r *chainHeaderReader) GetHeaderByHash(hash common.Hash) *types.Header {
+	header, _ := r.backend.HeaderByHash(r.ctx, hash)
+	return header
+}
+
+// GetTd is not served by the tracing backend, system calls don't need it.
+func (r *chainHeaderReader) GetTd(common.Hash, uint64) *big.Int {
+	return nil
+}
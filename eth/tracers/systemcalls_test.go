@@ -0,0 +1,194 @@
+package tracers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/consensus"
+	"github.com/ethereum/go-ethereum/consensus/bor/statefull"
+	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/core/state"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/eth/tracers/logger"
+	"github.com/ethereum/go-ethereum/params"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+var (
+	testValidatorContract     = common.HexToAddress("0x0000000000000000000000000000000000001000")
+	testStateReceiverContract = common.HexToAddress("0x0000000000000000000000000000000000001001")
+
+	// testSystemContractCode loads and stores slot 0, emits a log and returns 1
+	testSystemContractCode = common.FromHex("0x60005450600160005560006000a0600160005260206000f3")
+)
+
+// systemCallTestEngine wraps an engine with system calls to a span and a
+// state-sync contract, as bor makes on sprint start blocks. If err is set, the
+// state-syncs fail to be fetched.
+type systemCallTestEngine struct {
+	consensus.Engine
+	config *params.ChainConfig
+	err    error
+}
+
+func (e *systemCallTestEngine) TraceSystemCalls(chain consensus.ChainHeaderReader, header *types.Header, state *state.StateDB, tracer statefull.SystemCallTracer) error {
+	if e.err != nil {
+		return e.err
+	}
+
+	cx := statefull.ChainContext{Chain: chain, Bor: e, Tracer: tracer}
+
+	for _, contract := range []common.Address{testValidatorContract, testStateReceiverContract, testStateReceiverContract} {
+		if _, err := statefull.ApplyMessage(context.Background(), statefull.GetSystemMessage(contract, nil), state, header, e.config, cx); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func newSystemCallTestBackend(t *testing.T) (*testBackend, common.Hash) {
+	t.Helper()
+
+	accounts := newAccounts(2)
+	genesis := &core.Genesis{
+		Alloc: core.GenesisAlloc{
+			accounts[0].addr: {Balance: big.NewInt(params.Ether)},
+		},
+	}
+
+	var txHash common.Hash
+
+	backend := newSystemCallTestChain(t, 1, genesis, func(i int, b *core.BlockGen) {
+		tx, _ := types.SignTx(types.NewTransaction(uint64(i), accounts[1].addr, big.NewInt(1000), params.TxGas, b.BaseFee(), nil), types.HomesteadSigner{}, accounts[0].key)
+		b.AddTx(tx)
+		txHash = tx.Hash()
+	})
+
+	return backend, txHash
+}
+
+// newSystemCallTestChain creates a test backend whose engine makes a span and
+// two state-sync system calls in every block.
+func newSystemCallTestChain(t *testing.T, n int, genesis *core.Genesis, generator func(i int, b *core.BlockGen)) *testBackend {
+	t.Helper()
+
+	genesis.Alloc[testValidatorContract] = core.GenesisAccount{Balance: common.Big0, Code: testSystemContractCode}
+	genesis.Alloc[testStateReceiverContract] = core.GenesisAccount{Balance: common.Big0, Code: testSystemContractCode}
+
+	backend := newTestBackend(t, n, genesis, generator)
+
+	config := *backend.chainConfig
+	config.Bor = &params.BorConfig{
+		ValidatorContract:     testValidatorContract.Hex(),
+		StateReceiverContract: testStateReceiverContract.Hex(),
+		BurntContract:         map[string]string{"0": common.Address{}.Hex()},
+	}
+
+	backend.chainConfig = &config
+	backend.engine = &systemCallTestEngine{Engine: backend.engine, config: &config}
+
+	return backend
+}
+
+// checkSystemCallTrace checks that a system call was traced by the struct logger,
+// including the storage write and the log emission.
+func checkSystemCallTrace(t *testing.T, res *txTraceResult, kind string, contract common.Address, txHash common.Hash) {
+	t.Helper()
+
+	if res.SystemCall != kind || res.Contract == nil || *res.Contract != contract || res.TxHash != txHash || res.Error != "" {
+		t.Fatalf("system call trace mismatch: %+v", res)
+	}
+
+	var exec logger.ExecutionResult
+	if err := json.Unmarshal(res.Result.(json.RawMessage), &exec); err != nil {
+		t.Fatalf("failed to unmarshal result: %v", err)
+	}
+
+	ops := make(map[string]bool)
+	for _, log := range exec.StructLogs {
+		ops[log.Op] = true
+	}
+
+	if exec.Failed || !ops["SSTORE"] || !ops["LOG0"] {
+		t.Errorf("system call execution mismatch: %+v", exec)
+	}
+}
+
+func TestTraceBlockSystemCalls(t *testing.T) {
+	t.Parallel()
+
+	backend, txHash := newSystemCallTestBackend(t)
+	defer backend.chain.Stop()
+
+	api := NewAPI(backend)
+
+	// System calls are only traced on request
+	results, err := api.TraceBlockByNumber(context.Background(), rpc.BlockNumber(1), nil)
+	if err != nil {
+		t.Fatalf("failed to trace block: %v", err)
+	}
+
+	if len(results) != 1 {
+		t.Fatalf("result count mismatch: have %d, want 1", len(results))
+	}
+
+	results, err = api.TraceBlockByNumber(context.Background(), rpc.BlockNumber(1), &TraceConfig{SystemCalls: newBoolPtr(true)})
+	if err != nil {
+		t.Fatalf("failed to trace block: %v", err)
+	}
+
+	if len(results) != 4 {
+		t.Fatalf("result count mismatch: have %d, want 4", len(results))
+	}
+
+	if results[0].TxHash != txHash || results[0].SystemCall != "" {
+		t.Errorf("transaction trace mismatch: %+v", results[0])
+	}
+
+	block := backend.chain.GetBlockByNumber(1)
+	borTxHash := types.GetDerivedBorTxHash(types.BorReceiptKey(1, block.Hash()))
+
+	checkSystemCallTrace(t, results[1], systemCallSpan, testValidatorContract, borTxHash)
+	checkSystemCallTrace(t, results[2], systemCallStateSync, testStateReceiverContract, borTxHash)
+	checkSystemCallTrace(t, results[3], systemCallStateSync, testStateReceiverContract, borTxHash)
+}
+
+func TestTraceStateSyncTransaction(t *testing.T) {
+	t.Parallel()
+
+	backend, _ := newSystemCallTestBackend(t)
+	defer backend.chain.Stop()
+
+	block := backend.chain.GetBlockByNumber(1)
+	borTxHash := types.GetDerivedBorTxHash(types.BorReceiptKey(1, block.Hash()))
+	rawdb.WriteBorTxLookupEntry(backend.chaindb, block.Hash(), 1)
+
+	result, err := NewAPI(backend).TraceTransaction(context.Background(), borTxHash, nil)
+	if err != nil {
+		t.Fatalf("failed to trace state-sync transaction: %v", err)
+	}
+
+	// Only the state-sync calls make up the transaction
+	results, ok := result.([]*txTraceResult)
+	if !ok || len(results) != 2 {
+		t.Fatalf("result mismatch: %v", result)
+	}
+
+	for _, res := range results {
+		checkSystemCallTrace(t, res, systemCallStateSync, testStateReceiverContract, borTxHash)
+	}
+
+	// State-syncs failing to be fetched are reported instead of an empty trace
+	errHeimdall := errors.New("heimdall unreachable")
+	backend.engine.(*systemCallTestEngine).err = errHeimdall
+
+	if _, err := NewAPI(backend).TraceTransaction(context.Background(), borTxHash, nil); !errors.Is(err, errHeimdall) {
+		t.Fatalf("trace error mismatch: have %v, want %v", err, errHeimdall)
+	}
+}
@@ -0,0 +1,426 @@
+package tracers
+
+import (
+	"context"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/ethdb"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/rlp"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+// traceIndexTable is the prefix of the table holding the trace index in the
+// chain database.
+const traceIndexTable = "trace-index-"
+
+// Key prefixes of the trace index table.
+var (
+	traceIndexProgressKey = []byte("progress") // Progress of the indexer
+	traceIndexBlockPrefix = []byte("b")        // blockPrefix + num (uint64 big endian) + hash -> flat traces of the block
+	traceIndexFromPrefix  = []byte("f")        // fromPrefix + address + num (uint64 big endian) -> nil
+	traceIndexToPrefix    = []byte("t")        // toPrefix + address + num (uint64 big endian) -> nil
+)
+
+const (
+	// traceIndexInterval is the interval to check the chain for blocks to index.
+	traceIndexInterval = 2 * time.Second
+
+	// traceIndexMaxBackoff is the maximum delay before retrying after indexing
+	// failed, which it keeps doing if the state of the blocks is not available.
+	traceIndexMaxBackoff = 10 * time.Minute
+
+	// traceIndexBatch is the maximum number of blocks indexed in a round, after
+	// which the progress is persisted and the indexer checks for interruption.
+	traceIndexBatch = 128
+)
+
+// flatTraceTracer is the tracer producing the Parity style flat traces.
+var flatTraceTracer = "flatCallTracer"
+
+// flatTraceConfig makes the flat tracer report the Parity style errors.
+var flatTraceConfig = json.RawMessage(`{"convertParityErrors":true}`)
+
+// flatTrace is a Parity style flat call trace, kept in its JSON encoding along
+// with the fields needed to filter it.
+type flatTrace struct {
+	json.RawMessage
+	fields flatTraceFields
+}
+
+// flatTraceFields are the fields of a flat trace used for filtering.
+type flatTraceFields struct {
+	Action struct {
+		From          *common.Address `json:"from"`
+		To            *common.Address `json:"to"`
+		Address       *common.Address `json:"address"`       // Self-destructed contract
+		RefundAddress *common.Address `json:"refundAddress"` // Beneficiary of a self-destruct
+	} `json:"action"`
+	Result *struct {
+		Address *common.Address `json:"address"` // Created contract
+		Output  hexutil.Bytes   `json:"output"`
+	} `json:"result"`
+	TraceAddress    []int        `json:"traceAddress"`
+	TransactionHash *common.Hash `json:"transactionHash"`
+}
+
+// from returns the address a trace originates from.
+func (t *flatTrace) from() *common.Address {
+	if t.fields.Action.From != nil {
+		return t.fields.Action.From
+	}
+
+	return t.fields.Action.Address
+}
+
+// to returns the address a trace is directed to, the created contract or the
+// self-destruct beneficiary if not a call.
+func (t *flatTrace) to() *common.Address {
+	switch {
+	case t.fields.Action.To != nil:
+		return t.fields.Action.To
+	case t.fields.Action.RefundAddress != nil:
+		return t.fields.Action.RefundAddress
+	case t.fields.Result != nil:
+		return t.fields.Result.Address
+	default:
+		return nil
+	}
+}
+
+// newFlatTraces decodes the flat traces from their JSON encodings.
+func newFlatTraces(encoded []json.RawMessage) ([]*flatTrace, error) {
+	traces := make([]*flatTrace, len(encoded))
+
+	for i, enc := range encoded {
+		traces[i] = &flatTrace{RawMessage: enc}
+		if err := json.Unmarshal(enc, &traces[i].fields); err != nil {
+			return nil, err
+		}
+	}
+
+	return traces, nil
+}
+
+// traceIndexProgress is the persisted progress of the trace indexer, which has
+// indexed the canonical blocks in [Tail, Next).
+type traceIndexProgress struct {
+	Tail     uint64
+	Next     uint64
+	LastHash common.Hash // Hash of the last indexed block, to detect reorgs
+}
+
+// traceIndex is the table of the flat traces of the indexed blocks, along with
+// the numbers of the blocks each address appears in.
+type traceIndex struct {
+	db ethdb.Database
+}
+
+func newTraceIndex(chainDb ethdb.Database) *traceIndex {
+	return &traceIndex{db: rawdb.NewTable(chainDb, traceIndexTable)}
+}
+
+func traceIndexBlockKey(number uint64, hash common.Hash) []byte {
+	key := make([]byte, 0, len(traceIndexBlockPrefix)+8+common.HashLength)
+	key = append(key, traceIndexBlockPrefix...)
+	key = binary.BigEndian.AppendUint64(key, number)
+
+	return append(key, hash.Bytes()...)
+}
+
+func traceIndexAddressKey(prefix []byte, addr common.Address, number uint64) []byte {
+	key := make([]byte, 0, len(prefix)+common.AddressLength+8)
+	key = append(key, prefix...)
+	key = append(key, addr.Bytes()...)
+
+	return binary.BigEndian.AppendUint64(key, number)
+}
+
+// progress returns the progress of the indexer, nil if nothing was indexed.
+func (idx *traceIndex) progress() *traceIndexProgress {
+	data, _ := idx.db.Get(traceIndexProgressKey)
+	if len(data) == 0 {
+		return nil
+	}
+
+	var progress traceIndexProgress
+	if err := rlp.DecodeBytes(data, &progress); err != nil {
+		log.Error("Invalid trace index progress", "err", err)
+		return nil
+	}
+
+	return &progress
+}
+
+// covers returns whether the canonical blocks in [from, to] are indexed.
+func (idx *traceIndex) covers(from uint64, to uint64) bool {
+	progress := idx.progress()
+	return progress != nil && progress.Tail <= from && to < progress.Next
+}
+
+// blockTraces returns the flat traces of an indexed block.
+func (idx *traceIndex) blockTraces(number uint64, hash common.Hash) ([]json.RawMessage, bool) {
+	data, _ := idx.db.Get(traceIndexBlockKey(number, hash))
+	if len(data) == 0 {
+		return nil, false
+	}
+
+	var encoded [][]byte
+	if err := rlp.DecodeBytes(data, &encoded); err != nil {
+		log.Error("Invalid indexed traces", "number", number, "hash", hash, "err", err)
+		return nil, false
+	}
+
+	traces := make([]json.RawMessage, len(encoded))
+	for i, enc := range encoded {
+		traces[i] = enc
+	}
+
+	return traces, true
+}
+
+// blocks returns the numbers of the blocks in [from, to] an address appears in,
+// as the origin of a trace with the from prefix or its target with the to one.
+func (idx *traceIndex) blocks(prefix []byte, addr common.Address, from uint64, to uint64) []uint64 {
+	start := traceIndexAddressKey(prefix, addr, from)
+	it := idx.db.NewIterator(start[:len(prefix)+common.AddressLength], start[len(prefix)+common.AddressLength:])
+
+	defer it.Release()
+
+	var numbers []uint64
+
+	for it.Next() {
+		number := binary.BigEndian.Uint64(it.Key()[len(prefix)+common.AddressLength:])
+		if number > to {
+			break
+		}
+
+		numbers = append(numbers, number)
+	}
+
+	return numbers
+}
+
+// writeBlock stores the flat traces of a block and indexes their addresses.
+func (idx *traceIndex) writeBlock(batch ethdb.KeyValueWriter, number uint64, hash common.Hash, traces []*flatTrace) error {
+	encoded := make([][]byte, len(traces))
+	for i, trace := range traces {
+		encoded[i] = trace.RawMessage
+	}
+
+	data, err := rlp.EncodeToBytes(encoded)
+	if err != nil {
+		return err
+	}
+
+	if err := batch.Put(traceIndexBlockKey(number, hash), data); err != nil {
+		return err
+	}
+
+	for _, trace := range traces {
+		if addr := trace.from(); addr != nil {
+			if err := batch.Put(traceIndexAddressKey(traceIndexFromPrefix, *addr, number), nil); err != nil {
+				return err
+			}
+		}
+
+		if addr := trace.to(); addr != nil {
+			if err := batch.Put(traceIndexAddressKey(traceIndexToPrefix, *addr, number), nil); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// writeProgress stores the progress of the indexer.
+func (idx *traceIndex) writeProgress(batch ethdb.KeyValueWriter, progress *traceIndexProgress) error {
+	data, err := rlp.EncodeToBytes(progress)
+	if err != nil {
+		return err
+	}
+
+	return batch.Put(traceIndexProgressKey, data)
+}
+
+// TraceIndexer indexes the flat call traces of the canonical blocks in the
+// background, including the bor system calls, so that trace_filter can look up
+// the blocks an address appears in instead of re-executing the whole range.
+type TraceIndexer struct {
+	api   *API
+	index *traceIndex
+	from  uint64 // First block to index
+
+	quit chan struct{}
+	wg   sync.WaitGroup
+}
+
+// NewTraceIndexer creates a trace indexer starting at the given block. The state
+// of the indexed blocks must be available, so indexing the history requires an
+// archive node.
+func NewTraceIndexer(backend Backend, from uint64) *TraceIndexer {
+	if from == 0 {
+		from = 1 // Genesis has no traces
+	}
+
+	return &TraceIndexer{
+		api:   NewAPI(backend),
+		index: newTraceIndex(backend.ChainDb()),
+		from:  from,
+		quit:  make(chan struct{}),
+	}
+}
+
+// Start launches the background indexing, implementing node.Lifecycle.
+func (i *TraceIndexer) Start() error {
+	log.Info("Starting trace indexer", "from", i.from)
+
+	i.wg.Add(1)
+
+	go i.loop()
+
+	return nil
+}
+
+// Stop terminates the background indexing, implementing node.Lifecycle.
+func (i *TraceIndexer) Stop() error {
+	close(i.quit)
+	i.wg.Wait()
+
+	return nil
+}
+
+func (i *TraceIndexer) loop() {
+	defer i.wg.Done()
+
+	delay := traceIndexInterval
+
+	for {
+		for {
+			more, err := i.indexBatch(context.Background())
+			if err != nil {
+				// Back off, the failure is unlikely to go away soon
+				delay *= 2
+				if delay > traceIndexMaxBackoff {
+					delay = traceIndexMaxBackoff
+				}
+
+				log.Warn("Failed to index traces", "retry", common.PrettyDuration(delay), "err", err)
+
+				break
+			}
+
+			delay = traceIndexInterval
+
+			if !more {
+				break
+			}
+
+			select {
+			case <-i.quit:
+				return
+			default:
+			}
+		}
+
+		select {
+		case <-time.After(delay):
+		case <-i.quit:
+			return
+		}
+	}
+}
+
+// indexBatch rolls back the blocks reorged out of the chain, then indexes a
+// batch of canonical blocks, returning whether more blocks remain to be indexed.
+func (i *TraceIndexer) indexBatch(ctx context.Context) (bool, error) {
+	db := i.api.backend.ChainDb()
+
+	// Start over if the first block to index was changed
+	progress := i.index.progress()
+	if progress == nil || progress.Tail != i.from {
+		progress = &traceIndexProgress{Tail: i.from, Next: i.from}
+	}
+
+	// Rewind to the last indexed block still canonical. The stale address
+	// entries are harmless, traces being filtered again once loaded.
+	if progress.Next > progress.Tail && rawdb.ReadCanonicalHash(db, progress.Next-1) != progress.LastHash {
+		for progress.Next > progress.Tail {
+			number := progress.Next - 1
+			hash := rawdb.ReadCanonicalHash(db, number)
+
+			if ok, _ := i.index.db.Has(traceIndexBlockKey(number, hash)); ok {
+				progress.LastHash = hash
+				break
+			}
+
+			progress.Next--
+		}
+
+		if progress.Next == progress.Tail {
+			progress.LastHash = common.Hash{}
+		}
+
+		log.Info("Rewound trace index after reorg", "next", progress.Next)
+	}
+
+	head, err := i.api.backend.HeaderByNumber(ctx, rpc.LatestBlockNumber)
+	if err != nil {
+		return false, err
+	}
+
+	end := head.Number.Uint64()
+	if end >= progress.Next+traceIndexBatch {
+		end = progress.Next + traceIndexBatch - 1
+	}
+
+	batch := i.index.db.NewBatch()
+
+	for ; progress.Next <= end; progress.Next++ {
+		block, err := i.api.blockByNumber(ctx, rpc.BlockNumber(progress.Next))
+		if err != nil {
+			break
+		}
+
+		encoded, err := i.api.flatBlockTraces(ctx, block)
+		if err != nil {
+			err = fmt.Errorf("block %d: %w", progress.Next, err)
+			return false, errors.Join(err, i.flush(batch, progress))
+		}
+
+		traces, err := newFlatTraces(encoded)
+		if err != nil {
+			return false, err
+		}
+
+		if err := i.index.writeBlock(batch, block.NumberU64(), block.Hash(), traces); err != nil {
+			return false, err
+		}
+
+		progress.LastHash = block.Hash()
+	}
+
+	if err := i.flush(batch, progress); err != nil {
+		return false, err
+	}
+
+	return progress.Next <= head.Number.Uint64(), nil
+}
+
+// flush persists the indexed blocks along with the progress.
+func (i *TraceIndexer) flush(batch ethdb.Batch, progress *traceIndexProgress) error {
+	if err := i.index.writeProgress(batch, progress); err != nil {
+		return err
+	}
+
+	return batch.Write()
+}
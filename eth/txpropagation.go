@@ -0,0 +1,270 @@
+package eth
+
+import (
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/lru"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/metrics"
+)
+
+// Paths a transaction first reached the node through.
+const (
+	txPathAnnounce  = "announce"  // Hash announced by a peer, body fetched later
+	txPathBroadcast = "broadcast" // Full transaction pushed by a peer
+	txPathLocal     = "local"     // Submitted to the node itself
+)
+
+// txPropagationCacheSize is the number of transactions to keep the propagation
+// records of.
+const txPropagationCacheSize = 32768
+
+var (
+	txPropArrivalTimer   = metrics.NewRegisteredTimer("eth/txprop/arrival", nil)   // Time from the first announcement to the delivery
+	txPropInclusionTimer = metrics.NewRegisteredTimer("eth/txprop/inclusion", nil) // Time from the first sighting to the inclusion in a block
+
+	txPropFirstAnnounceMeter  = metrics.NewRegisteredMeter("eth/txprop/first/announce", nil)
+	txPropFirstBroadcastMeter = metrics.NewRegisteredMeter("eth/txprop/first/broadcast", nil)
+	txPropFirstLocalMeter     = metrics.NewRegisteredMeter("eth/txprop/first/local", nil)
+)
+
+// TxPropagation records how a transaction reached the node, was propagated
+// further and was included in the chain.
+type TxPropagation struct {
+	Hash      common.Hash `json:"hash"`
+	FirstSeen time.Time   `json:"firstSeen"`        // Time the transaction was first announced, delivered or submitted
+	Source    string      `json:"source,omitempty"` // Peer the transaction was first seen from, empty if local
+	Path      string      `json:"path"`             // Path the transaction was first seen through
+
+	Delivered    *time.Time `json:"delivered,omitempty"`    // Time the transaction body first arrived
+	DeliveryPeer string     `json:"deliveryPeer,omitempty"` // Peer the transaction body first arrived from
+	Fetched      bool       `json:"fetched"`                // Whether the body was requested after an announcement
+	ArrivalTime  string     `json:"arrivalTime,omitempty"`  // Delay between the first announcement and the delivery
+
+	Announces  int `json:"announces"`  // Number of announcements received from peers
+	Broadcasts int `json:"broadcasts"` // Number of full transaction broadcasts received from peers
+
+	Propagated          *time.Time `json:"propagated,omitempty"` // Time the transaction was first sent to peers
+	PropagatedDirect    int        `json:"propagatedDirect"`     // Number of peers the transaction was sent to
+	PropagatedAnnounced int        `json:"propagatedAnnounced"`  // Number of peers the transaction was announced to
+
+	IncludedBlock *uint64    `json:"includedBlock,omitempty"` // Number of the block including the transaction
+	Included      *time.Time `json:"included,omitempty"`      // Time the including block was imported
+	InclusionTime string     `json:"inclusionTime,omitempty"` // Delay between the first sighting and the inclusion
+}
+
+// TxPeerPropagation aggregates the transaction propagation statistics of a
+// connected peer.
+type TxPeerPropagation struct {
+	Announced   uint64 `json:"announced"`   // Transactions announced by the peer
+	Broadcast   uint64 `json:"broadcast"`   // Transactions broadcast by the peer
+	Fetched     uint64 `json:"fetched"`     // Transactions delivered by the peer on request
+	First       uint64 `json:"first"`       // Transactions first seen from the peer
+	Late        uint64 `json:"late"`        // Transactions seen from the peer after another source
+	AvgLateness string `json:"avgLateness"` // Average delay behind the first source of the late transactions
+
+	lateness time.Duration // Total delay behind the first source of the late transactions
+}
+
+// txPropagationTracker keeps the propagation records of the recently seen
+// transactions and the statistics of the connected peers.
+type txPropagationTracker struct {
+	txs   lru.BasicLRU[common.Hash, *TxPropagation]
+	peers map[string]*TxPeerPropagation
+
+	lock sync.Mutex
+}
+
+func newTxPropagationTracker() *txPropagationTracker {
+	return &txPropagationTracker{
+		txs:   lru.NewBasicLRU[common.Hash, *TxPropagation](txPropagationCacheSize),
+		peers: make(map[string]*TxPeerPropagation),
+	}
+}
+
+// record retrieves the propagation record of a transaction, creating it if the
+// transaction is seen for the first time. The caller must hold the lock.
+func (t *txPropagationTracker) record(hash common.Hash, peer string, path string, now time.Time) (*TxPropagation, bool) {
+	if rec, ok := t.txs.Get(hash); ok {
+		return rec, false
+	}
+
+	rec := &TxPropagation{Hash: hash, FirstSeen: now, Source: peer, Path: path}
+	t.txs.Add(hash, rec)
+
+	switch path {
+	case txPathAnnounce:
+		txPropFirstAnnounceMeter.Mark(1)
+	case txPathBroadcast:
+		txPropFirstBroadcastMeter.Mark(1)
+	case txPathLocal:
+		txPropFirstLocalMeter.Mark(1)
+	}
+
+	return rec, true
+}
+
+// peer retrieves the statistics of a peer. The caller must hold the lock.
+func (t *txPropagationTracker) peer(id string) *TxPeerPropagation {
+	stats, ok := t.peers[id]
+	if !ok {
+		stats = new(TxPeerPropagation)
+		t.peers[id] = stats
+	}
+
+	return stats
+}
+
+// seen accounts a transaction sighting to the peer, depending on whether the
+// peer was the first source. The caller must hold the lock.
+func (stats *TxPeerPropagation) seen(rec *TxPropagation, first bool, now time.Time) {
+	if first {
+		stats.First++
+		return
+	}
+
+	stats.Late++
+	stats.lateness += now.Sub(rec.FirstSeen)
+}
+
+// announced records the transactions announced by a peer.
+func (t *txPropagationTracker) announced(peer string, hashes []common.Hash) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	var (
+		now   = time.Now()
+		stats = t.peer(peer)
+	)
+
+	for _, hash := range hashes {
+		rec, first := t.record(hash, peer, txPathAnnounce, now)
+		rec.Announces++
+
+		stats.Announced++
+		stats.seen(rec, first, now)
+	}
+}
+
+// delivered records the transactions delivered by a peer, either on request or
+// broadcast.
+func (t *txPropagationTracker) delivered(peer string, txs []*types.Transaction, direct bool) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	var (
+		now   = time.Now()
+		stats = t.peer(peer)
+	)
+
+	for _, tx := range txs {
+		rec, first := t.record(tx.Hash(), peer, txPathBroadcast, now)
+
+		if rec.Delivered == nil {
+			rec.Delivered, rec.DeliveryPeer, rec.Fetched = &now, peer, direct
+
+			if rec.Path == txPathAnnounce {
+				arrival := now.Sub(rec.FirstSeen)
+				rec.ArrivalTime = common.PrettyDuration(arrival).String()
+				txPropArrivalTimer.Update(arrival)
+			}
+		}
+
+		if direct {
+			// Requested bodies follow the peer's own announcement, which was
+			// already accounted
+			stats.Fetched++
+			continue
+		}
+
+		rec.Broadcasts++
+
+		stats.Broadcast++
+		stats.seen(rec, first, now)
+	}
+}
+
+// propagated records a transaction sent to peers, directly or as an
+// announcement.
+func (t *txPropagationTracker) propagated(hash common.Hash, direct int, announced int) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	now := time.Now()
+
+	rec, _ := t.record(hash, "", txPathLocal, now)
+	if rec.Propagated == nil {
+		rec.Propagated = &now
+	}
+
+	rec.PropagatedDirect += direct
+	rec.PropagatedAnnounced += announced
+}
+
+// included records the inclusion of the tracked transactions of a block.
+func (t *txPropagationTracker) included(block *types.Block) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	var (
+		now    = time.Now()
+		number = block.NumberU64()
+	)
+
+	for _, tx := range block.Transactions() {
+		rec, ok := t.txs.Peek(tx.Hash())
+		if !ok || rec.IncludedBlock != nil {
+			continue
+		}
+
+		inclusion := now.Sub(rec.FirstSeen)
+
+		rec.IncludedBlock, rec.Included = &number, &now
+		rec.InclusionTime = common.PrettyDuration(inclusion).String()
+		txPropInclusionTimer.Update(inclusion)
+	}
+}
+
+// dropPeer discards the statistics of a disconnected peer.
+func (t *txPropagationTracker) dropPeer(peer string) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	delete(t.peers, peer)
+}
+
+// transaction returns a copy of the propagation record of a transaction.
+func (t *txPropagationTracker) transaction(hash common.Hash) (*TxPropagation, bool) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	rec, ok := t.txs.Peek(hash)
+	if !ok {
+		return nil, false
+	}
+
+	cpy := *rec
+
+	return &cpy, true
+}
+
+// peerStats returns a copy of the statistics of the connected peers.
+func (t *txPropagationTracker) peerStats() map[string]*TxPeerPropagation {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	res := make(map[string]*TxPeerPropagation, len(t.peers))
+
+	for id, stats := range t.peers {
+		cpy := *stats
+		if cpy.Late > 0 {
+			cpy.AvgLateness = common.PrettyDuration(cpy.lateness / time.Duration(cpy.Late)).String()
+		}
+
+		res[id] = &cpy
+	}
+
+	return res
+}
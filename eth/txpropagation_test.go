@@ -0,0 +1,74 @@
+package eth
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+func TestTxPropagationTracker(t *testing.T) {
+	t.Parallel()
+
+	var (
+		tracker = newTxPropagationTracker()
+		tx1     = types.NewTransaction(1, common.Address{}, big.NewInt(0), 21000, big.NewInt(1), nil)
+		tx2     = types.NewTransaction(2, common.Address{}, big.NewInt(0), 21000, big.NewInt(1), nil)
+		tx3     = types.NewTransaction(3, common.Address{}, big.NewInt(0), 21000, big.NewInt(1), nil)
+	)
+
+	// The first transaction is announced by A then B, and fetched from A
+	tracker.announced("A", []common.Hash{tx1.Hash()})
+	tracker.announced("B", []common.Hash{tx1.Hash()})
+	tracker.delivered("A", []*types.Transaction{tx1}, true)
+
+	// The second transaction is broadcast by B, and the third one is local
+	tracker.delivered("B", []*types.Transaction{tx2}, false)
+	tracker.propagated(tx2.Hash(), 2, 5)
+	tracker.propagated(tx3.Hash(), 3, 6)
+
+	tracker.included(types.NewBlockWithHeader(&types.Header{Number: big.NewInt(7)}).WithBody([]*types.Transaction{tx1, tx3}, nil))
+
+	rec, ok := tracker.transaction(tx1.Hash())
+	if !ok {
+		t.Fatal("announced transaction not tracked")
+	}
+
+	if rec.Path != txPathAnnounce || rec.Source != "A" || rec.Announces != 2 || !rec.Fetched || rec.DeliveryPeer != "A" || rec.ArrivalTime == "" {
+		t.Errorf("announced transaction record mismatch: %+v", rec)
+	}
+
+	if rec.IncludedBlock == nil || *rec.IncludedBlock != 7 || rec.InclusionTime == "" {
+		t.Errorf("announced transaction inclusion mismatch: %+v", rec)
+	}
+
+	rec, _ = tracker.transaction(tx2.Hash())
+	if rec.Path != txPathBroadcast || rec.Source != "B" || rec.Broadcasts != 1 || rec.Fetched || rec.PropagatedDirect != 2 || rec.PropagatedAnnounced != 5 {
+		t.Errorf("broadcast transaction record mismatch: %+v", rec)
+	}
+
+	if rec.IncludedBlock != nil {
+		t.Errorf("broadcast transaction unexpectedly included: %+v", rec)
+	}
+
+	rec, _ = tracker.transaction(tx3.Hash())
+	if rec.Path != txPathLocal || rec.Source != "" || rec.Propagated == nil || rec.IncludedBlock == nil {
+		t.Errorf("local transaction record mismatch: %+v", rec)
+	}
+
+	stats := tracker.peerStats()
+	if a := stats["A"]; a == nil || a.Announced != 1 || a.Fetched != 1 || a.First != 1 || a.Late != 0 {
+		t.Errorf("peer A statistics mismatch: %+v", a)
+	}
+
+	if b := stats["B"]; b == nil || b.Announced != 1 || b.Broadcast != 1 || b.First != 1 || b.Late != 1 || b.AvgLateness == "" {
+		t.Errorf("peer B statistics mismatch: %+v", b)
+	}
+
+	tracker.dropPeer("A")
+
+	if _, ok := tracker.peerStats()["A"]; ok {
+		t.Error("dropped peer statistics still tracked")
+	}
+}
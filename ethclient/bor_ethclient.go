@@ -2,12 +2,51 @@ package ethclient
 
 import (
 	"context"
+	"math/big"
 
 	ethereum "github.com/ethereum/go-ethereum"
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/consensus/bor/valset"
 	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/rpc"
 )
 
+// BorSnapshot is the bor consensus state at a block, as returned by
+// bor_getSnapshot.
+type BorSnapshot struct {
+	Number       uint64                    `json:"number"`       // Block number where the snapshot was created
+	Hash         common.Hash               `json:"hash"`         // Block hash where the snapshot was created
+	ValidatorSet *valset.ValidatorSet      `json:"validatorSet"` // Validator set at this moment
+	Recents      map[uint64]common.Address `json:"recents"`      // Set of recent signers for spam protections
+}
+
+// BorSignerDifficulty is the difficulty of a signer producing a block.
+type BorSignerDifficulty struct {
+	Signer     common.Address
+	Difficulty uint64
+}
+
+// BorProposerSequence is the in-turn order of the signers of a block, as
+// returned by bor_getSnapshotProposerSequence.
+type BorProposerSequence struct {
+	Signers []BorSignerDifficulty // Signers ranked by difficulty
+	Diff    int                   // Difficulty of the block author
+	Author  common.Address
+}
+
+// BorStateSync is a state-sync committed to, or removed from, the canonical
+// chain, as notified by bor_subscribe("stateSyncs").
+type BorStateSync struct {
+	ID          uint64         `json:"id"`
+	Contract    common.Address `json:"contract"`
+	Data        string         `json:"data"`
+	TxHash      common.Hash    `json:"txHash"`
+	BlockNumber uint64         `json:"blockNumber"`
+	BlockHash   common.Hash    `json:"blockHash"`
+	Removed     bool           `json:"removed"` // Whether the committing block was reorged out
+}
+
 // GetRootHash returns the merkle root of the block headers
 func (ec *Client) GetRootHash(ctx context.Context, startBlockNumber uint64, endBlockNumber uint64) (string, error) {
 	var rootHash string
@@ -39,3 +78,138 @@ func (ec *Client) GetBorBlockReceipt(ctx context.Context, hash common.Hash) (*ty
 
 	return r, err
 }
+
+// GetBorBlockLogs returns the logs of the bor state-sync transactions matching
+// the given filter.
+func (ec *Client) GetBorBlockLogs(ctx context.Context, q ethereum.FilterQuery) ([]types.Log, error) {
+	arg, err := toFilterArg(q)
+	if err != nil {
+		return nil, err
+	}
+
+	var result []types.Log
+	err = ec.c.CallContext(ctx, &result, "eth_getBorBlockLogs", arg)
+
+	return result, err
+}
+
+// GetSnapshot returns the bor snapshot at the given block. The latest block is
+// used if number is nil.
+func (ec *Client) GetSnapshot(ctx context.Context, number *big.Int) (*BorSnapshot, error) {
+	var snap *BorSnapshot
+
+	err := ec.c.CallContext(ctx, &snap, "bor_getSnapshot", toBlockNumArg(number))
+	if err == nil && snap == nil {
+		return nil, ethereum.NotFound
+	}
+
+	return snap, err
+}
+
+// GetSnapshotAtHash returns the bor snapshot at the block with the given hash.
+func (ec *Client) GetSnapshotAtHash(ctx context.Context, hash common.Hash) (*BorSnapshot, error) {
+	var snap *BorSnapshot
+
+	err := ec.c.CallContext(ctx, &snap, "bor_getSnapshotAtHash", hash)
+	if err == nil && snap == nil {
+		return nil, ethereum.NotFound
+	}
+
+	return snap, err
+}
+
+// GetSigners returns the authorized signers at the given block. The latest block
+// is used if number is nil.
+func (ec *Client) GetSigners(ctx context.Context, number *big.Int) ([]common.Address, error) {
+	var signers []common.Address
+	err := ec.c.CallContext(ctx, &signers, "bor_getSigners", toBlockNumArg(number))
+
+	return signers, err
+}
+
+// GetSignersAtHash returns the authorized signers at the block with the given
+// hash.
+func (ec *Client) GetSignersAtHash(ctx context.Context, hash common.Hash) ([]common.Address, error) {
+	var signers []common.Address
+	err := ec.c.CallContext(ctx, &signers, "bor_getSignersAtHash", hash)
+
+	return signers, err
+}
+
+// GetAuthor returns the signer of the given block, recovered from its seal.
+func (ec *Client) GetAuthor(ctx context.Context, blockNrOrHash rpc.BlockNumberOrHash) (common.Address, error) {
+	var author *common.Address
+
+	err := ec.c.CallContext(ctx, &author, "bor_getAuthor", blockNrOrHash)
+	if err == nil && author == nil {
+		return common.Address{}, ethereum.NotFound
+	}
+
+	if err != nil {
+		return common.Address{}, err
+	}
+
+	return *author, nil
+}
+
+// GetSnapshotProposer returns the in-turn signer of the given block.
+func (ec *Client) GetSnapshotProposer(ctx context.Context, blockNrOrHash rpc.BlockNumberOrHash) (common.Address, error) {
+	var proposer common.Address
+	err := ec.c.CallContext(ctx, &proposer, "bor_getSnapshotProposer", blockNrOrHash)
+
+	return proposer, err
+}
+
+// GetSnapshotProposerSequence returns the signers of the given block ranked by
+// their in-turn order, along with the block author.
+func (ec *Client) GetSnapshotProposerSequence(ctx context.Context, blockNrOrHash rpc.BlockNumberOrHash) (*BorProposerSequence, error) {
+	var sequence BorProposerSequence
+	if err := ec.c.CallContext(ctx, &sequence, "bor_getSnapshotProposerSequence", blockNrOrHash); err != nil {
+		return nil, err
+	}
+
+	return &sequence, nil
+}
+
+// GetCurrentProposer returns the proposer of the latest block.
+func (ec *Client) GetCurrentProposer(ctx context.Context) (common.Address, error) {
+	var proposer common.Address
+	err := ec.c.CallContext(ctx, &proposer, "bor_getCurrentProposer")
+
+	return proposer, err
+}
+
+// GetCurrentValidators returns the validators of the latest block.
+func (ec *Client) GetCurrentValidators(ctx context.Context) ([]*valset.Validator, error) {
+	var validators []*valset.Validator
+	err := ec.c.CallContext(ctx, &validators, "bor_getCurrentValidators")
+
+	return validators, err
+}
+
+// SendRawTransactionConditional injects a signed transaction into the pending
+// pool, to be included only while the given conditions hold (ERC-4337 bundles).
+func (ec *Client) SendRawTransactionConditional(ctx context.Context, tx *types.Transaction, options types.OptionsAA4337) error {
+	data, err := tx.MarshalBinary()
+	if err != nil {
+		return err
+	}
+
+	return ec.c.CallContext(ctx, nil, "bor_sendRawTransactionConditional", hexutil.Encode(data), options)
+}
+
+// SubscribeNewDeposits subscribes to notifications about the state-syncs from
+// the root chain bridge matching the given filter. An empty filter matches all
+// of them.
+func (ec *Client) SubscribeNewDeposits(ctx context.Context, q ethereum.StateSyncFilter, ch chan<- *types.StateSyncData) (ethereum.Subscription, error) {
+	return ec.c.EthSubscribe(ctx, ch, "newDeposits", q)
+}
+
+// SubscribeStateSyncs subscribes to notifications about the state-syncs
+// committed by the chain from the given ID on, replaying the past ones first.
+// A zero ID subscribes to the new state-syncs only.
+func (ec *Client) SubscribeStateSyncs(ctx context.Context, fromID uint64, ch chan<- *BorStateSync) (ethereum.Subscription, error) {
+	crit := map[string]interface{}{"fromID": fromID}
+
+	return ec.c.Subscribe(ctx, "bor", ch, "stateSyncs", crit)
+}
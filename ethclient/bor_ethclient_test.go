@@ -0,0 +1,272 @@
+package ethclient
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"reflect"
+	"testing"
+	"time"
+
+	ethereum "github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/consensus/bor/valset"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+var (
+	testBorSigner    = common.HexToAddress("0x1001")
+	testBorValidator = &valset.Validator{ID: 1, Address: testBorSigner, VotingPower: 10, ProposerPriority: -5}
+)
+
+// testBorService serves the bor namespace with fixed responses, recording the
+// arguments of the calls.
+type testBorService struct {
+	number  *rpc.BlockNumber
+	tx      hexutil.Bytes
+	options types.OptionsAA4337
+}
+
+func (s *testBorService) GetSnapshot(number *rpc.BlockNumber) (map[string]interface{}, error) {
+	s.number = number
+	if number != nil && *number > 10 {
+		return nil, nil
+	}
+
+	return map[string]interface{}{
+		"number":       10,
+		"hash":         common.Hash{0x01},
+		"validatorSet": valset.NewValidatorSet([]*valset.Validator{testBorValidator}),
+		"recents":      map[uint64]common.Address{10: testBorSigner},
+	}, nil
+}
+
+func (s *testBorService) GetAuthor(blockNrOrHash *rpc.BlockNumberOrHash) (*common.Address, error) {
+	if hash, ok := blockNrOrHash.Hash(); ok && hash != (common.Hash{0x01}) {
+		return nil, errors.New("unknown block")
+	}
+
+	return &testBorSigner, nil
+}
+
+func (s *testBorService) GetSnapshotProposerSequence(blockNrOrHash *rpc.BlockNumberOrHash) (interface{}, error) {
+	return map[string]interface{}{
+		"Signers": []map[string]interface{}{{"Signer": testBorSigner, "Difficulty": 1}},
+		"Diff":    1,
+		"Author":  testBorSigner,
+	}, nil
+}
+
+func (s *testBorService) GetCurrentValidators() []*valset.Validator {
+	return []*valset.Validator{testBorValidator}
+}
+
+func (s *testBorService) SendRawTransactionConditional(input hexutil.Bytes, options types.OptionsAA4337) common.Hash {
+	s.tx, s.options = input, options
+	return common.Hash{}
+}
+
+func (s *testBorService) StateSyncs(ctx context.Context, crit struct{ FromID uint64 }) (*rpc.Subscription, error) {
+	notifier, _ := rpc.NotifierFromContext(ctx)
+	sub := notifier.CreateSubscription()
+
+	go func() {
+		time.Sleep(10 * time.Millisecond)
+		notifier.Notify(sub.ID, map[string]interface{}{
+			"id":          crit.FromID,
+			"contract":    testBorSigner,
+			"data":        "c0de",
+			"txHash":      common.Hash{0x02},
+			"blockNumber": 16,
+			"blockHash":   common.Hash{0x01},
+			"removed":     false,
+		})
+	}()
+
+	return sub, nil
+}
+
+// testDepositService serves the deposit subscription with a single state-sync.
+type testDepositService struct{}
+
+func (s *testDepositService) NewDeposits(ctx context.Context, crit ethereum.StateSyncFilter) (*rpc.Subscription, error) {
+	notifier, _ := rpc.NotifierFromContext(ctx)
+	sub := notifier.CreateSubscription()
+
+	go func() {
+		time.Sleep(10 * time.Millisecond)
+		notifier.Notify(sub.ID, &types.StateSyncData{ID: crit.ID, Contract: crit.Contract, Data: "c0de"})
+	}()
+
+	return sub, nil
+}
+
+func newTestBorClient(t *testing.T) (*Client, *testBorService) {
+	t.Helper()
+
+	var (
+		server  = rpc.NewServer("", 0, 0)
+		service = new(testBorService)
+	)
+
+	if err := server.RegisterName("bor", service); err != nil {
+		t.Fatalf("failed to register bor service: %v", err)
+	}
+
+	if err := server.RegisterName("eth", new(testDepositService)); err != nil {
+		t.Fatalf("failed to register eth service: %v", err)
+	}
+
+	client := NewClient(rpc.DialInProc(server))
+
+	t.Cleanup(func() {
+		client.Close()
+		server.Stop()
+	})
+
+	return client, service
+}
+
+func TestBorClientSnapshot(t *testing.T) {
+	t.Parallel()
+
+	client, service := newTestBorClient(t)
+	ctx := context.Background()
+
+	snap, err := client.GetSnapshot(ctx, nil)
+	if err != nil {
+		t.Fatalf("failed to get snapshot: %v", err)
+	}
+
+	if service.number == nil || *service.number != rpc.LatestBlockNumber {
+		t.Errorf("requested block mismatch: %v", service.number)
+	}
+
+	if snap.Number != 10 || snap.Hash != (common.Hash{0x01}) || snap.Recents[10] != testBorSigner {
+		t.Errorf("snapshot mismatch: %+v", snap)
+	}
+
+	// Priorities are updated by the validator set
+	if vals := snap.ValidatorSet.Validators; len(vals) != 1 || vals[0].Address != testBorSigner || vals[0].VotingPower != 10 {
+		t.Errorf("snapshot validators mismatch: %+v", snap.ValidatorSet.Validators)
+	}
+
+	if _, err := client.GetSnapshot(ctx, big.NewInt(11)); !errors.Is(err, ethereum.NotFound) {
+		t.Errorf("missing snapshot error mismatch: %v", err)
+	}
+
+	author, err := client.GetAuthor(ctx, rpc.BlockNumberOrHashWithHash(common.Hash{0x01}, false))
+	if err != nil || author != testBorSigner {
+		t.Errorf("author mismatch: have %v (%v), want %v", author, err, testBorSigner)
+	}
+
+	if _, err := client.GetAuthor(ctx, rpc.BlockNumberOrHashWithHash(common.Hash{0x02}, false)); err == nil {
+		t.Error("author of unknown block returned")
+	}
+
+	sequence, err := client.GetSnapshotProposerSequence(ctx, rpc.BlockNumberOrHashWithNumber(rpc.LatestBlockNumber))
+	if err != nil {
+		t.Fatalf("failed to get proposer sequence: %v", err)
+	}
+
+	want := &BorProposerSequence{Signers: []BorSignerDifficulty{{testBorSigner, 1}}, Diff: 1, Author: testBorSigner}
+	if !reflect.DeepEqual(sequence, want) {
+		t.Errorf("proposer sequence mismatch: have %+v, want %+v", sequence, want)
+	}
+
+	validators, err := client.GetCurrentValidators(ctx)
+	if err != nil || len(validators) != 1 || !reflect.DeepEqual(validators[0], testBorValidator) {
+		t.Errorf("validators mismatch: %+v (%v)", validators, err)
+	}
+}
+
+func TestBorClientSendRawTransactionConditional(t *testing.T) {
+	t.Parallel()
+
+	client, service := newTestBorClient(t)
+
+	key, _ := crypto.GenerateKey()
+	tx, _ := types.SignTx(types.NewTransaction(0, common.Address{}, big.NewInt(1), 21000, big.NewInt(1), nil), types.HomesteadSigner{}, key)
+
+	max := uint64(100)
+	options := types.OptionsAA4337{
+		KnownAccounts:  types.KnownAccounts{testBorSigner: types.SingleFromHex(common.Hash{0x01}.Hex())},
+		BlockNumberMin: big.NewInt(5),
+		TimestampMax:   &max,
+	}
+
+	if err := client.SendRawTransactionConditional(context.Background(), tx, options); err != nil {
+		t.Fatalf("failed to send transaction: %v", err)
+	}
+
+	data, _ := tx.MarshalBinary()
+	if !reflect.DeepEqual([]byte(service.tx), data) {
+		t.Errorf("sent transaction mismatch: have %x, want %x", service.tx, data)
+	}
+
+	if !reflect.DeepEqual(service.options, options) {
+		t.Errorf("sent options mismatch: have %+v, want %+v", service.options, options)
+	}
+}
+
+func TestBorClientSubscribeNewDeposits(t *testing.T) {
+	t.Parallel()
+
+	client, _ := newTestBorClient(t)
+
+	ch := make(chan *types.StateSyncData)
+
+	sub, err := client.SubscribeNewDeposits(context.Background(), ethereum.StateSyncFilter{ID: 7, Contract: testBorSigner}, ch)
+	if err != nil {
+		t.Fatalf("failed to subscribe: %v", err)
+	}
+	defer sub.Unsubscribe()
+
+	select {
+	case sync := <-ch:
+		if sync.ID != 7 || sync.Contract != testBorSigner || sync.Data != "c0de" {
+			t.Errorf("deposit mismatch: %+v", sync)
+		}
+	case err := <-sub.Err():
+		t.Fatalf("subscription failed: %v", err)
+	case <-time.After(5 * time.Second):
+		t.Fatal("deposit timed out")
+	}
+}
+
+func TestBorClientSubscribeStateSyncs(t *testing.T) {
+	t.Parallel()
+
+	client, _ := newTestBorClient(t)
+
+	ch := make(chan *BorStateSync)
+
+	sub, err := client.SubscribeStateSyncs(context.Background(), 7, ch)
+	if err != nil {
+		t.Fatalf("failed to subscribe: %v", err)
+	}
+	defer sub.Unsubscribe()
+
+	want := &BorStateSync{
+		ID:          7,
+		Contract:    testBorSigner,
+		Data:        "c0de",
+		TxHash:      common.Hash{0x02},
+		BlockNumber: 16,
+		BlockHash:   common.Hash{0x01},
+	}
+
+	select {
+	case sync := <-ch:
+		if !reflect.DeepEqual(sync, want) {
+			t.Errorf("state-sync mismatch: have %+v, want %+v", sync, want)
+		}
+	case err := <-sub.Err():
+		t.Fatalf("subscription failed: %v", err)
+	case <-time.After(5 * time.Second):
+		t.Fatal("state-sync timed out")
+	}
+}
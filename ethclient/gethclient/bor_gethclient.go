@@ -0,0 +1,42 @@
+package gethclient
+
+import (
+	"context"
+	"encoding/json"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/eth/tracers"
+)
+
+// BorBlockTrace is the trace of a bor block, as returned by debug_traceBorBlock.
+type BorBlockTrace struct {
+	Transactions []*BorTxTrace   `json:"transactions"` // Traces of the transactions, the state-sync one included
+	Block        json.RawMessage `json:"block"`        // Traced block
+}
+
+// BorTxTrace is the trace of a transaction of a bor block.
+type BorTxTrace struct {
+	Result           json.RawMessage `json:"result"`           // Trace produced by the tracer
+	Error            string          `json:"error"`            // Trace failure produced by the tracer
+	IntermediateHash common.Hash     `json:"intermediatehash"` // Intermediate state root after the transaction
+}
+
+// TraceBorBlock traces the transactions of the given block, the bor state-sync
+// transaction included, with the given tracer configuration. The latest block
+// is traced if number is nil, and the struct logger used if config is nil.
+func (ec *Client) TraceBorBlock(ctx context.Context, number *big.Int, config *tracers.TraceConfig) (*BorBlockTrace, error) {
+	req := &tracers.TraceBlockRequest{Config: config}
+	if number != nil {
+		req.Number = number.Int64()
+	} else {
+		req.Number = -1
+	}
+
+	var trace BorBlockTrace
+	if err := ec.c.CallContext(ctx, &trace, "debug_traceBorBlock", req); err != nil {
+		return nil, err
+	}
+
+	return &trace, nil
+}
@@ -0,0 +1,55 @@
+package gethclient
+
+import (
+	"context"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/eth/tracers"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+// testBorDebugService serves debug_traceBorBlock, tracing the requested block as
+// a single transaction.
+type testBorDebugService struct{}
+
+func (s *testBorDebugService) TraceBorBlock(req *tracers.TraceBlockRequest) (*tracers.BlockTraceResult, error) {
+	tracer := "struct"
+	if req.Config != nil && req.Config.Tracer != nil {
+		tracer = *req.Config.Tracer
+	}
+
+	return &tracers.BlockTraceResult{
+		Transactions: []*tracers.TxTraceResult{{Result: map[string]interface{}{"tracer": tracer}, IntermediateHash: common.Hash{0x01}}},
+		Block:        map[string]interface{}{"number": req.Number},
+	}, nil
+}
+
+func TestTraceBorBlock(t *testing.T) {
+	t.Parallel()
+
+	server := rpc.NewServer("", 0, 0)
+	defer server.Stop()
+
+	if err := server.RegisterName("debug", new(testBorDebugService)); err != nil {
+		t.Fatalf("failed to register debug service: %v", err)
+	}
+
+	client := New(rpc.DialInProc(server))
+	defer client.c.Close()
+
+	tracer := "callTracer"
+
+	trace, err := client.TraceBorBlock(context.Background(), nil, &tracers.TraceConfig{Tracer: &tracer})
+	if err != nil {
+		t.Fatalf("failed to trace block: %v", err)
+	}
+
+	if string(trace.Block) != `{"number":-1}` {
+		t.Errorf("traced block mismatch: %s", trace.Block)
+	}
+
+	if len(trace.Transactions) != 1 || string(trace.Transactions[0].Result) != `{"tracer":"callTracer"}` || trace.Transactions[0].IntermediateHash != (common.Hash{0x01}) {
+		t.Errorf("transaction traces mismatch: %+v", trace.Transactions)
+	}
+}
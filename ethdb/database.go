@@ -123,6 +123,11 @@ type AncientWriter interface {
 	// will be removed all together.
 	TruncateTail(n uint64) (uint64, error)
 
+	// TruncateTableTail discards the first n ancient data of a single table whose
+	// history expires independently of the others. Tables sharing the common tail
+	// can't be truncated this way.
+	TruncateTableTail(kind string, n uint64) error
+
 	// Sync flushes all in-memory ancient store data to disk.
 	Sync() error
 
@@ -0,0 +1,387 @@
+package graphql
+
+import (
+	"context"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/consensus/bor/heimdall/span"
+	"github.com/ethereum/go-ethereum/consensus/bor/valset"
+	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/eth/filters"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+// maxStateSyncEvents is the maximum number of state-sync events returned by a
+// single stateSyncEvents query.
+const maxStateSyncEvents = 1000
+
+var errNoBorEngine = errors.New("bor queries require a bor chain")
+
+// borEngine is the part of the bor consensus engine backing the bor queries.
+type borEngine interface {
+	GetSpan(ctx context.Context, id uint64) (*span.HeimdallSpan, error)
+	GetCurrentValidators(ctx context.Context, headerHash common.Hash, blockNumber uint64) ([]*valset.Validator, error)
+}
+
+// stateSyncFetcher is the part of the bor consensus engine retrieving the
+// state-syncs of the blocks whose ones weren't stored.
+type stateSyncFetcher interface {
+	FetchStateSyncs(ctx context.Context, fromID uint64, header *types.Header) ([]*types.StateSyncData, error)
+}
+
+func (r *Resolver) borEngine() (borEngine, error) {
+	engine, ok := r.backend.Engine().(borEngine)
+	if !ok || r.backend.ChainConfig().Bor == nil {
+		return nil, errNoBorEngine
+	}
+	return engine, nil
+}
+
+// Validator represents a bor validator.
+type Validator struct {
+	validator *valset.Validator
+}
+
+func (v *Validator) ID(ctx context.Context) hexutil.Uint64 {
+	return hexutil.Uint64(v.validator.ID)
+}
+
+func (v *Validator) Address(ctx context.Context) common.Address {
+	return v.validator.Address
+}
+
+func (v *Validator) VotingPower(ctx context.Context) hexutil.Uint64 {
+	return hexutil.Uint64(v.validator.VotingPower)
+}
+
+func (v *Validator) ProposerPriority(ctx context.Context) hexutil.Big {
+	return hexutil.Big(*big.NewInt(v.validator.ProposerPriority))
+}
+
+func newValidators(validators []*valset.Validator) []*Validator {
+	ret := make([]*Validator, 0, len(validators))
+	for _, validator := range validators {
+		ret = append(ret, &Validator{validator: validator})
+	}
+	return ret
+}
+
+// Span represents a bor span, the range of blocks produced by a validator set.
+type Span struct {
+	span *span.HeimdallSpan
+}
+
+func (s *Span) ID(ctx context.Context) hexutil.Uint64 {
+	return hexutil.Uint64(s.span.ID)
+}
+
+func (s *Span) StartBlock(ctx context.Context) hexutil.Uint64 {
+	return hexutil.Uint64(s.span.StartBlock)
+}
+
+func (s *Span) EndBlock(ctx context.Context) hexutil.Uint64 {
+	return hexutil.Uint64(s.span.EndBlock)
+}
+
+func (s *Span) Validators(ctx context.Context) []*Validator {
+	return newValidators(s.span.ValidatorSet.Validators)
+}
+
+func (s *Span) Producers(ctx context.Context) []*Validator {
+	producers := make([]*valset.Validator, 0, len(s.span.SelectedProducers))
+	for i := range s.span.SelectedProducers {
+		producers = append(producers, &s.span.SelectedProducers[i])
+	}
+	return newValidators(producers)
+}
+
+func (s *Span) ChainID(ctx context.Context) string {
+	return s.span.ChainID
+}
+
+// StateSyncEvent represents a state-sync committed by a bor block.
+type StateSyncEvent struct {
+	sync  *types.StateSyncData
+	block *Block
+}
+
+func (e *StateSyncEvent) ID(ctx context.Context) hexutil.Uint64 {
+	return hexutil.Uint64(e.sync.ID)
+}
+
+func (e *StateSyncEvent) Contract(ctx context.Context) common.Address {
+	return e.sync.Contract
+}
+
+func (e *StateSyncEvent) Data(ctx context.Context) (hexutil.Bytes, error) {
+	return hex.DecodeString(e.sync.Data)
+}
+
+func (e *StateSyncEvent) TxHash(ctx context.Context) common.Hash {
+	return e.sync.TxHash
+}
+
+func (e *StateSyncEvent) Block(ctx context.Context) *Block {
+	return e.block
+}
+
+func (e *StateSyncEvent) Transaction(ctx context.Context) (*Transaction, error) {
+	return e.block.StateSyncTransaction(ctx)
+}
+
+// StateSyncFilterCriteria encapsulates the criteria of a `stateSyncEvents` query.
+type StateSyncFilterCriteria struct {
+	FromID    Long              // first state-sync id, inclusive
+	ToID      *Long             // last state-sync id, inclusive, the last committed one if nil
+	Contracts *[]common.Address // restricts matches to state-syncs to specific receiver contracts
+}
+
+// resolveStateSyncReceipt returns the receipt of the bor state-sync transaction
+// of this block, fetching it if necessary.
+func (b *Block) resolveStateSyncReceipt(ctx context.Context) (*types.Receipt, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.stateSyncReceipt != nil {
+		return b.stateSyncReceipt, nil
+	}
+	receipt, err := b.r.backend.GetBorBlockReceipt(ctx, b.hash)
+	if errors.Is(err, ethereum.NotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	b.stateSyncReceipt = receipt
+	return receipt, nil
+}
+
+// Author is the address of the account that sealed the block, recovered from the
+// seal signature on bor chains.
+func (b *Block) Author(ctx context.Context) (common.Address, error) {
+	header, err := b.resolveHeader(ctx)
+	if err != nil {
+		return common.Address{}, err
+	}
+	return b.r.backend.Engine().Author(header)
+}
+
+// Finalized reports whether the block is finalized, by a milestone on bor chains.
+func (b *Block) Finalized(ctx context.Context) (bool, error) {
+	header, err := b.resolveHeader(ctx)
+	if err != nil {
+		return false, err
+	}
+	finalized, err := b.r.backend.HeaderByNumber(ctx, rpc.FinalizedBlockNumber)
+	if err != nil || finalized == nil || finalized.Number.Cmp(header.Number) < 0 {
+		return false, nil
+	}
+	// Blocks of side chains are never finalized
+	canonical, err := b.r.backend.HeaderByNumber(ctx, rpc.BlockNumber(header.Number.Int64()))
+	if err != nil || canonical == nil {
+		return false, err
+	}
+	return canonical.Hash() == header.Hash(), nil
+}
+
+// StateSyncTransaction returns the bor transaction committing the state-syncs of
+// the block, or nil if the block committed none.
+func (b *Block) StateSyncTransaction(ctx context.Context) (*Transaction, error) {
+	header, err := b.resolveHeader(ctx)
+	if err != nil {
+		return nil, err
+	}
+	receipt, err := b.resolveStateSyncReceipt(ctx)
+	if err != nil || receipt == nil {
+		return nil, err
+	}
+	hash := types.GetDerivedBorTxHash(types.BorReceiptKey(header.Number.Uint64(), header.Hash()))
+	tx, _, _, index, err := b.r.backend.GetBorBlockTransactionWithBlockHash(ctx, hash, header.Hash())
+	if err != nil || tx == nil {
+		return nil, err
+	}
+	return &Transaction{
+		r:         b.r,
+		hash:      hash,
+		tx:        tx,
+		block:     b,
+		index:     index,
+		stateSync: true,
+	}, nil
+}
+
+// borLogs runs a block filter along with a bor block logs filter, returning the
+// logs of both.
+func (b *Block) borLogs(ctx context.Context, filter *filters.Filter, borFilter *filters.BorBlockLogsFilter) ([]*Log, error) {
+	logs, err := filter.Logs(ctx)
+	if err != nil {
+		return nil, err
+	}
+	borLogs, err := borFilter.Logs(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if logs == nil && borLogs == nil {
+		return nil, nil
+	}
+	return newLogs(b.r, types.MergeBorLogs(logs, borLogs)), nil
+}
+
+// getStateSyncLogs returns the logs of the bor state-sync transaction.
+func (t *Transaction) getStateSyncLogs(ctx context.Context, hash common.Hash) (*[]*Log, error) {
+	logs, err := t.r.backend.GetBorBlockLogs(ctx, hash)
+	if err != nil {
+		return nil, err
+	}
+	ret := make([]*Log, 0, len(logs))
+	for _, log := range logs {
+		ret = append(ret, &Log{
+			r:           t.r,
+			transaction: t,
+			log:         log,
+		})
+	}
+	return &ret, nil
+}
+
+// Span fetches a bor span from Heimdall.
+func (r *Resolver) Span(ctx context.Context, args struct{ ID Long }) (*Span, error) {
+	engine, err := r.borEngine()
+	if err != nil {
+		return nil, err
+	}
+	if args.ID < 0 {
+		return nil, nil
+	}
+	heimdallSpan, err := engine.GetSpan(ctx, uint64(args.ID))
+	if err != nil {
+		return nil, err
+	}
+	return &Span{span: heimdallSpan}, nil
+}
+
+// Validators returns the bor validator set at a block, the latest one if not
+// specified.
+func (r *Resolver) Validators(ctx context.Context, args struct{ Block *Long }) ([]*Validator, error) {
+	engine, err := r.borEngine()
+	if err != nil {
+		return nil, err
+	}
+	number := rpc.LatestBlockNumber
+	if args.Block != nil {
+		number = rpc.BlockNumber(*args.Block)
+	}
+	header, err := r.backend.HeaderByNumber(ctx, number)
+	if err != nil {
+		return nil, err
+	}
+	if header == nil {
+		return nil, fmt.Errorf("block #%d not found", number)
+	}
+	validators, err := engine.GetCurrentValidators(ctx, header.Hash(), header.Number.Uint64())
+	if err != nil {
+		return nil, err
+	}
+	return newValidators(validators), nil
+}
+
+// StateSyncEvents returns the state-syncs committed by the canonical chain in the
+// requested id range, as indexed by the node. The state-syncs of the indexed
+// blocks which weren't stored are retrieved from Heimdall.
+func (r *Resolver) StateSyncEvents(ctx context.Context, args struct{ Filter StateSyncFilterCriteria }) ([]*StateSyncEvent, error) {
+	if r.backend.ChainConfig().Bor == nil {
+		return nil, errNoBorEngine
+	}
+	db := r.backend.ChainDb()
+
+	from := uint64(args.Filter.FromID)
+	to := rawdb.ReadLastStateSyncID(db)
+	if args.Filter.ToID != nil && uint64(*args.Filter.ToID) < to {
+		to = uint64(*args.Filter.ToID)
+	}
+	if from == 0 {
+		from = 1
+	}
+	if from > to {
+		return []*StateSyncEvent{}, nil
+	}
+	if to-from >= maxStateSyncEvents {
+		return nil, fmt.Errorf("state-sync id range exceeds %d events", maxStateSyncEvents)
+	}
+	contracts := make(map[common.Address]struct{})
+	if args.Filter.Contracts != nil {
+		for _, contract := range *args.Filter.Contracts {
+			contracts[contract] = struct{}{}
+		}
+	}
+	var (
+		ret    = []*StateSyncEvent{}
+		block  *Block
+		syncs  map[uint64]*types.StateSyncData
+		loaded uint64 // Number of the block whose state-syncs are loaded
+	)
+	for id := from; id <= to; id++ {
+		number := rawdb.ReadStateSyncLookupEntry(db, id)
+		if number == nil {
+			// State-syncs committed before the index was built
+			return nil, fmt.Errorf("state-sync %d is not indexed", id)
+		}
+		// Load the state-syncs of the committing block once
+		if block == nil || loaded != *number {
+			hash := rawdb.ReadCanonicalHash(db, *number)
+			if hash == (common.Hash{}) {
+				return nil, fmt.Errorf("canonical block %d not found", *number)
+			}
+			numberOrHash := rpc.BlockNumberOrHashWithNumber(rpc.BlockNumber(*number))
+			block, loaded = &Block{r: r, numberOrHash: &numberOrHash, hash: hash}, *number
+
+			syncs = make(map[uint64]*types.StateSyncData)
+			for _, sync := range rawdb.ReadStateSyncs(db, hash, *number) {
+				syncs[sync.ID] = sync
+			}
+		}
+		sync, ok := syncs[id]
+		if !ok {
+			// The state-syncs of blocks frozen by older versions weren't kept,
+			// retrieve them from Heimdall
+			fetched, err := r.fetchStateSyncs(ctx, id, block.hash)
+			if err != nil {
+				return nil, fmt.Errorf("state-sync %d not found in block %d: %w", id, *number, err)
+			}
+			for _, sync := range fetched {
+				syncs[sync.ID] = sync
+			}
+			if sync, ok = syncs[id]; !ok {
+				return nil, fmt.Errorf("state-sync %d not found in block %d", id, *number)
+			}
+		}
+		if _, ok := contracts[sync.Contract]; len(contracts) > 0 && !ok {
+			continue
+		}
+		ret = append(ret, &StateSyncEvent{sync: sync, block: block})
+	}
+	return ret, nil
+}
+
+// fetchStateSyncs retrieves the state-syncs from the given id on, up to the
+// given block, from Heimdall.
+func (r *Resolver) fetchStateSyncs(ctx context.Context, fromID uint64, hash common.Hash) ([]*types.StateSyncData, error) {
+	fetcher, ok := r.backend.Engine().(stateSyncFetcher)
+	if !ok {
+		return nil, errNoBorEngine
+	}
+	header, err := r.backend.HeaderByHash(ctx, hash)
+	if err != nil {
+		return nil, err
+	}
+	if header == nil {
+		return nil, fmt.Errorf("block %x not found", hash)
+	}
+	return fetcher.FetchStateSyncs(ctx, fromID, header)
+}
@@ -0,0 +1,173 @@
+package graphql
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/consensus"
+	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/eth/filters"
+	"github.com/ethereum/go-ethereum/internal/ethapi"
+	"github.com/ethereum/go-ethereum/params"
+)
+
+// stateSyncEngine is a consensus engine serving state-syncs as Heimdall would.
+type stateSyncEngine struct {
+	consensus.Engine
+	syncs []*types.StateSyncData
+}
+
+func (e *stateSyncEngine) FetchStateSyncs(ctx context.Context, fromID uint64, header *types.Header) ([]*types.StateSyncData, error) {
+	var syncs []*types.StateSyncData
+	for _, sync := range e.syncs {
+		if sync.ID >= fromID {
+			syncs = append(syncs, sync)
+		}
+	}
+	return syncs, nil
+}
+
+// engineBackend overrides the consensus engine of a backend.
+type engineBackend struct {
+	ethapi.Backend
+	engine consensus.Engine
+}
+
+func (b *engineBackend) Engine() consensus.Engine {
+	return b.engine
+}
+
+func TestGraphQLBorFields(t *testing.T) {
+	var (
+		key, _  = crypto.GenerateKey()
+		addr    = crypto.PubkeyToAddress(key.PublicKey)
+		genesis = &core.Genesis{
+			Config:     params.AllEthashProtocolChanges,
+			GasLimit:   11500000,
+			Difficulty: big.NewInt(1048576),
+			Alloc: core.GenesisAlloc{
+				addr: {Balance: big.NewInt(params.Ether)},
+			},
+		}
+		signer   = types.LatestSigner(genesis.Config)
+		coinbase = common.HexToAddress("0xc0ffee")
+		receiver = common.HexToAddress("0x1001")
+		stack    = createNode(t)
+	)
+	defer stack.Close()
+
+	ethBackend, chain := newGQLBackend(t, stack, false, genesis, 2, func(i int, gen *core.BlockGen) {
+		gen.SetCoinbase(coinbase)
+		tx, _ := types.SignNewTx(key, signer, &types.LegacyTx{Nonce: uint64(i), To: &common.Address{}, Gas: 100000, GasPrice: big.NewInt(params.InitialBaseFee)})
+		gen.AddTx(tx)
+	})
+	// Commit a state-sync in the first block
+	var (
+		db        = ethBackend.ChainDb()
+		block     = chain[0]
+		stateSync = types.GetDerivedBorTxHash(types.BorReceiptKey(block.NumberU64(), block.Hash()))
+	)
+	rawdb.WriteBorReceipt(db, block.Hash(), block.NumberU64(), &types.ReceiptForStorage{
+		Status: types.ReceiptStatusSuccessful,
+		Logs:   []*types.Log{{Address: receiver, Topics: []common.Hash{{0x01}}}},
+	})
+	rawdb.WriteBorTxLookupEntry(db, block.Hash(), block.NumberU64())
+	rawdb.WriteStateSyncs(db, block.Hash(), block.NumberU64(), []*types.StateSyncData{
+		{ID: 1, Contract: receiver, Data: "c0de", TxHash: common.Hash{0x02}},
+		{ID: 2, Contract: common.HexToAddress("0x1002"), Data: "", TxHash: common.Hash{0x03}},
+	})
+	rawdb.WriteLastStateSyncID(db, 2)
+
+	filterSystem := filters.NewFilterSystem(ethBackend.APIBackend, filters.Config{BorLogs: true})
+	handler, err := newHandler(stack, ethBackend.APIBackend, filterSystem, []string{}, []string{})
+	if err != nil {
+		t.Fatalf("could not create graphql service: %v", err)
+	}
+	if err := stack.Start(); err != nil {
+		t.Fatalf("could not start node: %v", err)
+	}
+
+	for i, tt := range []struct {
+		body string
+		want string
+	}{
+		{
+			body: "{block(number: 1) { author finalized } }",
+			want: fmt.Sprintf(`{"block":{"author":"%s","finalized":false}}`, strings.ToLower(coinbase.Hex())),
+		},
+		{
+			body: "{block(number: 1) { stateSyncTransaction { hash index status logs { index account { address } } } } }",
+			want: fmt.Sprintf(`{"block":{"stateSyncTransaction":{"hash":"%s","index":"0x1","status":"0x1","logs":[{"index":"0x1","account":{"address":"%s"}}]}}}`, stateSync.Hex(), strings.ToLower(receiver.Hex())),
+		},
+		// Block logs include the state-sync ones
+		{
+			body: "{block(number: 1) { logs(filter: {}) { index transaction { index } } } }",
+			want: `{"block":{"logs":[{"index":"0x0","transaction":{"index":"0x0"}},{"index":"0x1","transaction":{"index":"0x1"}}]}}`,
+		},
+		// The state-sync transaction is resolved by its hash too
+		{
+			body: fmt.Sprintf(`{transaction(hash: "%s") { index block { number } } }`, stateSync.Hex()),
+			want: `{"transaction":{"index":"0x1","block":{"number":"0x1"}}}`,
+		},
+		{
+			body: "{block(number: 2) { stateSyncTransaction { hash } } }",
+			want: `{"block":{"stateSyncTransaction":null}}`,
+		},
+		{
+			body: "{stateSyncEvents(filter: {fromID: 1}) { id data block { number } transaction { hash } } }",
+			want: fmt.Sprintf(`{"stateSyncEvents":[{"id":"0x1","data":"0xc0de","block":{"number":"0x1"},"transaction":{"hash":"%s"}},{"id":"0x2","data":"0x","block":{"number":"0x1"},"transaction":{"hash":"%[1]s"}}]}`, stateSync.Hex()),
+		},
+		{
+			body: fmt.Sprintf(`{stateSyncEvents(filter: {fromID: 1, toID: 5, contracts: ["%s"]}) { id txHash } }`, receiver.Hex()),
+			want: fmt.Sprintf(`{"stateSyncEvents":[{"id":"0x1","txHash":"%s"}]}`, common.Hash{0x02}.Hex()),
+		},
+	} {
+		res := handler.Schema.Exec(context.Background(), tt.body, "", map[string]interface{}{})
+		if res.Errors != nil {
+			t.Fatalf("failed to execute query for testcase #%d: %v", i, res.Errors)
+		}
+		have, err := json.Marshal(res.Data)
+		if err != nil {
+			t.Fatalf("failed to encode graphql response for testcase #%d: %s", i, err)
+		}
+		if string(have) != tt.want {
+			t.Errorf("response unmatch for testcase #%d.\nhave:\n%s\nwant:\n%s", i, have, tt.want)
+		}
+	}
+	// State-syncs of blocks frozen without them are retrieved from Heimdall
+	rawdb.DeleteStateSyncs(db, block.Hash(), block.NumberU64())
+
+	query := struct{ Filter StateSyncFilterCriteria }{Filter: StateSyncFilterCriteria{FromID: 1}}
+	if _, err := (&Resolver{ethBackend.APIBackend, filterSystem}).StateSyncEvents(context.Background(), query); err == nil {
+		t.Errorf("missing state-syncs served without Heimdall")
+	}
+	resolver := &Resolver{&engineBackend{ethBackend.APIBackend, &stateSyncEngine{ethBackend.Engine(), []*types.StateSyncData{
+		{ID: 1, Contract: receiver, Data: "c0de"},
+		{ID: 2, Contract: common.HexToAddress("0x1002")},
+	}}}, filterSystem}
+	events, err := resolver.StateSyncEvents(context.Background(), query)
+	if err != nil {
+		t.Fatalf("failed to retrieve missing state-syncs: %v", err)
+	}
+	if len(events) != 2 || events[0].sync.ID != 1 || events[1].sync.ID != 2 || events[0].block.hash != block.Hash() {
+		t.Errorf("fetched state-syncs mismatch: %+v", events)
+	}
+	// Span and validator queries require the bor engine
+	for _, body := range []string{
+		"{span(id: 1) { id } }",
+		"{validators { address } }",
+	} {
+		res := handler.Schema.Exec(context.Background(), body, "", map[string]interface{}{})
+		if len(res.Errors) == 0 || !strings.Contains(res.Errors[0].Error(), errNoBorEngine.Error()) {
+			t.Errorf("query %q: unexpected errors %v", body, res.Errors)
+		}
+	}
+}
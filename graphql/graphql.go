@@ -215,9 +215,10 @@ type Transaction struct {
 	hash common.Hash // Must be present after initialization
 	mu   sync.Mutex
 	// mu protects following resources
-	tx    *types.Transaction
-	block *Block
-	index uint64
+	tx        *types.Transaction
+	block     *Block
+	index     uint64
+	stateSync bool // Whether the transaction is the bor state-sync one of its block
 }
 
 // resolve returns the internal transaction object, fetching it if needed.
@@ -241,6 +242,20 @@ func (t *Transaction) resolve(ctx context.Context) (*types.Transaction, *Block)
 		t.index = index
 		return t.tx, t.block
 	}
+	// Try to return a bor state-sync transaction
+	tx, blockHash, _, index, err = t.r.backend.GetBorBlockTransaction(ctx, t.hash)
+	if err == nil && tx != nil {
+		t.tx = tx
+		blockNrOrHash := rpc.BlockNumberOrHashWithHash(blockHash, false)
+		t.block = &Block{
+			r:            t.r,
+			numberOrHash: &blockNrOrHash,
+			hash:         blockHash,
+		}
+		t.index = index
+		t.stateSync = true
+		return t.tx, t.block
+	}
 	// No finalized transaction, try to retrieve it from the pool
 	t.tx = t.r.backend.GetPoolTransaction(t.hash)
 	return t.tx, nil
@@ -431,6 +446,9 @@ func (t *Transaction) getReceipt(ctx context.Context) (*types.Receipt, error) {
 	if block == nil {
 		return nil, nil
 	}
+	if t.stateSync {
+		return block.resolveStateSyncReceipt(ctx)
+	}
 	receipts, err := block.resolveReceipts(ctx)
 	if err != nil {
 		return nil, err
@@ -496,6 +514,9 @@ func (t *Transaction) Logs(ctx context.Context) (*[]*Log, error) {
 // getLogs returns log objects for the given tx.
 // Assumes block hash is resolved.
 func (t *Transaction) getLogs(ctx context.Context, hash common.Hash) (*[]*Log, error) {
+	if t.stateSync {
+		return t.getStateSyncLogs(ctx, hash)
+	}
 	var (
 		filter    = t.r.filterSystem.NewBlockFilter(hash, nil, nil)
 		logs, err = filter.Logs(ctx)
@@ -602,10 +623,11 @@ type Block struct {
 	numberOrHash *rpc.BlockNumberOrHash // Field resolvers assume numberOrHash is always present
 	mu           sync.Mutex
 	// mu protects following resources
-	hash     common.Hash // Must be resolved during initialization
-	header   *types.Header
-	block    *types.Block
-	receipts []*types.Receipt
+	hash             common.Hash // Must be resolved during initialization
+	header           *types.Header
+	block            *types.Block
+	receipts         []*types.Receipt
+	stateSyncReceipt *types.Receipt
 }
 
 // resolve returns the internal Block object representing this block, fetching
@@ -1045,6 +1067,11 @@ func runFilter(ctx context.Context, r *Resolver, filter *filters.Filter) ([]*Log
 	if err != nil || logs == nil {
 		return nil, err
 	}
+	return newLogs(r, logs), nil
+}
+
+// newLogs wraps the logs into `Log` objects.
+func newLogs(r *Resolver, logs []*types.Log) []*Log {
 	ret := make([]*Log, 0, len(logs))
 	for _, log := range logs {
 		ret = append(ret, &Log{
@@ -1053,7 +1080,7 @@ func runFilter(ctx context.Context, r *Resolver, filter *filters.Filter) ([]*Log
 			log:         log,
 		})
 	}
-	return ret, nil
+	return ret
 }
 
 func (b *Block) Logs(ctx context.Context, args struct{ Filter BlockFilterCriteria }) ([]*Log, error) {
@@ -1072,6 +1099,11 @@ func (b *Block) Logs(ctx context.Context, args struct{ Filter BlockFilterCriteri
 	}
 	filter := b.r.filterSystem.NewBlockFilter(hash, addresses, topics)
 
+	// Include the bor state-sync logs if enabled
+	if config := b.r.backend.ChainConfig().Bor; b.r.filterSystem.BorLogs() && config != nil {
+		return b.borLogs(ctx, filter, filters.NewBorBlockLogsFilter(b.r.backend, config, hash, addresses, topics))
+	}
+
 	// Run the filter and return all the logs
 	return runFilter(ctx, b.r, filter)
 }
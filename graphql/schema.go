@@ -248,7 +248,8 @@ const schema string = `
         # transactions are unavailable for this block, or if the index is out of
         # bounds, this field will be null.
         transactionAt(index: Long!): Transaction
-        # Logs returns a filtered set of logs from this block.
+        # Logs returns a filtered set of logs from this block. The logs of the
+        # bor state-sync transaction are included if bor logs are enabled.
         logs(filter: BlockFilterCriteria!): [Log!]!
         # Account fetches an Ethereum account at the current block's state.
         account(address: Address!): Account!
@@ -267,6 +268,15 @@ const schema string = `
         # Withdrawals is a list of withdrawals associated with this block. If
         # withdrawals are unavailable for this block, this field will be null.
         withdrawals: [Withdrawal!]
+        # Author is the account that sealed this block, recovered from the seal
+        # signature on bor chains.
+        author: Address!
+        # Finalized is true if this block is on the canonical chain at or below
+        # the finalized block, which is the last milestone on bor chains.
+        finalized: Boolean!
+        # StateSyncTransaction is the bor transaction committing the state-syncs
+        # of this block. If the block committed none, this field will be null.
+        stateSyncTransaction: Transaction
     }
 
     # CallData represents the data associated with a local contract call.
@@ -350,6 +360,64 @@ const schema string = `
       estimateGas(data: CallData!): Long!
     }
 
+    # Validator is a bor validator.
+    type Validator {
+        # ID is the Heimdall id of the validator.
+        id: Long!
+        # Address is the signer address of the validator.
+        address: Address!
+        # VotingPower is the voting power of the validator.
+        votingPower: Long!
+        # ProposerPriority is the priority of the validator to produce blocks.
+        proposerPriority: BigInt!
+    }
+
+    # Span is a range of bor blocks produced by a validator set.
+    type Span {
+        # ID is the number of the span.
+        id: Long!
+        # StartBlock is the first block of the span.
+        startBlock: Long!
+        # EndBlock is the last block of the span.
+        endBlock: Long!
+        # Validators is the validator set of the span.
+        validators: [Validator!]!
+        # Producers is the list of validators selected to produce the blocks.
+        producers: [Validator!]!
+        # ChainID is the bor chain id of the span.
+        chainID: String!
+    }
+
+    # StateSyncEvent is a state-sync from the root chain committed by a bor
+    # block.
+    type StateSyncEvent {
+        # ID is the id of the state-sync.
+        id: Long!
+        # Contract is the receiver contract of the state-sync.
+        contract: Address!
+        # Data is the data passed to the receiver contract.
+        data: Bytes!
+        # TxHash is the hash of the root chain transaction emitting the
+        # state-sync.
+        txHash: Bytes32!
+        # Block is the bor block committing the state-sync.
+        block: Block!
+        # Transaction is the bor state-sync transaction of the block.
+        transaction: Transaction
+    }
+
+    # StateSyncFilterCriteria encapsulates state-sync filter criteria.
+    input StateSyncFilterCriteria {
+        # FromID is the first state-sync id, inclusive.
+        fromID: Long!
+        # ToID is the last state-sync id, inclusive. Defaults to the last
+        # committed state-sync if not supplied.
+        toID: Long
+        # Contracts is a list of receiver contracts that are of interest. If
+        # this list is empty, results will not be filtered by contract.
+        contracts: [Address!]
+    }
+
     type Query {
         # Block fetches an Ethereum block by number or by hash. If neither is
         # supplied, the most recent known block is returned.
@@ -373,6 +441,14 @@ const schema string = `
         syncing: SyncState
         # ChainID returns the current chain ID for transaction replay protection.
         chainID: BigInt!
+        # Span fetches a bor span by id from Heimdall.
+        span(id: Long!): Span
+        # Validators returns the bor validator set at a block. Defaults to the
+        # latest block if not supplied.
+        validators(block: Long): [Validator!]!
+        # StateSyncEvents returns the state-syncs committed by the canonical
+        # chain matching the provided filter, at most 1000 at a time.
+        stateSyncEvents(filter: StateSyncFilterCriteria!): [StateSyncEvent!]!
     }
 
     type Mutation {
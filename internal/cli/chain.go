@@ -18,6 +18,9 @@ func (c *ChainCommand) MarkDown() string {
 		"The ```chain``` command groups actions to interact with the blockchain in the client:",
 		"- [```chain sethead```](./chain_sethead.md): Set the current chain to a certain block.",
 		"- [```chain watch```](./chain_watch.md): Watch the chainHead, reorg and fork events in real-time.",
+		"- [```chain export```](./chain_export.md): Export a chain segment with its Bor specific data.",
+		"- [```chain import```](./chain_import.md): Verify and import a chain segment with its Bor specific data.",
+		"- [```chain reindex-bor```](./chain_reindex-bor.md): Regenerate the bor receipts of a block range.",
 	}
 
 	return strings.Join(items, "\n\n")
@@ -31,7 +34,19 @@ func (c *ChainCommand) Help() string {
 	
   Set the new head of the chain:
   
-    $ bor chain sethead <number>`
+    $ bor chain sethead <number>
+
+  Export a chain segment:
+
+    $ bor chain export <file> <first> <last>
+
+  Import a chain segment:
+
+    $ bor chain import <file>
+
+  Regenerate the bor receipts of a block range:
+
+    $ bor chain reindex-bor <from> <to>`
 }
 
 // Synopsis implements the cli.Command interface
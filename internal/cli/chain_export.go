@@ -0,0 +1,107 @@
+package cli
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/cmd/utils"
+	"github.com/ethereum/go-ethereum/internal/cli/flagset"
+)
+
+// ChainExportCommand is the command to export a chain segment
+type ChainExportCommand struct {
+	*Meta
+
+	datadirAncient string
+	chunkSize      uint64
+}
+
+// MarkDown implements cli.MarkDown interface
+func (c *ChainExportCommand) MarkDown() string {
+	items := []string{
+		"# Chain export",
+		"The ```chain export <file> <first> <last>``` command exports a range of canonical blocks from the database at the given datadir location, together with the bor receipts, bor tx lookup entries, the Bor snapshots at the segment boundaries and the whitelisted checkpoint and milestone. The segment is versioned and split in checksummed chunks, it can be verified and imported on another node with ```chain import```. If the file ends with .gz, the output is gzipped.",
+		"## Arguments",
+		"- ```file```: The file to write the segment to.",
+		"- ```first```: The first block number of the segment.",
+		"- ```last```: The last block number of the segment.",
+		c.Flags().MarkDown(),
+	}
+
+	return strings.Join(items, "\n\n")
+}
+
+// Help implements the cli.Command interface
+func (c *ChainExportCommand) Help() string {
+	return `Usage: bor chain export <file> <first> <last>
+
+  This command exports a chain segment with its Bor specific data` + c.Flags().Help()
+}
+
+// Synopsis implements the cli.Command interface
+func (c *ChainExportCommand) Synopsis() string {
+	return "Export a chain segment"
+}
+
+func (c *ChainExportCommand) Flags() *flagset.Flagset {
+	flags := c.NewFlagSet("chain export")
+
+	flags.StringFlag(&flagset.StringFlag{
+		Name:    "datadir.ancient",
+		Value:   &c.datadirAncient,
+		Usage:   "Path of the ancient data directory to store information",
+		Default: "",
+	})
+
+	flags.Uint64Flag(&flagset.Uint64Flag{
+		Name:    "chunk-size",
+		Value:   &c.chunkSize,
+		Usage:   "Number of blocks per checksummed chunk",
+		Default: utils.DefaultBorSegmentChunkSize,
+	})
+
+	return flags
+}
+
+// Run implements the cli.Command interface
+func (c *ChainExportCommand) Run(args []string) int {
+	flags := c.Flags()
+	if err := flags.Parse(args); err != nil {
+		c.UI.Error(err.Error())
+		return 1
+	}
+
+	args = flags.Args()
+	if len(args) != 3 {
+		c.UI.Error("Expected the file, first and last block arguments")
+		return 1
+	}
+
+	first, err := strconv.ParseUint(args[1], 10, 64)
+	if err != nil {
+		c.UI.Error(err.Error())
+		return 1
+	}
+
+	last, err := strconv.ParseUint(args[2], 10, 64)
+	if err != nil {
+		c.UI.Error(err.Error())
+		return 1
+	}
+
+	stack, chaindb, err := openChainDatabase(c.dataDir, c.datadirAncient, true)
+	if err != nil {
+		c.UI.Error(err.Error())
+		return 1
+	}
+	defer stack.Close()
+
+	if err := utils.ExportBorSegment(chaindb, args[0], first, last, c.chunkSize); err != nil {
+		c.UI.Error(err.Error())
+		return 1
+	}
+
+	c.UI.Output("Done!")
+
+	return 0
+}
@@ -0,0 +1,109 @@
+package cli
+
+import (
+	"strings"
+
+	"github.com/ethereum/go-ethereum/cmd/utils"
+	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/eth/ethconfig"
+	"github.com/ethereum/go-ethereum/internal/cli/flagset"
+)
+
+// ChainImportCommand is the command to import a chain segment
+type ChainImportCommand struct {
+	*Meta
+
+	datadirAncient string
+	verify         bool
+}
+
+// MarkDown implements cli.MarkDown interface
+func (c *ChainImportCommand) MarkDown() string {
+	items := []string{
+		"# Chain import",
+		"The ```chain import <file>``` command verifies a chain segment written by ```chain export``` and imports it into the database at the given datadir location. Every chunk checksum, block commitment, total difficulty and block seal is checked and the segment must extend the local canonical chain without conflicting with it. Nothing is written unless the whole segment is valid. Blocks are imported without state, the node downloads the state when it next syncs. The Bor snapshots and the checkpoint and milestone carried by the segment must be on the verified chain, and the snapshots must match the ones derived locally. They are not imported: the snapshots are rebuilt locally and the finalized blocks are whitelisted from Heimdall.",
+		"## Arguments",
+		"- ```file```: The segment file to import.",
+		c.Flags().MarkDown(),
+	}
+
+	return strings.Join(items, "\n\n")
+}
+
+// Help implements the cli.Command interface
+func (c *ChainImportCommand) Help() string {
+	return `Usage: bor chain import <file>
+
+  This command imports a chain segment with its Bor specific data` + c.Flags().Help()
+}
+
+// Synopsis implements the cli.Command interface
+func (c *ChainImportCommand) Synopsis() string {
+	return "Import a chain segment"
+}
+
+func (c *ChainImportCommand) Flags() *flagset.Flagset {
+	flags := c.NewFlagSet("chain import")
+
+	flags.StringFlag(&flagset.StringFlag{
+		Name:    "datadir.ancient",
+		Value:   &c.datadirAncient,
+		Usage:   "Path of the ancient data directory to store information",
+		Default: "",
+	})
+
+	flags.BoolFlag(&flagset.BoolFlag{
+		Name:    "verify",
+		Value:   &c.verify,
+		Usage:   "Only verify the segment against the database without importing it",
+		Default: false,
+	})
+
+	return flags
+}
+
+// Run implements the cli.Command interface
+func (c *ChainImportCommand) Run(args []string) int {
+	flags := c.Flags()
+	if err := flags.Parse(args); err != nil {
+		c.UI.Error(err.Error())
+		return 1
+	}
+
+	args = flags.Args()
+	if len(args) != 1 {
+		c.UI.Error("No file provided")
+		return 1
+	}
+
+	stack, chaindb, err := openChainDatabase(c.dataDir, c.datadirAncient, c.verify)
+	if err != nil {
+		c.UI.Error(err.Error())
+		return 1
+	}
+	defer stack.Close()
+
+	config := rawdb.ReadChainConfig(chaindb, rawdb.ReadCanonicalHash(chaindb, 0))
+	if config == nil {
+		c.UI.Error("Chain config not found")
+		return 1
+	}
+
+	// The engine only verifies the seals of the imported headers, which doesn't
+	// require Heimdall
+	engine, err := ethconfig.CreateConsensusEngine(config, &ethconfig.Config{WithoutHeimdall: true}, chaindb, nil)
+	if err != nil {
+		c.UI.Error(err.Error())
+		return 1
+	}
+	defer engine.Close()
+
+	if err := utils.ImportBorSegment(chaindb, engine, args[0], c.verify); err != nil {
+		c.UI.Error(err.Error())
+		return 1
+	}
+
+	c.UI.Output("Done!")
+
+	return 0
+}
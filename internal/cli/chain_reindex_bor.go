@@ -0,0 +1,175 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/internal/cli/flagset"
+	"github.com/ethereum/go-ethereum/internal/cli/server/proto"
+)
+
+// ChainReindexBorCommand is the command to regenerate the bor receipts
+type ChainReindexBorCommand struct {
+	*Meta2
+
+	status bool
+	stop   bool
+	peers  bool
+}
+
+// MarkDown implements cli.MarkDown interface
+func (c *ChainReindexBorCommand) MarkDown() string {
+	items := []string{
+		"# Chain reindex-bor",
+		"The ```chain reindex-bor <from> <to>``` command regenerates the bor receipts and bor tx lookup entries of a block range in the background, so that state-sync transactions and ```eth_getBorBlockLogs``` data are available on nodes that ran without ```bor.logs``` or lost their bor receipts. Only the state-sync system calls of the sprint start blocks are re-executed, on top of the parent state which must be available. The progress is persisted and an interrupted reindex resumes when the node restarts.",
+		"With ```--peers```, the missing bor receipts of the range are fetched from the connected peers instead, which works without the parent states and for blocks in the freezer. Receipts are only accepted for blocks of the local canonical chain, and the command waits for the backfill to complete.",
+		"## Arguments",
+		"- ```from```: The first block number to reindex.",
+		"- ```to```: The last block number to reindex.",
+		c.Flags().MarkDown(),
+	}
+
+	return strings.Join(items, "\n\n")
+}
+
+// Help implements the cli.Command interface
+func (c *ChainReindexBorCommand) Help() string {
+	return `Usage: bor chain reindex-bor <from> <to>
+
+  This command regenerates the bor receipts of a block range.
+
+  Show the progress of the reindex:
+
+    $ bor chain reindex-bor --status
+
+  Stop the reindex:
+
+    $ bor chain reindex-bor --stop
+
+  Fetch the missing bor receipts of a block range from peers:
+
+    $ bor chain reindex-bor --peers <from> <to>` + c.Flags().Help()
+}
+
+func (c *ChainReindexBorCommand) Flags() *flagset.Flagset {
+	flags := c.NewFlagSet("chain reindex-bor")
+
+	flags.BoolFlag(&flagset.BoolFlag{
+		Name:    "status",
+		Usage:   "Show the progress of the reindex",
+		Default: false,
+		Value:   &c.status,
+	})
+
+	flags.BoolFlag(&flagset.BoolFlag{
+		Name:    "stop",
+		Usage:   "Stop the reindex, it can be resumed by restarting the node",
+		Default: false,
+		Value:   &c.stop,
+	})
+
+	flags.BoolFlag(&flagset.BoolFlag{
+		Name:    "peers",
+		Usage:   "Fetch the missing bor receipts of the range from peers instead of replaying the state-sync system calls",
+		Default: false,
+		Value:   &c.peers,
+	})
+
+	return flags
+}
+
+// Synopsis implements the cli.Command interface
+func (c *ChainReindexBorCommand) Synopsis() string {
+	return "Regenerate the bor receipts of a block range"
+}
+
+// Run implements the cli.Command interface
+func (c *ChainReindexBorCommand) Run(args []string) int {
+	flags := c.Flags()
+	if err := flags.Parse(args); err != nil {
+		c.UI.Error(err.Error())
+		return 1
+	}
+
+	req := &proto.ChainReindexBorRequest{}
+
+	args = flags.Args()
+
+	switch {
+	case c.stop:
+		req.Action = proto.ChainReindexBorRequest_STOP
+	case c.status:
+		req.Action = proto.ChainReindexBorRequest_STATUS
+	default:
+		if len(args) != 2 {
+			c.UI.Error("Expected the from and to block arguments")
+			return 1
+		}
+
+		from, err := strconv.ParseUint(args[0], 10, 64)
+		if err != nil {
+			c.UI.Error(err.Error())
+			return 1
+		}
+
+		to, err := strconv.ParseUint(args[1], 10, 64)
+		if err != nil {
+			c.UI.Error(err.Error())
+			return 1
+		}
+
+		req.Action, req.From, req.To = proto.ChainReindexBorRequest_START, from, to
+
+		if c.peers {
+			req.Action = proto.ChainReindexBorRequest_BACKFILL
+		}
+	}
+
+	borClt, err := c.BorConn()
+	if err != nil {
+		c.UI.Error(err.Error())
+		return 1
+	}
+
+	resp, err := borClt.ChainReindexBor(context.Background(), req)
+	if err != nil {
+		c.UI.Error(err.Error())
+		return 1
+	}
+
+	if req.Action == proto.ChainReindexBorRequest_BACKFILL {
+		c.UI.Output(formatKV([]string{
+			fmt.Sprintf("From|%d", resp.From),
+			fmt.Sprintf("To|%d", resp.To),
+			fmt.Sprintf("Backfilled|%d", resp.Backfilled),
+		}))
+
+		return 0
+	}
+
+	c.UI.Output(printBorReindexStatus(resp))
+
+	return 0
+}
+
+func printBorReindexStatus(resp *proto.ChainReindexBorResponse) string {
+	if resp.To == 0 && !resp.Running {
+		return "No bor reindex in progress"
+	}
+
+	kv := []string{
+		fmt.Sprintf("Running|%t", resp.Running),
+		fmt.Sprintf("From|%d", resp.From),
+		fmt.Sprintf("To|%d", resp.To),
+		fmt.Sprintf("Next|%d", resp.Next),
+		fmt.Sprintf("Written|%d", resp.Written),
+	}
+
+	if resp.Error != "" {
+		kv = append(kv, fmt.Sprintf("Error|%s", resp.Error))
+	}
+
+	return formatKV(kv)
+}
@@ -1,13 +1,17 @@
 package cli
 
 import (
+	"errors"
 	"fmt"
 	"os"
 
 	"github.com/ethereum/go-ethereum/accounts/keystore"
+	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/ethdb"
 	"github.com/ethereum/go-ethereum/internal/cli/flagset"
 	"github.com/ethereum/go-ethereum/internal/cli/server"
 	"github.com/ethereum/go-ethereum/internal/cli/server/proto"
+	"github.com/ethereum/go-ethereum/internal/grpcauth"
 	"github.com/ethereum/go-ethereum/node"
 
 	"github.com/mitchellh/cli"
@@ -103,6 +107,21 @@ func Commands() map[string]MarkDownCommandFactory {
 				Meta2: meta2,
 			}, nil
 		},
+		"debug trace-tx": func() (MarkDownCommand, error) {
+			return &DebugTraceTxCommand{
+				Meta2: meta2,
+			}, nil
+		},
+		"debug dump-state": func() (MarkDownCommand, error) {
+			return &DebugDumpStateCommand{
+				Meta2: meta2,
+			}, nil
+		},
+		"debug db-stats": func() (MarkDownCommand, error) {
+			return &DebugDatabaseStatsCommand{
+				Meta2: meta2,
+			}, nil
+		},
 		"chain": func() (MarkDownCommand, error) {
 			return &ChainCommand{
 				UI: ui,
@@ -118,6 +137,21 @@ func Commands() map[string]MarkDownCommandFactory {
 				Meta2: meta2,
 			}, nil
 		},
+		"chain reindex-bor": func() (MarkDownCommand, error) {
+			return &ChainReindexBorCommand{
+				Meta2: meta2,
+			}, nil
+		},
+		"chain export": func() (MarkDownCommand, error) {
+			return &ChainExportCommand{
+				Meta: meta,
+			}, nil
+		},
+		"chain import": func() (MarkDownCommand, error) {
+			return &ChainImportCommand{
+				Meta: meta,
+			}, nil
+		},
 		"account": func() (MarkDownCommand, error) {
 			return &Account{
 				UI: ui,
@@ -163,11 +197,36 @@ func Commands() map[string]MarkDownCommandFactory {
 				Meta2: meta2,
 			}, nil
 		},
+		"txpool": func() (MarkDownCommand, error) {
+			return &TxPoolCommand{
+				UI: ui,
+			}, nil
+		},
+		"txpool inspect": func() (MarkDownCommand, error) {
+			return &TxPoolInspectCommand{
+				Meta2: meta2,
+			}, nil
+		},
+		"txpool clear": func() (MarkDownCommand, error) {
+			return &TxPoolClearCommand{
+				Meta2: meta2,
+			}, nil
+		},
+		"txpool reset-nonce": func() (MarkDownCommand, error) {
+			return &TxPoolResetNonceCommand{
+				Meta2: meta2,
+			}, nil
+		},
 		"status": func() (MarkDownCommand, error) {
 			return &StatusCommand{
 				Meta2: meta2,
 			}, nil
 		},
+		"reload": func() (MarkDownCommand, error) {
+			return &ReloadCommand{
+				Meta2: meta2,
+			}, nil
+		},
 		"fingerprint": func() (MarkDownCommand, error) {
 			return &FingerprintCommand{
 				UI: ui,
@@ -190,6 +249,21 @@ func Commands() map[string]MarkDownCommandFactory {
 				Meta2: meta2,
 			}, nil
 		},
+		"db": func() (MarkDownCommand, error) {
+			return &DBCommand{
+				UI: ui,
+			}, nil
+		},
+		"db verify": func() (MarkDownCommand, error) {
+			return &DBVerifyCommand{
+				Meta: meta,
+			}, nil
+		},
+		"db repair": func() (MarkDownCommand, error) {
+			return &DBRepairCommand{
+				Meta: meta,
+			}, nil
+		},
 		"snapshot": func() (MarkDownCommand, error) {
 			return &SnapshotCommand{
 				UI: ui,
@@ -207,8 +281,17 @@ type Meta2 struct {
 	UI cli.Ui
 
 	addr string
+
+	tlsCA   string
+	tlsCert string
+	tlsKey  string
+	token   string
 }
 
+// grpcTokenEnv is the environment variable holding the default grpc token,
+// which keeps the token out of the process list.
+const grpcTokenEnv = "BOR_GRPC_TOKEN"
+
 func (m *Meta2) NewFlagSet(n string) *flagset.Flagset {
 	f := flagset.NewFlagSet(n)
 
@@ -218,12 +301,54 @@ func (m *Meta2) NewFlagSet(n string) *flagset.Flagset {
 		Usage:   "Address of the grpc endpoint",
 		Default: "127.0.0.1:3131",
 	})
+	f.StringFlag(&flagset.StringFlag{
+		Name:  "tls-ca",
+		Value: &m.tlsCA,
+		Usage: "CA bundle to verify the grpc endpoint, enables TLS",
+	})
+	f.StringFlag(&flagset.StringFlag{
+		Name:  "tls-cert",
+		Value: &m.tlsCert,
+		Usage: "Client certificate presented to the grpc endpoint",
+	})
+	f.StringFlag(&flagset.StringFlag{
+		Name:  "tls-key",
+		Value: &m.tlsKey,
+		Usage: "Private key of the client certificate",
+	})
+	f.StringFlag(&flagset.StringFlag{
+		Name:               "token",
+		Value:              &m.token,
+		Usage:              "Bearer token sent to the grpc endpoint (default: $" + grpcTokenEnv + ")",
+		Default:            os.Getenv(grpcTokenEnv),
+		HideDefaultFromDoc: true,
+	})
 
 	return f
 }
 
 func (m *Meta2) Conn() (*grpc.ClientConn, error) {
-	conn, err := grpc.Dial(m.addr, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	tlsConfig := &grpcauth.TLSConfig{
+		CertFile: m.tlsCert,
+		KeyFile:  m.tlsKey,
+		CAFile:   m.tlsCA,
+	}
+
+	creds := insecure.NewCredentials()
+
+	if tlsConfig.Enabled() {
+		var err error
+		if creds, err = grpcauth.NewClientCredentials(tlsConfig); err != nil {
+			return nil, err
+		}
+	}
+
+	opts := []grpc.DialOption{grpc.WithTransportCredentials(creds)}
+	if m.token != "" {
+		opts = append(opts, grpc.WithPerRPCCredentials(grpcauth.NewTokenCredentials(m.token, false)))
+	}
+
+	conn, err := grpc.Dial(m.addr, opts...)
 	if err != nil {
 		return nil, fmt.Errorf("failed to connect to server: %v", err)
 	}
@@ -302,3 +427,32 @@ func formatKV(in []string) string {
 
 	return columnize.Format(in, columnConf)
 }
+
+// openChainDatabase opens the chain database at the given datadir location
+// without starting the node. The database is closed with the returned node.
+func openChainDatabase(datadir string, ancient string, readonly bool) (*node.Node, ethdb.Database, error) {
+	if datadir == "" {
+		return nil, nil, errors.New("datadir is required")
+	}
+
+	stack, err := node.New(&node.Config{
+		DataDir: datadir,
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	dbHandles, err := server.MakeDatabaseHandles(0)
+	if err != nil {
+		stack.Close()
+		return nil, nil, err
+	}
+
+	chaindb, err := stack.OpenDatabaseWithFreezer(chaindataPath, 0, dbHandles, ancient, "", readonly, rawdb.ExtraDBConfig{})
+	if err != nil {
+		stack.Close()
+		return nil, nil, err
+	}
+
+	return stack, chaindb, nil
+}
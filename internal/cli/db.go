@@ -0,0 +1,49 @@
+package cli
+
+import (
+	"strings"
+
+	"github.com/mitchellh/cli"
+)
+
+// DBCommand is the command to group the database commands
+type DBCommand struct {
+	UI cli.Ui
+}
+
+// MarkDown implements cli.MarkDown interface
+func (c *DBCommand) MarkDown() string {
+	items := []string{
+		"# DB",
+		"The ```db``` command groups actions to inspect and repair the chain database:",
+		"- [```db verify```](./db_verify.md): Check the chain database for inconsistencies in the Bor specific data.",
+		"- [```db repair```](./db_repair.md): Rebuild derived data and remove orphaned entries found by ```db verify```.",
+	}
+
+	return strings.Join(items, "\n\n")
+}
+
+// Help implements the cli.Command interface
+func (c *DBCommand) Help() string {
+	return `Usage: bor db <subcommand>
+
+  This command groups actions to inspect and repair the chain database.
+
+  Check the database for inconsistencies:
+
+    $ bor db verify --datadir <datadir>
+
+  Repair the inconsistencies:
+
+    $ bor db repair --datadir <datadir>`
+}
+
+// Synopsis implements the cli.Command interface
+func (c *DBCommand) Synopsis() string {
+	return "Inspect and repair the chain database"
+}
+
+// Run implements the cli.Command interface
+func (c *DBCommand) Run(args []string) int {
+	return cli.RunResultHelp
+}
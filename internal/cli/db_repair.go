@@ -0,0 +1,129 @@
+package cli
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/internal/cli/flagset"
+)
+
+// DBRepairCommand is the command to repair the chain database
+type DBRepairCommand struct {
+	*Meta
+
+	datadirAncient string
+	from           uint64
+	to             uint64
+	yes            bool
+}
+
+// MarkDown implements cli.MarkDown interface
+func (c *DBRepairCommand) MarkDown() string {
+	items := []string{
+		"# DB repair",
+		"The ```db repair``` command runs the checks of ```db verify``` on the database at the given datadir location and fixes the issues that can be solved without downloading data: missing transaction and bor tx lookup entries and hash to number mappings are rebuilt, while orphaned bor receipts, orphaned bor tx lookup entries, leftovers of frozen blocks in the key-value store and invalid ```LastMilestone```, ```LastCheckpoint``` and ```LockField``` keys are removed. Missing headers, bodies and receipts are only reported.",
+		c.Flags().MarkDown(),
+	}
+
+	return strings.Join(items, "\n\n")
+}
+
+// Help implements the cli.Command interface
+func (c *DBRepairCommand) Help() string {
+	return `Usage: bor db repair [--yes]
+
+  This command repairs the inconsistencies found in the chain database` + c.Flags().Help()
+}
+
+// Synopsis implements the cli.Command interface
+func (c *DBRepairCommand) Synopsis() string {
+	return "Repair inconsistencies in the chain database"
+}
+
+func (c *DBRepairCommand) Flags() *flagset.Flagset {
+	flags := dbIntegrityFlags(c.NewFlagSet("db repair"), &c.datadirAncient, &c.from, &c.to)
+
+	flags.BoolFlag(&flagset.BoolFlag{
+		Name:    "yes",
+		Usage:   "Repair without asking for confirmation",
+		Default: false,
+		Value:   &c.yes,
+	})
+
+	return flags
+}
+
+// Run implements the cli.Command interface
+func (c *DBRepairCommand) Run(args []string) int {
+	flags := c.Flags()
+	if err := flags.Parse(args); err != nil {
+		c.UI.Error(err.Error())
+		return 1
+	}
+
+	stack, chaindb, err := openChainDatabase(c.dataDir, c.datadirAncient, false)
+	if err != nil {
+		c.UI.Error(err.Error())
+		return 1
+	}
+	defer stack.Close()
+
+	issues, err := verifyChainDatabase(chaindb, c.from, c.to)
+	if err != nil {
+		c.UI.Error(err.Error())
+		return 1
+	}
+
+	var unrepairable []*rawdb.BorIntegrityIssue
+
+	for _, issue := range issues {
+		if !issue.Repairable() {
+			unrepairable = append(unrepairable, issue)
+		}
+
+		c.UI.Output(issue.String())
+	}
+
+	if len(issues) == 0 {
+		c.UI.Output("No issues found")
+		return 0
+	}
+
+	if len(issues) == len(unrepairable) {
+		c.UI.Output(fmt.Sprintf("No repairable issues found, %d issues left", len(unrepairable)))
+		return 1
+	}
+
+	if !c.yes {
+		response, err := c.UI.Ask(fmt.Sprintf("Repair %d issues? (y/n)", len(issues)-len(unrepairable)))
+		if err != nil {
+			c.UI.Error(err.Error())
+			return 1
+		}
+
+		if response != "y" {
+			c.UI.Output("repair aborted")
+			return 0
+		}
+	}
+
+	repaired, err := rawdb.RepairBorData(chaindb, issues)
+	if err != nil {
+		c.UI.Error(err.Error())
+		return 1
+	}
+
+	c.UI.Output(fmt.Sprintf("Repaired %d issues, %d issues left", repaired, len(unrepairable)))
+
+	return boolToExitCode(len(unrepairable) == 0)
+}
+
+// boolToExitCode maps a successful outcome to a zero exit code.
+func boolToExitCode(ok bool) int {
+	if ok {
+		return 0
+	}
+
+	return 1
+}
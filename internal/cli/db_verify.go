@@ -0,0 +1,133 @@
+package cli
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/ethdb"
+	"github.com/ethereum/go-ethereum/internal/cli/flagset"
+)
+
+// DBVerifyCommand is the command to check the chain database
+type DBVerifyCommand struct {
+	*Meta
+
+	datadirAncient string
+	from           uint64
+	to             uint64
+}
+
+// MarkDown implements cli.MarkDown interface
+func (c *DBVerifyCommand) MarkDown() string {
+	items := []string{
+		"# DB verify",
+		"The ```db verify``` command cross-checks the canonical headers and bodies of the database at the given datadir location against the receipts, transaction indices, bor receipts (both in the key-value store and in the freezer) and bor tx lookup entries, as well as the ```LastMilestone```, ```LastCheckpoint``` and ```LockField``` keys. It reports gaps, orphaned entries and freezer boundary inconsistencies and exits with a non-zero status if any is found. Use ```db repair``` to fix them.",
+		c.Flags().MarkDown(),
+	}
+
+	return strings.Join(items, "\n\n")
+}
+
+// Help implements the cli.Command interface
+func (c *DBVerifyCommand) Help() string {
+	return `Usage: bor db verify
+
+  This command checks the chain database for inconsistencies` + c.Flags().Help()
+}
+
+// Synopsis implements the cli.Command interface
+func (c *DBVerifyCommand) Synopsis() string {
+	return "Check the chain database for inconsistencies"
+}
+
+func (c *DBVerifyCommand) Flags() *flagset.Flagset {
+	return dbIntegrityFlags(c.NewFlagSet("db verify"), &c.datadirAncient, &c.from, &c.to)
+}
+
+// Run implements the cli.Command interface
+func (c *DBVerifyCommand) Run(args []string) int {
+	flags := c.Flags()
+	if err := flags.Parse(args); err != nil {
+		c.UI.Error(err.Error())
+		return 1
+	}
+
+	stack, chaindb, err := openChainDatabase(c.dataDir, c.datadirAncient, true)
+	if err != nil {
+		c.UI.Error(err.Error())
+		return 1
+	}
+	defer stack.Close()
+
+	issues, err := verifyChainDatabase(chaindb, c.from, c.to)
+	if err != nil {
+		c.UI.Error(err.Error())
+		return 1
+	}
+
+	repairable := 0
+
+	for _, issue := range issues {
+		if issue.Repairable() {
+			repairable++
+		}
+
+		c.UI.Output(issue.String())
+	}
+
+	if len(issues) > 0 {
+		c.UI.Error(formatKV([]string{
+			fmt.Sprintf("Issues|%d", len(issues)),
+			fmt.Sprintf("Repairable|%d", repairable),
+		}))
+
+		return 1
+	}
+
+	c.UI.Output("No issues found")
+
+	return 0
+}
+
+// dbIntegrityFlags adds the flags shared by the db verify and repair commands.
+func dbIntegrityFlags(flags *flagset.Flagset, datadirAncient *string, from *uint64, to *uint64) *flagset.Flagset {
+	flags.StringFlag(&flagset.StringFlag{
+		Name:    "datadir.ancient",
+		Value:   datadirAncient,
+		Usage:   "Path of the ancient data directory to store information",
+		Default: "",
+	})
+
+	flags.Uint64Flag(&flagset.Uint64Flag{
+		Name:    "from",
+		Value:   from,
+		Usage:   "First block number to check",
+		Default: 0,
+	})
+
+	flags.Uint64Flag(&flagset.Uint64Flag{
+		Name:    "to",
+		Value:   to,
+		Usage:   "Last block number to check, 0 checks up to the head header",
+		Default: 0,
+	})
+
+	return flags
+}
+
+// verifyChainDatabase checks the Bor specific data in the given block range.
+func verifyChainDatabase(db ethdb.Database, from uint64, to uint64) ([]*rawdb.BorIntegrityIssue, error) {
+	config := rawdb.ReadChainConfig(db, rawdb.ReadCanonicalHash(db, 0))
+	if config == nil {
+		return nil, errors.New("chain config not found")
+	}
+
+	if to == 0 {
+		to = math.MaxUint64
+	}
+
+	return rawdb.VerifyBorData(db, config, from, to)
+}
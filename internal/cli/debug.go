@@ -50,6 +50,9 @@ func (d *DebugCommand) MarkDown() string {
 		"The ```bor debug``` command takes a debug dump of the running client.",
 		"- [```bor debug pprof```](./debug_pprof.md): Dumps bor pprof traces.",
 		"- [```bor debug block <number>```](./debug_block.md): Dumps bor block traces.",
+		"- [```bor debug trace-tx <hash>```](./debug_trace-tx.md): Dumps the trace of a transaction.",
+		"- [```bor debug dump-state [number]```](./debug_dump-state.md): Dumps the accounts of the state at a block.",
+		"- [```bor debug db-stats```](./debug_db-stats.md): Prints the database statistics.",
 	}
 	items = append(items, examples...)
 
@@ -68,7 +71,19 @@ func (c *DebugCommand) Help() string {
 
 	Get the block traces:
 
-		$ bor debug block <number>`
+		$ bor debug block <number>
+
+	Get the trace of a transaction:
+
+		$ bor debug trace-tx <hash>
+
+	Dump the state at a block:
+
+		$ bor debug dump-state [number]
+
+	Print the database statistics:
+
+		$ bor debug db-stats`
 }
 
 // Synopsis implements the cli.Command interface
@@ -0,0 +1,91 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/internal/cli/flagset"
+	"github.com/ethereum/go-ethereum/internal/cli/server/proto"
+)
+
+// DebugDatabaseStatsCommand is the command to print the database statistics
+type DebugDatabaseStatsCommand struct {
+	*Meta2
+}
+
+// MarkDown implements cli.MarkDown interface
+func (c *DebugDatabaseStatsCommand) MarkDown() string {
+	items := []string{
+		"# Debug db-stats",
+		"The ```bor debug db-stats``` command prints the freezer item counts, the size of each freezer table " +
+			"and the statistics reported by the key-value store of the running client.",
+		c.Flags().MarkDown(),
+	}
+
+	return strings.Join(items, "\n\n")
+}
+
+// Help implements the cli.Command interface
+func (c *DebugDatabaseStatsCommand) Help() string {
+	return `Usage: bor debug db-stats
+
+  This command is used to print the database statistics of the running client`
+}
+
+func (c *DebugDatabaseStatsCommand) Flags() *flagset.Flagset {
+	return c.NewFlagSet("db-stats")
+}
+
+// Synopsis implements the cli.Command interface
+func (c *DebugDatabaseStatsCommand) Synopsis() string {
+	return "Print the database statistics"
+}
+
+// Run implements the cli.Command interface
+func (c *DebugDatabaseStatsCommand) Run(args []string) int {
+	flags := c.Flags()
+	if err := flags.Parse(args); err != nil {
+		c.UI.Error(err.Error())
+		return 1
+	}
+
+	borClt, err := c.BorConn()
+	if err != nil {
+		c.UI.Error(err.Error())
+		return 1
+	}
+
+	resp, err := borClt.DebugDatabaseStats(context.Background(), &proto.DebugDatabaseStatsRequest{})
+	if err != nil {
+		c.UI.Error(err.Error())
+		return 1
+	}
+
+	c.UI.Output(formatDatabaseStats(resp))
+
+	return 0
+}
+
+func formatDatabaseStats(resp *proto.DebugDatabaseStatsResponse) string {
+	out := formatKV([]string{
+		fmt.Sprintf("Ancients|%d", resp.Ancients),
+		fmt.Sprintf("Tail|%d", resp.Tail),
+	})
+
+	rows := make([]string, len(resp.Tables)+1)
+	rows[0] = "Table|Size"
+
+	for i, table := range resp.Tables {
+		rows[i+1] = fmt.Sprintf("%s|%s", table.Name, common.StorageSize(table.Size).String())
+	}
+
+	out += "\n\n" + formatList(rows)
+
+	if resp.EngineStats != "" {
+		out += "\n\n" + strings.TrimSpace(resp.EngineStats)
+	}
+
+	return out
+}
@@ -0,0 +1,194 @@
+package cli
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"google.golang.org/protobuf/encoding/protojson"
+
+	"github.com/ethereum/go-ethereum/internal/cli/flagset"
+	"github.com/ethereum/go-ethereum/internal/cli/server/proto"
+)
+
+// DebugDumpStateCommand is the command to dump the state of a block
+type DebugDumpStateCommand struct {
+	*Meta2
+
+	output      string
+	skipCode    bool
+	skipStorage bool
+	max         uint64
+}
+
+// MarkDown implements cli.MarkDown interface
+func (c *DebugDumpStateCommand) MarkDown() string {
+	items := []string{
+		"# Debug dump-state",
+		"The ```bor debug dump-state [number]``` command will create an archive containing the accounts of the state " +
+			"at the given block, or at the current head if no number is given. Each account is written as a json line. " +
+			"The state of the block must be available, which for older blocks requires an archive node.",
+		c.Flags().MarkDown(),
+	}
+
+	return strings.Join(items, "\n\n")
+}
+
+// Help implements the cli.Command interface
+func (c *DebugDumpStateCommand) Help() string {
+	return `Usage: bor debug dump-state [number]
+
+  This command is used to dump the state of a block
+
+  ` + c.Flags().Help()
+}
+
+func (c *DebugDumpStateCommand) Flags() *flagset.Flagset {
+	flags := c.NewFlagSet("dump-state")
+
+	flags.StringFlag(&flagset.StringFlag{
+		Name:  "output",
+		Value: &c.output,
+		Usage: "Output directory",
+	})
+	flags.BoolFlag(&flagset.BoolFlag{
+		Name:  "skip-code",
+		Value: &c.skipCode,
+		Usage: "Do not include the contract code",
+	})
+	flags.BoolFlag(&flagset.BoolFlag{
+		Name:  "skip-storage",
+		Value: &c.skipStorage,
+		Usage: "Do not include the contract storage",
+	})
+	flags.Uint64Flag(&flagset.Uint64Flag{
+		Name:  "max",
+		Value: &c.max,
+		Usage: "Maximum number of accounts to dump (0 for no limit)",
+	})
+
+	return flags
+}
+
+// Synopsis implements the cli.Command interface
+func (c *DebugDumpStateCommand) Synopsis() string {
+	return "Dump the state of a block"
+}
+
+// Run implements the cli.Command interface
+func (c *DebugDumpStateCommand) Run(args []string) int {
+	flags := c.Flags()
+	if err := flags.Parse(args); err != nil {
+		c.UI.Error(err.Error())
+		return 1
+	}
+
+	// The latest block is dumped if none is given
+	var number *int64
+
+	if args = flags.Args(); len(args) > 0 {
+		num, err := strconv.ParseInt(args[0], 10, 64)
+		if err != nil {
+			c.UI.Error(fmt.Sprintf("invalid block number: %s", args[0]))
+			return 1
+		}
+
+		number = &num
+	}
+
+	borClt, err := c.BorConn()
+	if err != nil {
+		c.UI.Error(err.Error())
+		return 1
+	}
+
+	dEnv := &debugEnv{
+		output: c.output,
+		prefix: "bor-state-dump-",
+	}
+	if err := dEnv.init(); err != nil {
+		c.UI.Error(err.Error())
+		return 1
+	}
+
+	c.UI.Output("Starting state dump...")
+	c.UI.Output("")
+
+	stream, err := borClt.DebugDumpState(context.Background(), &proto.DebugDumpStateRequest{
+		Number:      number,
+		SkipCode:    c.skipCode,
+		SkipStorage: c.skipStorage,
+		Max:         c.max,
+	})
+	if err != nil {
+		c.UI.Error(err.Error())
+		return 1
+	}
+
+	accounts, err := writeDumpState(filepath.Join(dEnv.dst, "state.jsonl"), stream)
+	if err != nil {
+		c.UI.Error(err.Error())
+		return 1
+	}
+
+	if err := dEnv.finish(); err != nil {
+		c.UI.Error(err.Error())
+		return 1
+	}
+
+	c.UI.Output(fmt.Sprintf("Dumped %d accounts", accounts))
+
+	if c.output != "" {
+		c.UI.Output(fmt.Sprintf("Created debug directory: %s", dEnv.dst))
+	} else {
+		c.UI.Output(fmt.Sprintf("Created state dump archive: %s", dEnv.tarName()))
+	}
+
+	return 0
+}
+
+// writeDumpState writes the streamed accounts to the given file, one json
+// object per line, and returns the number of accounts written.
+func writeDumpState(path string, stream proto.Bor_DebugDumpStateClient) (uint64, error) {
+	file, err := os.OpenFile(path, os.O_RDWR|os.O_CREATE, 0644)
+	if err != nil {
+		return 0, err
+	}
+	defer file.Close()
+
+	var (
+		out      = bufio.NewWriter(file)
+		m        = protojson.MarshalOptions{}
+		accounts uint64
+	)
+
+	for {
+		msg, err := stream.Recv()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+
+		if err != nil {
+			return accounts, err
+		}
+
+		data, err := m.Marshal(msg)
+		if err != nil {
+			return accounts, err
+		}
+
+		if _, err := out.Write(append(data, '\n')); err != nil {
+			return accounts, err
+		}
+
+		accounts++
+	}
+
+	return accounts, out.Flush()
+}
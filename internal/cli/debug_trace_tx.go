@@ -0,0 +1,128 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/internal/cli/flagset"
+	"github.com/ethereum/go-ethereum/internal/cli/server/proto"
+)
+
+// DebugTraceTxCommand is the command to trace a transaction
+type DebugTraceTxCommand struct {
+	*Meta2
+
+	output       string
+	tracer       string
+	tracerConfig string
+}
+
+// MarkDown implements cli.MarkDown interface
+func (c *DebugTraceTxCommand) MarkDown() string {
+	items := []string{
+		"# Debug trace-tx",
+		"The ```bor debug trace-tx <hash>``` command will create an archive containing the trace of a transaction. " +
+			"By default the struct logger is used, any of the built-in tracers can be selected instead.",
+		c.Flags().MarkDown(),
+	}
+
+	return strings.Join(items, "\n\n")
+}
+
+// Help implements the cli.Command interface
+func (c *DebugTraceTxCommand) Help() string {
+	return `Usage: bor debug trace-tx <hash>
+
+  This command is used get the trace of a transaction
+
+  ` + c.Flags().Help()
+}
+
+func (c *DebugTraceTxCommand) Flags() *flagset.Flagset {
+	flags := c.NewFlagSet("trace-tx")
+
+	flags.StringFlag(&flagset.StringFlag{
+		Name:  "output",
+		Value: &c.output,
+		Usage: "Output directory",
+	})
+	flags.StringFlag(&flagset.StringFlag{
+		Name:  "tracer",
+		Value: &c.tracer,
+		Usage: "Name of the tracer to use (e.g. callTracer, prestateTracer)",
+	})
+	flags.StringFlag(&flagset.StringFlag{
+		Name:  "tracer-config",
+		Value: &c.tracerConfig,
+		Usage: "Tracer configuration as JSON",
+	})
+
+	return flags
+}
+
+// Synopsis implements the cli.Command interface
+func (c *DebugTraceTxCommand) Synopsis() string {
+	return "Get trace of a transaction"
+}
+
+// Run implements the cli.Command interface
+func (c *DebugTraceTxCommand) Run(args []string) int {
+	flags := c.Flags()
+	if err := flags.Parse(args); err != nil {
+		c.UI.Error(err.Error())
+		return 1
+	}
+
+	args = flags.Args()
+	if len(args) != 1 {
+		c.UI.Error("No transaction hash provided")
+		return 1
+	}
+
+	borClt, err := c.BorConn()
+	if err != nil {
+		c.UI.Error(err.Error())
+		return 1
+	}
+
+	dEnv := &debugEnv{
+		output: c.output,
+		prefix: "bor-tx-trace-",
+	}
+	if err := dEnv.init(); err != nil {
+		c.UI.Error(err.Error())
+		return 1
+	}
+
+	c.UI.Output("Starting transaction tracer...")
+	c.UI.Output("")
+
+	stream, err := borClt.DebugTraceTransaction(context.Background(), &proto.DebugTraceTransactionRequest{
+		Hash:         args[0],
+		Tracer:       c.tracer,
+		TracerConfig: c.tracerConfig,
+	})
+	if err != nil {
+		c.UI.Error(err.Error())
+		return 1
+	}
+
+	if err := dEnv.writeFromStream("tx.json", stream); err != nil {
+		c.UI.Error(err.Error())
+		return 1
+	}
+
+	if err := dEnv.finish(); err != nil {
+		c.UI.Error(err.Error())
+		return 1
+	}
+
+	if c.output != "" {
+		c.UI.Output(fmt.Sprintf("Created debug directory: %s", dEnv.dst))
+	} else {
+		c.UI.Output(fmt.Sprintf("Created transaction trace archive: %s", dEnv.tarName()))
+	}
+
+	return 0
+}
@@ -78,18 +78,19 @@ func formatPeers(peers []*proto.Peer) string {
 	}
 
 	rows := make([]string, len(peers)+1)
-	rows[0] = "ID|Enode|Name|Caps|Static|Trusted"
+	rows[0] = "ID|Enode|Name|Caps|Static|Trusted|Score"
 
 	for i, d := range peers {
 		enode := strings.TrimPrefix(d.Enode, "enode://")
 
-		rows[i+1] = fmt.Sprintf("%s|%s|%s|%s|%v|%v",
+		rows[i+1] = fmt.Sprintf("%s|%s|%s|%s|%v|%v|%d",
 			d.Id,
 			enode[:10],
 			d.Name,
 			strings.Join(d.Caps, ","),
 			d.Static,
-			d.Trusted)
+			d.Trusted,
+			d.Score)
 	}
 
 	return formatList(rows)
@@ -89,6 +89,7 @@ func formatPeer(peer *proto.Peer) string {
 		fmt.Sprintf("Enode|%s", peer.Enode),
 		fmt.Sprintf("Static|%v", peer.Static),
 		fmt.Sprintf("Trusted|%v", peer.Trusted),
+		fmt.Sprintf("Score|%d", peer.Score),
 	})
 
 	return base
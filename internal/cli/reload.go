@@ -0,0 +1,87 @@
+package cli
+
+import (
+	"context"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/internal/cli/flagset"
+	"github.com/ethereum/go-ethereum/internal/cli/server/proto"
+)
+
+// ReloadCommand is the command to reload the configuration of a running client
+type ReloadCommand struct {
+	*Meta2
+}
+
+// MarkDown implements cli.MarkDown interface
+func (c *ReloadCommand) MarkDown() string {
+	items := []string{
+		"# Reload",
+		"The ```reload``` command re-reads the config file of a running client and applies the settings which can be changed without a restart: " +
+			"log verbosity and vmodule, txpool price limit and slots, miner gas limit, extradata and recommit, " +
+			"rpc gas cap, evm timeout and tx fee cap, max peers, and static and trusted nodes. " +
+			"Other changed settings are listed as requiring a restart. Sending `SIGHUP` to the client has the same effect.",
+		c.Flags().MarkDown(),
+	}
+
+	return strings.Join(items, "\n\n")
+}
+
+// Help implements the cli.Command interface
+func (c *ReloadCommand) Help() string {
+	return `Usage: bor reload
+
+  Reload the configuration of the running client`
+}
+
+func (c *ReloadCommand) Flags() *flagset.Flagset {
+	return c.NewFlagSet("reload")
+}
+
+// Synopsis implements the cli.Command interface
+func (c *ReloadCommand) Synopsis() string {
+	return "Reload the configuration of the running client"
+}
+
+// Run implements the cli.Command interface
+func (c *ReloadCommand) Run(args []string) int {
+	flags := c.Flags()
+	if err := flags.Parse(args); err != nil {
+		c.UI.Error(err.Error())
+		return 1
+	}
+
+	borClt, err := c.BorConn()
+	if err != nil {
+		c.UI.Error(err.Error())
+		return 1
+	}
+
+	resp, err := borClt.ConfigReload(context.Background(), &proto.ConfigReloadRequest{})
+	if err != nil {
+		c.UI.Error(err.Error())
+		return 1
+	}
+
+	c.UI.Output(printConfigReload(resp))
+
+	return 0
+}
+
+func printConfigReload(resp *proto.ConfigReloadResponse) string {
+	if len(resp.Applied) == 0 && len(resp.Restart) == 0 {
+		return "No configuration changes"
+	}
+
+	lines := []string{"Field|Status"}
+
+	for _, field := range resp.Applied {
+		lines = append(lines, field+"|applied")
+	}
+
+	for _, field := range resp.Restart {
+		lines = append(lines, field+"|restart required")
+	}
+
+	return formatList(lines)
+}
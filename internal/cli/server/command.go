@@ -27,6 +27,9 @@ type Command struct {
 
 	configFile string
 
+	// args are the cli arguments the server was started with
+	args []string
+
 	srv *Server
 }
 
@@ -157,6 +160,8 @@ func (c *Command) extractFlags(args []string) error {
 
 // Run implements the cli.Command interface
 func (c *Command) Run(args []string) int {
+	c.args = args
+
 	err := c.extractFlags(args)
 	if err != nil {
 		c.UI.Error(err.Error())
@@ -172,7 +177,7 @@ func (c *Command) Run(args []string) int {
 		}()
 	}
 
-	srv, err := NewServer(c.config, WithGRPCAddress())
+	srv, err := NewServer(c.config, WithGRPCAddress(), WithConfigLoader(c.loadConfig))
 	if err != nil {
 		c.UI.Error(err.Error())
 		return 1
@@ -188,6 +193,11 @@ func (c *Command) handleSignals() int {
 	signal.Notify(signalCh, os.Interrupt, syscall.SIGTERM, syscall.SIGHUP)
 
 	sig := <-signalCh
+	for sig == syscall.SIGHUP {
+		c.reloadConfig()
+
+		sig = <-signalCh
+	}
 
 	c.UI.Output(fmt.Sprintf("Caught signal: %v", sig))
 	c.UI.Output("Gracefully shutting down agent...")
@@ -210,6 +220,32 @@ func (c *Command) handleSignals() int {
 	return 1
 }
 
+// loadConfig re-reads the config file and re-applies the cli flags the server
+// was started with, returning the resulting configuration.
+func (c *Command) loadConfig() (*Config, error) {
+	cmd := &Command{UI: c.UI}
+	if err := cmd.extractFlags(c.args); err != nil {
+		return nil, err
+	}
+
+	return cmd.config, nil
+}
+
+// reloadConfig applies the reloadable parts of the configuration on SIGHUP.
+func (c *Command) reloadConfig() {
+	log.Info("Caught SIGHUP, reloading configuration")
+
+	_, restart, err := c.srv.ReloadConfig()
+	if err != nil {
+		log.Error("Failed to reload configuration", "err", err)
+		return
+	}
+
+	if len(restart) > 0 {
+		log.Warn("Some configuration changes require a restart", "fields", strings.Join(restart, ","))
+	}
+}
+
 // GetConfig returns the user specified config
 func (c *Command) GetConfig() *Config {
 	return c.cliConfig
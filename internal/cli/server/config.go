@@ -24,12 +24,15 @@ import (
 	"github.com/ethereum/go-ethereum/cmd/utils"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/common/fdlimit"
+	"github.com/ethereum/go-ethereum/consensus/bor"
+	"github.com/ethereum/go-ethereum/core/txpool/condpool"
 	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/ethereum/go-ethereum/eth/downloader"
 	"github.com/ethereum/go-ethereum/eth/ethconfig"
 	"github.com/ethereum/go-ethereum/eth/gasprice"
 	"github.com/ethereum/go-ethereum/internal/cli/server/chains"
 	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/miner"
 	"github.com/ethereum/go-ethereum/node"
 	"github.com/ethereum/go-ethereum/p2p"
 	"github.com/ethereum/go-ethereum/p2p/enode"
@@ -90,6 +93,10 @@ type Config struct {
 	// BorLogs enables bor log retrieval
 	BorLogs bool `hcl:"bor.logs,optional" toml:"bor.logs,optional"`
 
+	// BorTrustedCheckpoint is the Heimdall checkpoint to bootstrap the chain from,
+	// in the form of <start>-<end>:<root hash>:<end block hash>
+	BorTrustedCheckpoint string `hcl:"bor.trustedcheckpoint,optional" toml:"bor.trustedcheckpoint,optional"`
+
 	// Ethstats is the address of the ethstats server to send telemetry
 	Ethstats string `hcl:"ethstats,optional" toml:"ethstats,optional"`
 
@@ -212,6 +219,9 @@ type P2PConfig struct {
 	// Discovery has the p2p discovery related settings
 	Discovery *P2PDiscovery `hcl:"discovery,block" toml:"discovery,block"`
 
+	// Topology has the sentry deployment related settings
+	Topology *P2PTopology `hcl:"topology,block" toml:"topology,block"`
+
 	// TxArrivalWait sets the maximum duration the transaction fetcher will wait for
 	// an announced transaction to arrive before explicitly requesting it
 	TxArrivalWait    time.Duration `hcl:"-,optional" toml:"-"`
@@ -244,6 +254,17 @@ type P2PDiscovery struct {
 	DNS []string `hcl:"dns,optional" toml:"dns,optional"`
 }
 
+type P2PTopology struct {
+	// Mode is the role of the node in a sentry deployment, either "sentry" or "validator"
+	Mode string `hcl:"mode,optional" toml:"mode,optional"`
+
+	// SentryNodes is the list of sentries a validator exclusively connects to
+	SentryNodes []string `hcl:"sentry-nodes,optional" toml:"sentry-nodes,optional"`
+
+	// ValidatorNodes is the list of validators protected by a sentry
+	ValidatorNodes []string `hcl:"validator-nodes,optional" toml:"validator-nodes,optional"`
+}
+
 type HeimdallConfig struct {
 	// URL is the url of the heimdall server
 	URL string `hcl:"url,optional" toml:"url,optional"`
@@ -254,6 +275,18 @@ type HeimdallConfig struct {
 	// GRPCAddress is the address of the heimdall grpc server
 	GRPCAddress string `hcl:"grpc-address,optional" toml:"grpc-address,optional"`
 
+	// GRPCTLSCert is the client certificate presented to the heimdall grpc server
+	GRPCTLSCert string `hcl:"grpc-tls-cert,optional" toml:"grpc-tls-cert,optional"`
+
+	// GRPCTLSKey is the private key of the client certificate
+	GRPCTLSKey string `hcl:"grpc-tls-key,optional" toml:"grpc-tls-key,optional"`
+
+	// GRPCTLSCA is the CA bundle to verify the heimdall grpc server, enables TLS
+	GRPCTLSCA string `hcl:"grpc-tls-ca,optional" toml:"grpc-tls-ca,optional"`
+
+	// GRPCToken is the bearer token sent to the heimdall grpc server
+	GRPCToken string `hcl:"grpc-token,optional" toml:"grpc-token,optional"`
+
 	// RunHeimdall is used to run heimdall as a child process
 	RunHeimdall bool `hcl:"bor.runheimdall,optional" toml:"bor.runheimdall,optional"`
 
@@ -299,6 +332,12 @@ type TxPoolConfig struct {
 	// lifetime is the maximum amount of time non-executable transaction are queued
 	LifeTime    time.Duration `hcl:"-,optional" toml:"-"`
 	LifeTimeRaw string        `hcl:"lifetime,optional" toml:"lifetime,optional"`
+
+	// ConditionalBundlerSlots is the maximum number of conditional transactions pooled per bundler
+	ConditionalBundlerSlots uint64 `hcl:"conditionalbundlerslots,optional" toml:"conditionalbundlerslots,optional"`
+
+	// ConditionalGlobalSlots is the maximum number of conditional transactions pooled in total
+	ConditionalGlobalSlots uint64 `hcl:"conditionalglobalslots,optional" toml:"conditionalglobalslots,optional"`
 }
 
 type SealerConfig struct {
@@ -323,6 +362,12 @@ type SealerConfig struct {
 	RecommitRaw string        `hcl:"recommit,optional" toml:"recommit,optional"`
 
 	CommitInterruptFlag bool `hcl:"commitinterrupt,optional" toml:"commitinterrupt,optional"`
+
+	// TxOrdering is the transaction ordering mode used when building blocks (price or fair)
+	TxOrdering string `hcl:"txordering,optional" toml:"txordering,optional"`
+
+	// SenderGasCap is the maximum gas a single sender may use per block in fair ordering (0 = unlimited)
+	SenderGasCap uint64 `hcl:"sendergascap,optional" toml:"sendergascap,optional"`
 }
 
 type JsonRPCConfig struct {
@@ -360,6 +405,13 @@ type JsonRPCConfig struct {
 
 	// EnablePersonal enables the deprecated personal namespace.
 	EnablePersonal bool `hcl:"enabledeprecatedpersonal,optional" toml:"enabledeprecatedpersonal,optional"`
+
+	// TraceIndex enables the background indexing of the flat call traces served
+	// by the trace namespace
+	TraceIndex bool `hcl:"traceindex,optional" toml:"traceindex,optional"`
+
+	// TraceIndexFrom is the first block to index the traces of
+	TraceIndexFrom uint64 `hcl:"traceindexfrom,optional" toml:"traceindexfrom,optional"`
 }
 
 type AUTHConfig struct {
@@ -380,6 +432,21 @@ type AUTHConfig struct {
 type GRPCConfig struct {
 	// Addr is the bind address for the grpc rpc server
 	Addr string `hcl:"addr,optional" toml:"addr,optional"`
+
+	// TLSCert is the certificate of the grpc server, enables TLS
+	TLSCert string `hcl:"tls-cert,optional" toml:"tls-cert,optional"`
+
+	// TLSKey is the private key of the grpc server certificate
+	TLSKey string `hcl:"tls-key,optional" toml:"tls-key,optional"`
+
+	// TLSClientCA is the CA bundle to verify client certificates, enables mutual TLS
+	TLSClientCA string `hcl:"tls-client-ca,optional" toml:"tls-client-ca,optional"`
+
+	// Token is the bearer token required by all the grpc methods
+	Token string `hcl:"token,optional" toml:"token,optional"`
+
+	// AdminToken is the bearer token required by the admin grpc methods
+	AdminToken string `hcl:"admin-token,optional" toml:"admin-token,optional"`
 }
 
 type APIConfig struct {
@@ -538,6 +605,13 @@ type CacheConfig struct {
 	// TxLookupLimit sets the maximum number of blocks from head whose tx indices are reserved.
 	TxLookupLimit uint64 `hcl:"txlookuplimit,optional" toml:"txlookuplimit,optional"`
 
+	// BorReceiptLimit sets the maximum number of blocks from head whose bor receipts are reserved.
+	// It requires a non-zero TxLookupLimit, as bor receipts expire alongside the transaction index.
+	BorReceiptLimit uint64 `hcl:"borreceiptlimit,optional" toml:"borreceiptlimit,optional"`
+
+	// ChangeSets enables the indexing of the account and storage changes of the imported blocks
+	ChangeSets bool `hcl:"changesets,optional" toml:"changesets,optional"`
+
 	// Number of block states to keep in memory (default = 128)
 	TriesInMemory uint64 `hcl:"triesinmemory,optional" toml:"triesinmemory,optional"`
 
@@ -632,6 +706,11 @@ func DefaultConfig() *Config {
 				TrustedNodes: []string{},
 				DNS:          []string{},
 			},
+			Topology: &P2PTopology{
+				Mode:           "",
+				SentryNodes:    []string{},
+				ValidatorNodes: []string{},
+			},
 		},
 		Heimdall: &HeimdallConfig{
 			URL:         "http://localhost:1317",
@@ -654,6 +733,9 @@ func DefaultConfig() *Config {
 			AccountQueue: 16,
 			GlobalQueue:  32768,
 			LifeTime:     3 * time.Hour,
+
+			ConditionalBundlerSlots: condpool.DefaultConfig.BundlerSlots,
+			ConditionalGlobalSlots:  condpool.DefaultConfig.GlobalSlots,
 		},
 		Sealer: &SealerConfig{
 			Enabled:             false,
@@ -663,6 +745,8 @@ func DefaultConfig() *Config {
 			ExtraData:           "",
 			Recommit:            125 * time.Second,
 			CommitInterruptFlag: true,
+			TxOrdering:          miner.TxOrderingPrice,
+			SenderGasCap:        0,
 		},
 		Gpo: &GpoConfig{
 			Blocks:           20,
@@ -680,6 +764,8 @@ func DefaultConfig() *Config {
 			RPCEVMTimeout:       ethconfig.Defaults.RPCEVMTimeout,
 			AllowUnprotectedTxs: false,
 			EnablePersonal:      false,
+			TraceIndex:          false,
+			TraceIndexFrom:      0,
 			Http: &APIConfig{
 				Enabled:                     false,
 				Port:                        8545,
@@ -894,14 +980,24 @@ func (c *Config) loadChain() error {
 
 	c.chain = chain
 
-	// preload some default values that depend on the chain file
-	if c.P2P.Discovery.DNS == nil {
-		c.P2P.Discovery.DNS = c.chain.DNS
-	}
-
 	return nil
 }
 
+// developerCopy returns a copy of the configuration to apply the developer mode
+// overrides to, so that the configuration compared on reload keeps the values
+// set by the user. The overridden sections are copied as well.
+func (c *Config) developerCopy() *Config {
+	cpy := *c
+
+	p2p, sealer, cache := *c.P2P, *c.Sealer, *c.Cache
+	rpc, http := *c.JsonRPC, *c.JsonRPC.Http
+
+	rpc.Http = &http
+	cpy.P2P, cpy.Sealer, cpy.Cache, cpy.JsonRPC = &p2p, &sealer, &cache, &rpc
+
+	return &cpy
+}
+
 //nolint:gocognit
 func (c *Config) buildEth(stack *node.Node, accountManager *accounts.Manager) (*ethconfig.Config, error) {
 	dbHandles, err := MakeDatabaseHandles(c.Cache.FDLimit)
@@ -911,6 +1007,15 @@ func (c *Config) buildEth(stack *node.Node, accountManager *accounts.Manager) (*
 
 	n := ethconfig.Defaults
 
+	// Developer mode overrides some settings on a copy, only the developer
+	// chain is kept in the configuration
+	if c.Developer.Enabled {
+		active := c
+		c = c.developerCopy()
+
+		defer func() { active.chain = c.chain }()
+	}
+
 	// only update for non-developer mode as we don't yet
 	// have the chain object for it.
 	if !c.Developer.Enabled {
@@ -921,6 +1026,10 @@ func (c *Config) buildEth(stack *node.Node, accountManager *accounts.Manager) (*
 	n.HeimdallURL = c.Heimdall.URL
 	n.WithoutHeimdall = c.Heimdall.Without
 	n.HeimdallgRPCAddress = c.Heimdall.GRPCAddress
+	n.HeimdallgRPCTLSCert = c.Heimdall.GRPCTLSCert
+	n.HeimdallgRPCTLSKey = c.Heimdall.GRPCTLSKey
+	n.HeimdallgRPCTLSCA = c.Heimdall.GRPCTLSCA
+	n.HeimdallgRPCToken = c.Heimdall.GRPCToken
 	n.RunHeimdall = c.Heimdall.RunHeimdall
 	n.RunHeimdallArgs = c.Heimdall.RunHeimdallArgs
 	n.UseHeimdallApp = c.Heimdall.UseHeimdallApp
@@ -955,6 +1064,9 @@ func (c *Config) buildEth(stack *node.Node, accountManager *accounts.Manager) (*
 		n.TxPool.AccountQueue = c.TxPool.AccountQueue
 		n.TxPool.GlobalQueue = c.TxPool.GlobalQueue
 		n.TxPool.Lifetime = c.TxPool.LifeTime
+
+		n.CondPool.BundlerSlots = c.TxPool.ConditionalBundlerSlots
+		n.CondPool.GlobalSlots = c.TxPool.ConditionalGlobalSlots
 	}
 
 	// miner options
@@ -964,6 +1076,14 @@ func (c *Config) buildEth(stack *node.Node, accountManager *accounts.Manager) (*
 		n.Miner.GasCeil = c.Sealer.GasCeil
 		n.Miner.ExtraData = []byte(c.Sealer.ExtraData)
 		n.Miner.CommitInterruptFlag = c.Sealer.CommitInterruptFlag
+		n.Miner.SenderGasCap = c.Sealer.SenderGasCap
+
+		switch ordering := c.Sealer.TxOrdering; ordering {
+		case "", miner.TxOrderingPrice, miner.TxOrderingFair:
+			n.Miner.TxOrdering = ordering
+		default:
+			return nil, fmt.Errorf("tx ordering '%s' not found", ordering)
+		}
 
 		if etherbase := c.Sealer.Etherbase; etherbase != "" {
 			if !common.IsHexAddress(etherbase) {
@@ -1054,8 +1174,14 @@ func (c *Config) buildEth(stack *node.Node, accountManager *accounts.Manager) (*
 
 	// discovery (this params should be in node.Config)
 	{
-		n.EthDiscoveryURLs = c.P2P.Discovery.DNS
-		n.SnapDiscoveryURLs = c.P2P.Discovery.DNS
+		// default to the discovery urls of the chain file
+		dns := c.P2P.Discovery.DNS
+		if dns == nil {
+			dns = c.chain.DNS
+		}
+
+		n.EthDiscoveryURLs = dns
+		n.SnapDiscoveryURLs = dns
 	}
 
 	// RequiredBlocks
@@ -1111,6 +1237,13 @@ func (c *Config) buildEth(stack *node.Node, accountManager *accounts.Manager) (*
 		n.NoPrefetch = c.Cache.NoPrefetch
 		n.Preimages = c.Cache.Preimages
 		n.TxLookupLimit = c.Cache.TxLookupLimit
+		n.BorReceiptLimit = c.Cache.BorReceiptLimit
+
+		// Bor receipts expire alongside the transaction index only
+		if n.BorReceiptLimit != 0 && n.TxLookupLimit == 0 {
+			return nil, fmt.Errorf("borreceiptlimit %d requires a non-zero txlookuplimit", n.BorReceiptLimit)
+		}
+		n.ChangeSets = c.Cache.ChangeSets
 		n.TrieTimeout = c.Cache.TrieTimeout
 		n.TriesInMemory = c.Cache.TriesInMemory
 		n.FilterLogCacheSize = c.Cache.FilterLogCacheSize
@@ -1141,10 +1274,7 @@ func (c *Config) buildEth(stack *node.Node, accountManager *accounts.Manager) (*
 	case "full":
 		n.SyncMode = downloader.FullSync
 	case "snap":
-		// n.SyncMode = downloader.SnapSync // TODO(snap): Uncomment when we have snap sync working
-		n.SyncMode = downloader.FullSync
-
-		log.Warn("Bor doesn't support Snap Sync yet, switching to Full Sync mode")
+		n.SyncMode = downloader.SnapSync
 	default:
 		return nil, fmt.Errorf("sync mode '%s' not found", c.SyncMode)
 	}
@@ -1178,6 +1308,19 @@ func (c *Config) buildEth(stack *node.Node, accountManager *accounts.Manager) (*
 	n.BorLogs = c.BorLogs
 	n.DatabaseHandles = dbHandles
 
+	if c.BorTrustedCheckpoint != "" {
+		checkpoint, err := bor.ParseTrustedCheckpoint(c.BorTrustedCheckpoint)
+		if err != nil {
+			return nil, err
+		}
+
+		if n.SyncMode != downloader.SnapSync {
+			log.Warn("Trusted checkpoint is only used by snap sync", "syncmode", c.SyncMode)
+		}
+
+		n.BorTrustedCheckpoint = checkpoint
+	}
+
 	n.ParallelEVM.Enable = c.ParallelEVM.Enable
 	n.ParallelEVM.SpeculativeProcesses = c.ParallelEVM.SpeculativeProcesses
 	n.RPCReturnDataLimit = c.RPCReturnDataLimit
@@ -1294,6 +1437,11 @@ func getNodeKey(hex string, file string) *ecdsa.PrivateKey {
 }
 
 func (c *Config) buildNode() (*node.Config, error) {
+	// Developer mode overrides some settings on a copy
+	if c.Developer.Enabled {
+		c = c.developerCopy()
+	}
+
 	ipcPath := ""
 	if !c.JsonRPC.IPCDisable {
 		ipcPath = clientIdentifier + ".ipc"
@@ -1320,6 +1468,7 @@ func (c *Config) buildNode() (*node.Config, error) {
 			DiscoveryV4:     c.P2P.Discovery.DiscoveryV4,
 			DiscoveryV5:     c.P2P.Discovery.V5Enabled,
 			TxArrivalWait:   c.P2P.TxArrivalWait,
+			TopologyMode:    c.P2P.Topology.Mode,
 		},
 		HTTPModules:         c.JsonRPC.Http.API,
 		HTTPCors:            c.JsonRPC.Http.Cors,
@@ -1427,6 +1576,21 @@ func (c *Config) buildNode() (*node.Config, error) {
 		if len(cfg.P2P.TrustedNodes) == 0 {
 			cfg.P2P.TrustedNodes = cfg.TrustedNodes()
 		}
+
+		// Topology
+		if cfg.P2P.Sentries, err = parseBootnodes(c.P2P.Topology.SentryNodes); err != nil {
+			return nil, err
+		}
+
+		if cfg.P2P.Validators, err = parseBootnodes(c.P2P.Topology.ValidatorNodes); err != nil {
+			return nil, err
+		}
+
+		if err := cfg.P2P.ValidateTopology(); err != nil {
+			return nil, err
+		}
+	} else {
+		cfg.P2P.TopologyMode = ""
 	}
 
 	if c.P2P.NoDiscover {
@@ -0,0 +1,314 @@
+package server
+
+import (
+	"fmt"
+	"math/big"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/p2p/enode"
+)
+
+// reloadFunc applies a single config field of the given configuration to the
+// running server and records it in the active configuration. The functions
+// must be idempotent, as fields sharing a setter trigger it once per change.
+type reloadFunc func(s *Server, config *Config) error
+
+// reloaders lists the config fields, keyed by their config file path, which
+// can be applied to a running node without a restart.
+var reloaders = map[string]reloadFunc{
+	"verbosity":                   reloadLogger,
+	"log-level":                   reloadLogger,
+	"log.vmodule":                 reloadLogger,
+	"txpool.pricelimit":           reloadTxPoolPriceLimit,
+	"txpool.accountslots":         reloadTxPoolSlots,
+	"txpool.globalslots":          reloadTxPoolSlots,
+	"txpool.accountqueue":         reloadTxPoolSlots,
+	"txpool.globalqueue":          reloadTxPoolSlots,
+	"miner.gaslimit":              reloadMinerGasCeil,
+	"miner.extradata":             reloadMinerExtraData,
+	"miner.recommit":              reloadMinerRecommit,
+	"jsonrpc.gascap":              reloadRPCCaps,
+	"jsonrpc.evmtimeout":          reloadRPCCaps,
+	"jsonrpc.txfeecap":            reloadRPCCaps,
+	"p2p.maxpeers":                reloadMaxPeers,
+	"p2p.discovery.static-nodes":  reloadStaticNodes,
+	"p2p.discovery.trusted-nodes": reloadTrustedNodes,
+	"grpc.token":                  reloadGRPCTokens,
+	"grpc.admin-token":            reloadGRPCTokens,
+}
+
+// ReloadConfig reloads the server configuration via the registered config
+// loader and applies it. It returns the changed fields which were applied and
+// the changed fields which only take effect after a restart.
+func (s *Server) ReloadConfig() ([]string, []string, error) {
+	if s.configLoader == nil {
+		return nil, nil, fmt.Errorf("config reload is not supported")
+	}
+
+	config, err := s.configLoader()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return s.applyConfig(config)
+}
+
+// applyConfig compares the given configuration with the active one, applies
+// the reloadable changes and reports the ones which require a restart.
+func (s *Server) applyConfig(config *Config) ([]string, []string, error) {
+	s.reloadLock.Lock()
+	defer s.reloadLock.Unlock()
+
+	var applied, restart []string
+
+	for _, field := range diffConfig(s.config, config) {
+		reload, ok := reloaders[field]
+		if !ok {
+			restart = append(restart, field)
+			continue
+		}
+
+		if err := reload(s, config); err != nil {
+			return applied, restart, fmt.Errorf("failed to reload %s: %v", field, err)
+		}
+
+		applied = append(applied, field)
+	}
+
+	log.Info("Reloaded configuration", "applied", strings.Join(applied, ","), "restart", strings.Join(restart, ","))
+
+	return applied, restart, nil
+}
+
+func reloadLogger(s *Server, config *Config) error {
+	s.config.Verbosity, s.config.LogLevel = config.Verbosity, config.LogLevel
+	s.config.Logging.Vmodule = config.Logging.Vmodule
+
+	setupLogger(VerbosityIntToString(s.config.Verbosity), *s.config.Logging)
+
+	return nil
+}
+
+func reloadTxPoolPriceLimit(s *Server, config *Config) error {
+	s.backend.TxPool().SetGasTip(new(big.Int).SetUint64(config.TxPool.PriceLimit))
+	s.config.TxPool.PriceLimit = config.TxPool.PriceLimit
+
+	return nil
+}
+
+func reloadTxPoolSlots(s *Server, config *Config) error {
+	pool := config.TxPool
+	s.backend.LegacyPool().SetSlots(pool.AccountSlots, pool.GlobalSlots, pool.AccountQueue, pool.GlobalQueue)
+
+	s.config.TxPool.AccountSlots, s.config.TxPool.GlobalSlots = pool.AccountSlots, pool.GlobalSlots
+	s.config.TxPool.AccountQueue, s.config.TxPool.GlobalQueue = pool.AccountQueue, pool.GlobalQueue
+
+	return nil
+}
+
+func reloadMinerGasCeil(s *Server, config *Config) error {
+	s.backend.Miner().SetGasCeil(config.Sealer.GasCeil)
+	s.config.Sealer.GasCeil = config.Sealer.GasCeil
+
+	return nil
+}
+
+func reloadMinerExtraData(s *Server, config *Config) error {
+	if err := s.backend.Miner().SetExtra([]byte(config.Sealer.ExtraData)); err != nil {
+		return err
+	}
+
+	s.config.Sealer.ExtraData = config.Sealer.ExtraData
+
+	return nil
+}
+
+func reloadMinerRecommit(s *Server, config *Config) error {
+	s.backend.Miner().SetRecommitInterval(config.Sealer.Recommit)
+	s.config.Sealer.Recommit = config.Sealer.Recommit
+
+	return nil
+}
+
+func reloadRPCCaps(s *Server, config *Config) error {
+	s.backend.APIBackend.SetRPCGasCap(config.JsonRPC.GasCap)
+	s.backend.APIBackend.SetRPCEVMTimeout(config.JsonRPC.RPCEVMTimeout)
+	s.backend.APIBackend.SetRPCTxFeeCap(config.JsonRPC.TxFeeCap)
+
+	s.config.JsonRPC.GasCap = config.JsonRPC.GasCap
+	s.config.JsonRPC.RPCEVMTimeout = config.JsonRPC.RPCEVMTimeout
+	s.config.JsonRPC.TxFeeCap = config.JsonRPC.TxFeeCap
+
+	return nil
+}
+
+func reloadMaxPeers(s *Server, config *Config) error {
+	s.node.Server().SetMaxPeers(int(config.P2P.MaxPeers))
+	s.config.P2P.MaxPeers = config.P2P.MaxPeers
+
+	return nil
+}
+
+func reloadStaticNodes(s *Server, config *Config) error {
+	added, removed, err := diffNodes(s.config.P2P.Discovery.StaticNodes, config.P2P.Discovery.StaticNodes)
+	if err != nil {
+		return err
+	}
+
+	srv := s.node.Server()
+	for _, node := range removed {
+		srv.RemovePeer(node)
+	}
+
+	for _, node := range added {
+		srv.AddPeer(node)
+	}
+
+	s.config.P2P.Discovery.StaticNodes = config.P2P.Discovery.StaticNodes
+
+	return nil
+}
+
+func reloadTrustedNodes(s *Server, config *Config) error {
+	added, removed, err := diffNodes(s.config.P2P.Discovery.TrustedNodes, config.P2P.Discovery.TrustedNodes)
+	if err != nil {
+		return err
+	}
+
+	srv := s.node.Server()
+	for _, node := range removed {
+		srv.RemoveTrustedPeer(node)
+	}
+
+	for _, node := range added {
+		srv.AddTrustedPeer(node)
+	}
+
+	s.config.P2P.Discovery.TrustedNodes = config.P2P.Discovery.TrustedNodes
+
+	return nil
+}
+
+func reloadGRPCTokens(s *Server, config *Config) error {
+	// Without a grpc server the tokens are only recorded
+	if s.grpcAuth != nil {
+		s.grpcAuth.SetTokens(config.GRPC.Token, config.GRPC.AdminToken)
+	}
+
+	s.config.GRPC.Token, s.config.GRPC.AdminToken = config.GRPC.Token, config.GRPC.AdminToken
+
+	return nil
+}
+
+// diffNodes parses two lists of enode urls and returns the nodes which were
+// added to and removed from the old list.
+func diffNodes(oldURLs, newURLs []string) (added, removed []*enode.Node, err error) {
+	oldNodes, err := parseBootnodes(oldURLs)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	newNodes, err := parseBootnodes(newURLs)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	known := make(map[enode.ID]bool, len(oldNodes))
+	for _, node := range oldNodes {
+		known[node.ID()] = true
+	}
+
+	for _, node := range newNodes {
+		if !known[node.ID()] {
+			added = append(added, node)
+		}
+
+		delete(known, node.ID())
+	}
+
+	for _, node := range oldNodes {
+		if known[node.ID()] {
+			removed = append(removed, node)
+		}
+	}
+
+	return added, removed, nil
+}
+
+// diffConfig returns the config file paths of all the fields which differ
+// between the two configurations, sorted alphabetically.
+func diffConfig(a, b *Config) []string {
+	var fields []string
+
+	diffStruct(reflect.ValueOf(a).Elem(), reflect.ValueOf(b).Elem(), "", &fields)
+	sort.Strings(fields)
+
+	return fields
+}
+
+var bigIntType = reflect.TypeOf(big.Int{})
+
+func diffStruct(a, b reflect.Value, prefix string, fields *[]string) {
+	typ := a.Type()
+
+	for i := 0; i < typ.NumField(); i++ {
+		field := typ.Field(i)
+		if !field.IsExported() || strings.HasSuffix(field.Name, "Raw") {
+			continue
+		}
+
+		// Parsed fields are named after their raw config file counterpart
+		tagged := field
+		if name := hclName(field); name == "-" {
+			raw, ok := typ.FieldByName(field.Name + "Raw")
+			if !ok {
+				continue
+			}
+
+			tagged = raw
+		}
+
+		path := prefix + hclName(tagged)
+
+		fa, fb := a.Field(i), b.Field(i)
+		if fa.Kind() == reflect.Ptr && fa.Type().Elem().Kind() == reflect.Struct && fa.Type().Elem() != bigIntType {
+			switch {
+			case fa.IsNil() && fb.IsNil():
+			case fa.IsNil() || fb.IsNil():
+				*fields = append(*fields, path)
+			default:
+				diffStruct(fa.Elem(), fb.Elem(), path+".", fields)
+			}
+
+			continue
+		}
+
+		if !equalValue(fa, fb) {
+			*fields = append(*fields, path)
+		}
+	}
+}
+
+// equalValue compares two config values, treating nil and empty collections
+// alike and comparing big integers by value.
+func equalValue(a, b reflect.Value) bool {
+	switch a.Kind() {
+	case reflect.Slice, reflect.Map:
+		if a.Len() == 0 && b.Len() == 0 {
+			return true
+		}
+	case reflect.Ptr:
+		if a.Type().Elem() == bigIntType && !a.IsNil() && !b.IsNil() {
+			return a.Interface().(*big.Int).Cmp(b.Interface().(*big.Int)) == 0
+		}
+	}
+
+	return reflect.DeepEqual(a.Interface(), b.Interface())
+}
+
+func hclName(field reflect.StructField) string {
+	return strings.Split(field.Tag.Get("hcl"), ",")[0]
+}
@@ -0,0 +1,110 @@
+package server
+
+import (
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestDiffConfig(t *testing.T) {
+	t.Parallel()
+
+	a, b := DefaultConfig(), DefaultConfig()
+	require.Empty(t, diffConfig(a, b))
+
+	b.Verbosity = 5
+	b.TxPool.PriceLimit = 30_000_000_000
+	b.Sealer.Recommit = time.Minute
+	b.Sealer.GasPrice = big.NewInt(1)
+	b.P2P.Discovery.StaticNodes = []string{"enode://a"}
+	b.Cache.Cache = 2048
+
+	require.Equal(t, []string{
+		"cache.cache",
+		"miner.gasprice",
+		"miner.recommit",
+		"p2p.discovery.static-nodes",
+		"txpool.pricelimit",
+		"verbosity",
+	}, diffConfig(a, b))
+
+	// Empty and nil lists are treated alike
+	a.P2P.Discovery.TrustedNodes = nil
+	b.P2P.Discovery.TrustedNodes = []string{}
+	require.NotContains(t, diffConfig(a, b), "p2p.discovery.trusted-nodes")
+}
+
+func TestServer_ConfigReload(t *testing.T) {
+	t.Parallel()
+
+	config := DefaultConfig()
+	config.Developer.Enabled = true
+	config.Developer.Period = 2
+
+	server, err := CreateMockServer(config)
+	require.NoError(t, err)
+
+	defer CloseMockServer(server)
+
+	// Nothing to do without a config loader
+	_, _, err = server.ReloadConfig()
+	require.Error(t, err)
+
+	next := *server.config
+	nextPool, nextSealer, nextRPC, nextCache := *next.TxPool, *next.Sealer, *next.JsonRPC, *next.Cache
+	next.TxPool, next.Sealer, next.JsonRPC, next.Cache = &nextPool, &nextSealer, &nextRPC, &nextCache
+
+	next.TxPool.PriceLimit = 2
+	next.TxPool.GlobalSlots = 1024
+	next.Sealer.GasCeil = 20_000_000
+	next.JsonRPC.GasCap = 1_000_000
+	next.Cache.Cache = 2048
+
+	server.configLoader = func() (*Config, error) { return &next, nil }
+
+	applied, restart, err := server.ReloadConfig()
+	require.NoError(t, err)
+	require.Equal(t, []string{"jsonrpc.gascap", "miner.gaslimit", "txpool.globalslots", "txpool.pricelimit"}, applied)
+	require.Equal(t, []string{"cache.cache"}, restart)
+
+	require.Equal(t, uint64(1_000_000), server.backend.APIBackend.RPCGasCap())
+	require.Equal(t, uint64(2), server.config.TxPool.PriceLimit)
+
+	// Reloading the same configuration again only reports pending restarts
+	applied, restart, err = server.ReloadConfig()
+	require.NoError(t, err)
+	require.Empty(t, applied)
+	require.Equal(t, []string{"cache.cache"}, restart)
+}
+
+func TestServer_ConfigReloadDeveloper(t *testing.T) {
+	t.Parallel()
+
+	newConfig := func() *Config {
+		config := DefaultConfig()
+		config.Developer.Enabled = true
+		config.Developer.Period = 2
+
+		return config
+	}
+
+	server, err := CreateMockServer(newConfig())
+	require.NoError(t, err)
+
+	defer CloseMockServer(server)
+
+	// The developer mode overrides must not be seen as changes on reload
+	next := newConfig()
+	next.GRPC.Addr = server.config.GRPC.Addr
+	next.DataDir = server.config.DataDir
+	next.JsonRPC.Http.Port = server.config.JsonRPC.Http.Port
+
+	server.configLoader = func() (*Config, error) { return next, nil }
+
+	applied, restart, err := server.ReloadConfig()
+	require.NoError(t, err)
+	require.Empty(t, applied)
+	require.Empty(t, restart)
+}
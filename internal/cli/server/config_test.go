@@ -123,6 +123,52 @@ func TestConfigBootnodesDefault(t *testing.T) {
 	})
 }
 
+func TestConfigTopology(t *testing.T) {
+	t.Run("Validator", func(t *testing.T) {
+		config := DefaultConfig()
+		config.P2P.Topology.Mode = "validator"
+		config.P2P.Topology.SentryNodes = []string{dummyEnodeAddr}
+
+		cfg, err := config.buildNode()
+		assert.NoError(t, err)
+		assert.Equal(t, "validator", cfg.P2P.TopologyMode)
+		assert.Len(t, cfg.P2P.Sentries, 1)
+	})
+	t.Run("MissingSentries", func(t *testing.T) {
+		config := DefaultConfig()
+		config.P2P.Topology.Mode = "validator"
+
+		_, err := config.buildNode()
+		assert.Error(t, err)
+	})
+	t.Run("UnknownMode", func(t *testing.T) {
+		config := DefaultConfig()
+		config.P2P.Topology.Mode = "relay"
+		config.P2P.Topology.ValidatorNodes = []string{dummyEnodeAddr}
+
+		_, err := config.buildNode()
+		assert.Error(t, err)
+	})
+}
+
+func TestConfigBorReceiptLimit(t *testing.T) {
+	config := DefaultConfig()
+	assert.NoError(t, config.loadChain())
+
+	config.Cache.BorReceiptLimit = 1000
+	config.Cache.TxLookupLimit = 0
+
+	// Bor receipts can't expire without the transaction index doing so
+	_, err := config.buildEth(nil, nil)
+	assert.Error(t, err)
+
+	config.Cache.TxLookupLimit = 500
+
+	cfg, err := config.buildEth(nil, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(1000), cfg.BorReceiptLimit)
+}
+
 func TestMakePasswordListFromFile(t *testing.T) {
 	t.Parallel()
 
@@ -88,7 +88,7 @@ func (c *Command) Flags(config *Config) *flagset.Flagset {
 	})
 	f.StringFlag(&flagset.StringFlag{
 		Name:    "syncmode",
-		Usage:   `Blockchain sync mode (only "full" sync supported)`,
+		Usage:   `Blockchain sync mode ("full" or "snap")`,
 		Value:   &c.cliConfig.SyncMode,
 		Default: c.cliConfig.SyncMode,
 	})
@@ -116,6 +116,12 @@ func (c *Command) Flags(config *Config) *flagset.Flagset {
 		Value:   &c.cliConfig.BorLogs,
 		Default: c.cliConfig.BorLogs,
 	})
+	f.StringFlag(&flagset.StringFlag{
+		Name:    "bor.trustedcheckpoint",
+		Usage:   "Heimdall checkpoint to bootstrap the chain from, as <start>-<end>:<root hash>:<end block hash>. Headers up to its end block synced by snap sync are anchored to it instead of having their seals and validator sets verified",
+		Value:   &c.cliConfig.BorTrustedCheckpoint,
+		Default: c.cliConfig.BorTrustedCheckpoint,
+	})
 
 	// logging related flags (log-level and verbosity is present above, it will be removed soon)
 	f.StringFlag(&flagset.StringFlag{
@@ -172,6 +178,30 @@ func (c *Command) Flags(config *Config) *flagset.Flagset {
 		Value:   &c.cliConfig.Heimdall.GRPCAddress,
 		Default: c.cliConfig.Heimdall.GRPCAddress,
 	})
+	f.StringFlag(&flagset.StringFlag{
+		Name:    "bor.heimdallgRPC.tlscert",
+		Usage:   "Client certificate presented to the Heimdall gRPC service",
+		Value:   &c.cliConfig.Heimdall.GRPCTLSCert,
+		Default: c.cliConfig.Heimdall.GRPCTLSCert,
+	})
+	f.StringFlag(&flagset.StringFlag{
+		Name:    "bor.heimdallgRPC.tlskey",
+		Usage:   "Private key of the Heimdall gRPC client certificate",
+		Value:   &c.cliConfig.Heimdall.GRPCTLSKey,
+		Default: c.cliConfig.Heimdall.GRPCTLSKey,
+	})
+	f.StringFlag(&flagset.StringFlag{
+		Name:    "bor.heimdallgRPC.tlsca",
+		Usage:   "CA bundle to verify the Heimdall gRPC service, enables TLS",
+		Value:   &c.cliConfig.Heimdall.GRPCTLSCA,
+		Default: c.cliConfig.Heimdall.GRPCTLSCA,
+	})
+	f.StringFlag(&flagset.StringFlag{
+		Name:    "bor.heimdallgRPC.token",
+		Usage:   "Bearer token sent to the Heimdall gRPC service",
+		Value:   &c.cliConfig.Heimdall.GRPCToken,
+		Default: c.cliConfig.Heimdall.GRPCToken,
+	})
 	f.BoolFlag(&flagset.BoolFlag{
 		Name:    "bor.runheimdall",
 		Usage:   "Run Heimdall service as a child process",
@@ -269,6 +299,20 @@ func (c *Command) Flags(config *Config) *flagset.Flagset {
 		Default: c.cliConfig.TxPool.LifeTime,
 		Group:   "Transaction Pool",
 	})
+	f.Uint64Flag(&flagset.Uint64Flag{
+		Name:    "txpool.conditionalbundlerslots",
+		Usage:   "Maximum number of conditional transactions pooled per bundler",
+		Value:   &c.cliConfig.TxPool.ConditionalBundlerSlots,
+		Default: c.cliConfig.TxPool.ConditionalBundlerSlots,
+		Group:   "Transaction Pool",
+	})
+	f.Uint64Flag(&flagset.Uint64Flag{
+		Name:    "txpool.conditionalglobalslots",
+		Usage:   "Maximum number of conditional transactions pooled in total",
+		Value:   &c.cliConfig.TxPool.ConditionalGlobalSlots,
+		Default: c.cliConfig.TxPool.ConditionalGlobalSlots,
+		Group:   "Transaction Pool",
+	})
 
 	// sealer options
 	f.BoolFlag(&flagset.BoolFlag{
@@ -320,6 +364,20 @@ func (c *Command) Flags(config *Config) *flagset.Flagset {
 		Default: c.cliConfig.Sealer.CommitInterruptFlag,
 		Group:   "Sealer",
 	})
+	f.StringFlag(&flagset.StringFlag{
+		Name:    "miner.txordering",
+		Usage:   "Transaction ordering used when building blocks ('price' or 'fair' for sender-fair round-robin within price tiers)",
+		Value:   &c.cliConfig.Sealer.TxOrdering,
+		Default: c.cliConfig.Sealer.TxOrdering,
+		Group:   "Sealer",
+	})
+	f.Uint64Flag(&flagset.Uint64Flag{
+		Name:    "miner.sendergascap",
+		Usage:   "Maximum gas a single sender may use per block when using fair transaction ordering (0 = unlimited)",
+		Value:   &c.cliConfig.Sealer.SenderGasCap,
+		Default: c.cliConfig.Sealer.SenderGasCap,
+		Group:   "Sealer",
+	})
 
 	// ethstats
 	f.StringFlag(&flagset.StringFlag{
@@ -438,6 +496,20 @@ func (c *Command) Flags(config *Config) *flagset.Flagset {
 		Default: c.cliConfig.Cache.TxLookupLimit,
 		Group:   "Cache",
 	})
+	f.Uint64Flag(&flagset.Uint64Flag{
+		Name:    "borreceiptlimit",
+		Usage:   "Number of recent blocks to keep bor receipts for, never below txlookuplimit and requiring a non-zero txlookuplimit (0 = entire chain)",
+		Value:   &c.cliConfig.Cache.BorReceiptLimit,
+		Default: c.cliConfig.Cache.BorReceiptLimit,
+		Group:   "Cache",
+	})
+	f.BoolFlag(&flagset.BoolFlag{
+		Name:    "cache.changesets",
+		Usage:   "Index the account balance, nonce and storage changes of the imported blocks, serving bor_getBalanceChanges and bor_getStorageHistory",
+		Value:   &c.cliConfig.Cache.ChangeSets,
+		Default: c.cliConfig.Cache.ChangeSets,
+		Group:   "Cache",
+	})
 	f.IntFlag(&flagset.IntFlag{
 		Name:    "fdlimit",
 		Usage:   "Raise the open file descriptor resource limit (default = system fd limit)",
@@ -512,6 +584,20 @@ func (c *Command) Flags(config *Config) *flagset.Flagset {
 		Default: c.cliConfig.JsonRPC.EnablePersonal,
 		Group:   "JsonRPC",
 	})
+	f.BoolFlag(&flagset.BoolFlag{
+		Name:    "rpc.traceindex",
+		Usage:   "Index the flat call traces of the canonical blocks in the background, serving trace_filter from the index (requires the state of the indexed blocks)",
+		Value:   &c.cliConfig.JsonRPC.TraceIndex,
+		Default: c.cliConfig.JsonRPC.TraceIndex,
+		Group:   "JsonRPC",
+	})
+	f.Uint64Flag(&flagset.Uint64Flag{
+		Name:    "rpc.traceindexfrom",
+		Usage:   "First block to index the flat call traces of",
+		Value:   &c.cliConfig.JsonRPC.TraceIndexFrom,
+		Default: c.cliConfig.JsonRPC.TraceIndexFrom,
+		Group:   "JsonRPC",
+	})
 	f.BoolFlag(&flagset.BoolFlag{
 		Name:    "ipcdisable",
 		Usage:   "Disable the IPC-RPC server",
@@ -786,6 +872,27 @@ func (c *Command) Flags(config *Config) *flagset.Flagset {
 		Default: c.cliConfig.P2P.Discovery.V5Enabled,
 		Group:   "P2P",
 	})
+	f.StringFlag(&flagset.StringFlag{
+		Name:    "topology",
+		Usage:   "Role of the node in a sentry deployment (sentry|validator)",
+		Value:   &c.cliConfig.P2P.Topology.Mode,
+		Default: c.cliConfig.P2P.Topology.Mode,
+		Group:   "P2P",
+	})
+	f.SliceStringFlag(&flagset.SliceStringFlag{
+		Name:    "sentry-nodes",
+		Usage:   "Comma separated enode URLs of the only peers of a validator",
+		Value:   &c.cliConfig.P2P.Topology.SentryNodes,
+		Default: c.cliConfig.P2P.Topology.SentryNodes,
+		Group:   "P2P",
+	})
+	f.SliceStringFlag(&flagset.SliceStringFlag{
+		Name:    "validator-nodes",
+		Usage:   "Comma separated enode URLs of the validators behind a sentry",
+		Value:   &c.cliConfig.P2P.Topology.ValidatorNodes,
+		Default: c.cliConfig.P2P.Topology.ValidatorNodes,
+		Group:   "P2P",
+	})
 	f.DurationFlag(&flagset.DurationFlag{
 		Name:    "txarrivalwait",
 		Usage:   "Maximum duration to wait for a transaction before explicitly requesting it",
@@ -938,6 +1045,36 @@ func (c *Command) Flags(config *Config) *flagset.Flagset {
 		Value:   &c.cliConfig.GRPC.Addr,
 		Default: c.cliConfig.GRPC.Addr,
 	})
+	f.StringFlag(&flagset.StringFlag{
+		Name:    "grpc.tls-cert",
+		Usage:   "Certificate of the GRPC server, enables TLS",
+		Value:   &c.cliConfig.GRPC.TLSCert,
+		Default: c.cliConfig.GRPC.TLSCert,
+	})
+	f.StringFlag(&flagset.StringFlag{
+		Name:    "grpc.tls-key",
+		Usage:   "Private key of the GRPC server certificate",
+		Value:   &c.cliConfig.GRPC.TLSKey,
+		Default: c.cliConfig.GRPC.TLSKey,
+	})
+	f.StringFlag(&flagset.StringFlag{
+		Name:    "grpc.tls-client-ca",
+		Usage:   "CA bundle to verify client certificates, enables mutual TLS",
+		Value:   &c.cliConfig.GRPC.TLSClientCA,
+		Default: c.cliConfig.GRPC.TLSClientCA,
+	})
+	f.StringFlag(&flagset.StringFlag{
+		Name:    "grpc.token",
+		Usage:   "Bearer token required by all the GRPC methods",
+		Value:   &c.cliConfig.GRPC.Token,
+		Default: c.cliConfig.GRPC.Token,
+	})
+	f.StringFlag(&flagset.StringFlag{
+		Name:    "grpc.admin-token",
+		Usage:   "Bearer token required by the admin GRPC methods (e.g. chain sethead, peers remove)",
+		Value:   &c.cliConfig.GRPC.AdminToken,
+		Default: c.cliConfig.GRPC.AdminToken,
+	})
 
 	// developer
 	f.BoolFlag(&flagset.BoolFlag{
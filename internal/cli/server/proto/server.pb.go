@@ -21,6 +21,59 @@ const (
 	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
 )
 
+type ChainReindexBorRequest_Action int32
+
+const (
+	ChainReindexBorRequest_STATUS   ChainReindexBorRequest_Action = 0
+	ChainReindexBorRequest_START    ChainReindexBorRequest_Action = 1
+	ChainReindexBorRequest_STOP     ChainReindexBorRequest_Action = 2
+	ChainReindexBorRequest_BACKFILL ChainReindexBorRequest_Action = 3
+)
+
+// Enum value maps for ChainReindexBorRequest_Action.
+var (
+	ChainReindexBorRequest_Action_name = map[int32]string{
+		0: "STATUS",
+		1: "START",
+		2: "STOP",
+		3: "BACKFILL",
+	}
+	ChainReindexBorRequest_Action_value = map[string]int32{
+		"STATUS":   0,
+		"START":    1,
+		"STOP":     2,
+		"BACKFILL": 3,
+	}
+)
+
+func (x ChainReindexBorRequest_Action) Enum() *ChainReindexBorRequest_Action {
+	p := new(ChainReindexBorRequest_Action)
+	*p = x
+
+	return p
+}
+
+func (x ChainReindexBorRequest_Action) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (ChainReindexBorRequest_Action) Descriptor() protoreflect.EnumDescriptor {
+	return file_internal_cli_server_proto_server_proto_enumTypes[0].Descriptor()
+}
+
+func (ChainReindexBorRequest_Action) Type() protoreflect.EnumType {
+	return &file_internal_cli_server_proto_server_proto_enumTypes[0]
+}
+
+func (x ChainReindexBorRequest_Action) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use ChainReindexBorRequest_Action.Descriptor instead.
+func (ChainReindexBorRequest_Action) EnumDescriptor() ([]byte, []int) {
+	return file_internal_cli_server_proto_server_proto_rawDescGZIP(), []int{16, 0}
+}
+
 type DebugPprofRequest_Type int32
 
 const (
@@ -55,11 +108,11 @@ func (x DebugPprofRequest_Type) String() string {
 }
 
 func (DebugPprofRequest_Type) Descriptor() protoreflect.EnumDescriptor {
-	return file_internal_cli_server_proto_server_proto_enumTypes[0].Descriptor()
+	return file_internal_cli_server_proto_server_proto_enumTypes[1].Descriptor()
 }
 
 func (DebugPprofRequest_Type) Type() protoreflect.EnumType {
-	return &file_internal_cli_server_proto_server_proto_enumTypes[0]
+	return &file_internal_cli_server_proto_server_proto_enumTypes[1]
 }
 
 func (x DebugPprofRequest_Type) Number() protoreflect.EnumNumber {
@@ -68,7 +121,7 @@ func (x DebugPprofRequest_Type) Number() protoreflect.EnumNumber {
 
 // Deprecated: Use DebugPprofRequest_Type.Descriptor instead.
 func (DebugPprofRequest_Type) EnumDescriptor() ([]byte, []int) {
-	return file_internal_cli_server_proto_server_proto_rawDescGZIP(), []int{19, 0}
+	return file_internal_cli_server_proto_server_proto_rawDescGZIP(), []int{34, 0}
 }
 
 type TraceRequest struct {
@@ -754,6 +807,7 @@ type Peer struct {
 	Name    string   `protobuf:"bytes,5,opt,name=name,proto3" json:"name,omitempty"`
 	Trusted bool     `protobuf:"varint,6,opt,name=trusted,proto3" json:"trusted,omitempty"`
 	Static  bool     `protobuf:"varint,7,opt,name=static,proto3" json:"static,omitempty"`
+	Score   int64    `protobuf:"varint,8,opt,name=score,proto3" json:"score,omitempty"`
 }
 
 func (x *Peer) Reset() {
@@ -848,6 +902,14 @@ func (x *Peer) GetStatic() bool {
 	return false
 }
 
+func (x *Peer) GetScore() int64 {
+	if x != nil {
+		return x.Score
+	}
+
+	return 0
+}
+
 type ChainSetHeadRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -942,16 +1004,18 @@ func (*ChainSetHeadResponse) Descriptor() ([]byte, []int) {
 	return file_internal_cli_server_proto_server_proto_rawDescGZIP(), []int{15}
 }
 
-type StatusRequest struct {
+type ChainReindexBorRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Wait bool `protobuf:"varint,1,opt,name=Wait,proto3" json:"Wait,omitempty"`
+	Action ChainReindexBorRequest_Action `protobuf:"varint,1,opt,name=action,proto3,enum=proto.ChainReindexBorRequest_Action" json:"action,omitempty"`
+	From   uint64                        `protobuf:"varint,2,opt,name=from,proto3" json:"from,omitempty"`
+	To     uint64                        `protobuf:"varint,3,opt,name=to,proto3" json:"to,omitempty"`
 }
 
-func (x *StatusRequest) Reset() {
-	*x = StatusRequest{}
+func (x *ChainReindexBorRequest) Reset() {
+	*x = ChainReindexBorRequest{}
 
 	if protoimpl.UnsafeEnabled {
 		mi := &file_internal_cli_server_proto_server_proto_msgTypes[16]
@@ -960,13 +1024,13 @@ func (x *StatusRequest) Reset() {
 	}
 }
 
-func (x *StatusRequest) String() string {
+func (x *ChainReindexBorRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*StatusRequest) ProtoMessage() {}
+func (*ChainReindexBorRequest) ProtoMessage() {}
 
-func (x *StatusRequest) ProtoReflect() protoreflect.Message {
+func (x *ChainReindexBorRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_internal_cli_server_proto_server_proto_msgTypes[16]
 
 	if protoimpl.UnsafeEnabled && x != nil {
@@ -981,34 +1045,51 @@ func (x *StatusRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use StatusRequest.ProtoReflect.Descriptor instead.
-func (*StatusRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use ChainReindexBorRequest.ProtoReflect.Descriptor instead.
+func (*ChainReindexBorRequest) Descriptor() ([]byte, []int) {
 	return file_internal_cli_server_proto_server_proto_rawDescGZIP(), []int{16}
 }
 
-func (x *StatusRequest) GetWait() bool {
+func (x *ChainReindexBorRequest) GetAction() ChainReindexBorRequest_Action {
 	if x != nil {
-		return x.Wait
+		return x.Action
 	}
 
-	return false
+	return ChainReindexBorRequest_STATUS
 }
 
-type StatusResponse struct {
+func (x *ChainReindexBorRequest) GetFrom() uint64 {
+	if x != nil {
+		return x.From
+	}
+
+	return 0
+}
+
+func (x *ChainReindexBorRequest) GetTo() uint64 {
+	if x != nil {
+		return x.To
+	}
+
+	return 0
+}
+
+type ChainReindexBorResponse struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	CurrentBlock  *Header                 `protobuf:"bytes,1,opt,name=currentBlock,proto3" json:"currentBlock,omitempty"`
-	CurrentHeader *Header                 `protobuf:"bytes,2,opt,name=currentHeader,proto3" json:"currentHeader,omitempty"`
-	NumPeers      int64                   `protobuf:"varint,3,opt,name=numPeers,proto3" json:"numPeers,omitempty"`
-	SyncMode      string                  `protobuf:"bytes,4,opt,name=syncMode,proto3" json:"syncMode,omitempty"`
-	Syncing       *StatusResponse_Syncing `protobuf:"bytes,5,opt,name=syncing,proto3" json:"syncing,omitempty"`
-	Forks         []*StatusResponse_Fork  `protobuf:"bytes,6,rep,name=forks,proto3" json:"forks,omitempty"`
+	Running    bool   `protobuf:"varint,1,opt,name=running,proto3" json:"running,omitempty"`
+	From       uint64 `protobuf:"varint,2,opt,name=from,proto3" json:"from,omitempty"`
+	To         uint64 `protobuf:"varint,3,opt,name=to,proto3" json:"to,omitempty"`
+	Next       uint64 `protobuf:"varint,4,opt,name=next,proto3" json:"next,omitempty"`
+	Written    uint64 `protobuf:"varint,5,opt,name=written,proto3" json:"written,omitempty"`
+	Error      string `protobuf:"bytes,6,opt,name=error,proto3" json:"error,omitempty"`
+	Backfilled uint64 `protobuf:"varint,7,opt,name=backfilled,proto3" json:"backfilled,omitempty"`
 }
 
-func (x *StatusResponse) Reset() {
-	*x = StatusResponse{}
+func (x *ChainReindexBorResponse) Reset() {
+	*x = ChainReindexBorResponse{}
 
 	if protoimpl.UnsafeEnabled {
 		mi := &file_internal_cli_server_proto_server_proto_msgTypes[17]
@@ -1017,13 +1098,13 @@ func (x *StatusResponse) Reset() {
 	}
 }
 
-func (x *StatusResponse) String() string {
+func (x *ChainReindexBorResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*StatusResponse) ProtoMessage() {}
+func (*ChainReindexBorResponse) ProtoMessage() {}
 
-func (x *StatusResponse) ProtoReflect() protoreflect.Message {
+func (x *ChainReindexBorResponse) ProtoReflect() protoreflect.Message {
 	mi := &file_internal_cli_server_proto_server_proto_msgTypes[17]
 
 	if protoimpl.UnsafeEnabled && x != nil {
@@ -1038,70 +1119,75 @@ func (x *StatusResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use StatusResponse.ProtoReflect.Descriptor instead.
-func (*StatusResponse) Descriptor() ([]byte, []int) {
+// Deprecated: Use ChainReindexBorResponse.ProtoReflect.Descriptor instead.
+func (*ChainReindexBorResponse) Descriptor() ([]byte, []int) {
 	return file_internal_cli_server_proto_server_proto_rawDescGZIP(), []int{17}
 }
 
-func (x *StatusResponse) GetCurrentBlock() *Header {
+func (x *ChainReindexBorResponse) GetRunning() bool {
 	if x != nil {
-		return x.CurrentBlock
+		return x.Running
 	}
 
-	return nil
+	return false
 }
 
-func (x *StatusResponse) GetCurrentHeader() *Header {
+func (x *ChainReindexBorResponse) GetFrom() uint64 {
 	if x != nil {
-		return x.CurrentHeader
+		return x.From
 	}
 
-	return nil
+	return 0
 }
 
-func (x *StatusResponse) GetNumPeers() int64 {
+func (x *ChainReindexBorResponse) GetTo() uint64 {
 	if x != nil {
-		return x.NumPeers
+		return x.To
 	}
 
 	return 0
 }
 
-func (x *StatusResponse) GetSyncMode() string {
+func (x *ChainReindexBorResponse) GetNext() uint64 {
 	if x != nil {
-		return x.SyncMode
+		return x.Next
 	}
 
-	return ""
+	return 0
 }
 
-func (x *StatusResponse) GetSyncing() *StatusResponse_Syncing {
+func (x *ChainReindexBorResponse) GetWritten() uint64 {
 	if x != nil {
-		return x.Syncing
+		return x.Written
 	}
 
-	return nil
+	return 0
 }
 
-func (x *StatusResponse) GetForks() []*StatusResponse_Fork {
+func (x *ChainReindexBorResponse) GetError() string {
 	if x != nil {
-		return x.Forks
+		return x.Error
 	}
 
-	return nil
+	return ""
 }
 
-type Header struct {
+func (x *ChainReindexBorResponse) GetBackfilled() uint64 {
+	if x != nil {
+		return x.Backfilled
+	}
+
+	return 0
+}
+
+type ConfigReloadRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
-
-	Hash   string `protobuf:"bytes,1,opt,name=hash,proto3" json:"hash,omitempty"`
-	Number uint64 `protobuf:"varint,2,opt,name=number,proto3" json:"number,omitempty"`
 }
 
-func (x *Header) Reset() {
-	*x = Header{}
+func (x *ConfigReloadRequest) Reset() {
+	*x = ConfigReloadRequest{}
 
 	if protoimpl.UnsafeEnabled {
 		mi := &file_internal_cli_server_proto_server_proto_msgTypes[18]
@@ -1110,13 +1196,13 @@ func (x *Header) Reset() {
 	}
 }
 
-func (x *Header) String() string {
+func (x *ConfigReloadRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*Header) ProtoMessage() {}
+func (*ConfigReloadRequest) ProtoMessage() {}
 
-func (x *Header) ProtoReflect() protoreflect.Message {
+func (x *ConfigReloadRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_internal_cli_server_proto_server_proto_msgTypes[18]
 
 	if protoimpl.UnsafeEnabled && x != nil {
@@ -1131,39 +1217,22 @@ func (x *Header) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use Header.ProtoReflect.Descriptor instead.
-func (*Header) Descriptor() ([]byte, []int) {
+// Deprecated: Use ConfigReloadRequest.ProtoReflect.Descriptor instead.
+func (*ConfigReloadRequest) Descriptor() ([]byte, []int) {
 	return file_internal_cli_server_proto_server_proto_rawDescGZIP(), []int{18}
 }
 
-func (x *Header) GetHash() string {
-	if x != nil {
-		return x.Hash
-	}
-
-	return ""
-}
-
-func (x *Header) GetNumber() uint64 {
-	if x != nil {
-		return x.Number
-	}
-
-	return 0
-}
-
-type DebugPprofRequest struct {
+type ConfigReloadResponse struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Type    DebugPprofRequest_Type `protobuf:"varint,1,opt,name=type,proto3,enum=proto.DebugPprofRequest_Type" json:"type,omitempty"`
-	Profile string                 `protobuf:"bytes,2,opt,name=profile,proto3" json:"profile,omitempty"`
-	Seconds int64                  `protobuf:"varint,3,opt,name=seconds,proto3" json:"seconds,omitempty"`
+	Applied []string `protobuf:"bytes,1,rep,name=applied,proto3" json:"applied,omitempty"`
+	Restart []string `protobuf:"bytes,2,rep,name=restart,proto3" json:"restart,omitempty"`
 }
 
-func (x *DebugPprofRequest) Reset() {
-	*x = DebugPprofRequest{}
+func (x *ConfigReloadResponse) Reset() {
+	*x = ConfigReloadResponse{}
 
 	if protoimpl.UnsafeEnabled {
 		mi := &file_internal_cli_server_proto_server_proto_msgTypes[19]
@@ -1172,13 +1241,13 @@ func (x *DebugPprofRequest) Reset() {
 	}
 }
 
-func (x *DebugPprofRequest) String() string {
+func (x *ConfigReloadResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*DebugPprofRequest) ProtoMessage() {}
+func (*ConfigReloadResponse) ProtoMessage() {}
 
-func (x *DebugPprofRequest) ProtoReflect() protoreflect.Message {
+func (x *ConfigReloadResponse) ProtoReflect() protoreflect.Message {
 	mi := &file_internal_cli_server_proto_server_proto_msgTypes[19]
 
 	if protoimpl.UnsafeEnabled && x != nil {
@@ -1193,45 +1262,37 @@ func (x *DebugPprofRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use DebugPprofRequest.ProtoReflect.Descriptor instead.
-func (*DebugPprofRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use ConfigReloadResponse.ProtoReflect.Descriptor instead.
+func (*ConfigReloadResponse) Descriptor() ([]byte, []int) {
 	return file_internal_cli_server_proto_server_proto_rawDescGZIP(), []int{19}
 }
 
-func (x *DebugPprofRequest) GetType() DebugPprofRequest_Type {
-	if x != nil {
-		return x.Type
-	}
-
-	return DebugPprofRequest_LOOKUP
-}
-
-func (x *DebugPprofRequest) GetProfile() string {
+func (x *ConfigReloadResponse) GetApplied() []string {
 	if x != nil {
-		return x.Profile
+		return x.Applied
 	}
 
-	return ""
+	return nil
 }
 
-func (x *DebugPprofRequest) GetSeconds() int64 {
+func (x *ConfigReloadResponse) GetRestart() []string {
 	if x != nil {
-		return x.Seconds
+		return x.Restart
 	}
 
-	return 0
+	return nil
 }
 
-type DebugBlockRequest struct {
+type TxPoolInspectRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Number int64 `protobuf:"varint,1,opt,name=number,proto3" json:"number,omitempty"`
+	Address string `protobuf:"bytes,1,opt,name=address,proto3" json:"address,omitempty"`
 }
 
-func (x *DebugBlockRequest) Reset() {
-	*x = DebugBlockRequest{}
+func (x *TxPoolInspectRequest) Reset() {
+	*x = TxPoolInspectRequest{}
 
 	if protoimpl.UnsafeEnabled {
 		mi := &file_internal_cli_server_proto_server_proto_msgTypes[20]
@@ -1240,13 +1301,13 @@ func (x *DebugBlockRequest) Reset() {
 	}
 }
 
-func (x *DebugBlockRequest) String() string {
+func (x *TxPoolInspectRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*DebugBlockRequest) ProtoMessage() {}
+func (*TxPoolInspectRequest) ProtoMessage() {}
 
-func (x *DebugBlockRequest) ProtoReflect() protoreflect.Message {
+func (x *TxPoolInspectRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_internal_cli_server_proto_server_proto_msgTypes[20]
 
 	if protoimpl.UnsafeEnabled && x != nil {
@@ -1261,34 +1322,31 @@ func (x *DebugBlockRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use DebugBlockRequest.ProtoReflect.Descriptor instead.
-func (*DebugBlockRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use TxPoolInspectRequest.ProtoReflect.Descriptor instead.
+func (*TxPoolInspectRequest) Descriptor() ([]byte, []int) {
 	return file_internal_cli_server_proto_server_proto_rawDescGZIP(), []int{20}
 }
 
-func (x *DebugBlockRequest) GetNumber() int64 {
+func (x *TxPoolInspectRequest) GetAddress() string {
 	if x != nil {
-		return x.Number
+		return x.Address
 	}
 
-	return 0
+	return ""
 }
 
-type DebugFileResponse struct {
+type TxPoolInspectResponse struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	// Types that are assignable to Event:
-	//
-	//	*DebugFileResponse_Open_
-	//	*DebugFileResponse_Input_
-	//	*DebugFileResponse_Eof
-	Event isDebugFileResponse_Event `protobuf_oneof:"event"`
+	Pending      uint64                               `protobuf:"varint,1,opt,name=pending,proto3" json:"pending,omitempty"`
+	Queued       uint64                               `protobuf:"varint,2,opt,name=queued,proto3" json:"queued,omitempty"`
+	Transactions []*TxPoolInspectResponse_Transaction `protobuf:"bytes,3,rep,name=transactions,proto3" json:"transactions,omitempty"`
 }
 
-func (x *DebugFileResponse) Reset() {
-	*x = DebugFileResponse{}
+func (x *TxPoolInspectResponse) Reset() {
+	*x = TxPoolInspectResponse{}
 
 	if protoimpl.UnsafeEnabled {
 		mi := &file_internal_cli_server_proto_server_proto_msgTypes[21]
@@ -1297,13 +1355,13 @@ func (x *DebugFileResponse) Reset() {
 	}
 }
 
-func (x *DebugFileResponse) String() string {
+func (x *TxPoolInspectResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*DebugFileResponse) ProtoMessage() {}
+func (*TxPoolInspectResponse) ProtoMessage() {}
 
-func (x *DebugFileResponse) ProtoReflect() protoreflect.Message {
+func (x *TxPoolInspectResponse) ProtoReflect() protoreflect.Message {
 	mi := &file_internal_cli_server_proto_server_proto_msgTypes[21]
 
 	if protoimpl.UnsafeEnabled && x != nil {
@@ -1318,93 +1376,103 @@ func (x *DebugFileResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use DebugFileResponse.ProtoReflect.Descriptor instead.
-func (*DebugFileResponse) Descriptor() ([]byte, []int) {
+// Deprecated: Use TxPoolInspectResponse.ProtoReflect.Descriptor instead.
+func (*TxPoolInspectResponse) Descriptor() ([]byte, []int) {
 	return file_internal_cli_server_proto_server_proto_rawDescGZIP(), []int{21}
 }
 
-func (m *DebugFileResponse) GetEvent() isDebugFileResponse_Event {
-	if m != nil {
-		return m.Event
+func (x *TxPoolInspectResponse) GetPending() uint64 {
+	if x != nil {
+		return x.Pending
 	}
 
-	return nil
+	return 0
 }
 
-func (x *DebugFileResponse) GetOpen() *DebugFileResponse_Open {
-	if x, ok := x.GetEvent().(*DebugFileResponse_Open_); ok {
-		return x.Open
+func (x *TxPoolInspectResponse) GetQueued() uint64 {
+	if x != nil {
+		return x.Queued
 	}
 
-	return nil
+	return 0
 }
 
-func (x *DebugFileResponse) GetInput() *DebugFileResponse_Input {
-	if x, ok := x.GetEvent().(*DebugFileResponse_Input_); ok {
-		return x.Input
+func (x *TxPoolInspectResponse) GetTransactions() []*TxPoolInspectResponse_Transaction {
+	if x != nil {
+		return x.Transactions
 	}
 
 	return nil
 }
 
-func (x *DebugFileResponse) GetEof() *emptypb.Empty {
-	if x, ok := x.GetEvent().(*DebugFileResponse_Eof); ok {
-		return x.Eof
-	}
-
-	return nil
+type TxPoolClearRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
 }
 
-type isDebugFileResponse_Event interface {
-	isDebugFileResponse_Event()
-}
+func (x *TxPoolClearRequest) Reset() {
+	*x = TxPoolClearRequest{}
 
-type DebugFileResponse_Open_ struct {
-	Open *DebugFileResponse_Open `protobuf:"bytes,1,opt,name=open,proto3,oneof"`
+	if protoimpl.UnsafeEnabled {
+		mi := &file_internal_cli_server_proto_server_proto_msgTypes[22]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
 }
 
-type DebugFileResponse_Input_ struct {
-	Input *DebugFileResponse_Input `protobuf:"bytes,2,opt,name=input,proto3,oneof"`
+func (x *TxPoolClearRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
 }
 
-type DebugFileResponse_Eof struct {
-	Eof *emptypb.Empty `protobuf:"bytes,3,opt,name=eof,proto3,oneof"`
-}
+func (*TxPoolClearRequest) ProtoMessage() {}
 
-func (*DebugFileResponse_Open_) isDebugFileResponse_Event() {}
+func (x *TxPoolClearRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_cli_server_proto_server_proto_msgTypes[22]
 
-func (*DebugFileResponse_Input_) isDebugFileResponse_Event() {}
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
 
-func (*DebugFileResponse_Eof) isDebugFileResponse_Event() {}
+		return ms
+	}
 
-type StatusResponse_Fork struct {
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TxPoolClearRequest.ProtoReflect.Descriptor instead.
+func (*TxPoolClearRequest) Descriptor() ([]byte, []int) {
+	return file_internal_cli_server_proto_server_proto_rawDescGZIP(), []int{22}
+}
+
+type TxPoolClearResponse struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Name     string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
-	Block    int64  `protobuf:"varint,2,opt,name=block,proto3" json:"block,omitempty"`
-	Disabled bool   `protobuf:"varint,3,opt,name=disabled,proto3" json:"disabled,omitempty"`
+	Dropped uint64 `protobuf:"varint,1,opt,name=dropped,proto3" json:"dropped,omitempty"`
 }
 
-func (x *StatusResponse_Fork) Reset() {
-	*x = StatusResponse_Fork{}
+func (x *TxPoolClearResponse) Reset() {
+	*x = TxPoolClearResponse{}
 
 	if protoimpl.UnsafeEnabled {
-		mi := &file_internal_cli_server_proto_server_proto_msgTypes[22]
+		mi := &file_internal_cli_server_proto_server_proto_msgTypes[23]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *StatusResponse_Fork) String() string {
+func (x *TxPoolClearResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*StatusResponse_Fork) ProtoMessage() {}
+func (*TxPoolClearResponse) ProtoMessage() {}
 
-func (x *StatusResponse_Fork) ProtoReflect() protoreflect.Message {
-	mi := &file_internal_cli_server_proto_server_proto_msgTypes[22]
+func (x *TxPoolClearResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_cli_server_proto_server_proto_msgTypes[23]
 
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -1418,63 +1486,1587 @@ func (x *StatusResponse_Fork) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use StatusResponse_Fork.ProtoReflect.Descriptor instead.
-func (*StatusResponse_Fork) Descriptor() ([]byte, []int) {
-	return file_internal_cli_server_proto_server_proto_rawDescGZIP(), []int{17, 0}
+// Deprecated: Use TxPoolClearResponse.ProtoReflect.Descriptor instead.
+func (*TxPoolClearResponse) Descriptor() ([]byte, []int) {
+	return file_internal_cli_server_proto_server_proto_rawDescGZIP(), []int{23}
 }
 
-func (x *StatusResponse_Fork) GetName() string {
+func (x *TxPoolClearResponse) GetDropped() uint64 {
 	if x != nil {
-		return x.Name
+		return x.Dropped
 	}
 
-	return ""
+	return 0
 }
 
-func (x *StatusResponse_Fork) GetBlock() int64 {
-	if x != nil {
-		return x.Block
+type TxPoolResetNonceRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Address string `protobuf:"bytes,1,opt,name=address,proto3" json:"address,omitempty"`
+}
+
+func (x *TxPoolResetNonceRequest) Reset() {
+	*x = TxPoolResetNonceRequest{}
+
+	if protoimpl.UnsafeEnabled {
+		mi := &file_internal_cli_server_proto_server_proto_msgTypes[24]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *TxPoolResetNonceRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TxPoolResetNonceRequest) ProtoMessage() {}
+
+func (x *TxPoolResetNonceRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_cli_server_proto_server_proto_msgTypes[24]
+
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+
+		return ms
+	}
+
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TxPoolResetNonceRequest.ProtoReflect.Descriptor instead.
+func (*TxPoolResetNonceRequest) Descriptor() ([]byte, []int) {
+	return file_internal_cli_server_proto_server_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *TxPoolResetNonceRequest) GetAddress() string {
+	if x != nil {
+		return x.Address
+	}
+
+	return ""
+}
+
+type TxPoolResetNonceResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Dropped uint64 `protobuf:"varint,1,opt,name=dropped,proto3" json:"dropped,omitempty"`
+	Nonce   uint64 `protobuf:"varint,2,opt,name=nonce,proto3" json:"nonce,omitempty"`
+}
+
+func (x *TxPoolResetNonceResponse) Reset() {
+	*x = TxPoolResetNonceResponse{}
+
+	if protoimpl.UnsafeEnabled {
+		mi := &file_internal_cli_server_proto_server_proto_msgTypes[25]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *TxPoolResetNonceResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TxPoolResetNonceResponse) ProtoMessage() {}
+
+func (x *TxPoolResetNonceResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_cli_server_proto_server_proto_msgTypes[25]
+
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+
+		return ms
+	}
+
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TxPoolResetNonceResponse.ProtoReflect.Descriptor instead.
+func (*TxPoolResetNonceResponse) Descriptor() ([]byte, []int) {
+	return file_internal_cli_server_proto_server_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *TxPoolResetNonceResponse) GetDropped() uint64 {
+	if x != nil {
+		return x.Dropped
+	}
+
+	return 0
+}
+
+func (x *TxPoolResetNonceResponse) GetNonce() uint64 {
+	if x != nil {
+		return x.Nonce
+	}
+
+	return 0
+}
+
+type DebugTraceTransactionRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Hash         string `protobuf:"bytes,1,opt,name=hash,proto3" json:"hash,omitempty"`
+	Tracer       string `protobuf:"bytes,2,opt,name=tracer,proto3" json:"tracer,omitempty"`
+	TracerConfig string `protobuf:"bytes,3,opt,name=tracerConfig,proto3" json:"tracerConfig,omitempty"`
+}
+
+func (x *DebugTraceTransactionRequest) Reset() {
+	*x = DebugTraceTransactionRequest{}
+
+	if protoimpl.UnsafeEnabled {
+		mi := &file_internal_cli_server_proto_server_proto_msgTypes[26]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *DebugTraceTransactionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DebugTraceTransactionRequest) ProtoMessage() {}
+
+func (x *DebugTraceTransactionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_cli_server_proto_server_proto_msgTypes[26]
+
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+
+		return ms
+	}
+
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DebugTraceTransactionRequest.ProtoReflect.Descriptor instead.
+func (*DebugTraceTransactionRequest) Descriptor() ([]byte, []int) {
+	return file_internal_cli_server_proto_server_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *DebugTraceTransactionRequest) GetHash() string {
+	if x != nil {
+		return x.Hash
+	}
+
+	return ""
+}
+
+func (x *DebugTraceTransactionRequest) GetTracer() string {
+	if x != nil {
+		return x.Tracer
+	}
+
+	return ""
+}
+
+func (x *DebugTraceTransactionRequest) GetTracerConfig() string {
+	if x != nil {
+		return x.TracerConfig
+	}
+
+	return ""
+}
+
+type DebugDumpStateRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Number      *int64 `protobuf:"varint,1,opt,name=number,proto3,oneof" json:"number,omitempty"`
+	SkipCode    bool   `protobuf:"varint,2,opt,name=skipCode,proto3" json:"skipCode,omitempty"`
+	SkipStorage bool   `protobuf:"varint,3,opt,name=skipStorage,proto3" json:"skipStorage,omitempty"`
+	Max         uint64 `protobuf:"varint,4,opt,name=max,proto3" json:"max,omitempty"`
+}
+
+func (x *DebugDumpStateRequest) Reset() {
+	*x = DebugDumpStateRequest{}
+
+	if protoimpl.UnsafeEnabled {
+		mi := &file_internal_cli_server_proto_server_proto_msgTypes[27]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *DebugDumpStateRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DebugDumpStateRequest) ProtoMessage() {}
+
+func (x *DebugDumpStateRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_cli_server_proto_server_proto_msgTypes[27]
+
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+
+		return ms
+	}
+
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DebugDumpStateRequest.ProtoReflect.Descriptor instead.
+func (*DebugDumpStateRequest) Descriptor() ([]byte, []int) {
+	return file_internal_cli_server_proto_server_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *DebugDumpStateRequest) GetNumber() int64 {
+	if x != nil && x.Number != nil {
+		return *x.Number
+	}
+
+	return 0
+}
+
+func (x *DebugDumpStateRequest) GetSkipCode() bool {
+	if x != nil {
+		return x.SkipCode
+	}
+
+	return false
+}
+
+func (x *DebugDumpStateRequest) GetSkipStorage() bool {
+	if x != nil {
+		return x.SkipStorage
+	}
+
+	return false
+}
+
+func (x *DebugDumpStateRequest) GetMax() uint64 {
+	if x != nil {
+		return x.Max
+	}
+
+	return 0
+}
+
+type DebugDumpStateResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Address  string            `protobuf:"bytes,1,opt,name=address,proto3" json:"address,omitempty"`
+	Balance  string            `protobuf:"bytes,2,opt,name=balance,proto3" json:"balance,omitempty"`
+	Nonce    uint64            `protobuf:"varint,3,opt,name=nonce,proto3" json:"nonce,omitempty"`
+	Root     string            `protobuf:"bytes,4,opt,name=root,proto3" json:"root,omitempty"`
+	CodeHash string            `protobuf:"bytes,5,opt,name=codeHash,proto3" json:"codeHash,omitempty"`
+	Code     []byte            `protobuf:"bytes,6,opt,name=code,proto3" json:"code,omitempty"`
+	Storage  map[string]string `protobuf:"bytes,7,rep,name=storage,proto3" json:"storage,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	Key      string            `protobuf:"bytes,8,opt,name=key,proto3" json:"key,omitempty"`
+}
+
+func (x *DebugDumpStateResponse) Reset() {
+	*x = DebugDumpStateResponse{}
+
+	if protoimpl.UnsafeEnabled {
+		mi := &file_internal_cli_server_proto_server_proto_msgTypes[28]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *DebugDumpStateResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DebugDumpStateResponse) ProtoMessage() {}
+
+func (x *DebugDumpStateResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_cli_server_proto_server_proto_msgTypes[28]
+
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+
+		return ms
+	}
+
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DebugDumpStateResponse.ProtoReflect.Descriptor instead.
+func (*DebugDumpStateResponse) Descriptor() ([]byte, []int) {
+	return file_internal_cli_server_proto_server_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *DebugDumpStateResponse) GetAddress() string {
+	if x != nil {
+		return x.Address
+	}
+
+	return ""
+}
+
+func (x *DebugDumpStateResponse) GetBalance() string {
+	if x != nil {
+		return x.Balance
+	}
+
+	return ""
+}
+
+func (x *DebugDumpStateResponse) GetNonce() uint64 {
+	if x != nil {
+		return x.Nonce
+	}
+
+	return 0
+}
+
+func (x *DebugDumpStateResponse) GetRoot() string {
+	if x != nil {
+		return x.Root
+	}
+
+	return ""
+}
+
+func (x *DebugDumpStateResponse) GetCodeHash() string {
+	if x != nil {
+		return x.CodeHash
+	}
+
+	return ""
+}
+
+func (x *DebugDumpStateResponse) GetCode() []byte {
+	if x != nil {
+		return x.Code
+	}
+
+	return nil
+}
+
+func (x *DebugDumpStateResponse) GetStorage() map[string]string {
+	if x != nil {
+		return x.Storage
+	}
+
+	return nil
+}
+
+func (x *DebugDumpStateResponse) GetKey() string {
+	if x != nil {
+		return x.Key
+	}
+
+	return ""
+}
+
+type DebugDatabaseStatsRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *DebugDatabaseStatsRequest) Reset() {
+	*x = DebugDatabaseStatsRequest{}
+
+	if protoimpl.UnsafeEnabled {
+		mi := &file_internal_cli_server_proto_server_proto_msgTypes[29]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *DebugDatabaseStatsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DebugDatabaseStatsRequest) ProtoMessage() {}
+
+func (x *DebugDatabaseStatsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_cli_server_proto_server_proto_msgTypes[29]
+
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+
+		return ms
+	}
+
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DebugDatabaseStatsRequest.ProtoReflect.Descriptor instead.
+func (*DebugDatabaseStatsRequest) Descriptor() ([]byte, []int) {
+	return file_internal_cli_server_proto_server_proto_rawDescGZIP(), []int{29}
+}
+
+type DebugDatabaseStatsResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Ancients    uint64                                     `protobuf:"varint,1,opt,name=ancients,proto3" json:"ancients,omitempty"`
+	Tail        uint64                                     `protobuf:"varint,2,opt,name=tail,proto3" json:"tail,omitempty"`
+	Tables      []*DebugDatabaseStatsResponse_FreezerTable `protobuf:"bytes,3,rep,name=tables,proto3" json:"tables,omitempty"`
+	EngineStats string                                     `protobuf:"bytes,4,opt,name=engineStats,proto3" json:"engineStats,omitempty"`
+}
+
+func (x *DebugDatabaseStatsResponse) Reset() {
+	*x = DebugDatabaseStatsResponse{}
+
+	if protoimpl.UnsafeEnabled {
+		mi := &file_internal_cli_server_proto_server_proto_msgTypes[30]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *DebugDatabaseStatsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DebugDatabaseStatsResponse) ProtoMessage() {}
+
+func (x *DebugDatabaseStatsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_cli_server_proto_server_proto_msgTypes[30]
+
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+
+		return ms
+	}
+
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DebugDatabaseStatsResponse.ProtoReflect.Descriptor instead.
+func (*DebugDatabaseStatsResponse) Descriptor() ([]byte, []int) {
+	return file_internal_cli_server_proto_server_proto_rawDescGZIP(), []int{30}
+}
+
+func (x *DebugDatabaseStatsResponse) GetAncients() uint64 {
+	if x != nil {
+		return x.Ancients
+	}
+
+	return 0
+}
+
+func (x *DebugDatabaseStatsResponse) GetTail() uint64 {
+	if x != nil {
+		return x.Tail
+	}
+
+	return 0
+}
+
+func (x *DebugDatabaseStatsResponse) GetTables() []*DebugDatabaseStatsResponse_FreezerTable {
+	if x != nil {
+		return x.Tables
+	}
+
+	return nil
+}
+
+func (x *DebugDatabaseStatsResponse) GetEngineStats() string {
+	if x != nil {
+		return x.EngineStats
+	}
+
+	return ""
+}
+
+type StatusRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Wait bool `protobuf:"varint,1,opt,name=Wait,proto3" json:"Wait,omitempty"`
+}
+
+func (x *StatusRequest) Reset() {
+	*x = StatusRequest{}
+
+	if protoimpl.UnsafeEnabled {
+		mi := &file_internal_cli_server_proto_server_proto_msgTypes[31]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *StatusRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StatusRequest) ProtoMessage() {}
+
+func (x *StatusRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_cli_server_proto_server_proto_msgTypes[31]
+
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+
+		return ms
+	}
+
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StatusRequest.ProtoReflect.Descriptor instead.
+func (*StatusRequest) Descriptor() ([]byte, []int) {
+	return file_internal_cli_server_proto_server_proto_rawDescGZIP(), []int{31}
+}
+
+func (x *StatusRequest) GetWait() bool {
+	if x != nil {
+		return x.Wait
+	}
+
+	return false
+}
+
+type StatusResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	CurrentBlock  *Header                            `protobuf:"bytes,1,opt,name=currentBlock,proto3" json:"currentBlock,omitempty"`
+	CurrentHeader *Header                            `protobuf:"bytes,2,opt,name=currentHeader,proto3" json:"currentHeader,omitempty"`
+	NumPeers      int64                              `protobuf:"varint,3,opt,name=numPeers,proto3" json:"numPeers,omitempty"`
+	SyncMode      string                             `protobuf:"bytes,4,opt,name=syncMode,proto3" json:"syncMode,omitempty"`
+	Syncing       *StatusResponse_Syncing            `protobuf:"bytes,5,opt,name=syncing,proto3" json:"syncing,omitempty"`
+	Forks         []*StatusResponse_Fork             `protobuf:"bytes,6,rep,name=forks,proto3" json:"forks,omitempty"`
+	Heimdall      []*StatusResponse_HeimdallEndpoint `protobuf:"bytes,7,rep,name=heimdall,proto3" json:"heimdall,omitempty"`
+	Span          *StatusResponse_Span               `protobuf:"bytes,8,opt,name=span,proto3" json:"span,omitempty"`
+	Validator     *StatusResponse_Validator          `protobuf:"bytes,9,opt,name=validator,proto3" json:"validator,omitempty"`
+	Milestone     *StatusResponse_Finality           `protobuf:"bytes,10,opt,name=milestone,proto3" json:"milestone,omitempty"`
+	Checkpoint    *StatusResponse_Finality           `protobuf:"bytes,11,opt,name=checkpoint,proto3" json:"checkpoint,omitempty"`
+	MilestoneLock *StatusResponse_MilestoneLock      `protobuf:"bytes,12,opt,name=milestoneLock,proto3" json:"milestoneLock,omitempty"`
+	StateSync     *StatusResponse_StateSync          `protobuf:"bytes,13,opt,name=stateSync,proto3" json:"stateSync,omitempty"`
+}
+
+func (x *StatusResponse) Reset() {
+	*x = StatusResponse{}
+
+	if protoimpl.UnsafeEnabled {
+		mi := &file_internal_cli_server_proto_server_proto_msgTypes[32]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *StatusResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StatusResponse) ProtoMessage() {}
+
+func (x *StatusResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_cli_server_proto_server_proto_msgTypes[32]
+
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+
+		return ms
+	}
+
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StatusResponse.ProtoReflect.Descriptor instead.
+func (*StatusResponse) Descriptor() ([]byte, []int) {
+	return file_internal_cli_server_proto_server_proto_rawDescGZIP(), []int{32}
+}
+
+func (x *StatusResponse) GetCurrentBlock() *Header {
+	if x != nil {
+		return x.CurrentBlock
+	}
+
+	return nil
+}
+
+func (x *StatusResponse) GetCurrentHeader() *Header {
+	if x != nil {
+		return x.CurrentHeader
+	}
+
+	return nil
+}
+
+func (x *StatusResponse) GetNumPeers() int64 {
+	if x != nil {
+		return x.NumPeers
+	}
+
+	return 0
+}
+
+func (x *StatusResponse) GetSyncMode() string {
+	if x != nil {
+		return x.SyncMode
+	}
+
+	return ""
+}
+
+func (x *StatusResponse) GetSyncing() *StatusResponse_Syncing {
+	if x != nil {
+		return x.Syncing
+	}
+
+	return nil
+}
+
+func (x *StatusResponse) GetForks() []*StatusResponse_Fork {
+	if x != nil {
+		return x.Forks
+	}
+
+	return nil
+}
+
+func (x *StatusResponse) GetHeimdall() []*StatusResponse_HeimdallEndpoint {
+	if x != nil {
+		return x.Heimdall
+	}
+
+	return nil
+}
+
+func (x *StatusResponse) GetSpan() *StatusResponse_Span {
+	if x != nil {
+		return x.Span
+	}
+
+	return nil
+}
+
+func (x *StatusResponse) GetValidator() *StatusResponse_Validator {
+	if x != nil {
+		return x.Validator
+	}
+
+	return nil
+}
+
+func (x *StatusResponse) GetMilestone() *StatusResponse_Finality {
+	if x != nil {
+		return x.Milestone
+	}
+
+	return nil
+}
+
+func (x *StatusResponse) GetCheckpoint() *StatusResponse_Finality {
+	if x != nil {
+		return x.Checkpoint
+	}
+
+	return nil
+}
+
+func (x *StatusResponse) GetMilestoneLock() *StatusResponse_MilestoneLock {
+	if x != nil {
+		return x.MilestoneLock
+	}
+
+	return nil
+}
+
+func (x *StatusResponse) GetStateSync() *StatusResponse_StateSync {
+	if x != nil {
+		return x.StateSync
+	}
+
+	return nil
+}
+
+type Header struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Hash   string `protobuf:"bytes,1,opt,name=hash,proto3" json:"hash,omitempty"`
+	Number uint64 `protobuf:"varint,2,opt,name=number,proto3" json:"number,omitempty"`
+}
+
+func (x *Header) Reset() {
+	*x = Header{}
+
+	if protoimpl.UnsafeEnabled {
+		mi := &file_internal_cli_server_proto_server_proto_msgTypes[33]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Header) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Header) ProtoMessage() {}
+
+func (x *Header) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_cli_server_proto_server_proto_msgTypes[33]
+
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+
+		return ms
+	}
+
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Header.ProtoReflect.Descriptor instead.
+func (*Header) Descriptor() ([]byte, []int) {
+	return file_internal_cli_server_proto_server_proto_rawDescGZIP(), []int{33}
+}
+
+func (x *Header) GetHash() string {
+	if x != nil {
+		return x.Hash
+	}
+
+	return ""
+}
+
+func (x *Header) GetNumber() uint64 {
+	if x != nil {
+		return x.Number
+	}
+
+	return 0
+}
+
+type DebugPprofRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Type    DebugPprofRequest_Type `protobuf:"varint,1,opt,name=type,proto3,enum=proto.DebugPprofRequest_Type" json:"type,omitempty"`
+	Profile string                 `protobuf:"bytes,2,opt,name=profile,proto3" json:"profile,omitempty"`
+	Seconds int64                  `protobuf:"varint,3,opt,name=seconds,proto3" json:"seconds,omitempty"`
+}
+
+func (x *DebugPprofRequest) Reset() {
+	*x = DebugPprofRequest{}
+
+	if protoimpl.UnsafeEnabled {
+		mi := &file_internal_cli_server_proto_server_proto_msgTypes[34]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *DebugPprofRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DebugPprofRequest) ProtoMessage() {}
+
+func (x *DebugPprofRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_cli_server_proto_server_proto_msgTypes[34]
+
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+
+		return ms
+	}
+
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DebugPprofRequest.ProtoReflect.Descriptor instead.
+func (*DebugPprofRequest) Descriptor() ([]byte, []int) {
+	return file_internal_cli_server_proto_server_proto_rawDescGZIP(), []int{34}
+}
+
+func (x *DebugPprofRequest) GetType() DebugPprofRequest_Type {
+	if x != nil {
+		return x.Type
+	}
+
+	return DebugPprofRequest_LOOKUP
+}
+
+func (x *DebugPprofRequest) GetProfile() string {
+	if x != nil {
+		return x.Profile
+	}
+
+	return ""
+}
+
+func (x *DebugPprofRequest) GetSeconds() int64 {
+	if x != nil {
+		return x.Seconds
+	}
+
+	return 0
+}
+
+type DebugBlockRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Number int64 `protobuf:"varint,1,opt,name=number,proto3" json:"number,omitempty"`
+}
+
+func (x *DebugBlockRequest) Reset() {
+	*x = DebugBlockRequest{}
+
+	if protoimpl.UnsafeEnabled {
+		mi := &file_internal_cli_server_proto_server_proto_msgTypes[35]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *DebugBlockRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DebugBlockRequest) ProtoMessage() {}
+
+func (x *DebugBlockRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_cli_server_proto_server_proto_msgTypes[35]
+
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+
+		return ms
+	}
+
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DebugBlockRequest.ProtoReflect.Descriptor instead.
+func (*DebugBlockRequest) Descriptor() ([]byte, []int) {
+	return file_internal_cli_server_proto_server_proto_rawDescGZIP(), []int{35}
+}
+
+func (x *DebugBlockRequest) GetNumber() int64 {
+	if x != nil {
+		return x.Number
+	}
+
+	return 0
+}
+
+type DebugFileResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Types that are assignable to Event:
+	//
+	//	*DebugFileResponse_Open_
+	//	*DebugFileResponse_Input_
+	//	*DebugFileResponse_Eof
+	Event isDebugFileResponse_Event `protobuf_oneof:"event"`
+}
+
+func (x *DebugFileResponse) Reset() {
+	*x = DebugFileResponse{}
+
+	if protoimpl.UnsafeEnabled {
+		mi := &file_internal_cli_server_proto_server_proto_msgTypes[36]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *DebugFileResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DebugFileResponse) ProtoMessage() {}
+
+func (x *DebugFileResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_cli_server_proto_server_proto_msgTypes[36]
+
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+
+		return ms
+	}
+
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DebugFileResponse.ProtoReflect.Descriptor instead.
+func (*DebugFileResponse) Descriptor() ([]byte, []int) {
+	return file_internal_cli_server_proto_server_proto_rawDescGZIP(), []int{36}
+}
+
+func (m *DebugFileResponse) GetEvent() isDebugFileResponse_Event {
+	if m != nil {
+		return m.Event
+	}
+
+	return nil
+}
+
+func (x *DebugFileResponse) GetOpen() *DebugFileResponse_Open {
+	if x, ok := x.GetEvent().(*DebugFileResponse_Open_); ok {
+		return x.Open
+	}
+
+	return nil
+}
+
+func (x *DebugFileResponse) GetInput() *DebugFileResponse_Input {
+	if x, ok := x.GetEvent().(*DebugFileResponse_Input_); ok {
+		return x.Input
+	}
+
+	return nil
+}
+
+func (x *DebugFileResponse) GetEof() *emptypb.Empty {
+	if x, ok := x.GetEvent().(*DebugFileResponse_Eof); ok {
+		return x.Eof
+	}
+
+	return nil
+}
+
+type isDebugFileResponse_Event interface {
+	isDebugFileResponse_Event()
+}
+
+type DebugFileResponse_Open_ struct {
+	Open *DebugFileResponse_Open `protobuf:"bytes,1,opt,name=open,proto3,oneof"`
+}
+
+type DebugFileResponse_Input_ struct {
+	Input *DebugFileResponse_Input `protobuf:"bytes,2,opt,name=input,proto3,oneof"`
+}
+
+type DebugFileResponse_Eof struct {
+	Eof *emptypb.Empty `protobuf:"bytes,3,opt,name=eof,proto3,oneof"`
+}
+
+func (*DebugFileResponse_Open_) isDebugFileResponse_Event() {}
+
+func (*DebugFileResponse_Input_) isDebugFileResponse_Event() {}
+
+func (*DebugFileResponse_Eof) isDebugFileResponse_Event() {}
+
+type TxPoolInspectResponse_Transaction struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Hash      string `protobuf:"bytes,1,opt,name=hash,proto3" json:"hash,omitempty"`
+	From      string `protobuf:"bytes,2,opt,name=from,proto3" json:"from,omitempty"`
+	To        string `protobuf:"bytes,3,opt,name=to,proto3" json:"to,omitempty"`
+	Nonce     uint64 `protobuf:"varint,4,opt,name=nonce,proto3" json:"nonce,omitempty"`
+	Value     string `protobuf:"bytes,5,opt,name=value,proto3" json:"value,omitempty"`
+	Gas       uint64 `protobuf:"varint,6,opt,name=gas,proto3" json:"gas,omitempty"`
+	GasFeeCap string `protobuf:"bytes,7,opt,name=gasFeeCap,proto3" json:"gasFeeCap,omitempty"`
+	GasTipCap string `protobuf:"bytes,8,opt,name=gasTipCap,proto3" json:"gasTipCap,omitempty"`
+	Pending   bool   `protobuf:"varint,9,opt,name=pending,proto3" json:"pending,omitempty"`
+}
+
+func (x *TxPoolInspectResponse_Transaction) Reset() {
+	*x = TxPoolInspectResponse_Transaction{}
+
+	if protoimpl.UnsafeEnabled {
+		mi := &file_internal_cli_server_proto_server_proto_msgTypes[37]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *TxPoolInspectResponse_Transaction) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TxPoolInspectResponse_Transaction) ProtoMessage() {}
+
+func (x *TxPoolInspectResponse_Transaction) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_cli_server_proto_server_proto_msgTypes[37]
+
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+
+		return ms
+	}
+
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TxPoolInspectResponse_Transaction.ProtoReflect.Descriptor instead.
+func (*TxPoolInspectResponse_Transaction) Descriptor() ([]byte, []int) {
+	return file_internal_cli_server_proto_server_proto_rawDescGZIP(), []int{21, 0}
+}
+
+func (x *TxPoolInspectResponse_Transaction) GetHash() string {
+	if x != nil {
+		return x.Hash
+	}
+
+	return ""
+}
+
+func (x *TxPoolInspectResponse_Transaction) GetFrom() string {
+	if x != nil {
+		return x.From
+	}
+
+	return ""
+}
+
+func (x *TxPoolInspectResponse_Transaction) GetTo() string {
+	if x != nil {
+		return x.To
+	}
+
+	return ""
+}
+
+func (x *TxPoolInspectResponse_Transaction) GetNonce() uint64 {
+	if x != nil {
+		return x.Nonce
+	}
+
+	return 0
+}
+
+func (x *TxPoolInspectResponse_Transaction) GetValue() string {
+	if x != nil {
+		return x.Value
+	}
+
+	return ""
+}
+
+func (x *TxPoolInspectResponse_Transaction) GetGas() uint64 {
+	if x != nil {
+		return x.Gas
+	}
+
+	return 0
+}
+
+func (x *TxPoolInspectResponse_Transaction) GetGasFeeCap() string {
+	if x != nil {
+		return x.GasFeeCap
+	}
+
+	return ""
+}
+
+func (x *TxPoolInspectResponse_Transaction) GetGasTipCap() string {
+	if x != nil {
+		return x.GasTipCap
+	}
+
+	return ""
+}
+
+func (x *TxPoolInspectResponse_Transaction) GetPending() bool {
+	if x != nil {
+		return x.Pending
+	}
+
+	return false
+}
+
+type DebugDatabaseStatsResponse_FreezerTable struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Size uint64 `protobuf:"varint,2,opt,name=size,proto3" json:"size,omitempty"`
+}
+
+func (x *DebugDatabaseStatsResponse_FreezerTable) Reset() {
+	*x = DebugDatabaseStatsResponse_FreezerTable{}
+
+	if protoimpl.UnsafeEnabled {
+		mi := &file_internal_cli_server_proto_server_proto_msgTypes[39]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *DebugDatabaseStatsResponse_FreezerTable) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DebugDatabaseStatsResponse_FreezerTable) ProtoMessage() {}
+
+func (x *DebugDatabaseStatsResponse_FreezerTable) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_cli_server_proto_server_proto_msgTypes[39]
+
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+
+		return ms
+	}
+
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DebugDatabaseStatsResponse_FreezerTable.ProtoReflect.Descriptor instead.
+func (*DebugDatabaseStatsResponse_FreezerTable) Descriptor() ([]byte, []int) {
+	return file_internal_cli_server_proto_server_proto_rawDescGZIP(), []int{30, 0}
+}
+
+func (x *DebugDatabaseStatsResponse_FreezerTable) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+
+	return ""
+}
+
+func (x *DebugDatabaseStatsResponse_FreezerTable) GetSize() uint64 {
+	if x != nil {
+		return x.Size
+	}
+
+	return 0
+}
+
+type StatusResponse_Fork struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Name     string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Block    int64  `protobuf:"varint,2,opt,name=block,proto3" json:"block,omitempty"`
+	Disabled bool   `protobuf:"varint,3,opt,name=disabled,proto3" json:"disabled,omitempty"`
+}
+
+func (x *StatusResponse_Fork) Reset() {
+	*x = StatusResponse_Fork{}
+
+	if protoimpl.UnsafeEnabled {
+		mi := &file_internal_cli_server_proto_server_proto_msgTypes[40]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *StatusResponse_Fork) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StatusResponse_Fork) ProtoMessage() {}
+
+func (x *StatusResponse_Fork) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_cli_server_proto_server_proto_msgTypes[40]
+
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+
+		return ms
+	}
+
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StatusResponse_Fork.ProtoReflect.Descriptor instead.
+func (*StatusResponse_Fork) Descriptor() ([]byte, []int) {
+	return file_internal_cli_server_proto_server_proto_rawDescGZIP(), []int{32, 0}
+}
+
+func (x *StatusResponse_Fork) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+
+	return ""
+}
+
+func (x *StatusResponse_Fork) GetBlock() int64 {
+	if x != nil {
+		return x.Block
+	}
+
+	return 0
+}
+
+func (x *StatusResponse_Fork) GetDisabled() bool {
+	if x != nil {
+		return x.Disabled
+	}
+
+	return false
+}
+
+type StatusResponse_Syncing struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	StartingBlock int64 `protobuf:"varint,1,opt,name=startingBlock,proto3" json:"startingBlock,omitempty"`
+	HighestBlock  int64 `protobuf:"varint,2,opt,name=highestBlock,proto3" json:"highestBlock,omitempty"`
+	CurrentBlock  int64 `protobuf:"varint,3,opt,name=currentBlock,proto3" json:"currentBlock,omitempty"`
+}
+
+func (x *StatusResponse_Syncing) Reset() {
+	*x = StatusResponse_Syncing{}
+
+	if protoimpl.UnsafeEnabled {
+		mi := &file_internal_cli_server_proto_server_proto_msgTypes[41]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *StatusResponse_Syncing) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StatusResponse_Syncing) ProtoMessage() {}
+
+func (x *StatusResponse_Syncing) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_cli_server_proto_server_proto_msgTypes[41]
+
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+
+		return ms
+	}
+
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StatusResponse_Syncing.ProtoReflect.Descriptor instead.
+func (*StatusResponse_Syncing) Descriptor() ([]byte, []int) {
+	return file_internal_cli_server_proto_server_proto_rawDescGZIP(), []int{32, 1}
+}
+
+func (x *StatusResponse_Syncing) GetStartingBlock() int64 {
+	if x != nil {
+		return x.StartingBlock
+	}
+
+	return 0
+}
+
+func (x *StatusResponse_Syncing) GetHighestBlock() int64 {
+	if x != nil {
+		return x.HighestBlock
+	}
+
+	return 0
+}
+
+func (x *StatusResponse_Syncing) GetCurrentBlock() int64 {
+	if x != nil {
+		return x.CurrentBlock
+	}
+
+	return 0
+}
+
+type StatusResponse_HeimdallEndpoint struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Url       string `protobuf:"bytes,1,opt,name=url,proto3" json:"url,omitempty"`
+	Reachable bool   `protobuf:"varint,2,opt,name=reachable,proto3" json:"reachable,omitempty"`
+	LatencyMs int64  `protobuf:"varint,3,opt,name=latencyMs,proto3" json:"latencyMs,omitempty"`
+	Error     string `protobuf:"bytes,4,opt,name=error,proto3" json:"error,omitempty"`
+}
+
+func (x *StatusResponse_HeimdallEndpoint) Reset() {
+	*x = StatusResponse_HeimdallEndpoint{}
+
+	if protoimpl.UnsafeEnabled {
+		mi := &file_internal_cli_server_proto_server_proto_msgTypes[42]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *StatusResponse_HeimdallEndpoint) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StatusResponse_HeimdallEndpoint) ProtoMessage() {}
+
+func (x *StatusResponse_HeimdallEndpoint) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_cli_server_proto_server_proto_msgTypes[42]
+
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+
+		return ms
+	}
+
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StatusResponse_HeimdallEndpoint.ProtoReflect.Descriptor instead.
+func (*StatusResponse_HeimdallEndpoint) Descriptor() ([]byte, []int) {
+	return file_internal_cli_server_proto_server_proto_rawDescGZIP(), []int{32, 2}
+}
+
+func (x *StatusResponse_HeimdallEndpoint) GetUrl() string {
+	if x != nil {
+		return x.Url
+	}
+
+	return ""
+}
+
+func (x *StatusResponse_HeimdallEndpoint) GetReachable() bool {
+	if x != nil {
+		return x.Reachable
+	}
+
+	return false
+}
+
+func (x *StatusResponse_HeimdallEndpoint) GetLatencyMs() int64 {
+	if x != nil {
+		return x.LatencyMs
 	}
 
 	return 0
 }
 
-func (x *StatusResponse_Fork) GetDisabled() bool {
+func (x *StatusResponse_HeimdallEndpoint) GetError() string {
 	if x != nil {
-		return x.Disabled
+		return x.Error
+	}
+
+	return ""
+}
+
+type StatusResponse_Span struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id         uint64 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	StartBlock uint64 `protobuf:"varint,2,opt,name=startBlock,proto3" json:"startBlock,omitempty"`
+	EndBlock   uint64 `protobuf:"varint,3,opt,name=endBlock,proto3" json:"endBlock,omitempty"`
+}
+
+func (x *StatusResponse_Span) Reset() {
+	*x = StatusResponse_Span{}
+
+	if protoimpl.UnsafeEnabled {
+		mi := &file_internal_cli_server_proto_server_proto_msgTypes[43]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *StatusResponse_Span) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StatusResponse_Span) ProtoMessage() {}
+
+func (x *StatusResponse_Span) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_cli_server_proto_server_proto_msgTypes[43]
+
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+
+		return ms
+	}
+
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StatusResponse_Span.ProtoReflect.Descriptor instead.
+func (*StatusResponse_Span) Descriptor() ([]byte, []int) {
+	return file_internal_cli_server_proto_server_proto_rawDescGZIP(), []int{32, 3}
+}
+
+func (x *StatusResponse_Span) GetId() uint64 {
+	if x != nil {
+		return x.Id
+	}
+
+	return 0
+}
+
+func (x *StatusResponse_Span) GetStartBlock() uint64 {
+	if x != nil {
+		return x.StartBlock
+	}
+
+	return 0
+}
+
+func (x *StatusResponse_Span) GetEndBlock() uint64 {
+	if x != nil {
+		return x.EndBlock
+	}
+
+	return 0
+}
+
+type StatusResponse_Validator struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Address        string `protobuf:"bytes,1,opt,name=address,proto3" json:"address,omitempty"`
+	InValidatorSet bool   `protobuf:"varint,2,opt,name=inValidatorSet,proto3" json:"inValidatorSet,omitempty"`
+	NextSlot       uint64 `protobuf:"varint,3,opt,name=nextSlot,proto3" json:"nextSlot,omitempty"`
+}
+
+func (x *StatusResponse_Validator) Reset() {
+	*x = StatusResponse_Validator{}
+
+	if protoimpl.UnsafeEnabled {
+		mi := &file_internal_cli_server_proto_server_proto_msgTypes[44]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *StatusResponse_Validator) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StatusResponse_Validator) ProtoMessage() {}
+
+func (x *StatusResponse_Validator) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_cli_server_proto_server_proto_msgTypes[44]
+
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+
+		return ms
+	}
+
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StatusResponse_Validator.ProtoReflect.Descriptor instead.
+func (*StatusResponse_Validator) Descriptor() ([]byte, []int) {
+	return file_internal_cli_server_proto_server_proto_rawDescGZIP(), []int{32, 4}
+}
+
+func (x *StatusResponse_Validator) GetAddress() string {
+	if x != nil {
+		return x.Address
+	}
+
+	return ""
+}
+
+func (x *StatusResponse_Validator) GetInValidatorSet() bool {
+	if x != nil {
+		return x.InValidatorSet
 	}
 
 	return false
 }
 
-type StatusResponse_Syncing struct {
+func (x *StatusResponse_Validator) GetNextSlot() uint64 {
+	if x != nil {
+		return x.NextSlot
+	}
+
+	return 0
+}
+
+type StatusResponse_Finality struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	StartingBlock int64 `protobuf:"varint,1,opt,name=startingBlock,proto3" json:"startingBlock,omitempty"`
-	HighestBlock  int64 `protobuf:"varint,2,opt,name=highestBlock,proto3" json:"highestBlock,omitempty"`
-	CurrentBlock  int64 `protobuf:"varint,3,opt,name=currentBlock,proto3" json:"currentBlock,omitempty"`
+	Exists bool   `protobuf:"varint,1,opt,name=exists,proto3" json:"exists,omitempty"`
+	Number uint64 `protobuf:"varint,2,opt,name=number,proto3" json:"number,omitempty"`
+	Hash   string `protobuf:"bytes,3,opt,name=hash,proto3" json:"hash,omitempty"`
 }
 
-func (x *StatusResponse_Syncing) Reset() {
-	*x = StatusResponse_Syncing{}
+func (x *StatusResponse_Finality) Reset() {
+	*x = StatusResponse_Finality{}
 
 	if protoimpl.UnsafeEnabled {
-		mi := &file_internal_cli_server_proto_server_proto_msgTypes[23]
+		mi := &file_internal_cli_server_proto_server_proto_msgTypes[45]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *StatusResponse_Syncing) String() string {
+func (x *StatusResponse_Finality) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*StatusResponse_Syncing) ProtoMessage() {}
+func (*StatusResponse_Finality) ProtoMessage() {}
 
-func (x *StatusResponse_Syncing) ProtoReflect() protoreflect.Message {
-	mi := &file_internal_cli_server_proto_server_proto_msgTypes[23]
+func (x *StatusResponse_Finality) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_cli_server_proto_server_proto_msgTypes[45]
 
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -1488,35 +3080,184 @@ func (x *StatusResponse_Syncing) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use StatusResponse_Syncing.ProtoReflect.Descriptor instead.
-func (*StatusResponse_Syncing) Descriptor() ([]byte, []int) {
-	return file_internal_cli_server_proto_server_proto_rawDescGZIP(), []int{17, 1}
+// Deprecated: Use StatusResponse_Finality.ProtoReflect.Descriptor instead.
+func (*StatusResponse_Finality) Descriptor() ([]byte, []int) {
+	return file_internal_cli_server_proto_server_proto_rawDescGZIP(), []int{32, 5}
 }
 
-func (x *StatusResponse_Syncing) GetStartingBlock() int64 {
+func (x *StatusResponse_Finality) GetExists() bool {
 	if x != nil {
-		return x.StartingBlock
+		return x.Exists
+	}
+
+	return false
+}
+
+func (x *StatusResponse_Finality) GetNumber() uint64 {
+	if x != nil {
+		return x.Number
 	}
 
 	return 0
 }
 
-func (x *StatusResponse_Syncing) GetHighestBlock() int64 {
+func (x *StatusResponse_Finality) GetHash() string {
 	if x != nil {
-		return x.HighestBlock
+		return x.Hash
+	}
+
+	return ""
+}
+
+type StatusResponse_MilestoneLock struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Locked       bool     `protobuf:"varint,1,opt,name=locked,proto3" json:"locked,omitempty"`
+	Number       uint64   `protobuf:"varint,2,opt,name=number,proto3" json:"number,omitempty"`
+	Hash         string   `protobuf:"bytes,3,opt,name=hash,proto3" json:"hash,omitempty"`
+	MilestoneIds []string `protobuf:"bytes,4,rep,name=milestoneIds,proto3" json:"milestoneIds,omitempty"`
+}
+
+func (x *StatusResponse_MilestoneLock) Reset() {
+	*x = StatusResponse_MilestoneLock{}
+
+	if protoimpl.UnsafeEnabled {
+		mi := &file_internal_cli_server_proto_server_proto_msgTypes[46]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *StatusResponse_MilestoneLock) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StatusResponse_MilestoneLock) ProtoMessage() {}
+
+func (x *StatusResponse_MilestoneLock) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_cli_server_proto_server_proto_msgTypes[46]
+
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+
+		return ms
+	}
+
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StatusResponse_MilestoneLock.ProtoReflect.Descriptor instead.
+func (*StatusResponse_MilestoneLock) Descriptor() ([]byte, []int) {
+	return file_internal_cli_server_proto_server_proto_rawDescGZIP(), []int{32, 6}
+}
+
+func (x *StatusResponse_MilestoneLock) GetLocked() bool {
+	if x != nil {
+		return x.Locked
+	}
+
+	return false
+}
+
+func (x *StatusResponse_MilestoneLock) GetNumber() uint64 {
+	if x != nil {
+		return x.Number
 	}
 
 	return 0
 }
 
-func (x *StatusResponse_Syncing) GetCurrentBlock() int64 {
+func (x *StatusResponse_MilestoneLock) GetHash() string {
 	if x != nil {
-		return x.CurrentBlock
+		return x.Hash
+	}
+
+	return ""
+}
+
+func (x *StatusResponse_MilestoneLock) GetMilestoneIds() []string {
+	if x != nil {
+		return x.MilestoneIds
+	}
+
+	return nil
+}
+
+type StatusResponse_StateSync struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	LastStateId uint64 `protobuf:"varint,1,opt,name=lastStateId,proto3" json:"lastStateId,omitempty"`
+	Pending     int64  `protobuf:"varint,2,opt,name=pending,proto3" json:"pending,omitempty"`
+	Error       string `protobuf:"bytes,3,opt,name=error,proto3" json:"error,omitempty"`
+}
+
+func (x *StatusResponse_StateSync) Reset() {
+	*x = StatusResponse_StateSync{}
+
+	if protoimpl.UnsafeEnabled {
+		mi := &file_internal_cli_server_proto_server_proto_msgTypes[47]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *StatusResponse_StateSync) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StatusResponse_StateSync) ProtoMessage() {}
+
+func (x *StatusResponse_StateSync) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_cli_server_proto_server_proto_msgTypes[47]
+
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+
+		return ms
+	}
+
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StatusResponse_StateSync.ProtoReflect.Descriptor instead.
+func (*StatusResponse_StateSync) Descriptor() ([]byte, []int) {
+	return file_internal_cli_server_proto_server_proto_rawDescGZIP(), []int{32, 7}
+}
+
+func (x *StatusResponse_StateSync) GetLastStateId() uint64 {
+	if x != nil {
+		return x.LastStateId
+	}
+
+	return 0
+}
+
+func (x *StatusResponse_StateSync) GetPending() int64 {
+	if x != nil {
+		return x.Pending
 	}
 
 	return 0
 }
 
+func (x *StatusResponse_StateSync) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+
+	return ""
+}
+
 type DebugFileResponse_Open struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -1529,7 +3270,7 @@ func (x *DebugFileResponse_Open) Reset() {
 	*x = DebugFileResponse_Open{}
 
 	if protoimpl.UnsafeEnabled {
-		mi := &file_internal_cli_server_proto_server_proto_msgTypes[24]
+		mi := &file_internal_cli_server_proto_server_proto_msgTypes[48]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1542,7 +3283,7 @@ func (x *DebugFileResponse_Open) String() string {
 func (*DebugFileResponse_Open) ProtoMessage() {}
 
 func (x *DebugFileResponse_Open) ProtoReflect() protoreflect.Message {
-	mi := &file_internal_cli_server_proto_server_proto_msgTypes[24]
+	mi := &file_internal_cli_server_proto_server_proto_msgTypes[48]
 
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -1558,7 +3299,7 @@ func (x *DebugFileResponse_Open) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use DebugFileResponse_Open.ProtoReflect.Descriptor instead.
 func (*DebugFileResponse_Open) Descriptor() ([]byte, []int) {
-	return file_internal_cli_server_proto_server_proto_rawDescGZIP(), []int{21, 0}
+	return file_internal_cli_server_proto_server_proto_rawDescGZIP(), []int{36, 0}
 }
 
 func (x *DebugFileResponse_Open) GetHeaders() map[string]string {
@@ -1581,7 +3322,7 @@ func (x *DebugFileResponse_Input) Reset() {
 	*x = DebugFileResponse_Input{}
 
 	if protoimpl.UnsafeEnabled {
-		mi := &file_internal_cli_server_proto_server_proto_msgTypes[25]
+		mi := &file_internal_cli_server_proto_server_proto_msgTypes[49]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1594,7 +3335,7 @@ func (x *DebugFileResponse_Input) String() string {
 func (*DebugFileResponse_Input) ProtoMessage() {}
 
 func (x *DebugFileResponse_Input) ProtoReflect() protoreflect.Message {
-	mi := &file_internal_cli_server_proto_server_proto_msgTypes[25]
+	mi := &file_internal_cli_server_proto_server_proto_msgTypes[49]
 
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -1610,7 +3351,7 @@ func (x *DebugFileResponse_Input) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use DebugFileResponse_Input.ProtoReflect.Descriptor instead.
 func (*DebugFileResponse_Input) Descriptor() ([]byte, []int) {
-	return file_internal_cli_server_proto_server_proto_rawDescGZIP(), []int{21, 1}
+	return file_internal_cli_server_proto_server_proto_rawDescGZIP(), []int{36, 1}
 }
 
 func (x *DebugFileResponse_Input) GetData() []byte {
@@ -1667,7 +3408,7 @@ var file_internal_cli_server_proto_server_proto_rawDesc = []byte{
 	0x05, 0x65, 0x6e, 0x6f, 0x64, 0x65, 0x22, 0x36, 0x0a, 0x13, 0x50, 0x65, 0x65, 0x72, 0x73, 0x53,
 	0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x1f, 0x0a,
 	0x04, 0x70, 0x65, 0x65, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0b, 0x2e, 0x70, 0x72,
-	0x6f, 0x74, 0x6f, 0x2e, 0x50, 0x65, 0x65, 0x72, 0x52, 0x04, 0x70, 0x65, 0x65, 0x72, 0x22, 0x98,
+	0x6f, 0x74, 0x6f, 0x2e, 0x50, 0x65, 0x65, 0x72, 0x52, 0x04, 0x70, 0x65, 0x65, 0x72, 0x22, 0xae,
 	0x01, 0x0a, 0x04, 0x50, 0x65, 0x65, 0x72, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20,
 	0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x12, 0x14, 0x0a, 0x05, 0x65, 0x6e, 0x6f, 0x64, 0x65,
 	0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x65, 0x6e, 0x6f, 0x64, 0x65, 0x12, 0x10, 0x0a,
@@ -1677,123 +3418,352 @@ var file_internal_cli_server_proto_server_proto_rawDesc = []byte{
 	0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x74, 0x72, 0x75, 0x73, 0x74,
 	0x65, 0x64, 0x18, 0x06, 0x20, 0x01, 0x28, 0x08, 0x52, 0x07, 0x74, 0x72, 0x75, 0x73, 0x74, 0x65,
 	0x64, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x74, 0x61, 0x74, 0x69, 0x63, 0x18, 0x07, 0x20, 0x01, 0x28,
-	0x08, 0x52, 0x06, 0x73, 0x74, 0x61, 0x74, 0x69, 0x63, 0x22, 0x2d, 0x0a, 0x13, 0x43, 0x68, 0x61,
-	0x69, 0x6e, 0x53, 0x65, 0x74, 0x48, 0x65, 0x61, 0x64, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
-	0x12, 0x16, 0x0a, 0x06, 0x6e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04,
-	0x52, 0x06, 0x6e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x22, 0x16, 0x0a, 0x14, 0x43, 0x68, 0x61, 0x69,
-	0x6e, 0x53, 0x65, 0x74, 0x48, 0x65, 0x61, 0x64, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
-	0x22, 0x23, 0x0a, 0x0d, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
-	0x74, 0x12, 0x12, 0x0a, 0x04, 0x57, 0x61, 0x69, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x08, 0x52,
-	0x04, 0x57, 0x61, 0x69, 0x74, 0x22, 0xe2, 0x03, 0x0a, 0x0e, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73,
-	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x31, 0x0a, 0x0c, 0x63, 0x75, 0x72, 0x72,
-	0x65, 0x6e, 0x74, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0d,
-	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x48, 0x65, 0x61, 0x64, 0x65, 0x72, 0x52, 0x0c, 0x63,
-	0x75, 0x72, 0x72, 0x65, 0x6e, 0x74, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x12, 0x33, 0x0a, 0x0d, 0x63,
-	0x75, 0x72, 0x72, 0x65, 0x6e, 0x74, 0x48, 0x65, 0x61, 0x64, 0x65, 0x72, 0x18, 0x02, 0x20, 0x01,
-	0x28, 0x0b, 0x32, 0x0d, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x48, 0x65, 0x61, 0x64, 0x65,
-	0x72, 0x52, 0x0d, 0x63, 0x75, 0x72, 0x72, 0x65, 0x6e, 0x74, 0x48, 0x65, 0x61, 0x64, 0x65, 0x72,
-	0x12, 0x1a, 0x0a, 0x08, 0x6e, 0x75, 0x6d, 0x50, 0x65, 0x65, 0x72, 0x73, 0x18, 0x03, 0x20, 0x01,
-	0x28, 0x03, 0x52, 0x08, 0x6e, 0x75, 0x6d, 0x50, 0x65, 0x65, 0x72, 0x73, 0x12, 0x1a, 0x0a, 0x08,
-	0x73, 0x79, 0x6e, 0x63, 0x4d, 0x6f, 0x64, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08,
-	0x73, 0x79, 0x6e, 0x63, 0x4d, 0x6f, 0x64, 0x65, 0x12, 0x37, 0x0a, 0x07, 0x73, 0x79, 0x6e, 0x63,
-	0x69, 0x6e, 0x67, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1d, 0x2e, 0x70, 0x72, 0x6f, 0x74,
-	0x6f, 0x2e, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
-	0x2e, 0x53, 0x79, 0x6e, 0x63, 0x69, 0x6e, 0x67, 0x52, 0x07, 0x73, 0x79, 0x6e, 0x63, 0x69, 0x6e,
-	0x67, 0x12, 0x30, 0x0a, 0x05, 0x66, 0x6f, 0x72, 0x6b, 0x73, 0x18, 0x06, 0x20, 0x03, 0x28, 0x0b,
-	0x32, 0x1a, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52,
-	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x2e, 0x46, 0x6f, 0x72, 0x6b, 0x52, 0x05, 0x66, 0x6f,
-	0x72, 0x6b, 0x73, 0x1a, 0x4c, 0x0a, 0x04, 0x46, 0x6f, 0x72, 0x6b, 0x12, 0x12, 0x0a, 0x04, 0x6e,
-	0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12,
-	0x14, 0x0a, 0x05, 0x62, 0x6c, 0x6f, 0x63, 0x6b, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x05,
-	0x62, 0x6c, 0x6f, 0x63, 0x6b, 0x12, 0x1a, 0x0a, 0x08, 0x64, 0x69, 0x73, 0x61, 0x62, 0x6c, 0x65,
-	0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x08, 0x52, 0x08, 0x64, 0x69, 0x73, 0x61, 0x62, 0x6c, 0x65,
-	0x64, 0x1a, 0x77, 0x0a, 0x07, 0x53, 0x79, 0x6e, 0x63, 0x69, 0x6e, 0x67, 0x12, 0x24, 0x0a, 0x0d,
-	0x73, 0x74, 0x61, 0x72, 0x74, 0x69, 0x6e, 0x67, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x18, 0x01, 0x20,
-	0x01, 0x28, 0x03, 0x52, 0x0d, 0x73, 0x74, 0x61, 0x72, 0x74, 0x69, 0x6e, 0x67, 0x42, 0x6c, 0x6f,
-	0x63, 0x6b, 0x12, 0x22, 0x0a, 0x0c, 0x68, 0x69, 0x67, 0x68, 0x65, 0x73, 0x74, 0x42, 0x6c, 0x6f,
-	0x63, 0x6b, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0c, 0x68, 0x69, 0x67, 0x68, 0x65, 0x73,
-	0x74, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x12, 0x22, 0x0a, 0x0c, 0x63, 0x75, 0x72, 0x72, 0x65, 0x6e,
-	0x74, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0c, 0x63, 0x75,
-	0x72, 0x72, 0x65, 0x6e, 0x74, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x22, 0x34, 0x0a, 0x06, 0x48, 0x65,
-	0x61, 0x64, 0x65, 0x72, 0x12, 0x12, 0x0a, 0x04, 0x68, 0x61, 0x73, 0x68, 0x18, 0x01, 0x20, 0x01,
-	0x28, 0x09, 0x52, 0x04, 0x68, 0x61, 0x73, 0x68, 0x12, 0x16, 0x0a, 0x06, 0x6e, 0x75, 0x6d, 0x62,
-	0x65, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x04, 0x52, 0x06, 0x6e, 0x75, 0x6d, 0x62, 0x65, 0x72,
-	0x22, 0xa2, 0x01, 0x0a, 0x11, 0x44, 0x65, 0x62, 0x75, 0x67, 0x50, 0x70, 0x72, 0x6f, 0x66, 0x52,
-	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x31, 0x0a, 0x04, 0x74, 0x79, 0x70, 0x65, 0x18, 0x01,
-	0x20, 0x01, 0x28, 0x0e, 0x32, 0x1d, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x44, 0x65, 0x62,
-	0x75, 0x67, 0x50, 0x70, 0x72, 0x6f, 0x66, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x2e, 0x54,
-	0x79, 0x70, 0x65, 0x52, 0x04, 0x74, 0x79, 0x70, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x70, 0x72, 0x6f,
-	0x66, 0x69, 0x6c, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x70, 0x72, 0x6f, 0x66,
-	0x69, 0x6c, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x73, 0x65, 0x63, 0x6f, 0x6e, 0x64, 0x73, 0x18, 0x03,
-	0x20, 0x01, 0x28, 0x03, 0x52, 0x07, 0x73, 0x65, 0x63, 0x6f, 0x6e, 0x64, 0x73, 0x22, 0x26, 0x0a,
-	0x04, 0x54, 0x79, 0x70, 0x65, 0x12, 0x0a, 0x0a, 0x06, 0x4c, 0x4f, 0x4f, 0x4b, 0x55, 0x50, 0x10,
-	0x00, 0x12, 0x07, 0x0a, 0x03, 0x43, 0x50, 0x55, 0x10, 0x01, 0x12, 0x09, 0x0a, 0x05, 0x54, 0x52,
-	0x41, 0x43, 0x45, 0x10, 0x02, 0x22, 0x2b, 0x0a, 0x11, 0x44, 0x65, 0x62, 0x75, 0x67, 0x42, 0x6c,
-	0x6f, 0x63, 0x6b, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x16, 0x0a, 0x06, 0x6e, 0x75,
-	0x6d, 0x62, 0x65, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x06, 0x6e, 0x75, 0x6d, 0x62,
-	0x65, 0x72, 0x22, 0xdd, 0x02, 0x0a, 0x11, 0x44, 0x65, 0x62, 0x75, 0x67, 0x46, 0x69, 0x6c, 0x65,
-	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x33, 0x0a, 0x04, 0x6f, 0x70, 0x65, 0x6e,
-	0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1d, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x44,
-	0x65, 0x62, 0x75, 0x67, 0x46, 0x69, 0x6c, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
-	0x2e, 0x4f, 0x70, 0x65, 0x6e, 0x48, 0x00, 0x52, 0x04, 0x6f, 0x70, 0x65, 0x6e, 0x12, 0x36, 0x0a,
-	0x05, 0x69, 0x6e, 0x70, 0x75, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1e, 0x2e, 0x70,
-	0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x44, 0x65, 0x62, 0x75, 0x67, 0x46, 0x69, 0x6c, 0x65, 0x52, 0x65,
-	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x2e, 0x49, 0x6e, 0x70, 0x75, 0x74, 0x48, 0x00, 0x52, 0x05,
-	0x69, 0x6e, 0x70, 0x75, 0x74, 0x12, 0x2a, 0x0a, 0x03, 0x65, 0x6f, 0x66, 0x18, 0x03, 0x20, 0x01,
-	0x28, 0x0b, 0x32, 0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74,
-	0x6f, 0x62, 0x75, 0x66, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x48, 0x00, 0x52, 0x03, 0x65, 0x6f,
-	0x66, 0x1a, 0x88, 0x01, 0x0a, 0x04, 0x4f, 0x70, 0x65, 0x6e, 0x12, 0x44, 0x0a, 0x07, 0x68, 0x65,
-	0x61, 0x64, 0x65, 0x72, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x2a, 0x2e, 0x70, 0x72,
-	0x6f, 0x74, 0x6f, 0x2e, 0x44, 0x65, 0x62, 0x75, 0x67, 0x46, 0x69, 0x6c, 0x65, 0x52, 0x65, 0x73,
-	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x2e, 0x4f, 0x70, 0x65, 0x6e, 0x2e, 0x48, 0x65, 0x61, 0x64, 0x65,
-	0x72, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x07, 0x68, 0x65, 0x61, 0x64, 0x65, 0x72, 0x73,
-	0x1a, 0x3a, 0x0a, 0x0c, 0x48, 0x65, 0x61, 0x64, 0x65, 0x72, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79,
+	0x08, 0x52, 0x06, 0x73, 0x74, 0x61, 0x74, 0x69, 0x63, 0x12, 0x14, 0x0a, 0x05, 0x73, 0x63, 0x6f,
+	0x72, 0x65, 0x18, 0x08, 0x20, 0x01, 0x28, 0x03, 0x52, 0x05, 0x73, 0x63, 0x6f, 0x72, 0x65, 0x22,
+	0x2d, 0x0a, 0x13, 0x43, 0x68, 0x61, 0x69, 0x6e, 0x53, 0x65, 0x74, 0x48, 0x65, 0x61, 0x64, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x16, 0x0a, 0x06, 0x6e, 0x75, 0x6d, 0x62, 0x65, 0x72,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x06, 0x6e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x22, 0x16,
+	0x0a, 0x14, 0x43, 0x68, 0x61, 0x69, 0x6e, 0x53, 0x65, 0x74, 0x48, 0x65, 0x61, 0x64, 0x52, 0x65,
+	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0xb3, 0x01, 0x0a, 0x16, 0x43, 0x68, 0x61, 0x69, 0x6e,
+	0x52, 0x65, 0x69, 0x6e, 0x64, 0x65, 0x78, 0x42, 0x6f, 0x72, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x12, 0x3c, 0x0a, 0x06, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x0e, 0x32, 0x24, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x43, 0x68, 0x61, 0x69, 0x6e, 0x52,
+	0x65, 0x69, 0x6e, 0x64, 0x65, 0x78, 0x42, 0x6f, 0x72, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x2e, 0x41, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x06, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x12,
+	0x12, 0x0a, 0x04, 0x66, 0x72, 0x6f, 0x6d, 0x18, 0x02, 0x20, 0x01, 0x28, 0x04, 0x52, 0x04, 0x66,
+	0x72, 0x6f, 0x6d, 0x12, 0x0e, 0x0a, 0x02, 0x74, 0x6f, 0x18, 0x03, 0x20, 0x01, 0x28, 0x04, 0x52,
+	0x02, 0x74, 0x6f, 0x22, 0x37, 0x0a, 0x06, 0x41, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x0a, 0x0a,
+	0x06, 0x53, 0x54, 0x41, 0x54, 0x55, 0x53, 0x10, 0x00, 0x12, 0x09, 0x0a, 0x05, 0x53, 0x54, 0x41,
+	0x52, 0x54, 0x10, 0x01, 0x12, 0x08, 0x0a, 0x04, 0x53, 0x54, 0x4f, 0x50, 0x10, 0x02, 0x12, 0x0c,
+	0x0a, 0x08, 0x42, 0x41, 0x43, 0x4b, 0x46, 0x49, 0x4c, 0x4c, 0x10, 0x03, 0x22, 0xbb, 0x01, 0x0a,
+	0x17, 0x43, 0x68, 0x61, 0x69, 0x6e, 0x52, 0x65, 0x69, 0x6e, 0x64, 0x65, 0x78, 0x42, 0x6f, 0x72,
+	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x72, 0x75, 0x6e, 0x6e,
+	0x69, 0x6e, 0x67, 0x18, 0x01, 0x20, 0x01, 0x28, 0x08, 0x52, 0x07, 0x72, 0x75, 0x6e, 0x6e, 0x69,
+	0x6e, 0x67, 0x12, 0x12, 0x0a, 0x04, 0x66, 0x72, 0x6f, 0x6d, 0x18, 0x02, 0x20, 0x01, 0x28, 0x04,
+	0x52, 0x04, 0x66, 0x72, 0x6f, 0x6d, 0x12, 0x0e, 0x0a, 0x02, 0x74, 0x6f, 0x18, 0x03, 0x20, 0x01,
+	0x28, 0x04, 0x52, 0x02, 0x74, 0x6f, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x65, 0x78, 0x74, 0x18, 0x04,
+	0x20, 0x01, 0x28, 0x04, 0x52, 0x04, 0x6e, 0x65, 0x78, 0x74, 0x12, 0x18, 0x0a, 0x07, 0x77, 0x72,
+	0x69, 0x74, 0x74, 0x65, 0x6e, 0x18, 0x05, 0x20, 0x01, 0x28, 0x04, 0x52, 0x07, 0x77, 0x72, 0x69,
+	0x74, 0x74, 0x65, 0x6e, 0x12, 0x14, 0x0a, 0x05, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x18, 0x06, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x05, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x12, 0x1e, 0x0a, 0x0a, 0x62, 0x61,
+	0x63, 0x6b, 0x66, 0x69, 0x6c, 0x6c, 0x65, 0x64, 0x18, 0x07, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0a,
+	0x62, 0x61, 0x63, 0x6b, 0x66, 0x69, 0x6c, 0x6c, 0x65, 0x64, 0x22, 0x15, 0x0a, 0x13, 0x43, 0x6f,
+	0x6e, 0x66, 0x69, 0x67, 0x52, 0x65, 0x6c, 0x6f, 0x61, 0x64, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x22, 0x4a, 0x0a, 0x14, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x52, 0x65, 0x6c, 0x6f, 0x61,
+	0x64, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x61, 0x70, 0x70,
+	0x6c, 0x69, 0x65, 0x64, 0x18, 0x01, 0x20, 0x03, 0x28, 0x09, 0x52, 0x07, 0x61, 0x70, 0x70, 0x6c,
+	0x69, 0x65, 0x64, 0x12, 0x18, 0x0a, 0x07, 0x72, 0x65, 0x73, 0x74, 0x61, 0x72, 0x74, 0x18, 0x02,
+	0x20, 0x03, 0x28, 0x09, 0x52, 0x07, 0x72, 0x65, 0x73, 0x74, 0x61, 0x72, 0x74, 0x22, 0x30, 0x0a,
+	0x14, 0x54, 0x78, 0x50, 0x6f, 0x6f, 0x6c, 0x49, 0x6e, 0x73, 0x70, 0x65, 0x63, 0x74, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x18, 0x0a, 0x07, 0x61, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x61, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x22,
+	0xf3, 0x02, 0x0a, 0x15, 0x54, 0x78, 0x50, 0x6f, 0x6f, 0x6c, 0x49, 0x6e, 0x73, 0x70, 0x65, 0x63,
+	0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x70, 0x65, 0x6e,
+	0x64, 0x69, 0x6e, 0x67, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x07, 0x70, 0x65, 0x6e, 0x64,
+	0x69, 0x6e, 0x67, 0x12, 0x16, 0x0a, 0x06, 0x71, 0x75, 0x65, 0x75, 0x65, 0x64, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x04, 0x52, 0x06, 0x71, 0x75, 0x65, 0x75, 0x65, 0x64, 0x12, 0x4c, 0x0a, 0x0c, 0x74,
+	0x72, 0x61, 0x6e, 0x73, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28,
+	0x0b, 0x32, 0x28, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x54, 0x78, 0x50, 0x6f, 0x6f, 0x6c,
+	0x49, 0x6e, 0x73, 0x70, 0x65, 0x63, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x2e,
+	0x54, 0x72, 0x61, 0x6e, 0x73, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x0c, 0x74, 0x72, 0x61,
+	0x6e, 0x73, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x1a, 0xd9, 0x01, 0x0a, 0x0b, 0x54, 0x72,
+	0x61, 0x6e, 0x73, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x12, 0x0a, 0x04, 0x68, 0x61, 0x73,
+	0x68, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x68, 0x61, 0x73, 0x68, 0x12, 0x12, 0x0a,
+	0x04, 0x66, 0x72, 0x6f, 0x6d, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x66, 0x72, 0x6f,
+	0x6d, 0x12, 0x0e, 0x0a, 0x02, 0x74, 0x6f, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x74,
+	0x6f, 0x12, 0x14, 0x0a, 0x05, 0x6e, 0x6f, 0x6e, 0x63, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x04,
+	0x52, 0x05, 0x6e, 0x6f, 0x6e, 0x63, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65,
+	0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x12, 0x10, 0x0a,
+	0x03, 0x67, 0x61, 0x73, 0x18, 0x06, 0x20, 0x01, 0x28, 0x04, 0x52, 0x03, 0x67, 0x61, 0x73, 0x12,
+	0x1c, 0x0a, 0x09, 0x67, 0x61, 0x73, 0x46, 0x65, 0x65, 0x43, 0x61, 0x70, 0x18, 0x07, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x09, 0x67, 0x61, 0x73, 0x46, 0x65, 0x65, 0x43, 0x61, 0x70, 0x12, 0x1c, 0x0a,
+	0x09, 0x67, 0x61, 0x73, 0x54, 0x69, 0x70, 0x43, 0x61, 0x70, 0x18, 0x08, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x09, 0x67, 0x61, 0x73, 0x54, 0x69, 0x70, 0x43, 0x61, 0x70, 0x12, 0x18, 0x0a, 0x07, 0x70,
+	0x65, 0x6e, 0x64, 0x69, 0x6e, 0x67, 0x18, 0x09, 0x20, 0x01, 0x28, 0x08, 0x52, 0x07, 0x70, 0x65,
+	0x6e, 0x64, 0x69, 0x6e, 0x67, 0x22, 0x14, 0x0a, 0x12, 0x54, 0x78, 0x50, 0x6f, 0x6f, 0x6c, 0x43,
+	0x6c, 0x65, 0x61, 0x72, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x22, 0x2f, 0x0a, 0x13, 0x54,
+	0x78, 0x50, 0x6f, 0x6f, 0x6c, 0x43, 0x6c, 0x65, 0x61, 0x72, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
+	0x73, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x64, 0x72, 0x6f, 0x70, 0x70, 0x65, 0x64, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x04, 0x52, 0x07, 0x64, 0x72, 0x6f, 0x70, 0x70, 0x65, 0x64, 0x22, 0x33, 0x0a, 0x17,
+	0x54, 0x78, 0x50, 0x6f, 0x6f, 0x6c, 0x52, 0x65, 0x73, 0x65, 0x74, 0x4e, 0x6f, 0x6e, 0x63, 0x65,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x18, 0x0a, 0x07, 0x61, 0x64, 0x64, 0x72, 0x65,
+	0x73, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x61, 0x64, 0x64, 0x72, 0x65, 0x73,
+	0x73, 0x22, 0x4a, 0x0a, 0x18, 0x54, 0x78, 0x50, 0x6f, 0x6f, 0x6c, 0x52, 0x65, 0x73, 0x65, 0x74,
+	0x4e, 0x6f, 0x6e, 0x63, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x18, 0x0a,
+	0x07, 0x64, 0x72, 0x6f, 0x70, 0x70, 0x65, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x07,
+	0x64, 0x72, 0x6f, 0x70, 0x70, 0x65, 0x64, 0x12, 0x14, 0x0a, 0x05, 0x6e, 0x6f, 0x6e, 0x63, 0x65,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x04, 0x52, 0x05, 0x6e, 0x6f, 0x6e, 0x63, 0x65, 0x22, 0x6e, 0x0a,
+	0x1c, 0x44, 0x65, 0x62, 0x75, 0x67, 0x54, 0x72, 0x61, 0x63, 0x65, 0x54, 0x72, 0x61, 0x6e, 0x73,
+	0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x12, 0x0a,
+	0x04, 0x68, 0x61, 0x73, 0x68, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x68, 0x61, 0x73,
+	0x68, 0x12, 0x16, 0x0a, 0x06, 0x74, 0x72, 0x61, 0x63, 0x65, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x06, 0x74, 0x72, 0x61, 0x63, 0x65, 0x72, 0x12, 0x22, 0x0a, 0x0c, 0x74, 0x72, 0x61,
+	0x63, 0x65, 0x72, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x0c, 0x74, 0x72, 0x61, 0x63, 0x65, 0x72, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x22, 0x8f, 0x01,
+	0x0a, 0x15, 0x44, 0x65, 0x62, 0x75, 0x67, 0x44, 0x75, 0x6d, 0x70, 0x53, 0x74, 0x61, 0x74, 0x65,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1b, 0x0a, 0x06, 0x6e, 0x75, 0x6d, 0x62, 0x65,
+	0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x48, 0x00, 0x52, 0x06, 0x6e, 0x75, 0x6d, 0x62, 0x65,
+	0x72, 0x88, 0x01, 0x01, 0x12, 0x1a, 0x0a, 0x08, 0x73, 0x6b, 0x69, 0x70, 0x43, 0x6f, 0x64, 0x65,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x08, 0x52, 0x08, 0x73, 0x6b, 0x69, 0x70, 0x43, 0x6f, 0x64, 0x65,
+	0x12, 0x20, 0x0a, 0x0b, 0x73, 0x6b, 0x69, 0x70, 0x53, 0x74, 0x6f, 0x72, 0x61, 0x67, 0x65, 0x18,
+	0x03, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0b, 0x73, 0x6b, 0x69, 0x70, 0x53, 0x74, 0x6f, 0x72, 0x61,
+	0x67, 0x65, 0x12, 0x10, 0x0a, 0x03, 0x6d, 0x61, 0x78, 0x18, 0x04, 0x20, 0x01, 0x28, 0x04, 0x52,
+	0x03, 0x6d, 0x61, 0x78, 0x42, 0x09, 0x0a, 0x07, 0x5f, 0x6e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x22,
+	0xba, 0x02, 0x0a, 0x16, 0x44, 0x65, 0x62, 0x75, 0x67, 0x44, 0x75, 0x6d, 0x70, 0x53, 0x74, 0x61,
+	0x74, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x61, 0x64,
+	0x64, 0x72, 0x65, 0x73, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x61, 0x64, 0x64,
+	0x72, 0x65, 0x73, 0x73, 0x12, 0x18, 0x0a, 0x07, 0x62, 0x61, 0x6c, 0x61, 0x6e, 0x63, 0x65, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x62, 0x61, 0x6c, 0x61, 0x6e, 0x63, 0x65, 0x12, 0x14,
+	0x0a, 0x05, 0x6e, 0x6f, 0x6e, 0x63, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x04, 0x52, 0x05, 0x6e,
+	0x6f, 0x6e, 0x63, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x72, 0x6f, 0x6f, 0x74, 0x18, 0x04, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x04, 0x72, 0x6f, 0x6f, 0x74, 0x12, 0x1a, 0x0a, 0x08, 0x63, 0x6f, 0x64, 0x65,
+	0x48, 0x61, 0x73, 0x68, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x63, 0x6f, 0x64, 0x65,
+	0x48, 0x61, 0x73, 0x68, 0x12, 0x12, 0x0a, 0x04, 0x63, 0x6f, 0x64, 0x65, 0x18, 0x06, 0x20, 0x01,
+	0x28, 0x0c, 0x52, 0x04, 0x63, 0x6f, 0x64, 0x65, 0x12, 0x44, 0x0a, 0x07, 0x73, 0x74, 0x6f, 0x72,
+	0x61, 0x67, 0x65, 0x18, 0x07, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x2a, 0x2e, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x2e, 0x44, 0x65, 0x62, 0x75, 0x67, 0x44, 0x75, 0x6d, 0x70, 0x53, 0x74, 0x61, 0x74, 0x65,
+	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x2e, 0x53, 0x74, 0x6f, 0x72, 0x61, 0x67, 0x65,
+	0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x07, 0x73, 0x74, 0x6f, 0x72, 0x61, 0x67, 0x65, 0x12, 0x10,
+	0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x08, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79,
+	0x1a, 0x3a, 0x0a, 0x0c, 0x53, 0x74, 0x6f, 0x72, 0x61, 0x67, 0x65, 0x45, 0x6e, 0x74, 0x72, 0x79,
 	0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b,
 	0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28,
-	0x09, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x1a, 0x1b, 0x0a, 0x05,
-	0x49, 0x6e, 0x70, 0x75, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x64, 0x61, 0x74, 0x61, 0x18, 0x01, 0x20,
-	0x01, 0x28, 0x0c, 0x52, 0x04, 0x64, 0x61, 0x74, 0x61, 0x42, 0x07, 0x0a, 0x05, 0x65, 0x76, 0x65,
-	0x6e, 0x74, 0x32, 0xdb, 0x04, 0x0a, 0x03, 0x42, 0x6f, 0x72, 0x12, 0x3b, 0x0a, 0x08, 0x50, 0x65,
-	0x65, 0x72, 0x73, 0x41, 0x64, 0x64, 0x12, 0x16, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x50,
-	0x65, 0x65, 0x72, 0x73, 0x41, 0x64, 0x64, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x17,
-	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x50, 0x65, 0x65, 0x72, 0x73, 0x41, 0x64, 0x64, 0x52,
-	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x44, 0x0a, 0x0b, 0x50, 0x65, 0x65, 0x72, 0x73,
-	0x52, 0x65, 0x6d, 0x6f, 0x76, 0x65, 0x12, 0x19, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x50,
-	0x65, 0x65, 0x72, 0x73, 0x52, 0x65, 0x6d, 0x6f, 0x76, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
-	0x74, 0x1a, 0x1a, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x50, 0x65, 0x65, 0x72, 0x73, 0x52,
-	0x65, 0x6d, 0x6f, 0x76, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x3e, 0x0a,
-	0x09, 0x50, 0x65, 0x65, 0x72, 0x73, 0x4c, 0x69, 0x73, 0x74, 0x12, 0x17, 0x2e, 0x70, 0x72, 0x6f,
-	0x74, 0x6f, 0x2e, 0x50, 0x65, 0x65, 0x72, 0x73, 0x4c, 0x69, 0x73, 0x74, 0x52, 0x65, 0x71, 0x75,
-	0x65, 0x73, 0x74, 0x1a, 0x18, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x50, 0x65, 0x65, 0x72,
-	0x73, 0x4c, 0x69, 0x73, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x44, 0x0a,
-	0x0b, 0x50, 0x65, 0x65, 0x72, 0x73, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x19, 0x2e, 0x70,
-	0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x50, 0x65, 0x65, 0x72, 0x73, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73,
-	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1a, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e,
-	0x50, 0x65, 0x65, 0x72, 0x73, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f,
-	0x6e, 0x73, 0x65, 0x12, 0x47, 0x0a, 0x0c, 0x43, 0x68, 0x61, 0x69, 0x6e, 0x53, 0x65, 0x74, 0x48,
-	0x65, 0x61, 0x64, 0x12, 0x1a, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x43, 0x68, 0x61, 0x69,
-	0x6e, 0x53, 0x65, 0x74, 0x48, 0x65, 0x61, 0x64, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a,
-	0x1b, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x43, 0x68, 0x61, 0x69, 0x6e, 0x53, 0x65, 0x74,
-	0x48, 0x65, 0x61, 0x64, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x35, 0x0a, 0x06,
-	0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x14, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x53,
-	0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x15, 0x2e, 0x70,
+	0x09, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x22, 0x1b, 0x0a, 0x19,
+	0x44, 0x65, 0x62, 0x75, 0x67, 0x44, 0x61, 0x74, 0x61, 0x62, 0x61, 0x73, 0x65, 0x53, 0x74, 0x61,
+	0x74, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x22, 0xee, 0x01, 0x0a, 0x1a, 0x44, 0x65,
+	0x62, 0x75, 0x67, 0x44, 0x61, 0x74, 0x61, 0x62, 0x61, 0x73, 0x65, 0x53, 0x74, 0x61, 0x74, 0x73,
+	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x1a, 0x0a, 0x08, 0x61, 0x6e, 0x63, 0x69,
+	0x65, 0x6e, 0x74, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x08, 0x61, 0x6e, 0x63, 0x69,
+	0x65, 0x6e, 0x74, 0x73, 0x12, 0x12, 0x0a, 0x04, 0x74, 0x61, 0x69, 0x6c, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x04, 0x52, 0x04, 0x74, 0x61, 0x69, 0x6c, 0x12, 0x46, 0x0a, 0x06, 0x74, 0x61, 0x62, 0x6c,
+	0x65, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x2e, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x2e, 0x44, 0x65, 0x62, 0x75, 0x67, 0x44, 0x61, 0x74, 0x61, 0x62, 0x61, 0x73, 0x65, 0x53, 0x74,
+	0x61, 0x74, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x2e, 0x46, 0x72, 0x65, 0x65,
+	0x7a, 0x65, 0x72, 0x54, 0x61, 0x62, 0x6c, 0x65, 0x52, 0x06, 0x74, 0x61, 0x62, 0x6c, 0x65, 0x73,
+	0x12, 0x20, 0x0a, 0x0b, 0x65, 0x6e, 0x67, 0x69, 0x6e, 0x65, 0x53, 0x74, 0x61, 0x74, 0x73, 0x18,
+	0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x65, 0x6e, 0x67, 0x69, 0x6e, 0x65, 0x53, 0x74, 0x61,
+	0x74, 0x73, 0x1a, 0x36, 0x0a, 0x0c, 0x46, 0x72, 0x65, 0x65, 0x7a, 0x65, 0x72, 0x54, 0x61, 0x62,
+	0x6c, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x73, 0x69, 0x7a, 0x65, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x04, 0x52, 0x04, 0x73, 0x69, 0x7a, 0x65, 0x22, 0x23, 0x0a, 0x0d, 0x53, 0x74,
+	0x61, 0x74, 0x75, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x57,
+	0x61, 0x69, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x08, 0x52, 0x04, 0x57, 0x61, 0x69, 0x74, 0x22,
+	0xfc, 0x0b, 0x0a, 0x0e, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
+	0x73, 0x65, 0x12, 0x31, 0x0a, 0x0c, 0x63, 0x75, 0x72, 0x72, 0x65, 0x6e, 0x74, 0x42, 0x6c, 0x6f,
+	0x63, 0x6b, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0d, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x2e, 0x48, 0x65, 0x61, 0x64, 0x65, 0x72, 0x52, 0x0c, 0x63, 0x75, 0x72, 0x72, 0x65, 0x6e, 0x74,
+	0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x12, 0x33, 0x0a, 0x0d, 0x63, 0x75, 0x72, 0x72, 0x65, 0x6e, 0x74,
+	0x48, 0x65, 0x61, 0x64, 0x65, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0d, 0x2e, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x48, 0x65, 0x61, 0x64, 0x65, 0x72, 0x52, 0x0d, 0x63, 0x75, 0x72,
+	0x72, 0x65, 0x6e, 0x74, 0x48, 0x65, 0x61, 0x64, 0x65, 0x72, 0x12, 0x1a, 0x0a, 0x08, 0x6e, 0x75,
+	0x6d, 0x50, 0x65, 0x65, 0x72, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x08, 0x6e, 0x75,
+	0x6d, 0x50, 0x65, 0x65, 0x72, 0x73, 0x12, 0x1a, 0x0a, 0x08, 0x73, 0x79, 0x6e, 0x63, 0x4d, 0x6f,
+	0x64, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x73, 0x79, 0x6e, 0x63, 0x4d, 0x6f,
+	0x64, 0x65, 0x12, 0x37, 0x0a, 0x07, 0x73, 0x79, 0x6e, 0x63, 0x69, 0x6e, 0x67, 0x18, 0x05, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x1d, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x53, 0x74, 0x61, 0x74,
+	0x75, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x2e, 0x53, 0x79, 0x6e, 0x63, 0x69,
+	0x6e, 0x67, 0x52, 0x07, 0x73, 0x79, 0x6e, 0x63, 0x69, 0x6e, 0x67, 0x12, 0x30, 0x0a, 0x05, 0x66,
+	0x6f, 0x72, 0x6b, 0x73, 0x18, 0x06, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x2e, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
+	0x65, 0x2e, 0x46, 0x6f, 0x72, 0x6b, 0x52, 0x05, 0x66, 0x6f, 0x72, 0x6b, 0x73, 0x12, 0x42, 0x0a,
+	0x08, 0x68, 0x65, 0x69, 0x6d, 0x64, 0x61, 0x6c, 0x6c, 0x18, 0x07, 0x20, 0x03, 0x28, 0x0b, 0x32,
+	0x26, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x65,
+	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x2e, 0x48, 0x65, 0x69, 0x6d, 0x64, 0x61, 0x6c, 0x6c, 0x45,
+	0x6e, 0x64, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x52, 0x08, 0x68, 0x65, 0x69, 0x6d, 0x64, 0x61, 0x6c,
+	0x6c, 0x12, 0x2e, 0x0a, 0x04, 0x73, 0x70, 0x61, 0x6e, 0x18, 0x08, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x1a, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x65,
+	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x2e, 0x53, 0x70, 0x61, 0x6e, 0x52, 0x04, 0x73, 0x70, 0x61,
+	0x6e, 0x12, 0x3d, 0x0a, 0x09, 0x76, 0x61, 0x6c, 0x69, 0x64, 0x61, 0x74, 0x6f, 0x72, 0x18, 0x09,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x1f, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x53, 0x74, 0x61,
+	0x74, 0x75, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x2e, 0x56, 0x61, 0x6c, 0x69,
+	0x64, 0x61, 0x74, 0x6f, 0x72, 0x52, 0x09, 0x76, 0x61, 0x6c, 0x69, 0x64, 0x61, 0x74, 0x6f, 0x72,
+	0x12, 0x3c, 0x0a, 0x09, 0x6d, 0x69, 0x6c, 0x65, 0x73, 0x74, 0x6f, 0x6e, 0x65, 0x18, 0x0a, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x1e, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x53, 0x74, 0x61, 0x74,
+	0x75, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x2e, 0x46, 0x69, 0x6e, 0x61, 0x6c,
+	0x69, 0x74, 0x79, 0x52, 0x09, 0x6d, 0x69, 0x6c, 0x65, 0x73, 0x74, 0x6f, 0x6e, 0x65, 0x12, 0x3e,
+	0x0a, 0x0a, 0x63, 0x68, 0x65, 0x63, 0x6b, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x18, 0x0b, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x1e, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x53, 0x74, 0x61, 0x74, 0x75,
+	0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x2e, 0x46, 0x69, 0x6e, 0x61, 0x6c, 0x69,
+	0x74, 0x79, 0x52, 0x0a, 0x63, 0x68, 0x65, 0x63, 0x6b, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x12, 0x49,
+	0x0a, 0x0d, 0x6d, 0x69, 0x6c, 0x65, 0x73, 0x74, 0x6f, 0x6e, 0x65, 0x4c, 0x6f, 0x63, 0x6b, 0x18,
+	0x0c, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x23, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x53, 0x74,
+	0x61, 0x74, 0x75, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x2e, 0x4d, 0x69, 0x6c,
+	0x65, 0x73, 0x74, 0x6f, 0x6e, 0x65, 0x4c, 0x6f, 0x63, 0x6b, 0x52, 0x0d, 0x6d, 0x69, 0x6c, 0x65,
+	0x73, 0x74, 0x6f, 0x6e, 0x65, 0x4c, 0x6f, 0x63, 0x6b, 0x12, 0x3d, 0x0a, 0x09, 0x73, 0x74, 0x61,
+	0x74, 0x65, 0x53, 0x79, 0x6e, 0x63, 0x18, 0x0d, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1f, 0x2e, 0x70,
 	0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f,
-	0x6e, 0x73, 0x65, 0x12, 0x43, 0x0a, 0x0a, 0x43, 0x68, 0x61, 0x69, 0x6e, 0x57, 0x61, 0x74, 0x63,
-	0x68, 0x12, 0x18, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x43, 0x68, 0x61, 0x69, 0x6e, 0x57,
-	0x61, 0x74, 0x63, 0x68, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x19, 0x2e, 0x70, 0x72,
-	0x6f, 0x74, 0x6f, 0x2e, 0x43, 0x68, 0x61, 0x69, 0x6e, 0x57, 0x61, 0x74, 0x63, 0x68, 0x52, 0x65,
-	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x30, 0x01, 0x12, 0x42, 0x0a, 0x0a, 0x44, 0x65, 0x62, 0x75,
-	0x67, 0x50, 0x70, 0x72, 0x6f, 0x66, 0x12, 0x18, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x44,
-	0x65, 0x62, 0x75, 0x67, 0x50, 0x70, 0x72, 0x6f, 0x66, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
-	0x1a, 0x18, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x44, 0x65, 0x62, 0x75, 0x67, 0x46, 0x69,
-	0x6c, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x30, 0x01, 0x12, 0x42, 0x0a, 0x0a,
-	0x44, 0x65, 0x62, 0x75, 0x67, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x12, 0x18, 0x2e, 0x70, 0x72, 0x6f,
-	0x74, 0x6f, 0x2e, 0x44, 0x65, 0x62, 0x75, 0x67, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x52, 0x65, 0x71,
+	0x6e, 0x73, 0x65, 0x2e, 0x53, 0x74, 0x61, 0x74, 0x65, 0x53, 0x79, 0x6e, 0x63, 0x52, 0x09, 0x73,
+	0x74, 0x61, 0x74, 0x65, 0x53, 0x79, 0x6e, 0x63, 0x1a, 0x4c, 0x0a, 0x04, 0x46, 0x6f, 0x72, 0x6b,
+	0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04,
+	0x6e, 0x61, 0x6d, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x62, 0x6c, 0x6f, 0x63, 0x6b, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x03, 0x52, 0x05, 0x62, 0x6c, 0x6f, 0x63, 0x6b, 0x12, 0x1a, 0x0a, 0x08, 0x64, 0x69,
+	0x73, 0x61, 0x62, 0x6c, 0x65, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x08, 0x52, 0x08, 0x64, 0x69,
+	0x73, 0x61, 0x62, 0x6c, 0x65, 0x64, 0x1a, 0x77, 0x0a, 0x07, 0x53, 0x79, 0x6e, 0x63, 0x69, 0x6e,
+	0x67, 0x12, 0x24, 0x0a, 0x0d, 0x73, 0x74, 0x61, 0x72, 0x74, 0x69, 0x6e, 0x67, 0x42, 0x6c, 0x6f,
+	0x63, 0x6b, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0d, 0x73, 0x74, 0x61, 0x72, 0x74, 0x69,
+	0x6e, 0x67, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x12, 0x22, 0x0a, 0x0c, 0x68, 0x69, 0x67, 0x68, 0x65,
+	0x73, 0x74, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0c, 0x68,
+	0x69, 0x67, 0x68, 0x65, 0x73, 0x74, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x12, 0x22, 0x0a, 0x0c, 0x63,
+	0x75, 0x72, 0x72, 0x65, 0x6e, 0x74, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x18, 0x03, 0x20, 0x01, 0x28,
+	0x03, 0x52, 0x0c, 0x63, 0x75, 0x72, 0x72, 0x65, 0x6e, 0x74, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x1a,
+	0x76, 0x0a, 0x10, 0x48, 0x65, 0x69, 0x6d, 0x64, 0x61, 0x6c, 0x6c, 0x45, 0x6e, 0x64, 0x70, 0x6f,
+	0x69, 0x6e, 0x74, 0x12, 0x10, 0x0a, 0x03, 0x75, 0x72, 0x6c, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x03, 0x75, 0x72, 0x6c, 0x12, 0x1c, 0x0a, 0x09, 0x72, 0x65, 0x61, 0x63, 0x68, 0x61, 0x62,
+	0x6c, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x08, 0x52, 0x09, 0x72, 0x65, 0x61, 0x63, 0x68, 0x61,
+	0x62, 0x6c, 0x65, 0x12, 0x1c, 0x0a, 0x09, 0x6c, 0x61, 0x74, 0x65, 0x6e, 0x63, 0x79, 0x4d, 0x73,
+	0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x09, 0x6c, 0x61, 0x74, 0x65, 0x6e, 0x63, 0x79, 0x4d,
+	0x73, 0x12, 0x14, 0x0a, 0x05, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x05, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x1a, 0x52, 0x0a, 0x04, 0x53, 0x70, 0x61, 0x6e, 0x12,
+	0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x02, 0x69, 0x64, 0x12,
+	0x1e, 0x0a, 0x0a, 0x73, 0x74, 0x61, 0x72, 0x74, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x04, 0x52, 0x0a, 0x73, 0x74, 0x61, 0x72, 0x74, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x12,
+	0x1a, 0x0a, 0x08, 0x65, 0x6e, 0x64, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x18, 0x03, 0x20, 0x01, 0x28,
+	0x04, 0x52, 0x08, 0x65, 0x6e, 0x64, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x1a, 0x69, 0x0a, 0x09, 0x56,
+	0x61, 0x6c, 0x69, 0x64, 0x61, 0x74, 0x6f, 0x72, 0x12, 0x18, 0x0a, 0x07, 0x61, 0x64, 0x64, 0x72,
+	0x65, 0x73, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x61, 0x64, 0x64, 0x72, 0x65,
+	0x73, 0x73, 0x12, 0x26, 0x0a, 0x0e, 0x69, 0x6e, 0x56, 0x61, 0x6c, 0x69, 0x64, 0x61, 0x74, 0x6f,
+	0x72, 0x53, 0x65, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0e, 0x69, 0x6e, 0x56, 0x61,
+	0x6c, 0x69, 0x64, 0x61, 0x74, 0x6f, 0x72, 0x53, 0x65, 0x74, 0x12, 0x1a, 0x0a, 0x08, 0x6e, 0x65,
+	0x78, 0x74, 0x53, 0x6c, 0x6f, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x04, 0x52, 0x08, 0x6e, 0x65,
+	0x78, 0x74, 0x53, 0x6c, 0x6f, 0x74, 0x1a, 0x4e, 0x0a, 0x08, 0x46, 0x69, 0x6e, 0x61, 0x6c, 0x69,
+	0x74, 0x79, 0x12, 0x16, 0x0a, 0x06, 0x65, 0x78, 0x69, 0x73, 0x74, 0x73, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x08, 0x52, 0x06, 0x65, 0x78, 0x69, 0x73, 0x74, 0x73, 0x12, 0x16, 0x0a, 0x06, 0x6e, 0x75,
+	0x6d, 0x62, 0x65, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x04, 0x52, 0x06, 0x6e, 0x75, 0x6d, 0x62,
+	0x65, 0x72, 0x12, 0x12, 0x0a, 0x04, 0x68, 0x61, 0x73, 0x68, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x04, 0x68, 0x61, 0x73, 0x68, 0x1a, 0x77, 0x0a, 0x0d, 0x4d, 0x69, 0x6c, 0x65, 0x73, 0x74,
+	0x6f, 0x6e, 0x65, 0x4c, 0x6f, 0x63, 0x6b, 0x12, 0x16, 0x0a, 0x06, 0x6c, 0x6f, 0x63, 0x6b, 0x65,
+	0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x08, 0x52, 0x06, 0x6c, 0x6f, 0x63, 0x6b, 0x65, 0x64, 0x12,
+	0x16, 0x0a, 0x06, 0x6e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x04, 0x52,
+	0x06, 0x6e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x12, 0x12, 0x0a, 0x04, 0x68, 0x61, 0x73, 0x68, 0x18,
+	0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x68, 0x61, 0x73, 0x68, 0x12, 0x22, 0x0a, 0x0c, 0x6d,
+	0x69, 0x6c, 0x65, 0x73, 0x74, 0x6f, 0x6e, 0x65, 0x49, 0x64, 0x73, 0x18, 0x04, 0x20, 0x03, 0x28,
+	0x09, 0x52, 0x0c, 0x6d, 0x69, 0x6c, 0x65, 0x73, 0x74, 0x6f, 0x6e, 0x65, 0x49, 0x64, 0x73, 0x1a,
+	0x5d, 0x0a, 0x09, 0x53, 0x74, 0x61, 0x74, 0x65, 0x53, 0x79, 0x6e, 0x63, 0x12, 0x20, 0x0a, 0x0b,
+	0x6c, 0x61, 0x73, 0x74, 0x53, 0x74, 0x61, 0x74, 0x65, 0x49, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x04, 0x52, 0x0b, 0x6c, 0x61, 0x73, 0x74, 0x53, 0x74, 0x61, 0x74, 0x65, 0x49, 0x64, 0x12, 0x18,
+	0x0a, 0x07, 0x70, 0x65, 0x6e, 0x64, 0x69, 0x6e, 0x67, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52,
+	0x07, 0x70, 0x65, 0x6e, 0x64, 0x69, 0x6e, 0x67, 0x12, 0x14, 0x0a, 0x05, 0x65, 0x72, 0x72, 0x6f,
+	0x72, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x22, 0x34,
+	0x0a, 0x06, 0x48, 0x65, 0x61, 0x64, 0x65, 0x72, 0x12, 0x12, 0x0a, 0x04, 0x68, 0x61, 0x73, 0x68,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x68, 0x61, 0x73, 0x68, 0x12, 0x16, 0x0a, 0x06,
+	0x6e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x04, 0x52, 0x06, 0x6e, 0x75,
+	0x6d, 0x62, 0x65, 0x72, 0x22, 0xa2, 0x01, 0x0a, 0x11, 0x44, 0x65, 0x62, 0x75, 0x67, 0x50, 0x70,
+	0x72, 0x6f, 0x66, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x31, 0x0a, 0x04, 0x74, 0x79,
+	0x70, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x1d, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x2e, 0x44, 0x65, 0x62, 0x75, 0x67, 0x50, 0x70, 0x72, 0x6f, 0x66, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x2e, 0x54, 0x79, 0x70, 0x65, 0x52, 0x04, 0x74, 0x79, 0x70, 0x65, 0x12, 0x18, 0x0a,
+	0x07, 0x70, 0x72, 0x6f, 0x66, 0x69, 0x6c, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07,
+	0x70, 0x72, 0x6f, 0x66, 0x69, 0x6c, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x73, 0x65, 0x63, 0x6f, 0x6e,
+	0x64, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x07, 0x73, 0x65, 0x63, 0x6f, 0x6e, 0x64,
+	0x73, 0x22, 0x26, 0x0a, 0x04, 0x54, 0x79, 0x70, 0x65, 0x12, 0x0a, 0x0a, 0x06, 0x4c, 0x4f, 0x4f,
+	0x4b, 0x55, 0x50, 0x10, 0x00, 0x12, 0x07, 0x0a, 0x03, 0x43, 0x50, 0x55, 0x10, 0x01, 0x12, 0x09,
+	0x0a, 0x05, 0x54, 0x52, 0x41, 0x43, 0x45, 0x10, 0x02, 0x22, 0x2b, 0x0a, 0x11, 0x44, 0x65, 0x62,
+	0x75, 0x67, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x16,
+	0x0a, 0x06, 0x6e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x06,
+	0x6e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x22, 0xdd, 0x02, 0x0a, 0x11, 0x44, 0x65, 0x62, 0x75, 0x67,
+	0x46, 0x69, 0x6c, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x33, 0x0a, 0x04,
+	0x6f, 0x70, 0x65, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1d, 0x2e, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x2e, 0x44, 0x65, 0x62, 0x75, 0x67, 0x46, 0x69, 0x6c, 0x65, 0x52, 0x65, 0x73, 0x70,
+	0x6f, 0x6e, 0x73, 0x65, 0x2e, 0x4f, 0x70, 0x65, 0x6e, 0x48, 0x00, 0x52, 0x04, 0x6f, 0x70, 0x65,
+	0x6e, 0x12, 0x36, 0x0a, 0x05, 0x69, 0x6e, 0x70, 0x75, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x1e, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x44, 0x65, 0x62, 0x75, 0x67, 0x46, 0x69,
+	0x6c, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x2e, 0x49, 0x6e, 0x70, 0x75, 0x74,
+	0x48, 0x00, 0x52, 0x05, 0x69, 0x6e, 0x70, 0x75, 0x74, 0x12, 0x2a, 0x0a, 0x03, 0x65, 0x6f, 0x66,
+	0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x48, 0x00,
+	0x52, 0x03, 0x65, 0x6f, 0x66, 0x1a, 0x88, 0x01, 0x0a, 0x04, 0x4f, 0x70, 0x65, 0x6e, 0x12, 0x44,
+	0x0a, 0x07, 0x68, 0x65, 0x61, 0x64, 0x65, 0x72, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32,
+	0x2a, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x44, 0x65, 0x62, 0x75, 0x67, 0x46, 0x69, 0x6c,
+	0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x2e, 0x4f, 0x70, 0x65, 0x6e, 0x2e, 0x48,
+	0x65, 0x61, 0x64, 0x65, 0x72, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x07, 0x68, 0x65, 0x61,
+	0x64, 0x65, 0x72, 0x73, 0x1a, 0x3a, 0x0a, 0x0c, 0x48, 0x65, 0x61, 0x64, 0x65, 0x72, 0x73, 0x45,
+	0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01,
+	0x1a, 0x1b, 0x0a, 0x05, 0x49, 0x6e, 0x70, 0x75, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x64, 0x61, 0x74,
+	0x61, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x04, 0x64, 0x61, 0x74, 0x61, 0x42, 0x07, 0x0a,
+	0x05, 0x65, 0x76, 0x65, 0x6e, 0x74, 0x32, 0xe3, 0x09, 0x0a, 0x03, 0x42, 0x6f, 0x72, 0x12, 0x3b,
+	0x0a, 0x08, 0x50, 0x65, 0x65, 0x72, 0x73, 0x41, 0x64, 0x64, 0x12, 0x16, 0x2e, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x2e, 0x50, 0x65, 0x65, 0x72, 0x73, 0x41, 0x64, 0x64, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x1a, 0x17, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x50, 0x65, 0x65, 0x72, 0x73,
+	0x41, 0x64, 0x64, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x44, 0x0a, 0x0b, 0x50,
+	0x65, 0x65, 0x72, 0x73, 0x52, 0x65, 0x6d, 0x6f, 0x76, 0x65, 0x12, 0x19, 0x2e, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x2e, 0x50, 0x65, 0x65, 0x72, 0x73, 0x52, 0x65, 0x6d, 0x6f, 0x76, 0x65, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1a, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x50, 0x65,
+	0x65, 0x72, 0x73, 0x52, 0x65, 0x6d, 0x6f, 0x76, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
+	0x65, 0x12, 0x3e, 0x0a, 0x09, 0x50, 0x65, 0x65, 0x72, 0x73, 0x4c, 0x69, 0x73, 0x74, 0x12, 0x17,
+	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x50, 0x65, 0x65, 0x72, 0x73, 0x4c, 0x69, 0x73, 0x74,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x18, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e,
+	0x50, 0x65, 0x65, 0x72, 0x73, 0x4c, 0x69, 0x73, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
+	0x65, 0x12, 0x44, 0x0a, 0x0b, 0x50, 0x65, 0x65, 0x72, 0x73, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73,
+	0x12, 0x19, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x50, 0x65, 0x65, 0x72, 0x73, 0x53, 0x74,
+	0x61, 0x74, 0x75, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1a, 0x2e, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x2e, 0x50, 0x65, 0x65, 0x72, 0x73, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52,
+	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x47, 0x0a, 0x0c, 0x43, 0x68, 0x61, 0x69, 0x6e,
+	0x53, 0x65, 0x74, 0x48, 0x65, 0x61, 0x64, 0x12, 0x1a, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e,
+	0x43, 0x68, 0x61, 0x69, 0x6e, 0x53, 0x65, 0x74, 0x48, 0x65, 0x61, 0x64, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x1a, 0x1b, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x43, 0x68, 0x61, 0x69,
+	0x6e, 0x53, 0x65, 0x74, 0x48, 0x65, 0x61, 0x64, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
+	0x12, 0x50, 0x0a, 0x0f, 0x43, 0x68, 0x61, 0x69, 0x6e, 0x52, 0x65, 0x69, 0x6e, 0x64, 0x65, 0x78,
+	0x42, 0x6f, 0x72, 0x12, 0x1d, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x43, 0x68, 0x61, 0x69,
+	0x6e, 0x52, 0x65, 0x69, 0x6e, 0x64, 0x65, 0x78, 0x42, 0x6f, 0x72, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x1a, 0x1e, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x43, 0x68, 0x61, 0x69, 0x6e,
+	0x52, 0x65, 0x69, 0x6e, 0x64, 0x65, 0x78, 0x42, 0x6f, 0x72, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
+	0x73, 0x65, 0x12, 0x35, 0x0a, 0x06, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x14, 0x2e, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x1a, 0x15, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x53, 0x74, 0x61, 0x74, 0x75,
+	0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x43, 0x0a, 0x0a, 0x43, 0x68, 0x61,
+	0x69, 0x6e, 0x57, 0x61, 0x74, 0x63, 0x68, 0x12, 0x18, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e,
+	0x43, 0x68, 0x61, 0x69, 0x6e, 0x57, 0x61, 0x74, 0x63, 0x68, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x1a, 0x19, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x43, 0x68, 0x61, 0x69, 0x6e, 0x57,
+	0x61, 0x74, 0x63, 0x68, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x30, 0x01, 0x12, 0x42,
+	0x0a, 0x0a, 0x44, 0x65, 0x62, 0x75, 0x67, 0x50, 0x70, 0x72, 0x6f, 0x66, 0x12, 0x18, 0x2e, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x44, 0x65, 0x62, 0x75, 0x67, 0x50, 0x70, 0x72, 0x6f, 0x66, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x18, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x44,
+	0x65, 0x62, 0x75, 0x67, 0x46, 0x69, 0x6c, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
+	0x30, 0x01, 0x12, 0x42, 0x0a, 0x0a, 0x44, 0x65, 0x62, 0x75, 0x67, 0x42, 0x6c, 0x6f, 0x63, 0x6b,
+	0x12, 0x18, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x44, 0x65, 0x62, 0x75, 0x67, 0x42, 0x6c,
+	0x6f, 0x63, 0x6b, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x18, 0x2e, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x2e, 0x44, 0x65, 0x62, 0x75, 0x67, 0x46, 0x69, 0x6c, 0x65, 0x52, 0x65, 0x73, 0x70,
+	0x6f, 0x6e, 0x73, 0x65, 0x30, 0x01, 0x12, 0x47, 0x0a, 0x0c, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67,
+	0x52, 0x65, 0x6c, 0x6f, 0x61, 0x64, 0x12, 0x1a, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x43,
+	0x6f, 0x6e, 0x66, 0x69, 0x67, 0x52, 0x65, 0x6c, 0x6f, 0x61, 0x64, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x1a, 0x1b, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x43, 0x6f, 0x6e, 0x66, 0x69,
+	0x67, 0x52, 0x65, 0x6c, 0x6f, 0x61, 0x64, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12,
+	0x4a, 0x0a, 0x0d, 0x54, 0x78, 0x50, 0x6f, 0x6f, 0x6c, 0x49, 0x6e, 0x73, 0x70, 0x65, 0x63, 0x74,
+	0x12, 0x1b, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x54, 0x78, 0x50, 0x6f, 0x6f, 0x6c, 0x49,
+	0x6e, 0x73, 0x70, 0x65, 0x63, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1c, 0x2e,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x54, 0x78, 0x50, 0x6f, 0x6f, 0x6c, 0x49, 0x6e, 0x73, 0x70,
+	0x65, 0x63, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x44, 0x0a, 0x0b, 0x54,
+	0x78, 0x50, 0x6f, 0x6f, 0x6c, 0x43, 0x6c, 0x65, 0x61, 0x72, 0x12, 0x19, 0x2e, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x2e, 0x54, 0x78, 0x50, 0x6f, 0x6f, 0x6c, 0x43, 0x6c, 0x65, 0x61, 0x72, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1a, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x54, 0x78,
+	0x50, 0x6f, 0x6f, 0x6c, 0x43, 0x6c, 0x65, 0x61, 0x72, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
+	0x65, 0x12, 0x53, 0x0a, 0x10, 0x54, 0x78, 0x50, 0x6f, 0x6f, 0x6c, 0x52, 0x65, 0x73, 0x65, 0x74,
+	0x4e, 0x6f, 0x6e, 0x63, 0x65, 0x12, 0x1e, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x54, 0x78,
+	0x50, 0x6f, 0x6f, 0x6c, 0x52, 0x65, 0x73, 0x65, 0x74, 0x4e, 0x6f, 0x6e, 0x63, 0x65, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1f, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x54, 0x78,
+	0x50, 0x6f, 0x6f, 0x6c, 0x52, 0x65, 0x73, 0x65, 0x74, 0x4e, 0x6f, 0x6e, 0x63, 0x65, 0x52, 0x65,
+	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x58, 0x0a, 0x15, 0x44, 0x65, 0x62, 0x75, 0x67, 0x54,
+	0x72, 0x61, 0x63, 0x65, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x12,
+	0x23, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x44, 0x65, 0x62, 0x75, 0x67, 0x54, 0x72, 0x61,
+	0x63, 0x65, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x71,
 	0x75, 0x65, 0x73, 0x74, 0x1a, 0x18, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x44, 0x65, 0x62,
 	0x75, 0x67, 0x46, 0x69, 0x6c, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x30, 0x01,
-	0x42, 0x1c, 0x5a, 0x1a, 0x2f, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x6e, 0x61, 0x6c, 0x2f, 0x63, 0x6c,
-	0x69, 0x2f, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x06,
-	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+	0x12, 0x4f, 0x0a, 0x0e, 0x44, 0x65, 0x62, 0x75, 0x67, 0x44, 0x75, 0x6d, 0x70, 0x53, 0x74, 0x61,
+	0x74, 0x65, 0x12, 0x1c, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x44, 0x65, 0x62, 0x75, 0x67,
+	0x44, 0x75, 0x6d, 0x70, 0x53, 0x74, 0x61, 0x74, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x1a, 0x1d, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x44, 0x65, 0x62, 0x75, 0x67, 0x44, 0x75,
+	0x6d, 0x70, 0x53, 0x74, 0x61, 0x74, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x30,
+	0x01, 0x12, 0x59, 0x0a, 0x12, 0x44, 0x65, 0x62, 0x75, 0x67, 0x44, 0x61, 0x74, 0x61, 0x62, 0x61,
+	0x73, 0x65, 0x53, 0x74, 0x61, 0x74, 0x73, 0x12, 0x20, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e,
+	0x44, 0x65, 0x62, 0x75, 0x67, 0x44, 0x61, 0x74, 0x61, 0x62, 0x61, 0x73, 0x65, 0x53, 0x74, 0x61,
+	0x74, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x21, 0x2e, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x2e, 0x44, 0x65, 0x62, 0x75, 0x67, 0x44, 0x61, 0x74, 0x61, 0x62, 0x61, 0x73, 0x65, 0x53,
+	0x74, 0x61, 0x74, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x42, 0x1c, 0x5a, 0x1a,
+	0x2f, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x6e, 0x61, 0x6c, 0x2f, 0x63, 0x6c, 0x69, 0x2f, 0x73, 0x65,
+	0x72, 0x76, 0x65, 0x72, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x33,
 }
 
 var (
@@ -1809,76 +3779,128 @@ func file_internal_cli_server_proto_server_proto_rawDescGZIP() []byte {
 	return file_internal_cli_server_proto_server_proto_rawDescData
 }
 
-var file_internal_cli_server_proto_server_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
-var file_internal_cli_server_proto_server_proto_msgTypes = make([]protoimpl.MessageInfo, 27)
+var file_internal_cli_server_proto_server_proto_enumTypes = make([]protoimpl.EnumInfo, 2)
+var file_internal_cli_server_proto_server_proto_msgTypes = make([]protoimpl.MessageInfo, 51)
 var file_internal_cli_server_proto_server_proto_goTypes = []interface{}{
-	(DebugPprofRequest_Type)(0),     // 0: proto.DebugPprofRequest.Type
-	(*TraceRequest)(nil),            // 1: proto.TraceRequest
-	(*TraceResponse)(nil),           // 2: proto.TraceResponse
-	(*ChainWatchRequest)(nil),       // 3: proto.ChainWatchRequest
-	(*ChainWatchResponse)(nil),      // 4: proto.ChainWatchResponse
-	(*BlockStub)(nil),               // 5: proto.BlockStub
-	(*PeersAddRequest)(nil),         // 6: proto.PeersAddRequest
-	(*PeersAddResponse)(nil),        // 7: proto.PeersAddResponse
-	(*PeersRemoveRequest)(nil),      // 8: proto.PeersRemoveRequest
-	(*PeersRemoveResponse)(nil),     // 9: proto.PeersRemoveResponse
-	(*PeersListRequest)(nil),        // 10: proto.PeersListRequest
-	(*PeersListResponse)(nil),       // 11: proto.PeersListResponse
-	(*PeersStatusRequest)(nil),      // 12: proto.PeersStatusRequest
-	(*PeersStatusResponse)(nil),     // 13: proto.PeersStatusResponse
-	(*Peer)(nil),                    // 14: proto.Peer
-	(*ChainSetHeadRequest)(nil),     // 15: proto.ChainSetHeadRequest
-	(*ChainSetHeadResponse)(nil),    // 16: proto.ChainSetHeadResponse
-	(*StatusRequest)(nil),           // 17: proto.StatusRequest
-	(*StatusResponse)(nil),          // 18: proto.StatusResponse
-	(*Header)(nil),                  // 19: proto.Header
-	(*DebugPprofRequest)(nil),       // 20: proto.DebugPprofRequest
-	(*DebugBlockRequest)(nil),       // 21: proto.DebugBlockRequest
-	(*DebugFileResponse)(nil),       // 22: proto.DebugFileResponse
-	(*StatusResponse_Fork)(nil),     // 23: proto.StatusResponse.Fork
-	(*StatusResponse_Syncing)(nil),  // 24: proto.StatusResponse.Syncing
-	(*DebugFileResponse_Open)(nil),  // 25: proto.DebugFileResponse.Open
-	(*DebugFileResponse_Input)(nil), // 26: proto.DebugFileResponse.Input
-	nil,                             // 27: proto.DebugFileResponse.Open.HeadersEntry
-	(*emptypb.Empty)(nil),           // 28: google.protobuf.Empty
+	(ChainReindexBorRequest_Action)(0),        // 0: proto.ChainReindexBorRequest.Action
+	(DebugPprofRequest_Type)(0),               // 1: proto.DebugPprofRequest.Type
+	(*TraceRequest)(nil),                      // 2: proto.TraceRequest
+	(*TraceResponse)(nil),                     // 3: proto.TraceResponse
+	(*ChainWatchRequest)(nil),                 // 4: proto.ChainWatchRequest
+	(*ChainWatchResponse)(nil),                // 5: proto.ChainWatchResponse
+	(*BlockStub)(nil),                         // 6: proto.BlockStub
+	(*PeersAddRequest)(nil),                   // 7: proto.PeersAddRequest
+	(*PeersAddResponse)(nil),                  // 8: proto.PeersAddResponse
+	(*PeersRemoveRequest)(nil),                // 9: proto.PeersRemoveRequest
+	(*PeersRemoveResponse)(nil),               // 10: proto.PeersRemoveResponse
+	(*PeersListRequest)(nil),                  // 11: proto.PeersListRequest
+	(*PeersListResponse)(nil),                 // 12: proto.PeersListResponse
+	(*PeersStatusRequest)(nil),                // 13: proto.PeersStatusRequest
+	(*PeersStatusResponse)(nil),               // 14: proto.PeersStatusResponse
+	(*Peer)(nil),                              // 15: proto.Peer
+	(*ChainSetHeadRequest)(nil),               // 16: proto.ChainSetHeadRequest
+	(*ChainSetHeadResponse)(nil),              // 17: proto.ChainSetHeadResponse
+	(*ChainReindexBorRequest)(nil),            // 18: proto.ChainReindexBorRequest
+	(*ChainReindexBorResponse)(nil),           // 19: proto.ChainReindexBorResponse
+	(*ConfigReloadRequest)(nil),               // 20: proto.ConfigReloadRequest
+	(*ConfigReloadResponse)(nil),              // 21: proto.ConfigReloadResponse
+	(*TxPoolInspectRequest)(nil),              // 22: proto.TxPoolInspectRequest
+	(*TxPoolInspectResponse)(nil),             // 23: proto.TxPoolInspectResponse
+	(*TxPoolClearRequest)(nil),                // 24: proto.TxPoolClearRequest
+	(*TxPoolClearResponse)(nil),               // 25: proto.TxPoolClearResponse
+	(*TxPoolResetNonceRequest)(nil),           // 26: proto.TxPoolResetNonceRequest
+	(*TxPoolResetNonceResponse)(nil),          // 27: proto.TxPoolResetNonceResponse
+	(*DebugTraceTransactionRequest)(nil),      // 28: proto.DebugTraceTransactionRequest
+	(*DebugDumpStateRequest)(nil),             // 29: proto.DebugDumpStateRequest
+	(*DebugDumpStateResponse)(nil),            // 30: proto.DebugDumpStateResponse
+	(*DebugDatabaseStatsRequest)(nil),         // 31: proto.DebugDatabaseStatsRequest
+	(*DebugDatabaseStatsResponse)(nil),        // 32: proto.DebugDatabaseStatsResponse
+	(*StatusRequest)(nil),                     // 33: proto.StatusRequest
+	(*StatusResponse)(nil),                    // 34: proto.StatusResponse
+	(*Header)(nil),                            // 35: proto.Header
+	(*DebugPprofRequest)(nil),                 // 36: proto.DebugPprofRequest
+	(*DebugBlockRequest)(nil),                 // 37: proto.DebugBlockRequest
+	(*DebugFileResponse)(nil),                 // 38: proto.DebugFileResponse
+	(*TxPoolInspectResponse_Transaction)(nil), // 39: proto.TxPoolInspectResponse.Transaction
+	nil, // 40: proto.DebugDumpStateResponse.StorageEntry
+	(*DebugDatabaseStatsResponse_FreezerTable)(nil), // 41: proto.DebugDatabaseStatsResponse.FreezerTable
+	(*StatusResponse_Fork)(nil),                     // 42: proto.StatusResponse.Fork
+	(*StatusResponse_Syncing)(nil),                  // 43: proto.StatusResponse.Syncing
+	(*StatusResponse_HeimdallEndpoint)(nil),         // 44: proto.StatusResponse.HeimdallEndpoint
+	(*StatusResponse_Span)(nil),                     // 45: proto.StatusResponse.Span
+	(*StatusResponse_Validator)(nil),                // 46: proto.StatusResponse.Validator
+	(*StatusResponse_Finality)(nil),                 // 47: proto.StatusResponse.Finality
+	(*StatusResponse_MilestoneLock)(nil),            // 48: proto.StatusResponse.MilestoneLock
+	(*StatusResponse_StateSync)(nil),                // 49: proto.StatusResponse.StateSync
+	(*DebugFileResponse_Open)(nil),                  // 50: proto.DebugFileResponse.Open
+	(*DebugFileResponse_Input)(nil),                 // 51: proto.DebugFileResponse.Input
+	nil,                                             // 52: proto.DebugFileResponse.Open.HeadersEntry
+	(*emptypb.Empty)(nil),                           // 53: google.protobuf.Empty
 }
 var file_internal_cli_server_proto_server_proto_depIdxs = []int32{
-	5,  // 0: proto.ChainWatchResponse.oldchain:type_name -> proto.BlockStub
-	5,  // 1: proto.ChainWatchResponse.newchain:type_name -> proto.BlockStub
-	14, // 2: proto.PeersListResponse.peers:type_name -> proto.Peer
-	14, // 3: proto.PeersStatusResponse.peer:type_name -> proto.Peer
-	19, // 4: proto.StatusResponse.currentBlock:type_name -> proto.Header
-	19, // 5: proto.StatusResponse.currentHeader:type_name -> proto.Header
-	24, // 6: proto.StatusResponse.syncing:type_name -> proto.StatusResponse.Syncing
-	23, // 7: proto.StatusResponse.forks:type_name -> proto.StatusResponse.Fork
-	0,  // 8: proto.DebugPprofRequest.type:type_name -> proto.DebugPprofRequest.Type
-	25, // 9: proto.DebugFileResponse.open:type_name -> proto.DebugFileResponse.Open
-	26, // 10: proto.DebugFileResponse.input:type_name -> proto.DebugFileResponse.Input
-	28, // 11: proto.DebugFileResponse.eof:type_name -> google.protobuf.Empty
-	27, // 12: proto.DebugFileResponse.Open.headers:type_name -> proto.DebugFileResponse.Open.HeadersEntry
-	6,  // 13: proto.Bor.PeersAdd:input_type -> proto.PeersAddRequest
-	8,  // 14: proto.Bor.PeersRemove:input_type -> proto.PeersRemoveRequest
-	10, // 15: proto.Bor.PeersList:input_type -> proto.PeersListRequest
-	12, // 16: proto.Bor.PeersStatus:input_type -> proto.PeersStatusRequest
-	15, // 17: proto.Bor.ChainSetHead:input_type -> proto.ChainSetHeadRequest
-	17, // 18: proto.Bor.Status:input_type -> proto.StatusRequest
-	3,  // 19: proto.Bor.ChainWatch:input_type -> proto.ChainWatchRequest
-	20, // 20: proto.Bor.DebugPprof:input_type -> proto.DebugPprofRequest
-	21, // 21: proto.Bor.DebugBlock:input_type -> proto.DebugBlockRequest
-	7,  // 22: proto.Bor.PeersAdd:output_type -> proto.PeersAddResponse
-	9,  // 23: proto.Bor.PeersRemove:output_type -> proto.PeersRemoveResponse
-	11, // 24: proto.Bor.PeersList:output_type -> proto.PeersListResponse
-	13, // 25: proto.Bor.PeersStatus:output_type -> proto.PeersStatusResponse
-	16, // 26: proto.Bor.ChainSetHead:output_type -> proto.ChainSetHeadResponse
-	18, // 27: proto.Bor.Status:output_type -> proto.StatusResponse
-	4,  // 28: proto.Bor.ChainWatch:output_type -> proto.ChainWatchResponse
-	22, // 29: proto.Bor.DebugPprof:output_type -> proto.DebugFileResponse
-	22, // 30: proto.Bor.DebugBlock:output_type -> proto.DebugFileResponse
-	22, // [22:31] is the sub-list for method output_type
-	13, // [13:22] is the sub-list for method input_type
-	13, // [13:13] is the sub-list for extension type_name
-	13, // [13:13] is the sub-list for extension extendee
-	0,  // [0:13] is the sub-list for field type_name
+	6,  // 0: proto.ChainWatchResponse.oldchain:type_name -> proto.BlockStub
+	6,  // 1: proto.ChainWatchResponse.newchain:type_name -> proto.BlockStub
+	15, // 2: proto.PeersListResponse.peers:type_name -> proto.Peer
+	15, // 3: proto.PeersStatusResponse.peer:type_name -> proto.Peer
+	0,  // 4: proto.ChainReindexBorRequest.action:type_name -> proto.ChainReindexBorRequest.Action
+	39, // 5: proto.TxPoolInspectResponse.transactions:type_name -> proto.TxPoolInspectResponse.Transaction
+	40, // 6: proto.DebugDumpStateResponse.storage:type_name -> proto.DebugDumpStateResponse.StorageEntry
+	41, // 7: proto.DebugDatabaseStatsResponse.tables:type_name -> proto.DebugDatabaseStatsResponse.FreezerTable
+	35, // 8: proto.StatusResponse.currentBlock:type_name -> proto.Header
+	35, // 9: proto.StatusResponse.currentHeader:type_name -> proto.Header
+	43, // 10: proto.StatusResponse.syncing:type_name -> proto.StatusResponse.Syncing
+	42, // 11: proto.StatusResponse.forks:type_name -> proto.StatusResponse.Fork
+	44, // 12: proto.StatusResponse.heimdall:type_name -> proto.StatusResponse.HeimdallEndpoint
+	45, // 13: proto.StatusResponse.span:type_name -> proto.StatusResponse.Span
+	46, // 14: proto.StatusResponse.validator:type_name -> proto.StatusResponse.Validator
+	47, // 15: proto.StatusResponse.milestone:type_name -> proto.StatusResponse.Finality
+	47, // 16: proto.StatusResponse.checkpoint:type_name -> proto.StatusResponse.Finality
+	48, // 17: proto.StatusResponse.milestoneLock:type_name -> proto.StatusResponse.MilestoneLock
+	49, // 18: proto.StatusResponse.stateSync:type_name -> proto.StatusResponse.StateSync
+	1,  // 19: proto.DebugPprofRequest.type:type_name -> proto.DebugPprofRequest.Type
+	50, // 20: proto.DebugFileResponse.open:type_name -> proto.DebugFileResponse.Open
+	51, // 21: proto.DebugFileResponse.input:type_name -> proto.DebugFileResponse.Input
+	53, // 22: proto.DebugFileResponse.eof:type_name -> google.protobuf.Empty
+	52, // 23: proto.DebugFileResponse.Open.headers:type_name -> proto.DebugFileResponse.Open.HeadersEntry
+	7,  // 24: proto.Bor.PeersAdd:input_type -> proto.PeersAddRequest
+	9,  // 25: proto.Bor.PeersRemove:input_type -> proto.PeersRemoveRequest
+	11, // 26: proto.Bor.PeersList:input_type -> proto.PeersListRequest
+	13, // 27: proto.Bor.PeersStatus:input_type -> proto.PeersStatusRequest
+	16, // 28: proto.Bor.ChainSetHead:input_type -> proto.ChainSetHeadRequest
+	18, // 29: proto.Bor.ChainReindexBor:input_type -> proto.ChainReindexBorRequest
+	33, // 30: proto.Bor.Status:input_type -> proto.StatusRequest
+	4,  // 31: proto.Bor.ChainWatch:input_type -> proto.ChainWatchRequest
+	36, // 32: proto.Bor.DebugPprof:input_type -> proto.DebugPprofRequest
+	37, // 33: proto.Bor.DebugBlock:input_type -> proto.DebugBlockRequest
+	20, // 34: proto.Bor.ConfigReload:input_type -> proto.ConfigReloadRequest
+	22, // 35: proto.Bor.TxPoolInspect:input_type -> proto.TxPoolInspectRequest
+	24, // 36: proto.Bor.TxPoolClear:input_type -> proto.TxPoolClearRequest
+	26, // 37: proto.Bor.TxPoolResetNonce:input_type -> proto.TxPoolResetNonceRequest
+	28, // 38: proto.Bor.DebugTraceTransaction:input_type -> proto.DebugTraceTransactionRequest
+	29, // 39: proto.Bor.DebugDumpState:input_type -> proto.DebugDumpStateRequest
+	31, // 40: proto.Bor.DebugDatabaseStats:input_type -> proto.DebugDatabaseStatsRequest
+	8,  // 41: proto.Bor.PeersAdd:output_type -> proto.PeersAddResponse
+	10, // 42: proto.Bor.PeersRemove:output_type -> proto.PeersRemoveResponse
+	12, // 43: proto.Bor.PeersList:output_type -> proto.PeersListResponse
+	14, // 44: proto.Bor.PeersStatus:output_type -> proto.PeersStatusResponse
+	17, // 45: proto.Bor.ChainSetHead:output_type -> proto.ChainSetHeadResponse
+	19, // 46: proto.Bor.ChainReindexBor:output_type -> proto.ChainReindexBorResponse
+	34, // 47: proto.Bor.Status:output_type -> proto.StatusResponse
+	5,  // 48: proto.Bor.ChainWatch:output_type -> proto.ChainWatchResponse
+	38, // 49: proto.Bor.DebugPprof:output_type -> proto.DebugFileResponse
+	38, // 50: proto.Bor.DebugBlock:output_type -> proto.DebugFileResponse
+	21, // 51: proto.Bor.ConfigReload:output_type -> proto.ConfigReloadResponse
+	23, // 52: proto.Bor.TxPoolInspect:output_type -> proto.TxPoolInspectResponse
+	25, // 53: proto.Bor.TxPoolClear:output_type -> proto.TxPoolClearResponse
+	27, // 54: proto.Bor.TxPoolResetNonce:output_type -> proto.TxPoolResetNonceResponse
+	38, // 55: proto.Bor.DebugTraceTransaction:output_type -> proto.DebugFileResponse
+	30, // 56: proto.Bor.DebugDumpState:output_type -> proto.DebugDumpStateResponse
+	32, // 57: proto.Bor.DebugDatabaseStats:output_type -> proto.DebugDatabaseStatsResponse
+	41, // [41:58] is the sub-list for method output_type
+	24, // [24:41] is the sub-list for method input_type
+	24, // [24:24] is the sub-list for extension type_name
+	24, // [24:24] is the sub-list for extension extendee
+	0,  // [0:24] is the sub-list for field type_name
 }
 
 func init() { file_internal_cli_server_proto_server_proto_init() }
@@ -2081,7 +4103,7 @@ func file_internal_cli_server_proto_server_proto_init() {
 			}
 		}
 		file_internal_cli_server_proto_server_proto_msgTypes[16].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*StatusRequest); i {
+			switch v := v.(*ChainReindexBorRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2093,7 +4115,7 @@ func file_internal_cli_server_proto_server_proto_init() {
 			}
 		}
 		file_internal_cli_server_proto_server_proto_msgTypes[17].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*StatusResponse); i {
+			switch v := v.(*ChainReindexBorResponse); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2105,7 +4127,7 @@ func file_internal_cli_server_proto_server_proto_init() {
 			}
 		}
 		file_internal_cli_server_proto_server_proto_msgTypes[18].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*Header); i {
+			switch v := v.(*ConfigReloadRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2117,7 +4139,7 @@ func file_internal_cli_server_proto_server_proto_init() {
 			}
 		}
 		file_internal_cli_server_proto_server_proto_msgTypes[19].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*DebugPprofRequest); i {
+			switch v := v.(*ConfigReloadResponse); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2129,7 +4151,7 @@ func file_internal_cli_server_proto_server_proto_init() {
 			}
 		}
 		file_internal_cli_server_proto_server_proto_msgTypes[20].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*DebugBlockRequest); i {
+			switch v := v.(*TxPoolInspectRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2141,7 +4163,7 @@ func file_internal_cli_server_proto_server_proto_init() {
 			}
 		}
 		file_internal_cli_server_proto_server_proto_msgTypes[21].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*DebugFileResponse); i {
+			switch v := v.(*TxPoolInspectResponse); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2153,7 +4175,7 @@ func file_internal_cli_server_proto_server_proto_init() {
 			}
 		}
 		file_internal_cli_server_proto_server_proto_msgTypes[22].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*StatusResponse_Fork); i {
+			switch v := v.(*TxPoolClearRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2165,7 +4187,7 @@ func file_internal_cli_server_proto_server_proto_init() {
 			}
 		}
 		file_internal_cli_server_proto_server_proto_msgTypes[23].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*StatusResponse_Syncing); i {
+			switch v := v.(*TxPoolClearResponse); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2177,7 +4199,7 @@ func file_internal_cli_server_proto_server_proto_init() {
 			}
 		}
 		file_internal_cli_server_proto_server_proto_msgTypes[24].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*DebugFileResponse_Open); i {
+			switch v := v.(*TxPoolResetNonceRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2189,6 +4211,282 @@ func file_internal_cli_server_proto_server_proto_init() {
 			}
 		}
 		file_internal_cli_server_proto_server_proto_msgTypes[25].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*TxPoolResetNonceResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_internal_cli_server_proto_server_proto_msgTypes[26].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*DebugTraceTransactionRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_internal_cli_server_proto_server_proto_msgTypes[27].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*DebugDumpStateRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_internal_cli_server_proto_server_proto_msgTypes[28].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*DebugDumpStateResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_internal_cli_server_proto_server_proto_msgTypes[29].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*DebugDatabaseStatsRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_internal_cli_server_proto_server_proto_msgTypes[30].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*DebugDatabaseStatsResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_internal_cli_server_proto_server_proto_msgTypes[31].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*StatusRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_internal_cli_server_proto_server_proto_msgTypes[32].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*StatusResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_internal_cli_server_proto_server_proto_msgTypes[33].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Header); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_internal_cli_server_proto_server_proto_msgTypes[34].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*DebugPprofRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_internal_cli_server_proto_server_proto_msgTypes[35].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*DebugBlockRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_internal_cli_server_proto_server_proto_msgTypes[36].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*DebugFileResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_internal_cli_server_proto_server_proto_msgTypes[37].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*TxPoolInspectResponse_Transaction); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_internal_cli_server_proto_server_proto_msgTypes[39].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*DebugDatabaseStatsResponse_FreezerTable); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_internal_cli_server_proto_server_proto_msgTypes[40].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*StatusResponse_Fork); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_internal_cli_server_proto_server_proto_msgTypes[41].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*StatusResponse_Syncing); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_internal_cli_server_proto_server_proto_msgTypes[42].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*StatusResponse_HeimdallEndpoint); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_internal_cli_server_proto_server_proto_msgTypes[43].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*StatusResponse_Span); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_internal_cli_server_proto_server_proto_msgTypes[44].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*StatusResponse_Validator); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_internal_cli_server_proto_server_proto_msgTypes[45].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*StatusResponse_Finality); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_internal_cli_server_proto_server_proto_msgTypes[46].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*StatusResponse_MilestoneLock); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_internal_cli_server_proto_server_proto_msgTypes[47].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*StatusResponse_StateSync); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_internal_cli_server_proto_server_proto_msgTypes[48].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*DebugFileResponse_Open); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_internal_cli_server_proto_server_proto_msgTypes[49].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*DebugFileResponse_Input); i {
 			case 0:
 				return &v.state
@@ -2202,7 +4500,9 @@ func file_internal_cli_server_proto_server_proto_init() {
 		}
 	}
 
-	file_internal_cli_server_proto_server_proto_msgTypes[21].OneofWrappers = []interface{}{
+	file_internal_cli_server_proto_server_proto_msgTypes[27].OneofWrappers = []interface{}{}
+
+	file_internal_cli_server_proto_server_proto_msgTypes[36].OneofWrappers = []interface{}{
 		(*DebugFileResponse_Open_)(nil),
 		(*DebugFileResponse_Input_)(nil),
 		(*DebugFileResponse_Eof)(nil),
@@ -2214,8 +4514,8 @@ func file_internal_cli_server_proto_server_proto_init() {
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_internal_cli_server_proto_server_proto_rawDesc,
-			NumEnums:      1,
-			NumMessages:   27,
+			NumEnums:      2,
+			NumMessages:   51,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
@@ -27,10 +27,18 @@ type BorClient interface {
 	PeersList(ctx context.Context, in *PeersListRequest, opts ...grpc.CallOption) (*PeersListResponse, error)
 	PeersStatus(ctx context.Context, in *PeersStatusRequest, opts ...grpc.CallOption) (*PeersStatusResponse, error)
 	ChainSetHead(ctx context.Context, in *ChainSetHeadRequest, opts ...grpc.CallOption) (*ChainSetHeadResponse, error)
+	ChainReindexBor(ctx context.Context, in *ChainReindexBorRequest, opts ...grpc.CallOption) (*ChainReindexBorResponse, error)
 	Status(ctx context.Context, in *StatusRequest, opts ...grpc.CallOption) (*StatusResponse, error)
 	ChainWatch(ctx context.Context, in *ChainWatchRequest, opts ...grpc.CallOption) (Bor_ChainWatchClient, error)
 	DebugPprof(ctx context.Context, in *DebugPprofRequest, opts ...grpc.CallOption) (Bor_DebugPprofClient, error)
 	DebugBlock(ctx context.Context, in *DebugBlockRequest, opts ...grpc.CallOption) (Bor_DebugBlockClient, error)
+	ConfigReload(ctx context.Context, in *ConfigReloadRequest, opts ...grpc.CallOption) (*ConfigReloadResponse, error)
+	TxPoolInspect(ctx context.Context, in *TxPoolInspectRequest, opts ...grpc.CallOption) (*TxPoolInspectResponse, error)
+	TxPoolClear(ctx context.Context, in *TxPoolClearRequest, opts ...grpc.CallOption) (*TxPoolClearResponse, error)
+	TxPoolResetNonce(ctx context.Context, in *TxPoolResetNonceRequest, opts ...grpc.CallOption) (*TxPoolResetNonceResponse, error)
+	DebugTraceTransaction(ctx context.Context, in *DebugTraceTransactionRequest, opts ...grpc.CallOption) (Bor_DebugTraceTransactionClient, error)
+	DebugDumpState(ctx context.Context, in *DebugDumpStateRequest, opts ...grpc.CallOption) (Bor_DebugDumpStateClient, error)
+	DebugDatabaseStats(ctx context.Context, in *DebugDatabaseStatsRequest, opts ...grpc.CallOption) (*DebugDatabaseStatsResponse, error)
 }
 
 type borClient struct {
@@ -96,6 +104,17 @@ func (c *borClient) ChainSetHead(ctx context.Context, in *ChainSetHeadRequest, o
 	return out, nil
 }
 
+func (c *borClient) ChainReindexBor(ctx context.Context, in *ChainReindexBorRequest, opts ...grpc.CallOption) (*ChainReindexBorResponse, error) {
+	out := new(ChainReindexBorResponse)
+
+	err := c.cc.Invoke(ctx, "/proto.Bor/ChainReindexBor", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	return out, nil
+}
+
 func (c *borClient) Status(ctx context.Context, in *StatusRequest, opts ...grpc.CallOption) (*StatusResponse, error) {
 	out := new(StatusResponse)
 
@@ -215,6 +234,133 @@ func (x *borDebugBlockClient) Recv() (*DebugFileResponse, error) {
 	return m, nil
 }
 
+func (c *borClient) ConfigReload(ctx context.Context, in *ConfigReloadRequest, opts ...grpc.CallOption) (*ConfigReloadResponse, error) {
+	out := new(ConfigReloadResponse)
+
+	err := c.cc.Invoke(ctx, "/proto.Bor/ConfigReload", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	return out, nil
+}
+
+func (c *borClient) TxPoolInspect(ctx context.Context, in *TxPoolInspectRequest, opts ...grpc.CallOption) (*TxPoolInspectResponse, error) {
+	out := new(TxPoolInspectResponse)
+
+	err := c.cc.Invoke(ctx, "/proto.Bor/TxPoolInspect", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	return out, nil
+}
+
+func (c *borClient) TxPoolClear(ctx context.Context, in *TxPoolClearRequest, opts ...grpc.CallOption) (*TxPoolClearResponse, error) {
+	out := new(TxPoolClearResponse)
+
+	err := c.cc.Invoke(ctx, "/proto.Bor/TxPoolClear", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	return out, nil
+}
+
+func (c *borClient) TxPoolResetNonce(ctx context.Context, in *TxPoolResetNonceRequest, opts ...grpc.CallOption) (*TxPoolResetNonceResponse, error) {
+	out := new(TxPoolResetNonceResponse)
+
+	err := c.cc.Invoke(ctx, "/proto.Bor/TxPoolResetNonce", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	return out, nil
+}
+
+func (c *borClient) DebugTraceTransaction(ctx context.Context, in *DebugTraceTransactionRequest, opts ...grpc.CallOption) (Bor_DebugTraceTransactionClient, error) {
+	stream, err := c.cc.NewStream(ctx, &Bor_ServiceDesc.Streams[3], "/proto.Bor/DebugTraceTransaction", opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	x := &borDebugTraceTransactionClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+
+	return x, nil
+}
+
+type Bor_DebugTraceTransactionClient interface {
+	Recv() (*DebugFileResponse, error)
+	grpc.ClientStream
+}
+
+type borDebugTraceTransactionClient struct {
+	grpc.ClientStream
+}
+
+func (x *borDebugTraceTransactionClient) Recv() (*DebugFileResponse, error) {
+	m := new(DebugFileResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+
+	return m, nil
+}
+
+func (c *borClient) DebugDumpState(ctx context.Context, in *DebugDumpStateRequest, opts ...grpc.CallOption) (Bor_DebugDumpStateClient, error) {
+	stream, err := c.cc.NewStream(ctx, &Bor_ServiceDesc.Streams[4], "/proto.Bor/DebugDumpState", opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	x := &borDebugDumpStateClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+
+	return x, nil
+}
+
+type Bor_DebugDumpStateClient interface {
+	Recv() (*DebugDumpStateResponse, error)
+	grpc.ClientStream
+}
+
+type borDebugDumpStateClient struct {
+	grpc.ClientStream
+}
+
+func (x *borDebugDumpStateClient) Recv() (*DebugDumpStateResponse, error) {
+	m := new(DebugDumpStateResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+
+	return m, nil
+}
+
+func (c *borClient) DebugDatabaseStats(ctx context.Context, in *DebugDatabaseStatsRequest, opts ...grpc.CallOption) (*DebugDatabaseStatsResponse, error) {
+	out := new(DebugDatabaseStatsResponse)
+
+	err := c.cc.Invoke(ctx, "/proto.Bor/DebugDatabaseStats", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	return out, nil
+}
+
 // BorServer is the server API for Bor service.
 // All implementations must embed UnimplementedBorServer
 // for forward compatibility
@@ -224,10 +370,18 @@ type BorServer interface {
 	PeersList(context.Context, *PeersListRequest) (*PeersListResponse, error)
 	PeersStatus(context.Context, *PeersStatusRequest) (*PeersStatusResponse, error)
 	ChainSetHead(context.Context, *ChainSetHeadRequest) (*ChainSetHeadResponse, error)
+	ChainReindexBor(context.Context, *ChainReindexBorRequest) (*ChainReindexBorResponse, error)
 	Status(context.Context, *StatusRequest) (*StatusResponse, error)
 	ChainWatch(*ChainWatchRequest, Bor_ChainWatchServer) error
 	DebugPprof(*DebugPprofRequest, Bor_DebugPprofServer) error
 	DebugBlock(*DebugBlockRequest, Bor_DebugBlockServer) error
+	ConfigReload(context.Context, *ConfigReloadRequest) (*ConfigReloadResponse, error)
+	TxPoolInspect(context.Context, *TxPoolInspectRequest) (*TxPoolInspectResponse, error)
+	TxPoolClear(context.Context, *TxPoolClearRequest) (*TxPoolClearResponse, error)
+	TxPoolResetNonce(context.Context, *TxPoolResetNonceRequest) (*TxPoolResetNonceResponse, error)
+	DebugTraceTransaction(*DebugTraceTransactionRequest, Bor_DebugTraceTransactionServer) error
+	DebugDumpState(*DebugDumpStateRequest, Bor_DebugDumpStateServer) error
+	DebugDatabaseStats(context.Context, *DebugDatabaseStatsRequest) (*DebugDatabaseStatsResponse, error)
 	mustEmbedUnimplementedBorServer()
 }
 
@@ -250,6 +404,9 @@ func (UnimplementedBorServer) PeersStatus(context.Context, *PeersStatusRequest)
 func (UnimplementedBorServer) ChainSetHead(context.Context, *ChainSetHeadRequest) (*ChainSetHeadResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method ChainSetHead not implemented")
 }
+func (UnimplementedBorServer) ChainReindexBor(context.Context, *ChainReindexBorRequest) (*ChainReindexBorResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ChainReindexBor not implemented")
+}
 func (UnimplementedBorServer) Status(context.Context, *StatusRequest) (*StatusResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method Status not implemented")
 }
@@ -262,6 +419,27 @@ func (UnimplementedBorServer) DebugPprof(*DebugPprofRequest, Bor_DebugPprofServe
 func (UnimplementedBorServer) DebugBlock(*DebugBlockRequest, Bor_DebugBlockServer) error {
 	return status.Errorf(codes.Unimplemented, "method DebugBlock not implemented")
 }
+func (UnimplementedBorServer) ConfigReload(context.Context, *ConfigReloadRequest) (*ConfigReloadResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ConfigReload not implemented")
+}
+func (UnimplementedBorServer) TxPoolInspect(context.Context, *TxPoolInspectRequest) (*TxPoolInspectResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method TxPoolInspect not implemented")
+}
+func (UnimplementedBorServer) TxPoolClear(context.Context, *TxPoolClearRequest) (*TxPoolClearResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method TxPoolClear not implemented")
+}
+func (UnimplementedBorServer) TxPoolResetNonce(context.Context, *TxPoolResetNonceRequest) (*TxPoolResetNonceResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method TxPoolResetNonce not implemented")
+}
+func (UnimplementedBorServer) DebugTraceTransaction(*DebugTraceTransactionRequest, Bor_DebugTraceTransactionServer) error {
+	return status.Errorf(codes.Unimplemented, "method DebugTraceTransaction not implemented")
+}
+func (UnimplementedBorServer) DebugDumpState(*DebugDumpStateRequest, Bor_DebugDumpStateServer) error {
+	return status.Errorf(codes.Unimplemented, "method DebugDumpState not implemented")
+}
+func (UnimplementedBorServer) DebugDatabaseStats(context.Context, *DebugDatabaseStatsRequest) (*DebugDatabaseStatsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DebugDatabaseStats not implemented")
+}
 func (UnimplementedBorServer) mustEmbedUnimplementedBorServer() {}
 
 // UnsafeBorServer may be embedded to opt out of forward compatibility for this service.
@@ -380,6 +558,27 @@ func _Bor_ChainSetHead_Handler(srv interface{}, ctx context.Context, dec func(in
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Bor_ChainReindexBor_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ChainReindexBorRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+
+	if interceptor == nil {
+		return srv.(BorServer).ChainReindexBor(ctx, in)
+	}
+
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/proto.Bor/ChainReindexBor",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BorServer).ChainReindexBor(ctx, req.(*ChainReindexBorRequest))
+	}
+
+	return interceptor(ctx, in, info, handler)
+}
+
 func _Bor_Status_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(StatusRequest)
 	if err := dec(in); err != nil {
@@ -467,6 +666,155 @@ func (x *borDebugBlockServer) Send(m *DebugFileResponse) error {
 	return x.ServerStream.SendMsg(m)
 }
 
+func _Bor_ConfigReload_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ConfigReloadRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+
+	if interceptor == nil {
+		return srv.(BorServer).ConfigReload(ctx, in)
+	}
+
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/proto.Bor/ConfigReload",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BorServer).ConfigReload(ctx, req.(*ConfigReloadRequest))
+	}
+
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Bor_TxPoolInspect_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TxPoolInspectRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+
+	if interceptor == nil {
+		return srv.(BorServer).TxPoolInspect(ctx, in)
+	}
+
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/proto.Bor/TxPoolInspect",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BorServer).TxPoolInspect(ctx, req.(*TxPoolInspectRequest))
+	}
+
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Bor_TxPoolClear_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TxPoolClearRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+
+	if interceptor == nil {
+		return srv.(BorServer).TxPoolClear(ctx, in)
+	}
+
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/proto.Bor/TxPoolClear",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BorServer).TxPoolClear(ctx, req.(*TxPoolClearRequest))
+	}
+
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Bor_TxPoolResetNonce_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TxPoolResetNonceRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+
+	if interceptor == nil {
+		return srv.(BorServer).TxPoolResetNonce(ctx, in)
+	}
+
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/proto.Bor/TxPoolResetNonce",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BorServer).TxPoolResetNonce(ctx, req.(*TxPoolResetNonceRequest))
+	}
+
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Bor_DebugTraceTransaction_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(DebugTraceTransactionRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+
+	return srv.(BorServer).DebugTraceTransaction(m, &borDebugTraceTransactionServer{stream})
+}
+
+type Bor_DebugTraceTransactionServer interface {
+	Send(*DebugFileResponse) error
+	grpc.ServerStream
+}
+
+type borDebugTraceTransactionServer struct {
+	grpc.ServerStream
+}
+
+func (x *borDebugTraceTransactionServer) Send(m *DebugFileResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _Bor_DebugDumpState_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(DebugDumpStateRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+
+	return srv.(BorServer).DebugDumpState(m, &borDebugDumpStateServer{stream})
+}
+
+type Bor_DebugDumpStateServer interface {
+	Send(*DebugDumpStateResponse) error
+	grpc.ServerStream
+}
+
+type borDebugDumpStateServer struct {
+	grpc.ServerStream
+}
+
+func (x *borDebugDumpStateServer) Send(m *DebugDumpStateResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _Bor_DebugDatabaseStats_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DebugDatabaseStatsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+
+	if interceptor == nil {
+		return srv.(BorServer).DebugDatabaseStats(ctx, in)
+	}
+
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/proto.Bor/DebugDatabaseStats",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BorServer).DebugDatabaseStats(ctx, req.(*DebugDatabaseStatsRequest))
+	}
+
+	return interceptor(ctx, in, info, handler)
+}
+
 // Bor_ServiceDesc is the grpc.ServiceDesc for Bor service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -494,10 +842,34 @@ var Bor_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "ChainSetHead",
 			Handler:    _Bor_ChainSetHead_Handler,
 		},
+		{
+			MethodName: "ChainReindexBor",
+			Handler:    _Bor_ChainReindexBor_Handler,
+		},
 		{
 			MethodName: "Status",
 			Handler:    _Bor_Status_Handler,
 		},
+		{
+			MethodName: "ConfigReload",
+			Handler:    _Bor_ConfigReload_Handler,
+		},
+		{
+			MethodName: "TxPoolInspect",
+			Handler:    _Bor_TxPoolInspect_Handler,
+		},
+		{
+			MethodName: "TxPoolClear",
+			Handler:    _Bor_TxPoolClear_Handler,
+		},
+		{
+			MethodName: "TxPoolResetNonce",
+			Handler:    _Bor_TxPoolResetNonce_Handler,
+		},
+		{
+			MethodName: "DebugDatabaseStats",
+			Handler:    _Bor_DebugDatabaseStats_Handler,
+		},
 	},
 	Streams: []grpc.StreamDesc{
 		{
@@ -515,6 +887,16 @@ var Bor_ServiceDesc = grpc.ServiceDesc{
 			Handler:       _Bor_DebugBlock_Handler,
 			ServerStreams: true,
 		},
+		{
+			StreamName:    "DebugTraceTransaction",
+			Handler:       _Bor_DebugTraceTransaction_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "DebugDumpState",
+			Handler:       _Bor_DebugDumpState_Handler,
+			ServerStreams: true,
+		},
 	},
 	Metadata: "internal/cli/server/proto/server.proto",
 }
@@ -10,6 +10,7 @@ import (
 	"os"
 	"runtime"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/mattn/go-colorable"
@@ -35,6 +36,7 @@ import (
 	"github.com/ethereum/go-ethereum/graphql"
 	"github.com/ethereum/go-ethereum/internal/cli/server/pprof"
 	"github.com/ethereum/go-ethereum/internal/cli/server/proto"
+	"github.com/ethereum/go-ethereum/internal/grpcauth"
 	"github.com/ethereum/go-ethereum/log"
 	"github.com/ethereum/go-ethereum/metrics"
 	"github.com/ethereum/go-ethereum/metrics/influxdb"
@@ -56,6 +58,25 @@ type Server struct {
 
 	// tracerAPI to trace block executions
 	tracerAPI *tracers.API
+
+	// configLoader re-reads the configuration on reload requests
+	configLoader func() (*Config, error)
+	reloadLock   sync.Mutex
+
+	// grpcAuth authorizes the grpc calls by bearer token
+	grpcAuth *grpcauth.Authorizer
+}
+
+// adminMethods are the grpc methods modifying the node, which require the
+// admin token if one is configured.
+var adminMethods = []string{
+	"/proto.Bor/PeersAdd",
+	"/proto.Bor/PeersRemove",
+	"/proto.Bor/ChainSetHead",
+	"/proto.Bor/ChainReindexBor",
+	"/proto.Bor/ConfigReload",
+	"/proto.Bor/TxPoolClear",
+	"/proto.Bor/TxPoolResetNonce",
 }
 
 type serverOption func(srv *Server, config *Config) error
@@ -80,6 +101,15 @@ func WithGRPCListener(lis net.Listener) serverOption {
 	}
 }
 
+// WithConfigLoader sets the function used to re-read the configuration when
+// a config reload is requested.
+func WithConfigLoader(loader func() (*Config, error)) serverOption {
+	return func(srv *Server, _ *Config) error {
+		srv.configLoader = loader
+		return nil
+	}
+}
+
 func VerbosityIntToString(verbosity int) string {
 	mapIntToString := map[int]string{
 		5: "trace",
@@ -257,6 +287,10 @@ func NewServer(config *Config, opts ...serverOption) (*Server, error) {
 	stack.RegisterAPIs(tracers.APIs(srv.backend.APIBackend))
 	srv.tracerAPI = tracers.NewAPI(srv.backend.APIBackend)
 
+	if config.JsonRPC.TraceIndex {
+		stack.RegisterLifecycle(tracers.NewTraceIndexer(srv.backend.APIBackend, config.JsonRPC.TraceIndexFrom))
+	}
+
 	// graphql is started from another place
 	if config.JsonRPC.Graphql.Enabled {
 		if err := graphql.New(stack, srv.backend.APIBackend, filterSystem, config.JsonRPC.Graphql.Cors, config.JsonRPC.Graphql.VHost); err != nil {
@@ -438,7 +472,30 @@ func (s *Server) gRPCServerByAddress(addr string) error {
 }
 
 func (s *Server) gRPCServerByListener(listener net.Listener) error {
-	s.grpcServer = grpc.NewServer(s.withLoggingUnaryInterceptor())
+	s.grpcAuth = grpcauth.NewAuthorizer(s.config.GRPC.Token, s.config.GRPC.AdminToken, adminMethods)
+
+	opts := []grpc.ServerOption{
+		grpc.ChainUnaryInterceptor(s.loggingServerInterceptor, s.grpcAuth.UnaryInterceptor()),
+		grpc.ChainStreamInterceptor(s.grpcAuth.StreamInterceptor()),
+	}
+
+	tlsConfig := &grpcauth.TLSConfig{
+		CertFile: s.config.GRPC.TLSCert,
+		KeyFile:  s.config.GRPC.TLSKey,
+		CAFile:   s.config.GRPC.TLSClientCA,
+	}
+	if tlsConfig.Enabled() {
+		creds, err := grpcauth.NewServerCredentials(tlsConfig)
+		if err != nil {
+			return err
+		}
+
+		opts = append(opts, grpc.Creds(creds))
+	} else if s.config.GRPC.Token != "" || s.config.GRPC.AdminToken != "" {
+		log.Warn("GRPC tokens are accepted over an insecure connection, configure grpc.tls-cert and grpc.tls-key")
+	}
+
+	s.grpcServer = grpc.NewServer(opts...)
 	proto.RegisterBorServer(s.grpcServer, s)
 
 	go func() {
@@ -447,15 +504,11 @@ func (s *Server) gRPCServerByListener(listener net.Listener) error {
 		}
 	}()
 
-	log.Info("GRPC Server started", "addr", listener.Addr())
+	log.Info("GRPC Server started", "addr", listener.Addr(), "tls", tlsConfig.Enabled(), "mtls", tlsConfig.CAFile != "")
 
 	return nil
 }
 
-func (s *Server) withLoggingUnaryInterceptor() grpc.ServerOption {
-	return grpc.UnaryInterceptor(s.loggingServerInterceptor)
-}
-
 func (s *Server) loggingServerInterceptor(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
 	start := time.Now()
 	h, err := handler(ctx, req)
@@ -1,10 +1,19 @@
 package server
 
 import (
+	"context"
 	"testing"
 	"time"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+
+	"github.com/ethereum/go-ethereum/internal/cli/server/proto"
+	"github.com/ethereum/go-ethereum/internal/grpcauth"
 )
 
 func TestServer_DeveloperMode(t *testing.T) {
@@ -39,3 +48,64 @@ func TestServer_DeveloperMode(t *testing.T) {
 		}
 	}
 }
+
+func TestServer_GRPCAuth(t *testing.T) {
+	t.Parallel()
+
+	config := DefaultConfig()
+	config.Developer.Enabled = true
+	config.Developer.Period = 2
+	config.GRPC.Token = "read"
+	config.GRPC.AdminToken = "admin"
+
+	server, err := CreateMockServer(config)
+	require.NoError(t, err)
+
+	defer CloseMockServer(server)
+
+	dial := func(token string) proto.BorClient {
+		opts := []grpc.DialOption{grpc.WithTransportCredentials(insecure.NewCredentials())}
+		if token != "" {
+			opts = append(opts, grpc.WithPerRPCCredentials(grpcauth.NewTokenCredentials(token, false)))
+		}
+
+		conn, err := grpc.Dial("127.0.0.1"+server.config.GRPC.Addr, opts...)
+		require.NoError(t, err)
+
+		t.Cleanup(func() { conn.Close() })
+
+		return proto.NewBorClient(conn)
+	}
+
+	ctx := context.Background()
+
+	_, err = dial("").PeersList(ctx, &proto.PeersListRequest{})
+	require.Equal(t, codes.Unauthenticated, status.Code(err))
+
+	_, err = dial("read").PeersList(ctx, &proto.PeersListRequest{})
+	require.NoError(t, err)
+
+	_, err = dial("read").PeersRemove(ctx, &proto.PeersRemoveRequest{Enode: "invalid"})
+	require.Equal(t, codes.PermissionDenied, status.Code(err))
+
+	// The admin token passes the authorization, the handler rejects the enode
+	_, err = dial("admin").PeersRemove(ctx, &proto.PeersRemoveRequest{Enode: "invalid"})
+	require.Error(t, err)
+	require.Equal(t, codes.Unknown, status.Code(err))
+
+	// Rotated tokens apply without a restart
+	next := *server.config
+	nextGRPC := *next.GRPC
+	next.GRPC = &nextGRPC
+	next.GRPC.Token = "read2"
+
+	applied, _, err := server.applyConfig(&next)
+	require.NoError(t, err)
+	require.Equal(t, []string{"grpc.token"}, applied)
+
+	_, err = dial("read").PeersList(ctx, &proto.PeersListRequest{})
+	require.Equal(t, codes.Unauthenticated, status.Code(err))
+
+	_, err = dial("read2").PeersList(ctx, &proto.PeersListRequest{})
+	require.NoError(t, err)
+}
@@ -1,33 +1,55 @@
 package server
 
 import (
+	"bufio"
 	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
+	"io"
 	"math/big"
 	"reflect"
+	"sort"
 	"strings"
 	"time"
 
 	grpc_net_conn "github.com/JekaMas/go-grpc-net-conn"
 
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/consensus/bor"
 	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/core/state"
 	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/eth/downloader/whitelist"
 	"github.com/ethereum/go-ethereum/eth/tracers"
 	"github.com/ethereum/go-ethereum/eth/tracers/logger"
 	"github.com/ethereum/go-ethereum/internal/cli/server/pprof"
 	"github.com/ethereum/go-ethereum/internal/cli/server/proto"
+	"github.com/ethereum/go-ethereum/log"
 	"github.com/ethereum/go-ethereum/p2p"
 	"github.com/ethereum/go-ethereum/p2p/enode"
 )
 
 const chunkSize = 1024 * 1024 * 1024
 
+// streamBufferSize is the size of the buffer coalescing the writes to the
+// streamed debug files.
+const streamBufferSize = 1024 * 1024
+
 var ErrUnavailable = errors.New("bor service is currently unavailable, try again later")
 var ErrUnavailable2 = errors.New("bor service unavailable even after waiting for 10 seconds, make sure bor is running")
 
 func sendStreamDebugFile(stream proto.Bor_DebugPprofServer, headers map[string]string, data []byte) error {
+	return writeStreamDebugFile(stream, headers, func(w io.Writer) error {
+		_, err := w.Write(data)
+		return err
+	})
+}
+
+// writeStreamDebugFile opens the stream, sends what write produces in chunks
+// as it is written, then closes the stream.
+func writeStreamDebugFile(stream proto.Bor_DebugPprofServer, headers map[string]string, write func(w io.Writer) error) error {
 	// open the stream and send the headers
 	err := stream.Send(&proto.DebugFileResponse{
 		Event: &proto.DebugFileResponse_Open_{
@@ -51,7 +73,14 @@ func sendStreamDebugFile(stream proto.Bor_DebugPprofServer, headers map[string]s
 		Encode:  grpc_net_conn.ChunkedEncoder(encoder, chunkSize),
 	}
 
-	if _, err := conn.Write(data); err != nil {
+	// Coalesce the small writes, without holding more than a buffer
+	buffered := bufio.NewWriterSize(conn, streamBufferSize)
+
+	if err := write(buffered); err != nil {
+		return err
+	}
+
+	if err := buffered.Flush(); err != nil {
 		return err
 	}
 
@@ -103,6 +132,10 @@ func (s *Server) PeersAdd(ctx context.Context, req *proto.PeersAddRequest) (*pro
 	}
 
 	srv := s.node.Server()
+	if err := srv.CheckTopology(node); err != nil {
+		return nil, err
+	}
+
 	if req.Trusted {
 		srv.AddTrustedPeer(node)
 	} else {
@@ -133,7 +166,7 @@ func (s *Server) PeersList(ctx context.Context, req *proto.PeersListRequest) (*p
 
 	peers := s.node.Server().PeersInfo()
 	for _, p := range peers {
-		resp.Peers = append(resp.Peers, peerInfoToPeer(p))
+		resp.Peers = append(resp.Peers, s.peerInfoToPeer(p))
 	}
 
 	return resp, nil
@@ -154,14 +187,14 @@ func (s *Server) PeersStatus(ctx context.Context, req *proto.PeersStatusRequest)
 
 	resp := &proto.PeersStatusResponse{}
 	if peerInfo != nil {
-		resp.Peer = peerInfoToPeer(peerInfo)
+		resp.Peer = s.peerInfoToPeer(peerInfo)
 	}
 
 	return resp, nil
 }
 
-func peerInfoToPeer(info *p2p.PeerInfo) *proto.Peer {
-	return &proto.Peer{
+func (s *Server) peerInfoToPeer(info *p2p.PeerInfo) *proto.Peer {
+	peer := &proto.Peer{
 		Id:      info.ID,
 		Enode:   info.Enode,
 		Enr:     info.ENR,
@@ -170,6 +203,12 @@ func peerInfoToPeer(info *p2p.PeerInfo) *proto.Peer {
 		Trusted: info.Network.Trusted,
 		Static:  info.Network.Static,
 	}
+
+	if score, ok := s.backend.PeerRating(info.ID); ok {
+		peer.Score = int64(score)
+	}
+
+	return peer
 }
 
 func (s *Server) ChainSetHead(ctx context.Context, req *proto.ChainSetHeadRequest) (*proto.ChainSetHeadResponse, error) {
@@ -177,6 +216,37 @@ func (s *Server) ChainSetHead(ctx context.Context, req *proto.ChainSetHeadReques
 	return &proto.ChainSetHeadResponse{}, nil
 }
 
+func (s *Server) ChainReindexBor(ctx context.Context, req *proto.ChainReindexBorRequest) (*proto.ChainReindexBorResponse, error) {
+	chain := s.backend.BlockChain()
+
+	switch req.Action {
+	case proto.ChainReindexBorRequest_START:
+		if err := chain.StartBorReindex(req.From, req.To); err != nil {
+			return nil, err
+		}
+	case proto.ChainReindexBorRequest_STOP:
+		chain.StopBorReindex()
+	case proto.ChainReindexBorRequest_BACKFILL:
+		written, err := s.backend.BackfillBorReceipts(ctx, req.From, req.To)
+		if err != nil {
+			return nil, fmt.Errorf("backfilled %d bor receipts: %w", written, err)
+		}
+
+		return &proto.ChainReindexBorResponse{From: req.From, To: req.To, Backfilled: uint64(written)}, nil
+	}
+
+	status := chain.BorReindexStatus()
+
+	return &proto.ChainReindexBorResponse{
+		Running: status.Running,
+		From:    status.From,
+		To:      status.To,
+		Next:    status.Next,
+		Written: status.Written,
+		Error:   status.Err,
+	}, nil
+}
+
 func (s *Server) Status(ctx context.Context, in *proto.StatusRequest) (*proto.StatusResponse, error) {
 	if s.backend == nil && !in.Wait {
 		return nil, ErrUnavailable
@@ -218,9 +288,105 @@ func (s *Server) Status(ctx context.Context, in *proto.StatusRequest) (*proto.St
 		Forks: gatherForks(s.config.chain.Genesis.Config, s.config.chain.Genesis.Config.Bor),
 	}
 
+	if engine, ok := s.backend.Engine().(*bor.Bor); ok {
+		s.fillBorStatus(ctx, engine, apiBackend.CurrentBlock(), resp)
+	}
+
 	return resp, nil
 }
 
+// heimdallStatusTimeout bounds the Heimdall requests made while gathering the
+// status, so that an unreachable Heimdall does not stall the response.
+const heimdallStatusTimeout = 3 * time.Second
+
+// fillBorStatus adds the Bor specific health information to the status: the
+// Heimdall connectivity, current span, validator duties, finality and state
+// sync backlog.
+func (s *Server) fillBorStatus(ctx context.Context, engine *bor.Bor, head *types.Header, resp *proto.StatusResponse) {
+	if engine.HeimdallClient != nil {
+		resp.Heimdall = []*proto.StatusResponse_HeimdallEndpoint{s.probeHeimdall(ctx, engine.HeimdallClient)}
+	}
+
+	limit := head.Number.Uint64()
+	if span, err := engine.GetSpanner().GetCurrentSpan(ctx, head.Hash()); err == nil {
+		resp.Span = &proto.StatusResponse_Span{
+			Id:         span.ID,
+			StartBlock: span.StartBlock,
+			EndBlock:   span.EndBlock,
+		}
+		limit = span.EndBlock
+	} else {
+		log.Debug("Failed to retrieve current span", "err", err)
+	}
+
+	if etherbase, err := s.backend.Etherbase(); err == nil {
+		resp.Validator = &proto.StatusResponse_Validator{Address: etherbase.String()}
+
+		inSet, slot, err := engine.NextProducerSlot(s.backend.BlockChain(), head, etherbase, limit)
+		if err == nil {
+			resp.Validator.InValidatorSet, resp.Validator.NextSlot = inSet, slot
+		} else {
+			log.Debug("Failed to compute next producer slot", "err", err)
+		}
+	}
+
+	if validator, ok := s.backend.Downloader().ChainValidator.(*whitelist.Service); ok {
+		exists, number, hash := validator.GetWhitelistedMilestone()
+		resp.Milestone = &proto.StatusResponse_Finality{Exists: exists, Number: number, Hash: hash.String()}
+
+		exists, number, hash = validator.GetWhitelistedCheckpoint()
+		resp.Checkpoint = &proto.StatusResponse_Finality{Exists: exists, Number: number, Hash: hash.String()}
+
+		locked, number, hash := validator.GetLockedMilestone()
+		resp.MilestoneLock = &proto.StatusResponse_MilestoneLock{
+			Locked:       locked,
+			Number:       number,
+			Hash:         hash.String(),
+			MilestoneIds: validator.GetMilestoneIDsList(),
+		}
+	}
+
+	if engine.HeimdallClient != nil {
+		ctx, cancel := context.WithTimeout(ctx, heimdallStatusTimeout)
+		defer cancel()
+
+		lastStateID, pending, err := engine.StateSyncBacklog(ctx, head)
+
+		resp.StateSync = &proto.StatusResponse_StateSync{LastStateId: lastStateID, Pending: int64(pending)}
+		if err != nil {
+			resp.StateSync.Error = err.Error()
+		}
+	}
+}
+
+// probeHeimdall checks the connectivity and latency of the Heimdall endpoint
+// the consensus engine talks to.
+func (s *Server) probeHeimdall(ctx context.Context, client bor.IHeimdallClient) *proto.StatusResponse_HeimdallEndpoint {
+	endpoint := &proto.StatusResponse_HeimdallEndpoint{Url: s.config.Heimdall.URL}
+
+	switch {
+	case s.config.Heimdall.RunHeimdall && s.config.Heimdall.UseHeimdallApp:
+		endpoint.Url = "heimdall-app"
+	case s.config.Heimdall.GRPCAddress != "":
+		endpoint.Url = s.config.Heimdall.GRPCAddress
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, heimdallStatusTimeout)
+	defer cancel()
+
+	start := time.Now()
+	_, err := client.FetchCheckpointCount(ctx)
+	endpoint.LatencyMs = time.Since(start).Milliseconds()
+
+	if err != nil {
+		endpoint.Error = err.Error()
+	} else {
+		endpoint.Reachable = true
+	}
+
+	return endpoint
+}
+
 func headerToProtoHeader(h *types.Header) *proto.Header {
 	return &proto.Header{
 		Hash:   h.Hash().String(),
@@ -238,22 +404,11 @@ func (s *Server) DebugBlock(req *proto.DebugBlockRequest, stream proto.Bor_Debug
 		},
 	}
 
-	res, err := s.tracerAPI.TraceBorBlock(traceReq)
-	if err != nil {
-		return err
-	}
-
-	// this is memory heavy
-	data, err := json.Marshal(res)
-	if err != nil {
-		return err
-	}
-
-	if err := sendStreamDebugFile(stream, map[string]string{}, data); err != nil {
-		return err
-	}
-
-	return nil
+	// Stream the transaction traces as they complete, the whole block trace
+	// being too large to hold in memory
+	return writeStreamDebugFile(stream, map[string]string{}, func(w io.Writer) error {
+		return tracers.WriteBorBlockTrace(stream.Context(), s.tracerAPI, traceReq, w)
+	})
 }
 
 var bigIntT = reflect.TypeOf(new(big.Int)).Kind()
@@ -345,3 +500,217 @@ func (s *Server) ChainWatch(req *proto.ChainWatchRequest, reply proto.Bor_ChainW
 		}
 	}
 }
+
+func (s *Server) ConfigReload(ctx context.Context, req *proto.ConfigReloadRequest) (*proto.ConfigReloadResponse, error) {
+	applied, restart, err := s.ReloadConfig()
+	if err != nil {
+		return nil, err
+	}
+
+	return &proto.ConfigReloadResponse{Applied: applied, Restart: restart}, nil
+}
+
+func (s *Server) TxPoolInspect(ctx context.Context, req *proto.TxPoolInspectRequest) (*proto.TxPoolInspectResponse, error) {
+	var pending, queued map[common.Address][]*types.Transaction
+
+	if req.Address != "" {
+		if !common.IsHexAddress(req.Address) {
+			return nil, fmt.Errorf("invalid address: %s", req.Address)
+		}
+
+		addr := common.HexToAddress(req.Address)
+		accPending, accQueued := s.backend.TxPool().ContentFrom(addr)
+		pending = map[common.Address][]*types.Transaction{addr: accPending}
+		queued = map[common.Address][]*types.Transaction{addr: accQueued}
+	} else {
+		pending, queued = s.backend.TxPool().Content()
+	}
+
+	resp := &proto.TxPoolInspectResponse{}
+
+	for from, txs := range pending {
+		for _, tx := range txs {
+			resp.Transactions = append(resp.Transactions, txToProtoPoolTransaction(from, tx, true))
+		}
+
+		resp.Pending += uint64(len(txs))
+	}
+
+	for from, txs := range queued {
+		for _, tx := range txs {
+			resp.Transactions = append(resp.Transactions, txToProtoPoolTransaction(from, tx, false))
+		}
+
+		resp.Queued += uint64(len(txs))
+	}
+
+	sort.Slice(resp.Transactions, func(i, j int) bool {
+		a, b := resp.Transactions[i], resp.Transactions[j]
+		if a.From != b.From {
+			return a.From < b.From
+		}
+
+		return a.Nonce < b.Nonce
+	})
+
+	return resp, nil
+}
+
+func txToProtoPoolTransaction(from common.Address, tx *types.Transaction, pending bool) *proto.TxPoolInspectResponse_Transaction {
+	ptx := &proto.TxPoolInspectResponse_Transaction{
+		Hash:      tx.Hash().String(),
+		From:      from.String(),
+		Nonce:     tx.Nonce(),
+		Value:     tx.Value().String(),
+		Gas:       tx.Gas(),
+		GasFeeCap: tx.GasFeeCap().String(),
+		GasTipCap: tx.GasTipCap().String(),
+		Pending:   pending,
+	}
+	if to := tx.To(); to != nil {
+		ptx.To = to.String()
+	}
+
+	return ptx
+}
+
+func (s *Server) TxPoolClear(ctx context.Context, req *proto.TxPoolClearRequest) (*proto.TxPoolClearResponse, error) {
+	// Clear every subpool, conditional transactions included
+	dropped := s.backend.LegacyPool().Clear() + s.backend.ConditionalPool().Clear()
+	return &proto.TxPoolClearResponse{Dropped: uint64(dropped)}, nil
+}
+
+func (s *Server) TxPoolResetNonce(ctx context.Context, req *proto.TxPoolResetNonceRequest) (*proto.TxPoolResetNonceResponse, error) {
+	if !common.IsHexAddress(req.Address) {
+		return nil, fmt.Errorf("invalid address: %s", req.Address)
+	}
+
+	addr := common.HexToAddress(req.Address)
+	dropped := s.backend.LegacyPool().DropAccount(addr)
+
+	return &proto.TxPoolResetNonceResponse{
+		Dropped: uint64(dropped),
+		Nonce:   s.backend.TxPool().Nonce(addr),
+	}, nil
+}
+
+func (s *Server) DebugTraceTransaction(req *proto.DebugTraceTransactionRequest, stream proto.Bor_DebugTraceTransactionServer) error {
+	config := &tracers.TraceConfig{}
+	if req.Tracer != "" {
+		config.Tracer = &req.Tracer
+	}
+
+	if req.TracerConfig != "" {
+		config.TracerConfig = json.RawMessage(req.TracerConfig)
+	}
+
+	res, err := s.tracerAPI.TraceTransaction(stream.Context(), common.HexToHash(req.Hash), config)
+	if err != nil {
+		return err
+	}
+
+	data, err := json.Marshal(res)
+	if err != nil {
+		return err
+	}
+
+	return sendStreamDebugFile(stream, map[string]string{}, data)
+}
+
+// dumpStateCollector streams the accounts of a state dump to a gRPC client.
+type dumpStateCollector struct {
+	stream proto.Bor_DebugDumpStateServer
+	err    error
+}
+
+func (c *dumpStateCollector) OnRoot(common.Hash) {}
+
+func (c *dumpStateCollector) OnAccount(addr *common.Address, account state.DumpAccount) {
+	// The iteration cannot be aborted, skip the remaining accounts after a failure
+	if c.err != nil {
+		return
+	}
+
+	resp := &proto.DebugDumpStateResponse{
+		Balance:  account.Balance,
+		Nonce:    account.Nonce,
+		Root:     account.Root.String(),
+		CodeHash: account.CodeHash.String(),
+		Code:     account.Code,
+	}
+	if addr != nil {
+		resp.Address = addr.String()
+	} else {
+		resp.Key = account.SecureKey.String()
+	}
+
+	if len(account.Storage) > 0 {
+		resp.Storage = make(map[string]string, len(account.Storage))
+		for key, value := range account.Storage {
+			resp.Storage[key.String()] = value
+		}
+	}
+
+	c.err = c.stream.Send(resp)
+}
+
+func (s *Server) DebugDumpState(req *proto.DebugDumpStateRequest, stream proto.Bor_DebugDumpStateServer) error {
+	chain := s.backend.BlockChain()
+
+	// The latest block is dumped unless a block is given
+	header := chain.CurrentBlock()
+	if req.Number != nil && *req.Number >= 0 {
+		if header = chain.GetHeaderByNumber(uint64(*req.Number)); header == nil {
+			return fmt.Errorf("block #%d not found", *req.Number)
+		}
+	}
+
+	statedb, err := chain.StateAt(header.Root)
+	if err != nil {
+		return err
+	}
+
+	collector := &dumpStateCollector{stream: stream}
+	statedb.DumpToCollector(collector, &state.DumpConfig{
+		SkipCode:    req.SkipCode,
+		SkipStorage: req.SkipStorage,
+		Max:         req.Max,
+	})
+
+	return collector.err
+}
+
+func (s *Server) DebugDatabaseStats(ctx context.Context, req *proto.DebugDatabaseStatsRequest) (*proto.DebugDatabaseStatsResponse, error) {
+	db := s.backend.ChainDb()
+
+	ancients, err := db.Ancients()
+	if err != nil {
+		return nil, err
+	}
+
+	tail, err := db.Tail()
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &proto.DebugDatabaseStatsResponse{
+		Ancients: ancients,
+		Tail:     tail,
+	}
+
+	for _, table := range rawdb.ChainFreezerTables() {
+		size, err := db.AncientSize(table)
+		if err != nil {
+			return nil, err
+		}
+
+		resp.Tables = append(resp.Tables, &proto.DebugDatabaseStatsResponse_FreezerTable{Name: table, Size: size})
+	}
+
+	// Only the leveldb engine reports its internal statistics
+	if stats, err := db.Stat("leveldb.stats"); err == nil {
+		resp.EngineStats = stats
+	}
+
+	return resp, nil
+}
@@ -1,10 +1,12 @@
 package server
 
 import (
+	"context"
 	"math/big"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 
 	"github.com/ethereum/go-ethereum/internal/cli/server/proto"
 )
@@ -44,3 +46,61 @@ func TestGatherBlocks(t *testing.T) {
 	res := gatherForks(val, val2)
 	assert.Equal(t, res, expect)
 }
+
+type dumpStateStream struct {
+	proto.Bor_DebugDumpStateServer
+
+	accounts []*proto.DebugDumpStateResponse
+}
+
+func (s *dumpStateStream) Send(resp *proto.DebugDumpStateResponse) error {
+	s.accounts = append(s.accounts, resp)
+	return nil
+}
+
+func TestServer_AdminServices(t *testing.T) {
+	t.Parallel()
+
+	config := DefaultConfig()
+	config.Developer.Enabled = true
+	config.Developer.Period = 2
+
+	server, err := CreateMockServer(config)
+	require.NoError(t, err)
+
+	defer CloseMockServer(server)
+
+	ctx := context.Background()
+
+	inspect, err := server.TxPoolInspect(ctx, &proto.TxPoolInspectRequest{})
+	require.NoError(t, err)
+	require.Zero(t, inspect.Pending+inspect.Queued)
+
+	_, err = server.TxPoolInspect(ctx, &proto.TxPoolInspectRequest{Address: "0xinvalid"})
+	require.Error(t, err)
+
+	_, err = server.TxPoolResetNonce(ctx, &proto.TxPoolResetNonceRequest{Address: "0xinvalid"})
+	require.Error(t, err)
+
+	cleared, err := server.TxPoolClear(ctx, &proto.TxPoolClearRequest{})
+	require.NoError(t, err)
+	require.Zero(t, cleared.Dropped)
+
+	stats, err := server.DebugDatabaseStats(ctx, &proto.DebugDatabaseStatsRequest{})
+	require.NoError(t, err)
+	require.NotEmpty(t, stats.Tables)
+
+	// The developer genesis allocates the precompiles and the developer account
+	stream := &dumpStateStream{}
+	genesis := int64(0)
+	require.NoError(t, server.DebugDumpState(&proto.DebugDumpStateRequest{Number: &genesis, Max: 2}, stream))
+	require.Len(t, stream.accounts, 2)
+
+	// Without a block, the latest one is dumped
+	stream = &dumpStateStream{}
+	require.NoError(t, server.DebugDumpState(&proto.DebugDumpStateRequest{Max: 2}, stream))
+	require.Len(t, stream.accounts, 2)
+
+	missing := int64(1 << 40)
+	require.Error(t, server.DebugDumpState(&proto.DebugDumpStateRequest{Number: &missing}, &dumpStateStream{}))
+}
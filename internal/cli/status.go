@@ -34,6 +34,9 @@ func (p *StatusCommand) MarkDown() string {
 	items := []string{
 		"# Status",
 		"The ```status``` command outputs the status of the client.",
+		"Besides the chain head, peers and sync progress, nodes running the Bor consensus engine also report the Heimdall connectivity and latency, " +
+			"the current span, whether the node is part of the validator set and its next in-turn block, the latest whitelisted milestone and checkpoint, " +
+			"the milestone lock state and the state sync backlog.",
 	}
 
 	return strings.Join(items, "\n\n")
@@ -111,5 +114,85 @@ func printStatus(status *proto.StatusResponse) string {
 		formatList(forks),
 	}
 
+	full = append(full, printBorStatus(status)...)
+
 	return strings.Join(full, "\n")
 }
+
+// printBorStatus formats the Bor specific sections of the status, which are
+// only present when the node runs the Bor consensus engine.
+func printBorStatus(status *proto.StatusResponse) []string {
+	var full []string
+
+	if len(status.Heimdall) > 0 {
+		endpoints := []string{"Endpoint|Reachable|Latency|Error"}
+		for _, e := range status.Heimdall {
+			endpoints = append(endpoints, fmt.Sprintf("%s|%v|%dms|%s", e.Url, e.Reachable, e.LatencyMs, e.Error))
+		}
+
+		full = append(full, "\nHeimdall", formatList(endpoints))
+	}
+
+	if status.Span != nil {
+		full = append(full, "\nSpan", formatKV([]string{
+			fmt.Sprintf("ID|%d", status.Span.Id),
+			fmt.Sprintf("Start block|%d", status.Span.StartBlock),
+			fmt.Sprintf("End block|%d", status.Span.EndBlock),
+		}))
+	}
+
+	if status.Validator != nil {
+		nextSlot := emptyPlaceHolder
+		if status.Validator.NextSlot != 0 {
+			nextSlot = fmt.Sprintf("%d", status.Validator.NextSlot)
+		}
+
+		full = append(full, "\nValidator", formatKV([]string{
+			fmt.Sprintf("Address|%s", status.Validator.Address),
+			fmt.Sprintf("In validator set|%v", status.Validator.InValidatorSet),
+			fmt.Sprintf("Next slot|%s", nextSlot),
+		}))
+	}
+
+	printFinality := func(f *proto.StatusResponse_Finality) []string {
+		if f == nil || !f.Exists {
+			return []string{emptyPlaceHolder}
+		}
+
+		return []string{fmt.Sprintf("%d", f.Number), f.Hash}
+	}
+
+	if status.Milestone != nil || status.Checkpoint != nil {
+		full = append(full, "\nFinality", formatKV([]string{
+			fmt.Sprintf("Milestone|%s", strings.Join(printFinality(status.Milestone), " ")),
+			fmt.Sprintf("Checkpoint|%s", strings.Join(printFinality(status.Checkpoint), " ")),
+		}))
+	}
+
+	if lock := status.MilestoneLock; lock != nil {
+		locked := []string{fmt.Sprintf("Locked|%v", lock.Locked)}
+		if lock.Locked {
+			locked = append(locked,
+				fmt.Sprintf("Locked block|%d", lock.Number),
+				fmt.Sprintf("Locked hash|%s", lock.Hash),
+				fmt.Sprintf("Milestone IDs|%s", strings.Join(lock.MilestoneIds, ",")),
+			)
+		}
+
+		full = append(full, "\nMilestone Lock", formatKV(locked))
+	}
+
+	if sync := status.StateSync; sync != nil {
+		backlog := []string{
+			fmt.Sprintf("Last state ID|%d", sync.LastStateId),
+			fmt.Sprintf("Pending events|%d", sync.Pending),
+		}
+		if sync.Error != "" {
+			backlog = append(backlog, fmt.Sprintf("Error|%s", sync.Error))
+		}
+
+		full = append(full, "\nState Sync", formatKV(backlog))
+	}
+
+	return full
+}
@@ -1,12 +1,14 @@
 package cli
 
 import (
+	"strings"
 	"testing"
 
 	"github.com/mitchellh/cli"
 	"github.com/stretchr/testify/require"
 
 	"github.com/ethereum/go-ethereum/internal/cli/server"
+	"github.com/ethereum/go-ethereum/internal/cli/server/proto"
 )
 
 func TestStatusCommand(t *testing.T) {
@@ -40,3 +42,25 @@ func TestStatusCommand(t *testing.T) {
 
 	require.Equal(t, 0, status)
 }
+
+func TestPrintBorStatus(t *testing.T) {
+	t.Parallel()
+
+	// Non-bor nodes report no bor sections
+	require.Empty(t, printBorStatus(&proto.StatusResponse{}))
+
+	status := &proto.StatusResponse{
+		Heimdall:      []*proto.StatusResponse_HeimdallEndpoint{{Url: "http://localhost:1317", Reachable: true, LatencyMs: 12}},
+		Span:          &proto.StatusResponse_Span{Id: 3, StartBlock: 6656, EndBlock: 13055},
+		Validator:     &proto.StatusResponse_Validator{Address: "0x01", InValidatorSet: true, NextSlot: 6672},
+		Milestone:     &proto.StatusResponse_Finality{Exists: true, Number: 6640, Hash: "0x02"},
+		Checkpoint:    &proto.StatusResponse_Finality{},
+		MilestoneLock: &proto.StatusResponse_MilestoneLock{Locked: true, Number: 6660, Hash: "0x03", MilestoneIds: []string{"id"}},
+		StateSync:     &proto.StatusResponse_StateSync{LastStateId: 42, Pending: 2},
+	}
+
+	output := strings.Join(printBorStatus(status), "\n")
+	for _, expected := range []string{"http://localhost:1317", "13055", "6672", "6640 0x02", "Checkpoint = <none>", "6660", "Pending events = 2"} {
+		require.Contains(t, output, expected)
+	}
+}
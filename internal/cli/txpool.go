@@ -0,0 +1,54 @@
+package cli
+
+import (
+	"strings"
+
+	"github.com/mitchellh/cli"
+)
+
+// TxPoolCommand is the command to group the txpool commands
+type TxPoolCommand struct {
+	UI cli.Ui
+}
+
+// MarkDown implements cli.MarkDown interface
+func (c *TxPoolCommand) MarkDown() string {
+	items := []string{
+		"# TxPool",
+		"The ```txpool``` command groups actions to interact with the transaction pool:",
+		"- [```txpool inspect```](./txpool_inspect.md): Lists the pending and queued transactions of the pool.",
+		"- [```txpool clear```](./txpool_clear.md): Drops all the transactions from the pool.",
+		"- [```txpool reset-nonce```](./txpool_reset-nonce.md): Drops the transactions of an account and resets its pool nonce.",
+	}
+
+	return strings.Join(items, "\n\n")
+}
+
+// Help implements the cli.Command interface
+func (c *TxPoolCommand) Help() string {
+	return `Usage: bor txpool <subcommand>
+
+  This command groups actions to interact with the transaction pool.
+
+  List the transactions in the pool:
+
+    $ bor txpool inspect
+
+  Drop all the transactions from the pool:
+
+    $ bor txpool clear
+
+  Drop the transactions of an account and reset its nonce:
+
+    $ bor txpool reset-nonce <address>`
+}
+
+// Synopsis implements the cli.Command interface
+func (c *TxPoolCommand) Synopsis() string {
+	return "Interact with the transaction pool"
+}
+
+// Run implements the cli.Command interface
+func (c *TxPoolCommand) Run(args []string) int {
+	return cli.RunResultHelp
+}
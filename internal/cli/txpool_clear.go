@@ -0,0 +1,91 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/internal/cli/flagset"
+	"github.com/ethereum/go-ethereum/internal/cli/server/proto"
+)
+
+// TxPoolClearCommand is the command to drop all the transactions of the pool
+type TxPoolClearCommand struct {
+	*Meta2
+
+	yes bool
+}
+
+// MarkDown implements cli.MarkDown interface
+func (c *TxPoolClearCommand) MarkDown() string {
+	items := []string{
+		"# TxPool clear",
+		"The ```txpool clear``` command drops all the pending and queued transactions from the pool, conditional transactions included. The transactions are not broadcast again.",
+		c.Flags().MarkDown(),
+	}
+
+	return strings.Join(items, "\n\n")
+}
+
+// Help implements the cli.Command interface
+func (c *TxPoolClearCommand) Help() string {
+	return `Usage: bor txpool clear [--yes]
+
+  Drop all the transactions from the pool`
+}
+
+func (c *TxPoolClearCommand) Flags() *flagset.Flagset {
+	flags := c.NewFlagSet("txpool clear")
+
+	flags.BoolFlag(&flagset.BoolFlag{
+		Name:    "yes",
+		Usage:   "Clear the pool without confirmation",
+		Default: false,
+		Value:   &c.yes,
+	})
+
+	return flags
+}
+
+// Synopsis implements the cli.Command interface
+func (c *TxPoolClearCommand) Synopsis() string {
+	return "Drop all the transactions from the pool"
+}
+
+// Run implements the cli.Command interface
+func (c *TxPoolClearCommand) Run(args []string) int {
+	flags := c.Flags()
+	if err := flags.Parse(args); err != nil {
+		c.UI.Error(err.Error())
+		return 1
+	}
+
+	borClt, err := c.BorConn()
+	if err != nil {
+		c.UI.Error(err.Error())
+		return 1
+	}
+
+	if !c.yes {
+		response, err := c.UI.Ask("Are you sure you want to drop all the transactions of the pool? (y/n)")
+		if err != nil {
+			c.UI.Error(err.Error())
+			return 1
+		}
+
+		if response != "y" {
+			c.UI.Output("txpool clear aborted")
+			return 0
+		}
+	}
+
+	resp, err := borClt.TxPoolClear(context.Background(), &proto.TxPoolClearRequest{})
+	if err != nil {
+		c.UI.Error(err.Error())
+		return 1
+	}
+
+	c.UI.Output(fmt.Sprintf("Dropped %d transactions", resp.Dropped))
+
+	return 0
+}
@@ -0,0 +1,117 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/internal/cli/flagset"
+	"github.com/ethereum/go-ethereum/internal/cli/server/proto"
+)
+
+// TxPoolInspectCommand is the command to list the transactions of the pool
+type TxPoolInspectCommand struct {
+	*Meta2
+
+	from string
+}
+
+// MarkDown implements cli.MarkDown interface
+func (c *TxPoolInspectCommand) MarkDown() string {
+	items := []string{
+		"# TxPool inspect",
+		"The ```txpool inspect``` command lists the pending and queued transactions of the pool, sorted by sender and nonce.",
+		c.Flags().MarkDown(),
+	}
+
+	return strings.Join(items, "\n\n")
+}
+
+// Help implements the cli.Command interface
+func (c *TxPoolInspectCommand) Help() string {
+	return `Usage: bor txpool inspect [--from <address>]
+
+  List the pending and queued transactions of the pool.
+
+  ` + c.Flags().Help()
+}
+
+func (c *TxPoolInspectCommand) Flags() *flagset.Flagset {
+	flags := c.NewFlagSet("txpool inspect")
+
+	flags.StringFlag(&flagset.StringFlag{
+		Name:  "from",
+		Value: &c.from,
+		Usage: "Only list the transactions sent by this address",
+	})
+
+	return flags
+}
+
+// Synopsis implements the cli.Command interface
+func (c *TxPoolInspectCommand) Synopsis() string {
+	return "List the transactions of the pool"
+}
+
+// Run implements the cli.Command interface
+func (c *TxPoolInspectCommand) Run(args []string) int {
+	flags := c.Flags()
+	if err := flags.Parse(args); err != nil {
+		c.UI.Error(err.Error())
+		return 1
+	}
+
+	borClt, err := c.BorConn()
+	if err != nil {
+		c.UI.Error(err.Error())
+		return 1
+	}
+
+	resp, err := borClt.TxPoolInspect(context.Background(), &proto.TxPoolInspectRequest{Address: c.from})
+	if err != nil {
+		c.UI.Error(err.Error())
+		return 1
+	}
+
+	c.UI.Output(formatTxPoolInspect(resp))
+
+	return 0
+}
+
+func formatTxPoolInspect(resp *proto.TxPoolInspectResponse) string {
+	out := formatKV([]string{
+		fmt.Sprintf("Pending|%d", resp.Pending),
+		fmt.Sprintf("Queued|%d", resp.Queued),
+	})
+
+	if len(resp.Transactions) == 0 {
+		return out
+	}
+
+	rows := make([]string, len(resp.Transactions)+1)
+	rows[0] = "Hash|From|To|Nonce|Value|Gas|FeeCap|TipCap|Status"
+
+	for i, tx := range resp.Transactions {
+		to, status := tx.To, "queued"
+		if to == "" {
+			to = "<contract creation>"
+		}
+
+		if tx.Pending {
+			status = "pending"
+		}
+
+		rows[i+1] = fmt.Sprintf("%s|%s|%s|%d|%s|%d|%s|%s|%s",
+			tx.Hash,
+			tx.From,
+			to,
+			tx.Nonce,
+			tx.Value,
+			tx.Gas,
+			tx.GasFeeCap,
+			tx.GasTipCap,
+			status)
+	}
+
+	return out + "\n\n" + formatList(rows)
+}
@@ -0,0 +1,80 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/internal/cli/flagset"
+	"github.com/ethereum/go-ethereum/internal/cli/server/proto"
+)
+
+// TxPoolResetNonceCommand is the command to reset the pool nonce of an account
+type TxPoolResetNonceCommand struct {
+	*Meta2
+}
+
+// MarkDown implements cli.MarkDown interface
+func (c *TxPoolResetNonceCommand) MarkDown() string {
+	items := []string{
+		"# TxPool reset-nonce",
+		"The ```txpool reset-nonce <address>``` command drops all the transactions of an account from the pool " +
+			"and resets its pool nonce to the nonce of the current state. It can be used to recover an account " +
+			"whose transactions are stuck behind a nonce gap.",
+		"## Arguments",
+		"- ```address```: The address of the account.",
+		c.Flags().MarkDown(),
+	}
+
+	return strings.Join(items, "\n\n")
+}
+
+// Help implements the cli.Command interface
+func (c *TxPoolResetNonceCommand) Help() string {
+	return `Usage: bor txpool reset-nonce <address>
+
+  Drop the transactions of an account and reset its pool nonce`
+}
+
+func (c *TxPoolResetNonceCommand) Flags() *flagset.Flagset {
+	return c.NewFlagSet("txpool reset-nonce")
+}
+
+// Synopsis implements the cli.Command interface
+func (c *TxPoolResetNonceCommand) Synopsis() string {
+	return "Drop the transactions of an account and reset its pool nonce"
+}
+
+// Run implements the cli.Command interface
+func (c *TxPoolResetNonceCommand) Run(args []string) int {
+	flags := c.Flags()
+	if err := flags.Parse(args); err != nil {
+		c.UI.Error(err.Error())
+		return 1
+	}
+
+	args = flags.Args()
+	if len(args) != 1 {
+		c.UI.Error("No address provided")
+		return 1
+	}
+
+	borClt, err := c.BorConn()
+	if err != nil {
+		c.UI.Error(err.Error())
+		return 1
+	}
+
+	resp, err := borClt.TxPoolResetNonce(context.Background(), &proto.TxPoolResetNonceRequest{Address: args[0]})
+	if err != nil {
+		c.UI.Error(err.Error())
+		return 1
+	}
+
+	c.UI.Output(formatKV([]string{
+		fmt.Sprintf("Dropped|%d", resp.Dropped),
+		fmt.Sprintf("Nonce|%d", resp.Nonce),
+	}))
+
+	return 0
+}
@@ -1173,6 +1173,46 @@ func (diff *BlockOverrides) Apply(blockCtx *vm.BlockContext) {
 	}
 }
 
+// MakeHeader returns a copy of the given header with the overridden fields
+// applied.
+func (diff *BlockOverrides) MakeHeader(header *types.Header) *types.Header {
+	if diff == nil {
+		return header
+	}
+
+	h := types.CopyHeader(header)
+
+	if diff.Number != nil {
+		h.Number = diff.Number.ToInt()
+	}
+
+	if diff.Difficulty != nil {
+		h.Difficulty = diff.Difficulty.ToInt()
+	}
+
+	if diff.Time != nil {
+		h.Time = uint64(*diff.Time)
+	}
+
+	if diff.GasLimit != nil {
+		h.GasLimit = uint64(*diff.GasLimit)
+	}
+
+	if diff.Coinbase != nil {
+		h.Coinbase = *diff.Coinbase
+	}
+
+	if diff.Random != nil {
+		h.MixDigest = *diff.Random
+	}
+
+	if diff.BaseFee != nil {
+		h.BaseFee = diff.BaseFee.ToInt()
+	}
+
+	return h
+}
+
 // ChainContextBackend provides methods required to implement ChainContext.
 type ChainContextBackend interface {
 	Engine() consensus.Engine
@@ -1344,6 +1384,38 @@ func (s *BlockChainAPI) CallWithState(ctx context.Context, args TransactionArgs,
 	return result.Return(), result.Err
 }
 
+// SimulateV1 executes series of transactions on top of a base state. The
+// transactions are packed into blocks, each with its own block and state
+// overrides, and every block is executed on the state left by the previous
+// ones. The calls are applied like in a bor block, so the fee transfer logs
+// are emitted, but no state-sync transaction is committed.
+//
+// Note, this function doesn't make any changes in the state/blockchain and is
+// useful to simulate dependent transaction sequences without sending them.
+func (s *BlockChainAPI) SimulateV1(ctx context.Context, opts simOpts, blockNrOrHash *rpc.BlockNumberOrHash) ([]map[string]interface{}, error) {
+	if len(opts.BlockStateCalls) == 0 {
+		return nil, errors.New("empty input")
+	}
+
+	if len(opts.BlockStateCalls) > maxSimulateBlocks {
+		return nil, fmt.Errorf("too many blocks: %d > %d", len(opts.BlockStateCalls), maxSimulateBlocks)
+	}
+
+	if blockNrOrHash == nil {
+		n := rpc.BlockNumberOrHashWithNumber(rpc.LatestBlockNumber)
+		blockNrOrHash = &n
+	}
+
+	state, base, err := s.b.StateAndHeaderByNumberOrHash(ctx, *blockNrOrHash)
+	if state == nil || err != nil {
+		return nil, err
+	}
+
+	sim := newSimulator(ctx, s.b, state, base, opts.Validation)
+
+	return sim.execute(ctx, opts.BlockStateCalls)
+}
+
 func DoEstimateGas(ctx context.Context, b Backend, args TransactionArgs, blockNrOrHash rpc.BlockNumberOrHash, overrides *StateOverride, gasCap uint64) (hexutil.Uint64, error) {
 	// Binary search the gas requirement, as it may be higher than the amount used
 	var (
@@ -576,6 +576,10 @@ func (b testBackend) BloomStatus() (uint64, uint64) { panic("implement me") }
 func (b testBackend) ServiceFilter(ctx context.Context, session *bloombits.MatcherSession) {
 	panic("implement me")
 }
+func (b testBackend) BorBloomStatus() (uint64, uint64) { panic("implement me") }
+func (b testBackend) ServiceBorFilter(ctx context.Context, session *bloombits.MatcherSession) {
+	panic("implement me")
+}
 
 // GetBorBlockTransaction returns bor block tx
 func (b testBackend) GetBorBlockTransaction(ctx context.Context, hash common.Hash) (*types.Transaction, common.Hash, uint64, uint64, error) {
@@ -918,6 +922,96 @@ func TestCall(t *testing.T) {
 	}
 }
 
+func TestSimulateV1(t *testing.T) {
+	t.Parallel()
+	// Initialize test accounts
+	var (
+		accounts = newAccounts(1)
+		genesis  = &core.Genesis{
+			Config: params.TestChainConfig,
+			Alloc: core.GenesisAlloc{
+				accounts[0].addr: {Balance: big.NewInt(params.Ether)},
+			},
+		}
+		genBlocks = 10
+		recipient = common.HexToAddress("0xc0ffee")
+		reverter  = common.HexToAddress("0xbad")
+		balancer  = common.HexToAddress("0xba1")
+		hasher    = common.HexToAddress("0x4a5")
+	)
+	api := NewBlockChainAPI(newTestBackend(t, genBlocks, genesis, func(i int, b *core.BlockGen) {}))
+	overrides := StateOverride{
+		// revert without data
+		reverter: {Code: hex2Bytes("60006000fd")},
+		// return the balance of the recipient
+		balancer: {Code: hex2Bytes("73" + common.Bytes2Hex(recipient.Bytes()) + "3160005260206000f3")},
+		// return the hash of the gap block
+		hasher: {Code: hex2Bytes(fmt.Sprintf("60%02x4060005260206000f3", genBlocks+2))},
+	}
+	number := (*hexutil.Big)(big.NewInt(int64(genBlocks + 3)))
+	opts := simOpts{BlockStateCalls: []simBlock{
+		{
+			StateOverrides: &overrides,
+			Calls: []TransactionArgs{
+				{From: &accounts[0].addr, To: &recipient, Value: (*hexutil.Big)(big.NewInt(1000)), GasPrice: (*hexutil.Big)(big.NewInt(1))},
+				{From: &accounts[0].addr, To: &reverter},
+			},
+		},
+		{
+			BlockOverrides: &BlockOverrides{Number: number},
+			Calls:          []TransactionArgs{{To: &balancer}, {To: &hasher}},
+		},
+	}}
+	results, err := api.SimulateV1(context.Background(), opts, nil)
+	if err != nil {
+		t.Fatalf("failed to simulate: %v", err)
+	}
+	// The gap before the second block is filled with an empty one
+	if len(results) != 3 {
+		t.Fatalf("simulated blocks mismatch: have %d, want 3", len(results))
+	}
+	for i, block := range results {
+		if have, want := block["number"].(*hexutil.Big).ToInt().Uint64(), uint64(genBlocks+1+i); have != want {
+			t.Errorf("block %d: number mismatch: have %d, want %d", i, have, want)
+		}
+		if i > 0 && block["parentHash"] != results[i-1]["hash"] {
+			t.Errorf("block %d: parent hash mismatch: have %v, want %v", i, block["parentHash"], results[i-1]["hash"])
+		}
+		if i > 0 && block["timestamp"].(hexutil.Uint64) != results[i-1]["timestamp"].(hexutil.Uint64)+timestampIncrement {
+			t.Errorf("block %d: timestamp mismatch: have %v, parent %v", i, block["timestamp"], results[i-1]["timestamp"])
+		}
+	}
+	// The transfer emits the bor transfer and fee logs, and the revert doesn't abort the block
+	calls := results[0]["calls"].([]simCallResult)
+	if calls[0].Status != 1 || len(calls[0].Logs) != 2 {
+		t.Fatalf("transfer result mismatch: %+v", calls[0])
+	}
+	for i, log := range calls[0].Logs {
+		if log.Address != common.HexToAddress("0x1010") || log.Index != uint(i) || log.BlockHash != results[0]["hash"] {
+			t.Errorf("log %d mismatch: %+v", i, log)
+		}
+	}
+	if calls[1].Status != 0 || calls[1].Error == nil || calls[1].Error.Code != errCodeReverted {
+		t.Errorf("revert result mismatch: %+v", calls[1])
+	}
+	if results[0]["gasUsed"].(hexutil.Uint64) != calls[0].GasUsed+calls[1].GasUsed {
+		t.Errorf("block gas used mismatch: have %v, want %v", results[0]["gasUsed"], calls[0].GasUsed+calls[1].GasUsed)
+	}
+	// The later block sees the transfer and the simulated block hashes
+	calls = results[2]["calls"].([]simCallResult)
+	if balance := new(big.Int).SetBytes(calls[0].ReturnValue); balance.Cmp(big.NewInt(1000)) != 0 {
+		t.Errorf("recipient balance mismatch: have %v, want 1000", balance)
+	}
+	if hash := common.BytesToHash(calls[1].ReturnValue); hash != results[1]["hash"] {
+		t.Errorf("block hash mismatch: have %v, want %v", hash, results[1]["hash"])
+	}
+	// Blocks must be in order
+	opts.BlockStateCalls[1].BlockOverrides.Number = (*hexutil.Big)(big.NewInt(int64(genBlocks)))
+	if _, err := api.SimulateV1(context.Background(), opts, nil); err == nil {
+		t.Error("out of order blocks simulated")
+	}
+}
+
 type Account struct {
 	key  *ecdsa.PrivateKey
 	addr common.Address
@@ -2,9 +2,12 @@ package ethapi
 
 import (
 	"context"
+	"errors"
+	"fmt"
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/rawdb"
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/rpc"
 )
@@ -66,7 +69,8 @@ func NewBorAPI(b Backend) *BorAPI {
 }
 
 // SendRawTransactionConditional will add the signed transaction to the transaction pool.
-// The sender/bundler is responsible for signing the transaction
+// The sender/bundler is responsible for signing the transaction. A bundler cannot
+// have conditional and regular transactions pooled at the same time.
 func (api *BorAPI) SendRawTransactionConditional(ctx context.Context, input hexutil.Bytes, options types.OptionsAA4337) (common.Hash, error) {
 	tx := new(types.Transaction)
 	if err := tx.UnmarshalBinary(input); err != nil {
@@ -109,3 +113,120 @@ func (api *BorAPI) SendRawTransactionConditional(ctx context.Context, input hexu
 func (api *BorAPI) GetVoteOnHash(ctx context.Context, starBlockNr uint64, endBlockNr uint64, hash string, milestoneId string) (bool, error) {
 	return api.b.GetVoteOnHash(ctx, starBlockNr, endBlockNr, hash, milestoneId)
 }
+
+// maxChangeSetEntries is the maximum number of changes returned by a single
+// change-set query.
+const maxChangeSetEntries = 10000
+
+// RPCBalanceChange is the change of the balance and nonce of an account within
+// a block, as returned by bor_getBalanceChanges.
+type RPCBalanceChange struct {
+	BlockNumber hexutil.Uint64 `json:"blockNumber"`
+	BlockHash   common.Hash    `json:"blockHash"`
+	PrevBalance *hexutil.Big   `json:"prevBalance"`
+	Balance     *hexutil.Big   `json:"balance"`
+	PrevNonce   hexutil.Uint64 `json:"prevNonce"`
+	Nonce       hexutil.Uint64 `json:"nonce"`
+}
+
+// RPCStorageChange is the change of a storage slot within a block, as returned
+// by bor_getStorageHistory.
+type RPCStorageChange struct {
+	BlockNumber hexutil.Uint64 `json:"blockNumber"`
+	BlockHash   common.Hash    `json:"blockHash"`
+	PrevValue   common.Hash    `json:"prevValue"`
+	Value       common.Hash    `json:"value"`
+}
+
+// changeSetRange resolves the block range of a change-set query.
+func (api *BorAPI) changeSetRange(ctx context.Context, from, to rpc.BlockNumber) (uint64, uint64, error) {
+	resolve := func(number rpc.BlockNumber) (uint64, error) {
+		if number >= 0 {
+			return uint64(number), nil
+		}
+
+		header, err := api.b.HeaderByNumber(ctx, number)
+		if err != nil {
+			return 0, err
+		}
+
+		if header == nil {
+			return 0, fmt.Errorf("block %d not found", number)
+		}
+
+		return header.Number.Uint64(), nil
+	}
+
+	begin, err := resolve(from)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	end, err := resolve(to)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	if begin > end {
+		return 0, 0, errors.New("invalid block range")
+	}
+
+	return begin, end, nil
+}
+
+// GetBalanceChanges returns the balance and nonce changes of the given account
+// in the canonical blocks within [from, to], state-sync credits included. It
+// requires the change-set indexing (cache.changesets) to be enabled while the
+// blocks are imported.
+func (api *BorAPI) GetBalanceChanges(ctx context.Context, address common.Address, from, to rpc.BlockNumber) ([]*RPCBalanceChange, error) {
+	begin, end, err := api.changeSetRange(ctx, from, to)
+	if err != nil {
+		return nil, err
+	}
+
+	entries := rawdb.ReadAccountChanges(api.b.ChainDb(), address, begin, end, maxChangeSetEntries+1)
+	if len(entries) > maxChangeSetEntries {
+		return nil, fmt.Errorf("more than %d changes in range, narrow the block range", maxChangeSetEntries)
+	}
+
+	changes := make([]*RPCBalanceChange, 0, len(entries))
+	for _, entry := range entries {
+		changes = append(changes, &RPCBalanceChange{
+			BlockNumber: hexutil.Uint64(entry.Number),
+			BlockHash:   entry.Hash,
+			PrevBalance: (*hexutil.Big)(entry.PrevBalance),
+			Balance:     (*hexutil.Big)(entry.Balance),
+			PrevNonce:   hexutil.Uint64(entry.PrevNonce),
+			Nonce:       hexutil.Uint64(entry.Nonce),
+		})
+	}
+
+	return changes, nil
+}
+
+// GetStorageHistory returns the changes of the given storage slot in the
+// canonical blocks within [from, to]. It requires the change-set indexing
+// (cache.changesets) to be enabled while the blocks are imported.
+func (api *BorAPI) GetStorageHistory(ctx context.Context, address common.Address, slot common.Hash, from, to rpc.BlockNumber) ([]*RPCStorageChange, error) {
+	begin, end, err := api.changeSetRange(ctx, from, to)
+	if err != nil {
+		return nil, err
+	}
+
+	entries := rawdb.ReadStorageChanges(api.b.ChainDb(), address, slot, begin, end, maxChangeSetEntries+1)
+	if len(entries) > maxChangeSetEntries {
+		return nil, fmt.Errorf("more than %d changes in range, narrow the block range", maxChangeSetEntries)
+	}
+
+	changes := make([]*RPCStorageChange, 0, len(entries))
+	for _, entry := range entries {
+		changes = append(changes, &RPCStorageChange{
+			BlockNumber: hexutil.Uint64(entry.Number),
+			BlockHash:   entry.Hash,
+			PrevValue:   entry.Prev,
+			Value:       entry.Value,
+		})
+	}
+
+	return changes, nil
+}
@@ -0,0 +1,422 @@
+// Copyright 2023 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package ethapi
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/consensus/misc/eip1559"
+	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/core/state"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/core/vm"
+	"github.com/ethereum/go-ethereum/params"
+	"github.com/ethereum/go-ethereum/trie"
+)
+
+const (
+	// maxSimulateBlocks is the maximum number of blocks, the gaps between the
+	// requested ones included, that can be simulated in a single request.
+	maxSimulateBlocks = 256
+
+	// timestampIncrement is the default time between simulated blocks on chains
+	// without a bor block period.
+	timestampIncrement = 2
+)
+
+const (
+	errCodeReverted = -32000
+	errCodeVMError  = -32015
+)
+
+// simOpts are the inputs to eth_simulateV1.
+type simOpts struct {
+	BlockStateCalls []simBlock
+	Validation      bool
+}
+
+// simBlock is a batch of calls to be simulated sequentially, on top of the
+// given block and state overrides.
+type simBlock struct {
+	BlockOverrides *BlockOverrides
+	StateOverrides *StateOverride
+	Calls          []TransactionArgs
+}
+
+// simCallResult is the result of a simulated call.
+type simCallResult struct {
+	ReturnValue hexutil.Bytes  `json:"returnData"`
+	Logs        []*types.Log   `json:"logs"`
+	GasUsed     hexutil.Uint64 `json:"gasUsed"`
+	Status      hexutil.Uint64 `json:"status"`
+	Error       *callError     `json:"error,omitempty"`
+}
+
+// callError is the execution error of a simulated call which failed, the
+// calls it was packed with being executed nonetheless.
+type callError struct {
+	Message string `json:"message"`
+	Code    int    `json:"code"`
+	Data    string `json:"data,omitempty"`
+}
+
+// simChainContext resolves the headers of the simulated blocks before the
+// canonical ones, so that BLOCKHASH can reach them.
+type simChainContext struct {
+	*ChainContext
+	headers map[common.Hash]*types.Header
+}
+
+func (c *simChainContext) GetHeader(hash common.Hash, number uint64) *types.Header {
+	if header, ok := c.headers[hash]; ok {
+		return header
+	}
+
+	return c.ChainContext.GetHeader(hash, number)
+}
+
+// simulator executes batches of calls in consecutive blocks on top of a base
+// block, every block seeing the state changes of the previous ones.
+type simulator struct {
+	b        Backend
+	state    *state.StateDB
+	base     *types.Header
+	chain    *simChainContext
+	config   *params.ChainConfig
+	coinbase common.Address // Fee recipient of the blocks not overriding it
+	validate bool           // Whether to enforce the nonce and fee checks
+
+	gasCap  uint64 // Gas allowance of all the calls, 0 if unlimited
+	gasUsed uint64 // Gas used by the calls executed so far
+}
+
+// newSimulator creates a simulator on top of the given state and header.
+func newSimulator(ctx context.Context, b Backend, state *state.StateDB, base *types.Header, validate bool) *simulator {
+	// Bor blocks leave the coinbase empty, the fees go to the block signer
+	coinbase, err := b.Engine().Author(base)
+	if err != nil {
+		coinbase = base.Coinbase
+	}
+
+	return &simulator{
+		b:        b,
+		state:    state,
+		base:     base,
+		chain:    &simChainContext{ChainContext: NewChainContext(ctx, b), headers: make(map[common.Hash]*types.Header)},
+		config:   b.ChainConfig(),
+		coinbase: coinbase,
+		validate: validate,
+		gasCap:   b.RPCGasCap(),
+	}
+}
+
+// execute runs the calls of the given blocks, returning the resulting blocks
+// along with the per-call results.
+func (sim *simulator) execute(ctx context.Context, blocks []simBlock) ([]map[string]interface{}, error) {
+	// Setup context so it may be cancelled when the simulation has completed
+	// or, in case of unmetered gas, setup a context with a timeout.
+	var (
+		cancel  context.CancelFunc
+		timeout = sim.b.RPCEVMTimeout()
+	)
+
+	if timeout > 0 {
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+	} else {
+		ctx, cancel = context.WithCancel(ctx)
+	}
+	defer cancel()
+
+	blocks, err := sim.sanitizeChain(blocks)
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		results = make([]map[string]interface{}, len(blocks))
+		parent  = sim.base
+	)
+
+	for i, block := range blocks {
+		result, header, err := sim.processBlock(ctx, &block, parent, timeout)
+		if err != nil {
+			return nil, err
+		}
+
+		results[i] = result
+		parent = header
+	}
+
+	return results, nil
+}
+
+// sanitizeChain checks the numbers and timestamps of the requested blocks are
+// increasing, filling in the defaults and the gaps with empty blocks.
+func (sim *simulator) sanitizeChain(blocks []simBlock) ([]simBlock, error) {
+	var (
+		res        = make([]simBlock, 0, len(blocks))
+		prevNumber = sim.base.Number.Uint64()
+		prevTime   = sim.base.Time
+	)
+
+	for _, block := range blocks {
+		overrides := new(BlockOverrides)
+		if block.BlockOverrides != nil {
+			*overrides = *block.BlockOverrides
+		}
+
+		if overrides.Number == nil {
+			overrides.Number = (*hexutil.Big)(new(big.Int).SetUint64(prevNumber + 1))
+		}
+
+		number := overrides.Number.ToInt()
+		if !number.IsUint64() || number.Uint64() <= prevNumber {
+			return nil, fmt.Errorf("block numbers must be in order: %v <= %d", number, prevNumber)
+		}
+
+		if span := number.Uint64() - sim.base.Number.Uint64(); span > maxSimulateBlocks {
+			return nil, fmt.Errorf("too many blocks: %d > %d", span, maxSimulateBlocks)
+		}
+
+		// Fill the gap up to the requested block, for BLOCKHASH to walk back
+		for n := prevNumber + 1; n < number.Uint64(); n++ {
+			prevTime += sim.period(n)
+
+			gapTime := hexutil.Uint64(prevTime)
+			res = append(res, simBlock{BlockOverrides: &BlockOverrides{
+				Number: (*hexutil.Big)(new(big.Int).SetUint64(n)),
+				Time:   &gapTime,
+			}})
+		}
+
+		if overrides.Time == nil {
+			t := hexutil.Uint64(prevTime + sim.period(number.Uint64()))
+			overrides.Time = &t
+		} else if uint64(*overrides.Time) <= prevTime {
+			return nil, fmt.Errorf("block timestamps must be in order: %d <= %d", *overrides.Time, prevTime)
+		}
+
+		prevNumber, prevTime = number.Uint64(), uint64(*overrides.Time)
+
+		block.BlockOverrides = overrides
+		res = append(res, block)
+	}
+
+	return res, nil
+}
+
+// period returns the default time between the given block and its parent.
+func (sim *simulator) period(number uint64) uint64 {
+	if sim.config.Bor != nil && len(sim.config.Bor.Period) > 0 {
+		return sim.config.Bor.CalculatePeriod(number)
+	}
+
+	return timestampIncrement
+}
+
+// processBlock executes the calls of a block on top of the given parent,
+// returning the marshalled block and its header.
+func (sim *simulator) processBlock(ctx context.Context, block *simBlock, parent *types.Header, timeout time.Duration) (map[string]interface{}, *types.Header, error) {
+	header := sim.makeHeader(block.BlockOverrides, parent)
+
+	if err := block.StateOverrides.Apply(sim.state); err != nil {
+		return nil, nil, err
+	}
+
+	var (
+		number   = header.Number.Uint64()
+		gp       = new(core.GasPool).AddGas(header.GasLimit)
+		blockCtx = core.NewEVMBlockContext(header, sim.chain, &header.Coinbase)
+		vmConfig = &vm.Config{NoBaseFee: !sim.validate}
+
+		gasUsed  uint64
+		logIndex uint
+		txs      = make(types.Transactions, len(block.Calls))
+		receipts = make(types.Receipts, len(block.Calls))
+		calls    = make([]simCallResult, len(block.Calls))
+	)
+
+	for i := range block.Calls {
+		call := block.Calls[i]
+
+		if err := sim.sanitizeCall(&call, gp); err != nil {
+			return nil, nil, err
+		}
+
+		tx := call.ToTransaction()
+		txs[i] = tx
+
+		msg, err := call.ToMessage(0, header.BaseFee)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		if sim.validate {
+			msg.Nonce, msg.SkipAccountChecks = uint64(*call.Nonce), false
+		}
+
+		sim.state.SetTxContext(tx.Hash(), i)
+
+		evm, vmError := sim.b.GetEVM(ctx, msg, sim.state, header, vmConfig, &blockCtx)
+
+		// Wait for the context to be done and cancel the evm. Even if the
+		// EVM has finished, cancelling may be done (repeatedly)
+		go func() {
+			<-ctx.Done()
+			evm.Cancel()
+		}()
+
+		// The fee transfer log is added by the state transition, as in bor blocks
+		// nolint : contextcheck
+		result, err := core.ApplyMessage(evm, msg, gp, context.Background())
+		if err == nil {
+			err = vmError()
+		}
+
+		// If the timer caused an abort, return an appropriate error message
+		if evm.Cancelled() {
+			return nil, nil, fmt.Errorf("execution aborted (timeout = %v)", timeout)
+		}
+
+		if err != nil {
+			return nil, nil, fmt.Errorf("block %d, call %d: %w", number, i, err)
+		}
+
+		sim.state.Finalise(sim.config.IsEIP158(header.Number))
+
+		gasUsed += result.UsedGas
+		sim.gasUsed += result.UsedGas
+
+		logs := sim.state.GetLogs(tx.Hash(), number, common.Hash{})
+		for _, l := range logs {
+			l.Index = logIndex
+			logIndex++
+		}
+
+		receipt := &types.Receipt{
+			Type:              tx.Type(),
+			Status:            types.ReceiptStatusSuccessful,
+			CumulativeGasUsed: gasUsed,
+			Logs:              logs,
+			TxHash:            tx.Hash(),
+			GasUsed:           result.UsedGas,
+			BlockNumber:       header.Number,
+			TransactionIndex:  uint(i),
+		}
+
+		calls[i] = simCallResult{
+			ReturnValue: result.ReturnData,
+			Logs:        logs,
+			GasUsed:     hexutil.Uint64(result.UsedGas),
+			Status:      hexutil.Uint64(types.ReceiptStatusSuccessful),
+		}
+
+		if result.Failed() {
+			receipt.Status = types.ReceiptStatusFailed
+			calls[i].Status = hexutil.Uint64(types.ReceiptStatusFailed)
+
+			if errors.Is(result.Err, vm.ErrExecutionReverted) {
+				calls[i].Error = &callError{Message: newRevertError(result).Error(), Code: errCodeReverted, Data: hexutil.Encode(result.Revert())}
+			} else {
+				calls[i].Error = &callError{Message: result.Err.Error(), Code: errCodeVMError}
+			}
+		}
+
+		receipt.Bloom = types.CreateBloom(types.Receipts{receipt})
+		receipts[i] = receipt
+	}
+
+	header.GasUsed = gasUsed
+	header.Root = sim.state.IntermediateRoot(sim.config.IsEIP158(header.Number))
+	header.TxHash = types.DeriveSha(txs, trie.NewStackTrie(nil))
+	header.ReceiptHash = types.DeriveSha(receipts, trie.NewStackTrie(nil))
+	header.Bloom = types.CreateBloom(receipts)
+
+	hash := header.Hash()
+	sim.chain.headers[hash] = header
+
+	hashes := make([]common.Hash, len(txs))
+
+	for i, receipt := range receipts {
+		hashes[i] = txs[i].Hash()
+
+		for _, l := range receipt.Logs {
+			l.BlockHash = hash
+		}
+	}
+
+	fields := RPCMarshalHeader(header)
+	fields["transactions"] = hashes
+	fields["calls"] = calls
+
+	return fields, header, nil
+}
+
+// makeHeader assembles the header of a simulated block on top of its parent,
+// the requested overrides applied.
+func (sim *simulator) makeHeader(overrides *BlockOverrides, parent *types.Header) *types.Header {
+	header := overrides.MakeHeader(&types.Header{
+		ParentHash: parent.Hash(),
+		UncleHash:  types.EmptyUncleHash,
+		Coinbase:   sim.coinbase,
+		Difficulty: parent.Difficulty,
+		GasLimit:   parent.GasLimit,
+		MixDigest:  parent.MixDigest,
+	})
+
+	// Without validation the calls are free of fees unless a base fee is requested
+	if sim.config.IsLondon(header.Number) && (overrides == nil || overrides.BaseFee == nil) {
+		if sim.validate {
+			header.BaseFee = eip1559.CalcBaseFee(sim.config, parent)
+		} else {
+			header.BaseFee = new(big.Int)
+		}
+	}
+
+	return header
+}
+
+// sanitizeCall fills in the defaults of a call, checking it fits into the gas
+// left in the block and in the gas allowance.
+func (sim *simulator) sanitizeCall(call *TransactionArgs, gp *core.GasPool) error {
+	if call.Nonce == nil {
+		nonce := sim.state.GetNonce(call.from())
+		call.Nonce = (*hexutil.Uint64)(&nonce)
+	}
+
+	remaining := gp.Gas()
+	if sim.gasCap > 0 && sim.gasCap-sim.gasUsed < remaining {
+		remaining = sim.gasCap - sim.gasUsed
+	}
+
+	// Let the call use up the gas left by default
+	if call.Gas == nil {
+		call.Gas = (*hexutil.Uint64)(&remaining)
+	}
+
+	if uint64(*call.Gas) > remaining {
+		return fmt.Errorf("%w: have %d, want %d", core.ErrGasLimitReached, remaining, *call.Gas)
+	}
+
+	return nil
+}
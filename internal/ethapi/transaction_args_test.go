@@ -350,10 +350,12 @@ func (b *backendMock) TxPoolContent() (map[common.Address][]*types.Transaction,
 func (b *backendMock) TxPoolContentFrom(addr common.Address) ([]*types.Transaction, []*types.Transaction) {
 	return nil, nil
 }
-func (b *backendMock) SubscribeNewTxsEvent(chan<- core.NewTxsEvent) event.Subscription      { return nil }
-func (b *backendMock) BloomStatus() (uint64, uint64)                                        { return 0, 0 }
-func (b *backendMock) ServiceFilter(ctx context.Context, session *bloombits.MatcherSession) {}
-func (b *backendMock) SubscribeLogsEvent(ch chan<- []*types.Log) event.Subscription         { return nil }
+func (b *backendMock) SubscribeNewTxsEvent(chan<- core.NewTxsEvent) event.Subscription         { return nil }
+func (b *backendMock) BloomStatus() (uint64, uint64)                                           { return 0, 0 }
+func (b *backendMock) ServiceFilter(ctx context.Context, session *bloombits.MatcherSession)    {}
+func (b *backendMock) BorBloomStatus() (uint64, uint64)                                        { return 0, 0 }
+func (b *backendMock) ServiceBorFilter(ctx context.Context, session *bloombits.MatcherSession) {}
+func (b *backendMock) SubscribeLogsEvent(ch chan<- []*types.Log) event.Subscription            { return nil }
 func (b *backendMock) SubscribePendingLogsEvent(ch chan<- []*types.Log) event.Subscription {
 	return nil
 }
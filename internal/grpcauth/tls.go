@@ -0,0 +1,257 @@
+// Package grpcauth implements transport security and token based
+// authorization for the gRPC endpoints served and consumed by bor.
+package grpcauth
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc/credentials"
+
+	"github.com/ethereum/go-ethereum/log"
+)
+
+// TLSConfig holds the file locations of the TLS material of a gRPC endpoint.
+// The files are watched for changes, rotated certificates are picked up by
+// the next handshake without a restart.
+type TLSConfig struct {
+	// CertFile is the PEM encoded certificate presented to the remote side
+	CertFile string
+
+	// KeyFile is the PEM encoded private key of the certificate
+	KeyFile string
+
+	// CAFile is the PEM encoded CA bundle used to verify the remote side. On
+	// a server it enables mutual TLS, on a client it replaces the system roots.
+	CAFile string
+}
+
+// Enabled reports whether any TLS material is configured.
+func (c *TLSConfig) Enabled() bool {
+	return c != nil && (c.CertFile != "" || c.KeyFile != "" || c.CAFile != "")
+}
+
+// NewServerCredentials returns the transport credentials of a gRPC server
+// presenting the configured certificate. If a CA is configured, clients are
+// required to present a certificate signed by it.
+func NewServerCredentials(config *TLSConfig) (credentials.TransportCredentials, error) {
+	if config.CertFile == "" || config.KeyFile == "" {
+		return nil, errors.New("tls certificate and key are required")
+	}
+
+	certs, err := newKeyPairLoader(config.CertFile, config.KeyFile)
+	if err != nil {
+		return nil, err
+	}
+
+	base := &tls.Config{
+		MinVersion: tls.VersionTLS12,
+		NextProtos: []string{"h2"},
+		GetCertificate: func(*tls.ClientHelloInfo) (*tls.Certificate, error) {
+			return certs.get()
+		},
+	}
+
+	if config.CAFile != "" {
+		cas, err := newCertPoolLoader(config.CAFile)
+		if err != nil {
+			return nil, err
+		}
+
+		// The client CAs are part of the config, hand out a fresh one per
+		// handshake to pick up a rotated bundle.
+		base.ClientAuth = tls.RequireAndVerifyClientCert
+		base.GetConfigForClient = func(*tls.ClientHelloInfo) (*tls.Config, error) {
+			pool, err := cas.get()
+			if err != nil {
+				return nil, err
+			}
+
+			conf := base.Clone()
+			conf.GetConfigForClient = nil
+			conf.ClientCAs = pool
+
+			return conf, nil
+		}
+	}
+
+	return credentials.NewTLS(base), nil
+}
+
+// NewClientCredentials returns the transport credentials of a gRPC client. The
+// server is verified against the configured CA or the system roots, and the
+// configured certificate, if any, is presented when the server asks for one.
+func NewClientCredentials(config *TLSConfig) (credentials.TransportCredentials, error) {
+	if (config.CertFile == "") != (config.KeyFile == "") {
+		return nil, errors.New("tls certificate and key must be set together")
+	}
+
+	conf := &tls.Config{
+		MinVersion: tls.VersionTLS12,
+		NextProtos: []string{"h2"},
+	}
+
+	if config.CertFile != "" {
+		certs, err := newKeyPairLoader(config.CertFile, config.KeyFile)
+		if err != nil {
+			return nil, err
+		}
+
+		conf.GetClientCertificate = func(*tls.CertificateRequestInfo) (*tls.Certificate, error) {
+			return certs.get()
+		}
+	}
+
+	if config.CAFile != "" {
+		cas, err := newCertPoolLoader(config.CAFile)
+		if err != nil {
+			return nil, err
+		}
+
+		// The root CAs cannot be swapped on a live config, so the default
+		// verification is replaced by one against the current bundle.
+		conf.InsecureSkipVerify = true
+		conf.VerifyConnection = func(state tls.ConnectionState) error {
+			pool, err := cas.get()
+			if err != nil {
+				return err
+			}
+
+			return verifyServer(state, pool)
+		}
+	}
+
+	return credentials.NewTLS(conf), nil
+}
+
+// verifyServer verifies the certificate chain and the name of a server
+// against the given roots.
+func verifyServer(state tls.ConnectionState, roots *x509.CertPool) error {
+	if len(state.PeerCertificates) == 0 {
+		return errors.New("server presented no certificate")
+	}
+
+	opts := x509.VerifyOptions{
+		DNSName:       state.ServerName,
+		Roots:         roots,
+		Intermediates: x509.NewCertPool(),
+	}
+	for _, cert := range state.PeerCertificates[1:] {
+		opts.Intermediates.AddCert(cert)
+	}
+
+	_, err := state.PeerCertificates[0].Verify(opts)
+
+	return err
+}
+
+// fileLoader caches a value parsed from a set of files and parses it again
+// once any of the files is modified. If a reload fails the last good value
+// is kept, so a half written rotation does not break new connections.
+type fileLoader[T any] struct {
+	files []string
+	load  func() (T, error)
+
+	lock    sync.Mutex
+	value   T
+	modTime []time.Time
+}
+
+func newFileLoader[T any](load func() (T, error), files ...string) (*fileLoader[T], error) {
+	l := &fileLoader[T]{files: files, load: load}
+
+	modTime, err := l.stat()
+	if err != nil {
+		return nil, err
+	}
+
+	if l.value, err = load(); err != nil {
+		return nil, err
+	}
+
+	l.modTime = modTime
+
+	return l, nil
+}
+
+func (l *fileLoader[T]) stat() ([]time.Time, error) {
+	times := make([]time.Time, len(l.files))
+
+	for i, file := range l.files {
+		info, err := os.Stat(file)
+		if err != nil {
+			return nil, err
+		}
+
+		times[i] = info.ModTime()
+	}
+
+	return times, nil
+}
+
+func (l *fileLoader[T]) get() (T, error) {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+
+	modTime, err := l.stat()
+	if err != nil {
+		log.Warn("Failed to check TLS files for changes", "files", l.files, "err", err)
+		return l.value, nil
+	}
+
+	changed := false
+
+	for i := range modTime {
+		if !modTime[i].Equal(l.modTime[i]) {
+			changed = true
+		}
+	}
+
+	if !changed {
+		return l.value, nil
+	}
+
+	value, err := l.load()
+	if err != nil {
+		log.Warn("Failed to reload TLS files", "files", l.files, "err", err)
+		return l.value, nil
+	}
+
+	log.Info("Reloaded TLS files", "files", l.files)
+
+	l.value, l.modTime = value, modTime
+
+	return l.value, nil
+}
+
+func newKeyPairLoader(certFile, keyFile string) (*fileLoader[*tls.Certificate], error) {
+	return newFileLoader(func() (*tls.Certificate, error) {
+		cert, err := tls.LoadX509KeyPair(certFile, keyFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load tls key pair: %w", err)
+		}
+
+		return &cert, nil
+	}, certFile, keyFile)
+}
+
+func newCertPoolLoader(caFile string) (*fileLoader[*x509.CertPool], error) {
+	return newFileLoader(func() (*x509.CertPool, error) {
+		data, err := os.ReadFile(caFile)
+		if err != nil {
+			return nil, err
+		}
+
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(data) {
+			return nil, fmt.Errorf("no certificates found in %s", caFile)
+		}
+
+		return pool, nil
+	}, caFile)
+}
@@ -0,0 +1,195 @@
+package grpcauth
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"net"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+)
+
+type testCA struct {
+	cert *x509.Certificate
+	key  *ecdsa.PrivateKey
+	pem  []byte
+}
+
+func newTestCA(t *testing.T) *testCA {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	tmpl := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "test ca"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  true,
+		KeyUsage:              x509.KeyUsageCertSign,
+		BasicConstraintsValid: true,
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, tmpl, &key.PublicKey, key)
+	require.NoError(t, err)
+
+	cert, err := x509.ParseCertificate(der)
+	require.NoError(t, err)
+
+	return &testCA{cert: cert, key: key, pem: pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})}
+}
+
+// issue writes a certificate signed by the CA and its key to the directory.
+func (ca *testCA) issue(t *testing.T, dir, name string) (string, string) {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	tmpl := &x509.Certificate{
+		SerialNumber: big.NewInt(time.Now().UnixNano()),
+		Subject:      pkix.Name{CommonName: name},
+		DNSNames:     []string{"localhost"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth},
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, ca.cert, &key.PublicKey, ca.key)
+	require.NoError(t, err)
+
+	keyDer, err := x509.MarshalECPrivateKey(key)
+	require.NoError(t, err)
+
+	certFile, keyFile := filepath.Join(dir, name+".crt"), filepath.Join(dir, name+".key")
+	writeFile(t, certFile, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}))
+	writeFile(t, keyFile, pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDer}))
+
+	return certFile, keyFile
+}
+
+var writes atomic.Int64
+
+// writeFile writes the file with a fresh modification time, so rewrites
+// within the file system timestamp granularity are still detected.
+func writeFile(t *testing.T, file string, data []byte) {
+	t.Helper()
+
+	require.NoError(t, os.WriteFile(file, data, 0600))
+
+	modTime := time.Now().Add(time.Duration(writes.Add(1)) * time.Second)
+	require.NoError(t, os.Chtimes(file, modTime, modTime))
+}
+
+func startServer(t *testing.T, creds credentials.TransportCredentials) string {
+	t.Helper()
+
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+
+	srv := grpc.NewServer(grpc.Creds(creds))
+	healthpb.RegisterHealthServer(srv, health.NewServer())
+
+	go func() {
+		_ = srv.Serve(lis)
+	}()
+
+	t.Cleanup(srv.Stop)
+
+	_, port, _ := net.SplitHostPort(lis.Addr().String())
+
+	return net.JoinHostPort("localhost", port)
+}
+
+func checkHealth(addr string, creds credentials.TransportCredentials) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+
+	conn, err := grpc.DialContext(ctx, addr, grpc.WithTransportCredentials(creds), grpc.WithBlock(), grpc.FailOnNonTempDialError(true))
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	_, err = healthpb.NewHealthClient(conn).Check(ctx, &healthpb.HealthCheckRequest{})
+
+	return err
+}
+
+func TestMutualTLS(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+
+	ca := newTestCA(t)
+	caFile := filepath.Join(dir, "ca.crt")
+	writeFile(t, caFile, ca.pem)
+
+	serverCert, serverKey := ca.issue(t, dir, "server")
+	clientCert, clientKey := ca.issue(t, dir, "client")
+
+	serverCreds, err := NewServerCredentials(&TLSConfig{CertFile: serverCert, KeyFile: serverKey, CAFile: caFile})
+	require.NoError(t, err)
+
+	addr := startServer(t, serverCreds)
+
+	clientCreds, err := NewClientCredentials(&TLSConfig{CertFile: clientCert, KeyFile: clientKey, CAFile: caFile})
+	require.NoError(t, err)
+	require.NoError(t, checkHealth(addr, clientCreds))
+
+	// Clients without a certificate are rejected
+	anonCreds, err := NewClientCredentials(&TLSConfig{CAFile: caFile})
+	require.NoError(t, err)
+	require.Error(t, checkHealth(addr, anonCreds))
+
+	// Rotate the whole PKI on disk, the running server and client pick it up
+	rotated := newTestCA(t)
+	writeFile(t, caFile, rotated.pem)
+
+	rotated.issue(t, dir, "server")
+	rotated.issue(t, dir, "client")
+
+	require.NoError(t, checkHealth(addr, clientCreds))
+
+	// A client still trusting the old CA no longer accepts the server
+	staleDir := t.TempDir()
+	staleCA := filepath.Join(staleDir, "ca.crt")
+	writeFile(t, staleCA, ca.pem)
+
+	staleCert, staleKey := rotated.issue(t, staleDir, "client")
+	staleCreds, err := NewClientCredentials(&TLSConfig{CertFile: staleCert, KeyFile: staleKey, CAFile: staleCA})
+	require.NoError(t, err)
+	require.Error(t, checkHealth(addr, staleCreds))
+}
+
+func TestCredentialsConfig(t *testing.T) {
+	t.Parallel()
+
+	_, err := NewServerCredentials(&TLSConfig{CAFile: "ca.crt"})
+	require.Error(t, err)
+
+	_, err = NewClientCredentials(&TLSConfig{CertFile: "client.crt"})
+	require.Error(t, err)
+
+	_, err = NewServerCredentials(&TLSConfig{CertFile: "missing.crt", KeyFile: "missing.key"})
+	require.Error(t, err)
+
+	require.False(t, (*TLSConfig)(nil).Enabled())
+	require.True(t, (&TLSConfig{CAFile: "ca.crt"}).Enabled())
+}
@@ -0,0 +1,145 @@
+package grpcauth
+
+import (
+	"context"
+	"crypto/subtle"
+	"strings"
+	"sync"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	authorizationHeader = "authorization"
+	bearerPrefix        = "Bearer "
+)
+
+// tokenCredentials attaches a bearer token to every call of a gRPC client.
+type tokenCredentials struct {
+	token     string
+	secureTLS bool
+}
+
+// NewTokenCredentials returns per call credentials sending the given bearer
+// token. If requireTLS is set, the token is never sent over a plain connection.
+func NewTokenCredentials(token string, requireTLS bool) credentials.PerRPCCredentials {
+	return &tokenCredentials{token: token, secureTLS: requireTLS}
+}
+
+func (c *tokenCredentials) GetRequestMetadata(context.Context, ...string) (map[string]string, error) {
+	return map[string]string{authorizationHeader: bearerPrefix + c.token}, nil
+}
+
+func (c *tokenCredentials) RequireTransportSecurity() bool {
+	return c.secureTLS
+}
+
+// Authorizer authorizes the calls of a gRPC server by bearer token. Calls to
+// admin methods require the admin token if one is set, calls to all other
+// methods accept either token. Methods are open when no token guards them.
+type Authorizer struct {
+	admin map[string]bool
+
+	lock       sync.RWMutex
+	token      string
+	adminToken string
+}
+
+// NewAuthorizer creates an authorizer for the given tokens. The admin methods
+// are full gRPC method names, e.g. "/proto.Bor/ChainSetHead".
+func NewAuthorizer(token, adminToken string, adminMethods []string) *Authorizer {
+	a := &Authorizer{
+		admin:      make(map[string]bool, len(adminMethods)),
+		token:      token,
+		adminToken: adminToken,
+	}
+	for _, method := range adminMethods {
+		a.admin[method] = true
+	}
+
+	return a
+}
+
+// SetTokens replaces the tokens of the authorizer.
+func (a *Authorizer) SetTokens(token, adminToken string) {
+	a.lock.Lock()
+	defer a.lock.Unlock()
+
+	a.token, a.adminToken = token, adminToken
+}
+
+// Authorize checks the bearer token of an incoming call to the given method.
+func (a *Authorizer) Authorize(ctx context.Context, method string) error {
+	a.lock.RLock()
+	token, adminToken := a.token, a.adminToken
+	a.lock.RUnlock()
+
+	presented := bearerToken(ctx)
+
+	if a.admin[method] && adminToken != "" {
+		switch {
+		case equalToken(presented, adminToken):
+			return nil
+		case token != "" && equalToken(presented, token):
+			return status.Errorf(codes.PermissionDenied, "%s requires the admin token", method)
+		default:
+			return status.Error(codes.Unauthenticated, "missing or invalid admin token")
+		}
+	}
+
+	// Without a read token only the admin methods are guarded
+	if token == "" {
+		return nil
+	}
+
+	if equalToken(presented, token) || equalToken(presented, adminToken) {
+		return nil
+	}
+
+	return status.Error(codes.Unauthenticated, "missing or invalid token")
+}
+
+// UnaryInterceptor returns a server interceptor authorizing unary calls.
+func (a *Authorizer) UnaryInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		if err := a.Authorize(ctx, info.FullMethod); err != nil {
+			return nil, err
+		}
+
+		return handler(ctx, req)
+	}
+}
+
+// StreamInterceptor returns a server interceptor authorizing streaming calls.
+func (a *Authorizer) StreamInterceptor() grpc.StreamServerInterceptor {
+	return func(srv interface{}, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		if err := a.Authorize(stream.Context(), info.FullMethod); err != nil {
+			return err
+		}
+
+		return handler(srv, stream)
+	}
+}
+
+func bearerToken(ctx context.Context) string {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return ""
+	}
+
+	for _, value := range md.Get(authorizationHeader) {
+		if strings.HasPrefix(value, bearerPrefix) {
+			return strings.TrimPrefix(value, bearerPrefix)
+		}
+	}
+
+	return ""
+}
+
+func equalToken(presented, expected string) bool {
+	return expected != "" && subtle.ConstantTimeCompare([]byte(presented), []byte(expected)) == 1
+}
@@ -0,0 +1,68 @@
+package grpcauth
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+func TestAuthorizer(t *testing.T) {
+	t.Parallel()
+
+	const (
+		read  = "/proto.Bor/Status"
+		admin = "/proto.Bor/ChainSetHead"
+	)
+
+	withToken := func(token string) context.Context {
+		if token == "" {
+			return context.Background()
+		}
+
+		return metadata.NewIncomingContext(context.Background(), metadata.Pairs(authorizationHeader, bearerPrefix+token))
+	}
+
+	cases := []struct {
+		token, adminToken string
+		method, presented string
+		code              codes.Code
+	}{
+		// No tokens, everything is open
+		{"", "", read, "", codes.OK},
+		{"", "", admin, "", codes.OK},
+
+		// Single token guards every method
+		{"r", "", read, "", codes.Unauthenticated},
+		{"r", "", read, "r", codes.OK},
+		{"r", "", admin, "r", codes.OK},
+		{"r", "", admin, "x", codes.Unauthenticated},
+
+		// Admin token only guards the admin methods
+		{"", "a", read, "", codes.OK},
+		{"", "a", admin, "", codes.Unauthenticated},
+		{"", "a", admin, "a", codes.OK},
+
+		// Both tokens
+		{"r", "a", read, "a", codes.OK},
+		{"r", "a", read, "r", codes.OK},
+		{"r", "a", read, "x", codes.Unauthenticated},
+		{"r", "a", admin, "r", codes.PermissionDenied},
+		{"r", "a", admin, "a", codes.OK},
+	}
+
+	for i, c := range cases {
+		auth := NewAuthorizer(c.token, c.adminToken, []string{admin})
+		err := auth.Authorize(withToken(c.presented), c.method)
+		require.Equal(t, c.code, status.Code(err), "case %d", i)
+	}
+
+	// Tokens can be rotated on a live authorizer
+	auth := NewAuthorizer("r", "", nil)
+	auth.SetTokens("r2", "")
+	require.Error(t, auth.Authorize(withToken("r"), read))
+	require.NoError(t, auth.Authorize(withToken("r2"), read))
+}
@@ -71,6 +71,26 @@ web3._extend({
 			params: 2,
 			inputFormatter: [null]
 		}),
+		new web3._extend.Method({
+			name: 'getConditionalTransactionStatus',
+			call: 'bor_getConditionalTransactionStatus',
+			params: 1,
+		}),
+		new web3._extend.Method({
+			name: 'getBundlerQuota',
+			call: 'bor_getBundlerQuota',
+			params: 1,
+		}),
+		new web3._extend.Method({
+			name: 'getBalanceChanges',
+			call: 'bor_getBalanceChanges',
+			params: 3,
+		}),
+		new web3._extend.Method({
+			name: 'getStorageHistory',
+			call: 'bor_getStorageHistory',
+			params: 4,
+		}),
 	]
 });
 `
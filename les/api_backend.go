@@ -355,6 +355,13 @@ func (b *LesApiBackend) GetBorBlockLogs(ctx context.Context, hash common.Hash) (
 	return nil, errors.New("not implemented")
 }
 
+func (b *LesApiBackend) BorBloomStatus() (uint64, uint64) {
+	return params.BloomBitsBlocksClient, 0
+}
+
+func (b *LesApiBackend) ServiceBorFilter(ctx context.Context, session *bloombits.MatcherSession) {
+}
+
 func (b *LesApiBackend) GetBorBlockTransaction(ctx context.Context, txHash common.Hash) (*types.Transaction, common.Hash, uint64, uint64, error) {
 	return nil, common.Hash{}, 0, 0, errors.New("not implemented")
 }
@@ -53,6 +53,8 @@ type Config struct {
 	GasPrice            *big.Int       // Minimum gas price for mining a transaction
 	Recommit            time.Duration  // The time interval for miner to re-create mining work.
 	CommitInterruptFlag bool           // Interrupt commit when time is up ( default = true)
+	TxOrdering          string         `toml:",omitempty"` // Transaction ordering mode (price or fair, default = price)
+	SenderGasCap        uint64         `toml:",omitempty"` // Maximum gas a single sender may use per block in fair ordering (0 = unlimited)
 
 	NewPayloadTimeout time.Duration // The maximum time allowance for creating a new payload
 }
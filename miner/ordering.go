@@ -18,6 +18,7 @@ package miner
 
 import (
 	"container/heap"
+	"errors"
 	"math/big"
 
 	"github.com/ethereum/go-ethereum/common"
@@ -26,6 +27,42 @@ import (
 	"github.com/ethereum/go-ethereum/core/types"
 )
 
+// errSenderGasCapReached is returned if an account exhausted the gas it may use
+// within a single block.
+var errSenderGasCapReached = errors.New("sender gas cap reached")
+
+// Transaction ordering modes supported by the miner.
+const (
+	TxOrderingPrice = "price" // Profit-maximizing ordering by price and nonce
+	TxOrderingFair  = "fair"  // Sender-fair ordering by price tier, round-robin across senders
+)
+
+// orderedTransactionSet is a set of pending transactions that yields them in a
+// nonce-honouring order suitable for block production.
+type orderedTransactionSet interface {
+	// Peek returns the next transaction to be included, or nil if done.
+	Peek() *txpool.LazyTransaction
+
+	// Shift replaces the current head with the next one from the same account.
+	Shift()
+
+	// Pop removes the current head together with all subsequent transactions
+	// of the same account.
+	Pop()
+
+	// GetTxs returns the number of accounts with transactions left in the set.
+	GetTxs() int
+}
+
+// newOrderedTransactionSet creates the transaction set matching the ordering
+// mode configured for the miner.
+func newOrderedTransactionSet(config *Config, signer types.Signer, txs map[common.Address][]*txpool.LazyTransaction, baseFee *big.Int) orderedTransactionSet {
+	if config != nil && config.TxOrdering == TxOrderingFair {
+		return newTransactionsBySenderFairness(signer, txs, baseFee, config.SenderGasCap)
+	}
+	return newTransactionsByPriceAndNonce(signer, txs, baseFee)
+}
+
 // txWithMinerFee wraps a transaction with its gas price or effective miner gasTipCap
 type txWithMinerFee struct {
 	tx   *txpool.LazyTransaction
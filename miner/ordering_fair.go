@@ -0,0 +1,182 @@
+// Copyright 2024 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package miner
+
+import (
+	"container/heap"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/txpool"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/params"
+)
+
+// fairPriceTierStep is the width of a price tier used by the sender-fair
+// ordering. Transactions whose effective tips fall into the same tier are
+// considered equally priced and are served round-robin across senders.
+var fairPriceTierStep = big.NewInt(params.GWei)
+
+// txWithFairness wraps a head transaction with the metadata needed to order it
+// in a sender-fair way.
+type txWithFairness struct {
+	*txWithMinerFee
+
+	tier   *big.Int // Price tier of the effective miner tip
+	served int      // Number of transactions already yielded for the sender
+}
+
+// txByTierAndRound implements the heap interface, ordering head transactions by
+// price tier first, and by the number of transactions already served for their
+// sender second. Within the same tier and round, earlier seen transactions win.
+type txByTierAndRound []*txWithFairness
+
+func (s txByTierAndRound) Len() int { return len(s) }
+func (s txByTierAndRound) Less(i, j int) bool {
+	if cmp := s[i].tier.Cmp(s[j].tier); cmp != 0 {
+		return cmp > 0
+	}
+	if s[i].served != s[j].served {
+		return s[i].served < s[j].served
+	}
+	return s[i].tx.Time.Before(s[j].tx.Time)
+}
+func (s txByTierAndRound) Swap(i, j int) { s[i], s[j] = s[j], s[i] }
+
+func (s *txByTierAndRound) Push(x interface{}) {
+	*s = append(*s, x.(*txWithFairness))
+}
+
+func (s *txByTierAndRound) Pop() interface{} {
+	old := *s
+	n := len(old)
+	x := old[n-1]
+	old[n-1] = nil
+	*s = old[0 : n-1]
+	return x
+}
+
+// transactionsBySenderFairness represents a set of transactions that can return
+// transactions in a sender-fair order. Accounts paying a higher price tier are
+// still served first, but accounts in the same tier take turns, so a single
+// sender flooding the pool with many similarly priced transactions cannot crowd
+// everyone else out of the block. Optionally, the gas a single sender may claim
+// within the block is capped.
+type transactionsBySenderFairness struct {
+	txs     map[common.Address][]*txpool.LazyTransaction // Per account nonce-sorted list of transactions
+	heads   txByTierAndRound                             // Next transaction for each unique account (tier heap)
+	served  map[common.Address]int                       // Number of transactions yielded per account
+	gasUsed map[common.Address]uint64                    // Gas limit of the transactions yielded per account
+	signer  types.Signer                                 // Signer for the set of transactions
+	baseFee *big.Int                                     // Current base fee
+	gasCap  uint64                                       // Maximum gas a single account may use (0 = unlimited)
+}
+
+// newTransactionsBySenderFairness creates a transaction set that can retrieve
+// sender-fair sorted transactions in a nonce-honouring way.
+//
+// Note, the input map is reowned so the caller should not interact any more with
+// if after providing it to the constructor.
+func newTransactionsBySenderFairness(signer types.Signer, txs map[common.Address][]*txpool.LazyTransaction, baseFee *big.Int, gasCap uint64) *transactionsBySenderFairness {
+	set := &transactionsBySenderFairness{
+		txs:     txs,
+		heads:   make(txByTierAndRound, 0, len(txs)),
+		served:  make(map[common.Address]int, len(txs)),
+		gasUsed: make(map[common.Address]uint64, len(txs)),
+		signer:  signer,
+		baseFee: baseFee,
+		gasCap:  gasCap,
+	}
+	// Initialize a tier and round based heap with the head transactions
+	for from, accTxs := range txs {
+		wrapped, err := set.wrap(accTxs[0], from)
+		if err != nil {
+			delete(txs, from)
+			continue
+		}
+		set.heads = append(set.heads, wrapped)
+		txs[from] = accTxs[1:]
+	}
+	heap.Init(&set.heads)
+
+	return set
+}
+
+// wrap creates a tier-annotated head transaction for the given account. An error
+// is returned if the transaction cannot pay the base fee or if including it would
+// push the account over its gas allowance.
+func (t *transactionsBySenderFairness) wrap(tx *txpool.LazyTransaction, from common.Address) (*txWithFairness, error) {
+	wrapped, err := newTxWithMinerFee(tx, from, t.baseFee)
+	if err != nil {
+		return nil, err
+	}
+	if t.gasCap > 0 && t.served[from] > 0 {
+		// Only resolve the transaction if the account already spent some of its
+		// allowance, the first transaction of every account is always admitted
+		// and the block gas pool will take care of it.
+		if resolved := tx.Resolve(); resolved != nil && t.gasUsed[from]+resolved.Tx.Gas() > t.gasCap {
+			return nil, errSenderGasCapReached
+		}
+	}
+	return &txWithFairness{
+		txWithMinerFee: wrapped,
+		tier:           new(big.Int).Div(wrapped.fees, fairPriceTierStep),
+		served:         t.served[from],
+	}, nil
+}
+
+// Peek returns the next transaction in sender-fair order.
+func (t *transactionsBySenderFairness) Peek() *txpool.LazyTransaction {
+	if len(t.heads) == 0 {
+		return nil
+	}
+	return t.heads[0].tx
+}
+
+// Shift replaces the current best head with the next one from the same account,
+// accounting the consumed transaction against the account's fair share.
+//
+// Note, the gas limit of the shifted transaction is charged rather than the gas
+// it actually used, so the cap is enforced conservatively.
+func (t *transactionsBySenderFairness) Shift() {
+	head := t.heads[0]
+	acc := head.from
+
+	t.served[acc]++
+	if head.tx.Tx != nil {
+		t.gasUsed[acc] += head.tx.Tx.Tx.Gas()
+	}
+	if txs, ok := t.txs[acc]; ok && len(txs) > 0 {
+		if wrapped, err := t.wrap(txs[0], acc); err == nil {
+			t.heads[0], t.txs[acc] = wrapped, txs[1:]
+			heap.Fix(&t.heads, 0)
+			return
+		}
+	}
+	heap.Pop(&t.heads)
+}
+
+func (t *transactionsBySenderFairness) GetTxs() int {
+	return len(t.txs)
+}
+
+// Pop removes the best transaction, *not* replacing it with the next one from
+// the same account. This should be used when a transaction cannot be executed
+// and hence all subsequent ones should be discarded from the same account.
+func (t *transactionsBySenderFairness) Pop() {
+	heap.Pop(&t.heads)
+}
@@ -27,6 +27,7 @@ import (
 	"github.com/ethereum/go-ethereum/core/txpool"
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/params"
 )
 
 func TestTransactionPriceNonceSortLegacy(t *testing.T) {
@@ -186,3 +187,125 @@ func TestTransactionTimeSort(t *testing.T) {
 		}
 	}
 }
+
+// Tests that the sender-fair ordering serves accounts within the same price tier
+// round-robin, so a single spamming account cannot crowd out everyone else, while
+// still honouring nonce ordering and higher price tiers.
+func TestTransactionSenderFairSort(t *testing.T) {
+	signer := types.LatestSignerForChainID(common.Big1)
+
+	spammer, _ := crypto.GenerateKey()
+	spammerAddr := crypto.PubkeyToAddress(spammer.PublicKey)
+
+	keys := make([]*ecdsa.PrivateKey, 5)
+	for i := 0; i < len(keys); i++ {
+		keys[i], _ = crypto.GenerateKey()
+	}
+	newLazyTx := func(key *ecdsa.PrivateKey, nonce uint64, tip int64, seen time.Time) *txpool.LazyTransaction {
+		tx, _ := types.SignTx(types.NewTx(&types.DynamicFeeTx{
+			Nonce:     nonce,
+			To:        &common.Address{},
+			Value:     big.NewInt(100),
+			Gas:       21000,
+			GasFeeCap: big.NewInt(tip),
+			GasTipCap: big.NewInt(tip),
+		}), signer, key)
+		tx.SetTime(seen)
+
+		return &txpool.LazyTransaction{
+			Hash:      tx.Hash(),
+			Tx:        &txpool.Transaction{Tx: tx},
+			Time:      tx.Time(),
+			GasFeeCap: tx.GasFeeCap(),
+			GasTipCap: tx.GasTipCap(),
+		}
+	}
+	groups := func() map[common.Address][]*txpool.LazyTransaction {
+		groups := map[common.Address][]*txpool.LazyTransaction{}
+
+		// The spammer was seen first and pays a slightly higher tip in the same tier
+		for i := 0; i < 20; i++ {
+			groups[spammerAddr] = append(groups[spammerAddr], newLazyTx(spammer, uint64(i), 30*params.GWei+1, time.Unix(0, int64(i))))
+		}
+		for i, key := range keys {
+			addr := crypto.PubkeyToAddress(key.PublicKey)
+			groups[addr] = append(groups[addr], newLazyTx(key, 0, 30*params.GWei, time.Unix(100, int64(i))))
+		}
+		return groups
+	}
+	// The price ordering lets the spammer take all slots up front
+	priced := newTransactionsByPriceAndNonce(signer, groups(), big.NewInt(0))
+	for i := 0; i < 20; i++ {
+		if from, _ := types.Sender(signer, priced.Peek().Tx.Tx); from != spammerAddr {
+			t.Fatalf("price ordering tx #%d: have sender %x, want spammer", i, from)
+		}
+		priced.Shift()
+	}
+	// The fair ordering must serve every other account before the spammer's second tx
+	fair := newTransactionsBySenderFairness(signer, groups(), big.NewInt(0), 0)
+
+	var (
+		txs     types.Transactions
+		nonces  = make(map[common.Address]uint64)
+		seenAll = -1
+	)
+	for tx := fair.Peek(); tx != nil; tx = fair.Peek() {
+		from, _ := types.Sender(signer, tx.Tx.Tx)
+		if tx.Tx.Tx.Nonce() != nonces[from] {
+			t.Fatalf("invalid nonce ordering for %x: have %d, want %d", from[:4], tx.Tx.Tx.Nonce(), nonces[from])
+		}
+		nonces[from]++
+
+		txs = append(txs, tx.Tx.Tx)
+		if len(nonces) == len(keys)+1 && seenAll < 0 {
+			seenAll = len(txs)
+		}
+		fair.Shift()
+	}
+	if len(txs) != 20+len(keys) {
+		t.Fatalf("expected %d transactions, found %d", 20+len(keys), len(txs))
+	}
+	if seenAll != len(keys)+1 {
+		t.Errorf("all senders served after %d transactions, want %d", seenAll, len(keys)+1)
+	}
+	// A higher price tier must still be served first
+	boosted := groups()
+	rich, _ := crypto.GenerateKey()
+	boosted[crypto.PubkeyToAddress(rich.PublicKey)] = []*txpool.LazyTransaction{newLazyTx(rich, 0, 100*params.GWei, time.Unix(200, 0))}
+
+	fair = newTransactionsBySenderFairness(signer, boosted, big.NewInt(0), 0)
+	if from, _ := types.Sender(signer, fair.Peek().Tx.Tx); from != crypto.PubkeyToAddress(rich.PublicKey) {
+		t.Errorf("higher price tier not served first: have %x", from[:4])
+	}
+}
+
+// Tests that the sender-fair ordering stops yielding transactions of an account
+// once they would exceed the per sender gas cap.
+func TestTransactionSenderFairGasCap(t *testing.T) {
+	signer := types.LatestSignerForChainID(common.Big1)
+
+	key, _ := crypto.GenerateKey()
+	addr := crypto.PubkeyToAddress(key.PublicKey)
+
+	groups := map[common.Address][]*txpool.LazyTransaction{}
+	for i := 0; i < 10; i++ {
+		tx, _ := types.SignTx(types.NewTransaction(uint64(i), common.Address{}, big.NewInt(100), 21000, big.NewInt(1), nil), signer, key)
+		groups[addr] = append(groups[addr], &txpool.LazyTransaction{
+			Hash:      tx.Hash(),
+			Tx:        &txpool.Transaction{Tx: tx},
+			Time:      tx.Time(),
+			GasFeeCap: tx.GasFeeCap(),
+			GasTipCap: tx.GasTipCap(),
+		})
+	}
+	txset := newTransactionsBySenderFairness(signer, groups, nil, 3*21000+1)
+
+	count := 0
+	for tx := txset.Peek(); tx != nil; tx = txset.Peek() {
+		count++
+		txset.Shift()
+	}
+	if count != 3 {
+		t.Errorf("expected %d transactions within the gas cap, found %d", 3, count)
+	}
+}
@@ -175,7 +175,7 @@ func (w *worker) mainLoopWithDelay(ctx context.Context, delay uint, opcodeDelay
 						GasTipCap: tx.GasTipCap(),
 					})
 				}
-				txset := newTransactionsByPriceAndNonce(w.current.signer, txs, w.current.header.BaseFee)
+				txset := newOrderedTransactionSet(w.config, w.current.signer, txs, w.current.header.BaseFee)
 				tcount := w.current.tcount
 				w.commitTransactions(w.current, txset, nil, context.Background())
 
@@ -426,7 +426,7 @@ func (w *worker) fillTransactionsWithDelay(ctx context.Context, interrupt *atomi
 	)
 
 	if len(localTxs) > 0 {
-		var txs *transactionsByPriceAndNonce
+		var txs orderedTransactionSet
 
 		tracing.Exec(ctx, "", "worker.LocalTransactionsByPriceAndNonce", func(ctx context.Context, span trace.Span) {
 			var baseFee *uint256.Int
@@ -434,7 +434,7 @@ func (w *worker) fillTransactionsWithDelay(ctx context.Context, interrupt *atomi
 				baseFee = cmath.FromBig(env.header.BaseFee)
 			}
 
-			txs = newTransactionsByPriceAndNonce(env.signer, localTxs, baseFee.ToBig())
+			txs = newOrderedTransactionSet(w.config, env.signer, localTxs, baseFee.ToBig())
 
 			tracing.SetAttributes(
 				span,
@@ -454,7 +454,7 @@ func (w *worker) fillTransactionsWithDelay(ctx context.Context, interrupt *atomi
 	}
 
 	if len(remoteTxs) > 0 {
-		var txs *transactionsByPriceAndNonce
+		var txs orderedTransactionSet
 
 		tracing.Exec(ctx, "", "worker.RemoteTransactionsByPriceAndNonce", func(ctx context.Context, span trace.Span) {
 			var baseFee *uint256.Int
@@ -462,7 +462,7 @@ func (w *worker) fillTransactionsWithDelay(ctx context.Context, interrupt *atomi
 				baseFee = cmath.FromBig(env.header.BaseFee)
 			}
 
-			txs = newTransactionsByPriceAndNonce(env.signer, remoteTxs, baseFee.ToBig())
+			txs = newOrderedTransactionSet(w.config, env.signer, remoteTxs, baseFee.ToBig())
 
 			tracing.SetAttributes(
 				span,
@@ -492,7 +492,7 @@ func (w *worker) fillTransactionsWithDelay(ctx context.Context, interrupt *atomi
 
 // commitTransactionsWithDelay is commitTransactions() with extra params to induce artficial delays for tests such as commit-interrupt.
 // nolint:gocognit, unparam
-func (w *worker) commitTransactionsWithDelay(env *environment, txs *transactionsByPriceAndNonce, interrupt *atomic.Int32, interruptCtx context.Context) error {
+func (w *worker) commitTransactionsWithDelay(env *environment, txs orderedTransactionSet, interrupt *atomic.Int32, interruptCtx context.Context) error {
 	gasLimit := env.header.GasLimit
 	if env.gasPool == nil {
 		env.gasPool = new(core.GasPool).AddGas(gasLimit)
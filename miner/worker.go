@@ -636,7 +636,7 @@ func (w *worker) mainLoop(ctx context.Context) {
 						GasTipCap: tx.GasTipCap(),
 					})
 				}
-				txset := newTransactionsByPriceAndNonce(w.current.signer, txs, w.current.header.BaseFee)
+				txset := newOrderedTransactionSet(w.config, w.current.signer, txs, w.current.header.BaseFee)
 				tcount := w.current.tcount
 				w.commitTransactions(w.current, txset, nil, context.Background())
 
@@ -844,6 +844,11 @@ func (w *worker) makeEnv(parent *types.Header, header *types.Header, coinbase co
 
 	state.StartPrefetcher("miner")
 
+	// Track the changes of the sealed block if the chain indexes them
+	if w.chain.ChangeSetsEnabled() {
+		state.EnableChangeSet()
+	}
+
 	// Note the passed coinbase may be different with header.Coinbase.
 	env := &environment{
 		signer:   types.MakeSigner(w.chainConfig, header.Number, header.Time),
@@ -895,7 +900,7 @@ func (w *worker) commitTransaction(env *environment, tx *types.Transaction, inte
 	return receipt.Logs, nil
 }
 
-func (w *worker) commitTransactions(env *environment, txs *transactionsByPriceAndNonce, interrupt *atomic.Int32, interruptCtx context.Context) error {
+func (w *worker) commitTransactions(env *environment, txs orderedTransactionSet, interrupt *atomic.Int32, interruptCtx context.Context) error {
 	gasLimit := env.header.GasLimit
 	if env.gasPool == nil {
 		env.gasPool = new(core.GasPool).AddGas(gasLimit)
@@ -1440,7 +1445,7 @@ func (w *worker) fillTransactions(ctx context.Context, interrupt *atomic.Int32,
 	)
 
 	if len(localTxs) > 0 {
-		var txs *transactionsByPriceAndNonce
+		var txs orderedTransactionSet
 
 		tracing.Exec(ctx, "", "worker.LocalTransactionsByPriceAndNonce", func(ctx context.Context, span trace.Span) {
 			var baseFee *uint256.Int
@@ -1448,7 +1453,7 @@ func (w *worker) fillTransactions(ctx context.Context, interrupt *atomic.Int32,
 				baseFee = cmath.FromBig(env.header.BaseFee)
 			}
 
-			txs = newTransactionsByPriceAndNonce(env.signer, localTxs, baseFee.ToBig())
+			txs = newOrderedTransactionSet(w.config, env.signer, localTxs, baseFee.ToBig())
 
 			tracing.SetAttributes(
 				span,
@@ -1468,7 +1473,7 @@ func (w *worker) fillTransactions(ctx context.Context, interrupt *atomic.Int32,
 	}
 
 	if len(remoteTxs) > 0 {
-		var txs *transactionsByPriceAndNonce
+		var txs orderedTransactionSet
 
 		tracing.Exec(ctx, "", "worker.RemoteTransactionsByPriceAndNonce", func(ctx context.Context, span trace.Span) {
 			var baseFee *uint256.Int
@@ -1476,7 +1481,7 @@ func (w *worker) fillTransactions(ctx context.Context, interrupt *atomic.Int32,
 				baseFee = cmath.FromBig(env.header.BaseFee)
 			}
 
-			txs = newTransactionsByPriceAndNonce(env.signer, remoteTxs, baseFee.ToBig())
+			txs = newOrderedTransactionSet(w.config, env.signer, remoteTxs, baseFee.ToBig())
 
 			tracing.SetAttributes(
 				span,
@@ -91,6 +91,10 @@ func (api *adminAPI) AddPeer(url string) (bool, error) {
 		return false, fmt.Errorf("invalid enode: %v", err)
 	}
 
+	if err := server.CheckTopology(node); err != nil {
+		return false, err
+	}
+
 	server.AddPeer(node)
 
 	return true, nil
@@ -127,6 +131,10 @@ func (api *adminAPI) AddTrustedPeer(url string) (bool, error) {
 		return false, fmt.Errorf("invalid enode: %v", err)
 	}
 
+	if err := server.CheckTopology(node); err != nil {
+		return false, err
+	}
+
 	server.AddTrustedPeer(node)
 
 	return true, nil
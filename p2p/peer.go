@@ -504,6 +504,7 @@ type PeerInfo struct {
 		Inbound       bool   `json:"inbound"`
 		Trusted       bool   `json:"trusted"`
 		Static        bool   `json:"static"`
+		Role          string `json:"role,omitempty"` // Sentry deployment role of the peer
 	} `json:"network"`
 	Protocols map[string]interface{} `json:"protocols"` // Sub-protocol specific metadata fields
 }
@@ -532,6 +533,13 @@ func (p *Peer) Info() *PeerInfo {
 	info.Network.Trusted = p.rw.is(trustedConn)
 	info.Network.Static = p.rw.is(staticDialedConn)
 
+	switch {
+	case p.IsSentry():
+		info.Network.Role = TopologySentry
+	case p.IsValidator():
+		info.Network.Role = TopologyValidator
+	}
+
 	// Gather all the running protocol infos
 	for _, proto := range p.running {
 		protoInfo := interface{}("unknown")
@@ -170,6 +170,17 @@ type Config struct {
 	// TxArrivalWait is the duration (ms) that the node will wait after seeing
 	// an announced transaction before explicitly requesting it
 	TxArrivalWait time.Duration
+
+	// TopologyMode is the role of the node in a sentry deployment, either
+	// TopologySentry, TopologyValidator or empty for a regular node.
+	TopologyMode string `toml:",omitempty"`
+
+	// Sentries are the only nodes a node in validator mode dials and accepts.
+	Sentries []*enode.Node `toml:",omitempty"`
+
+	// Validators are the nodes a node in sentry mode protects. They are always
+	// kept connected and bypass MaxPeers.
+	Validators []*enode.Node `toml:",omitempty"`
 }
 
 // Server manages all peer connections.
@@ -214,6 +225,8 @@ type Server struct {
 
 	// State of run loop and listenLoop.
 	inboundHistory expHeap
+
+	roles map[enode.ID]connFlag // Sentry deployment role of the configured peers
 }
 
 type peerOpFunc func(map[enode.ID]*Peer)
@@ -231,6 +244,8 @@ const (
 	staticDialedConn
 	inboundConn
 	trustedConn
+	sentryConn
+	validatorConn
 )
 
 // conn wraps a network connection with information gathered
@@ -288,6 +303,14 @@ func (f connFlag) String() string {
 		s += "-inbound"
 	}
 
+	if f&sentryConn != 0 {
+		s += "-sentry"
+	}
+
+	if f&validatorConn != 0 {
+		s += "-validator"
+	}
+
 	if s != "" {
 		s = s[1:]
 	}
@@ -381,8 +404,13 @@ func (srv *Server) PeerCount() int {
 
 // AddPeer adds the given node to the static node set. When there is room in the peer set,
 // the server will connect to the node. If the connection fails for any reason, the server
-// will attempt to reconnect the peer.
+// will attempt to reconnect the peer. A validator only adds its sentries.
 func (srv *Server) AddPeer(node *enode.Node) {
+	if err := srv.CheckTopology(node); err != nil {
+		srv.log.Warn("Refusing to add static peer", "id", node.ID(), "err", err)
+		return
+	}
+
 	srv.dialsched.addStatic(node)
 }
 
@@ -420,8 +448,14 @@ func (srv *Server) RemovePeer(node *enode.Node) {
 }
 
 // AddTrustedPeer adds the given node to a reserved trusted list which allows the
-// node to always connect, even if the slot are full.
+// node to always connect, even if the slot are full. A validator only adds its
+// sentries.
 func (srv *Server) AddTrustedPeer(node *enode.Node) {
+	if err := srv.CheckTopology(node); err != nil {
+		srv.log.Warn("Refusing to add trusted peer", "id", node.ID(), "err", err)
+		return
+	}
+
 	select {
 	case srv.addtrusted <- node:
 	case <-srv.quit:
@@ -526,6 +560,10 @@ func (srv *Server) Start() (err error) {
 		srv.clock = mclock.System{}
 	}
 
+	if err := srv.setupTopology(); err != nil {
+		return err
+	}
+
 	if srv.NoDial && srv.ListenAddr == "" {
 		srv.log.Warn("P2P server will be useless, neither dialing nor listening")
 	}
@@ -838,6 +876,8 @@ running:
 				// Ensure that the trusted flag is set before checking against MaxPeers.
 				c.flags |= trustedConn
 			}
+			// Tag the configured sentries and validators with their role.
+			c.flags |= srv.roles[c.node.ID()]
 			// TODO: track in-progress inbound node IDs (pre-Peer) to avoid dialing them.
 			c.cont <- srv.postHandshakeChecks(peers, inboundCount, c)
 
@@ -899,6 +939,10 @@ running:
 }
 
 func (srv *Server) postHandshakeChecks(peers map[enode.ID]*Peer, inboundCount int, c *conn) error {
+	if err := srv.topologyChecks(c); err != nil {
+		return err
+	}
+
 	switch {
 	case !c.is(trustedConn) && len(peers) >= srv.MaxPeers:
 		return DiscTooManyPeers
@@ -0,0 +1,134 @@
+package p2p
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/p2p/enode"
+)
+
+// Roles of a node in a sentry deployment, where validators are only reachable
+// through their sentries.
+const (
+	TopologySentry    = "sentry"    // Public node protecting validators
+	TopologyValidator = "validator" // Node only talking to its sentries
+)
+
+var errNotSentry = errors.New("peer is not a configured sentry")
+
+// ValidateTopology checks the sentry deployment settings of the config.
+func (cfg *Config) ValidateTopology() error {
+	switch cfg.TopologyMode {
+	case "":
+		if len(cfg.Sentries) > 0 || len(cfg.Validators) > 0 {
+			return errors.New("sentry and validator nodes require a topology mode")
+		}
+	case TopologyValidator:
+		if len(cfg.Sentries) == 0 {
+			return errors.New("validator mode requires at least one sentry node")
+		}
+
+		if len(cfg.Validators) > 0 {
+			return errors.New("validator nodes can only be set in sentry mode")
+		}
+	case TopologySentry:
+		if len(cfg.Validators) == 0 {
+			return errors.New("sentry mode requires at least one validator node")
+		}
+
+		if len(cfg.Sentries) > 0 {
+			return errors.New("sentry nodes can only be set in validator mode")
+		}
+	default:
+		return fmt.Errorf("unknown topology mode %q", cfg.TopologyMode)
+	}
+
+	return nil
+}
+
+// setupTopology applies the role of the node to the p2p settings. A validator
+// only knows and trusts its sentries and never runs discovery, so that its
+// address is never gossiped. A sentry always keeps its validators connected
+// and trusts them so that they bypass MaxPeers.
+func (srv *Server) setupTopology() error {
+	if err := srv.ValidateTopology(); err != nil {
+		return err
+	}
+
+	srv.roles = make(map[enode.ID]connFlag)
+
+	switch srv.TopologyMode {
+	case TopologyValidator:
+		if !srv.NoDiscovery || len(srv.StaticNodes) > 0 {
+			srv.log.Warn("Validator mode, disabling discovery and static nodes", "sentries", len(srv.Sentries))
+		}
+
+		srv.NoDiscovery = true
+		srv.DiscoveryV4 = false
+		srv.DiscoveryV5 = false
+		srv.BootstrapNodes = nil
+		srv.BootstrapNodesV5 = nil
+		srv.StaticNodes = srv.Sentries
+		srv.TrustedNodes = srv.Sentries
+
+		for _, n := range srv.Sentries {
+			srv.roles[n.ID()] = sentryConn
+		}
+	case TopologySentry:
+		srv.StaticNodes = appendMissingNodes(srv.StaticNodes, srv.Validators)
+		srv.TrustedNodes = appendMissingNodes(srv.TrustedNodes, srv.Validators)
+
+		for _, n := range srv.Validators {
+			srv.roles[n.ID()] = validatorConn
+		}
+	}
+
+	return nil
+}
+
+// topologyChecks rejects the peers a validator must not talk to.
+func (srv *Server) topologyChecks(c *conn) error {
+	if srv.TopologyMode == TopologyValidator && !c.is(sentryConn) {
+		return errNotSentry
+	}
+
+	return nil
+}
+
+// CheckTopology returns an error if the node must not be added as a peer. In
+// validator mode, only the configured sentries are.
+func (srv *Server) CheckTopology(node *enode.Node) error {
+	if srv.TopologyMode == TopologyValidator && srv.roles[node.ID()] != sentryConn {
+		return errNotSentry
+	}
+
+	return nil
+}
+
+// appendMissingNodes appends the nodes which are not in the list yet.
+func appendMissingNodes(list []*enode.Node, nodes []*enode.Node) []*enode.Node {
+	known := make(map[enode.ID]bool, len(list))
+	for _, n := range list {
+		known[n.ID()] = true
+	}
+
+	for _, n := range nodes {
+		if !known[n.ID()] {
+			list = append(list, n)
+			known[n.ID()] = true
+		}
+	}
+
+	return list
+}
+
+// IsSentry returns whether the peer is a configured sentry of this validator.
+func (p *Peer) IsSentry() bool {
+	return p.rw.is(sentryConn)
+}
+
+// IsValidator returns whether the peer is a configured validator behind this
+// sentry.
+func (p *Peer) IsValidator() bool {
+	return p.rw.is(validatorConn)
+}
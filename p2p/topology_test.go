@@ -0,0 +1,152 @@
+package p2p
+
+import (
+	"net"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/internal/testlog"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/p2p/enode"
+	"github.com/ethereum/go-ethereum/p2p/enr"
+)
+
+func TestValidateTopology(t *testing.T) {
+	t.Parallel()
+
+	node := newNode(randomID(), "")
+
+	tests := []struct {
+		cfg     Config
+		wantErr bool
+	}{
+		{cfg: Config{}},
+		{cfg: Config{Sentries: []*enode.Node{node}}, wantErr: true},
+		{cfg: Config{TopologyMode: TopologyValidator}, wantErr: true},
+		{cfg: Config{TopologyMode: TopologyValidator, Sentries: []*enode.Node{node}}},
+		{cfg: Config{TopologyMode: TopologyValidator, Sentries: []*enode.Node{node}, Validators: []*enode.Node{node}}, wantErr: true},
+		{cfg: Config{TopologyMode: TopologySentry}, wantErr: true},
+		{cfg: Config{TopologyMode: TopologySentry, Validators: []*enode.Node{node}}},
+		{cfg: Config{TopologyMode: TopologySentry, Validators: []*enode.Node{node}, Sentries: []*enode.Node{node}}, wantErr: true},
+		{cfg: Config{TopologyMode: "relay"}, wantErr: true},
+	}
+
+	for i, tt := range tests {
+		if err := tt.cfg.ValidateTopology(); (err != nil) != tt.wantErr {
+			t.Errorf("test %d: unexpected error %v", i, err)
+		}
+	}
+}
+
+func TestServerTopologyValidator(t *testing.T) {
+	t.Parallel()
+
+	sentry := newNode(randomID(), "127.0.0.1:30303")
+
+	srv := &Server{
+		Config: Config{
+			PrivateKey:     newkey(),
+			MaxPeers:       10,
+			NoDial:         true,
+			DiscoveryV4:    true,
+			DiscoveryV5:    true,
+			StaticNodes:    []*enode.Node{newNode(randomID(), "127.0.0.1:30304")},
+			BootstrapNodes: []*enode.Node{newNode(randomID(), "127.0.0.1:30305")},
+			TopologyMode:   TopologyValidator,
+			Sentries:       []*enode.Node{sentry},
+			Logger:         testlog.Logger(t, log.LvlTrace),
+		},
+	}
+	if err := srv.Start(); err != nil {
+		t.Fatalf("could not start: %v", err)
+	}
+
+	defer srv.Stop()
+
+	if !srv.NoDiscovery || srv.DiscoveryV4 || srv.DiscoveryV5 || len(srv.BootstrapNodes) > 0 {
+		t.Error("discovery not disabled in validator mode")
+	}
+
+	if len(srv.StaticNodes) != 1 || srv.StaticNodes[0].ID() != sentry.ID() {
+		t.Errorf("static nodes not limited to the sentries: %v", srv.StaticNodes)
+	}
+
+	// Non-sentry peers are rejected
+	c := newTopologyConn(randomID())
+	if err := srv.checkpoint(c, srv.checkpointPostHandshake); err != errNotSentry {
+		t.Errorf("wrong error for non-sentry: %v", err)
+	}
+
+	// Sentries are accepted and trusted
+	c = newTopologyConn(sentry.ID())
+	if err := srv.checkpoint(c, srv.checkpointPostHandshake); err != nil {
+		t.Fatalf("unexpected error for sentry: %v", err)
+	}
+
+	if !c.is(sentryConn) || !c.is(trustedConn) {
+		t.Errorf("sentry flags not set: %v", c.flags)
+	}
+
+	// Only sentries can be added as peers, so that no other node is dialed
+	if err := srv.CheckTopology(newNode(randomID(), "127.0.0.1:30306")); err != errNotSentry {
+		t.Errorf("wrong error adding a non-sentry: %v", err)
+	}
+
+	if err := srv.CheckTopology(sentry); err != nil {
+		t.Errorf("unexpected error adding a sentry: %v", err)
+	}
+}
+
+func TestServerTopologySentry(t *testing.T) {
+	t.Parallel()
+
+	validator := newNode(randomID(), "127.0.0.1:30303")
+
+	srv := &Server{
+		Config: Config{
+			PrivateKey:   newkey(),
+			MaxPeers:     1,
+			NoDial:       true,
+			NoDiscovery:  true,
+			TopologyMode: TopologySentry,
+			Validators:   []*enode.Node{validator},
+			Logger:       testlog.Logger(t, log.LvlTrace),
+		},
+	}
+	if err := srv.Start(); err != nil {
+		t.Fatalf("could not start: %v", err)
+	}
+
+	defer srv.Stop()
+
+	// Fill up the peer set with a regular peer
+	if err := srv.checkpoint(newTopologyConn(randomID()), srv.checkpointAddPeer); err != nil {
+		t.Fatalf("could not add conn: %v", err)
+	}
+
+	if err := srv.checkpoint(newTopologyConn(randomID()), srv.checkpointPostHandshake); err != DiscTooManyPeers {
+		t.Errorf("wrong error for regular peer: %v", err)
+	}
+
+	// Validators bypass the peer limit
+	c := newTopologyConn(validator.ID())
+	if err := srv.checkpoint(c, srv.checkpointPostHandshake); err != nil {
+		t.Fatalf("unexpected error for validator: %v", err)
+	}
+
+	if !c.is(validatorConn) || !c.is(trustedConn) {
+		t.Errorf("validator flags not set: %v", c.flags)
+	}
+
+	// Sentries can add any peer
+	if err := srv.CheckTopology(newNode(randomID(), "127.0.0.1:30304")); err != nil {
+		t.Errorf("unexpected error adding a peer: %v", err)
+	}
+}
+
+func newTopologyConn(id enode.ID) *conn {
+	fd, _ := net.Pipe()
+	tx := newTestTransport(&newkey().PublicKey, fd, nil)
+	node := enode.SignNull(new(enr.Record), id)
+
+	return &conn{fd: fd, transport: tx, flags: inboundConn, node: node, cont: make(chan error)}
+}